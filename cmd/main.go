@@ -1,44 +1,315 @@
 package main
 
 import (
-	"fmt"
+	"context"
+	"crypto/rand"
 	"log"
+	"net"
+	"os"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	postgres2 "github.com/tranvu1111/go-students-new/internal/infrastructure/db/postgres"
-	"gorm.io/driver/postgres"
+	"github.com/tranvu1111/go-students-new/internal/infrastructure/db/driver"
+	"github.com/tranvu1111/go-students-new/internal/infrastructure/db/replica"
+	"github.com/tranvu1111/go-students-new/internal/infrastructure/db/resilience"
 	"gorm.io/gorm"
 	"github.com/tranvu1111/go-students-new/internal/interface/api/rest"
+	"github.com/tranvu1111/go-students-new/internal/interface/api/rest/openapi"
+	studentgrpc "github.com/tranvu1111/go-students-new/internal/interface/api/grpc"
+	"github.com/tranvu1111/go-students-new/internal/interface/api/graphql"
 	"github.com/tranvu1111/go-students-new/internal/application/services"
+	"github.com/tranvu1111/go-students-new/internal/application/interfaces"
+	"github.com/tranvu1111/go-students-new/internal/infrastructure/outbox"
+	"github.com/tranvu1111/go-students-new/internal/infrastructure/webhook"
+	"github.com/tranvu1111/go-students-new/internal/infrastructure/mail"
+	"github.com/tranvu1111/go-students-new/internal/infrastructure/notification"
+	"github.com/tranvu1111/go-students-new/internal/infrastructure/verification"
+	"github.com/tranvu1111/go-students-new/internal/domain/entities"
+	"github.com/tranvu1111/go-students-new/internal/domain/repositories"
+	"github.com/tranvu1111/go-students-new/internal/infrastructure/cache"
+	"github.com/tranvu1111/go-students-new/internal/config"
 
 )
 
+// startupRetryPolicy waits about half a minute for the database to come up.
+var startupRetryPolicy = resilience.RetryPolicy{MaxAttempts: 8, BaseDelay: 500 * time.Millisecond, MaxDelay: 10 * time.Second}
+
 func main(){
 	gin.SetMode(gin.ReleaseMode)
 
-	dsn := "host=localhost user=postgres password=tranvu123@ dbname=demodb port=5432 sslmode=disable"
-	port := ":8080"
+	cfg, err := config.Load()
+	if err != nil {
+		log.Fatalf("Invalid configuration : %v", err)
+	}
 	
-	gormDB, err := gorm.Open(postgres.Open(dsn), &gorm.Config{})
+	gormDB, err := driver.OpenWithRetry(context.Background(), cfg.DatabaseDriver, cfg.DatabaseDSN, &gorm.Config{}, startupRetryPolicy)
 	if err != nil {
 		log.Fatalf("Failed to connect to database : %v" , err)
 	}
+	pool := driver.Pool{
+		MaxOpenConns:    cfg.DatabaseMaxOpenConns,
+		MaxIdleConns:    cfg.DatabaseMaxIdleConns,
+		ConnMaxLifetime: cfg.DatabaseConnMaxLifetime,
+		ConnMaxIdleTime: cfg.DatabaseConnMaxIdleTime,
+	}
+	if err := driver.ConfigurePool(gormDB, pool); err != nil {
+		log.Fatalf("Failed to configure the connection pool : %v", err)
+	}
+
+	if err := postgres2.AutoMigrate(gormDB); err != nil {
+		log.Fatalf("Failed to migrate database : %v", err)
+	}
 
-	gormDB.AutoMigrate()
+	// The cache stays outside the guard, so that cached students are served
+	// while the breaker is open.
+	guard := resilience.NewGuard(resilience.DefaultRetryPolicy, resilience.NewBreaker(5, 10*time.Second))
+	studentRepo := cachedStudentRepo(resilience.NewStudentRepo(gormStudentRepo(cfg.DatabaseDriver, gormDB, pool), guard))
+	idempotencyRepo := resilience.NewIdempotencyRepo(postgres2.NewGormIdempotencyRepository(gormDB), guard)
+	auditRepo := resilience.NewAuditRepo(postgres2.NewGormAuditRepo(gormDB), guard)
+	statusRepo := resilience.NewStudentStatusRepo(postgres2.NewGormStudentStatusRepo(gormDB), guard)
+	programRepo := resilience.NewProgramRepo(postgres2.NewGormProgramRepo(gormDB), guard)
 
-	studentRepo := postgres2.NewGormStudentRepo(gormDB)
-	idempotencyRepo := postgres2.NewGormIdempotencyRepository(gormDB)
 
+	studentService := services.NewStudentService(studentRepo, idempotencyRepo, auditRepo, statusRepo, programRepo)
+	programService := services.NewProgramService(programRepo, studentRepo)
 
-	studentService := services.NewStudentService(studentRepo, idempotencyRepo)
+	webhookSubscriptionRepo := postgres2.NewGormWebhookSubscriptionRepo(gormDB)
+	webhookDeliveryRepo := postgres2.NewGormWebhookDeliveryRepo(gormDB)
+	webhookService := services.NewWebhookService(webhookSubscriptionRepo, webhookDeliveryRepo)
+
+	mailer := newMailer()
+	verificationSecret := emailVerificationSecret()
+	verificationSink := verification.NewEmailSink(studentRepo, postgres2.NewGormVerificationEmailRepo(gormDB), mailer, verificationSecret, emailVerificationTTL(), os.Getenv("EMAIL_VERIFICATION_LINK"))
+	emailVerificationService := services.NewEmailVerificationService(studentRepo, verificationSecret)
+
+	notificationTemplateRepo := postgres2.NewGormNotificationTemplateRepo(gormDB)
+	notificationRepo := postgres2.NewGormNotificationRepo(gormDB)
+	notificationService := services.NewNotificationService(notificationTemplateRepo, notificationRepo)
+	notificationSink := notification.NewSink(notificationTemplateRepo, notificationRepo, studentRepo, notificationDefaultLocale())
+
+	sinks := append(outboxSinks(), webhook.NewSubscriptionSink(webhookSubscriptionRepo, webhookDeliveryRepo), verificationSink, notificationSink)
+	relay := outbox.NewRelay(postgres2.NewGormOutboxRepo(gormDB), 2*time.Second, 100, entities.DefaultOutboxRetryPolicy, sinks...)
+	go relay.Run(context.Background())
+
+	dispatcher := webhook.NewDispatcher(webhookSubscriptionRepo, webhookDeliveryRepo, nil, entities.DefaultWebhookRetryPolicy)
+	go dispatcher.Run(context.Background())
+
+	notificationDispatcher := notification.NewDispatcher(notificationRepo, notificationChannels(mailer), entities.DefaultNotificationRetryPolicy)
+	go notificationDispatcher.Run(context.Background())
 	
 
+	go serveGRPC(cfg.GRPCAddr, studentService)
+
 	r := gin.Default()
-	rest.NewStudentController(r, studentService)
+	r.Use(rest.RequestContextMiddleware())
+	r.Use(rest.LocaleMiddleware())
+
+	spec := openapi.StudentsSpec()
+	openapi.Register(r, spec)
+	r.Use(openapi.ValidationMiddleware(spec))
+	rest.NewStudentController(r, studentService, studentControllerOptions()...)
+	rest.NewWebhookController(r, webhookService)
+	rest.NewProgramController(r, programService)
+	rest.NewEmailVerificationController(r, emailVerificationService)
+	rest.NewNotificationController(r, notificationService)
+	graphql.Register(r, studentService)
 
 	
-	if err := r.Run(fmt.Sprintf("%s", port));err != nil {
+	if err := r.Run(cfg.HTTPAddr);err != nil {
 		log.Fatalf("Gin server failed to start: %v", err)
 	}
 	
-}
\ No newline at end of file
+}
+
+// serveGRPC runs the gRPC API on addr, next to the REST API.
+func serveGRPC(addr string, studentService interfaces.StudentService) {
+	listener, err := net.Listen("tcp", addr)
+	if err != nil {
+		log.Fatalf("Failed to listen for gRPC : %v", err)
+	}
+	if err := studentgrpc.NewServer(studentService).Serve(listener); err != nil {
+		log.Fatalf("gRPC server failed : %v", err)
+	}
+}
+
+// outboxSinks configures where student events are published:
+// OUTBOX_WEBHOOK_URL and OUTBOX_FILE, falling back to stdout.
+func outboxSinks() []outbox.Sink {
+	var sinks []outbox.Sink
+	if url := os.Getenv("OUTBOX_WEBHOOK_URL"); url != "" {
+		sinks = append(sinks, outbox.NewWebhookSink(url, nil))
+	}
+	if path := os.Getenv("OUTBOX_FILE"); path != "" {
+		fileSink, err := outbox.NewFileSink(path)
+		if err != nil {
+			log.Fatalf("Failed to open outbox file : %v", err)
+		}
+		sinks = append(sinks, fileSink)
+	}
+	if len(sinks) == 0 {
+		sinks = append(sinks, outbox.NewStdoutSink())
+	}
+	return sinks
+}
+
+// gormStudentRepo reads students from the read replicas listed, comma
+// separated, in DATABASE_REPLICA_DSNS, when set. Replicas are checked every
+// DATABASE_REPLICA_CHECK_INTERVAL, 10s by default; reads fall back to the
+// primary while none is healthy, including replicas that are down at startup.
+func gormStudentRepo(driverName string, primary *gorm.DB, pool driver.Pool) repositories.StudentRepository {
+	var replicas []*gorm.DB
+	for _, dsn := range strings.Split(os.Getenv("DATABASE_REPLICA_DSNS"), ",") {
+		if dsn = strings.TrimSpace(dsn); dsn == "" {
+			continue
+		}
+		replicaDB, err := driver.Open(driverName, dsn, &gorm.Config{DisableAutomaticPing: true})
+		if err != nil {
+			log.Fatalf("Failed to open read replica : %v", err)
+		}
+		if err := driver.ConfigurePool(replicaDB, pool); err != nil {
+			log.Fatalf("Failed to configure the read replica pool : %v", err)
+		}
+		replicas = append(replicas, replicaDB)
+	}
+	if len(replicas) == 0 {
+		return postgres2.NewGormStudentRepo(primary)
+	}
+
+	interval := 10 * time.Second
+	if value := os.Getenv("DATABASE_REPLICA_CHECK_INTERVAL"); value != "" {
+		parsed, err := time.ParseDuration(value)
+		if err != nil {
+			log.Fatalf("Invalid DATABASE_REPLICA_CHECK_INTERVAL : %v", err)
+		}
+		interval = parsed
+	}
+
+	router := replica.NewRouter(primary, replicas...)
+	router.CheckHealth(context.Background())
+	go router.Run(context.Background(), interval)
+	return postgres2.NewRoutedGormStudentRepo(router)
+}
+
+// cachedStudentRepo puts an in-process read cache in front of the repository
+// when STUDENT_CACHE_SIZE is set; STUDENT_CACHE_TTL defaults to 30s.
+func cachedStudentRepo(repo repositories.StudentRepository) repositories.StudentRepository {
+	size, _ := strconv.Atoi(os.Getenv("STUDENT_CACHE_SIZE"))
+	if size <= 0 {
+		return repo
+	}
+
+	ttl := 30 * time.Second
+	if value := os.Getenv("STUDENT_CACHE_TTL"); value != "" {
+		parsed, err := time.ParseDuration(value)
+		if err != nil {
+			log.Fatalf("Invalid STUDENT_CACHE_TTL : %v", err)
+		}
+		ttl = parsed
+	}
+	return cache.NewCachedStudentRepo(repo, size, ttl)
+}
+
+// studentControllerOptions reads STUDENT_CACHE_CONTROL, the Cache-Control
+// header of student reads.
+func studentControllerOptions() []rest.StudentControllerOption {
+	var options []rest.StudentControllerOption
+	if value := os.Getenv("STUDENT_CACHE_CONTROL"); value != "" {
+		options = append(options, rest.WithCacheControl(value))
+	}
+	return options
+}
+
+// newMailer sends through SMTP_HOST when set, on SMTP_PORT (587 by default)
+// as MAIL_FROM, logging in with SMTP_USERNAME and SMTP_PASSWORD. Otherwise
+// mail is appended to MAIL_FILE, or written to stdout.
+func newMailer() mail.Mailer {
+	if host := os.Getenv("SMTP_HOST"); host != "" {
+		port := 587
+		if value := os.Getenv("SMTP_PORT"); value != "" {
+			parsed, err := strconv.Atoi(value)
+			if err != nil {
+				log.Fatalf("Invalid SMTP_PORT : %v", err)
+			}
+			port = parsed
+		}
+		return mail.NewSMTPMailer(host, port, os.Getenv("SMTP_USERNAME"), os.Getenv("SMTP_PASSWORD"), os.Getenv("MAIL_FROM"))
+	}
+	if path := os.Getenv("MAIL_FILE"); path != "" {
+		fileMailer, err := mail.NewFileMailer(path)
+		if err != nil {
+			log.Fatalf("Failed to open mail file : %v", err)
+		}
+		return fileMailer
+	}
+	return mail.NewLogMailer()
+}
+
+// emailVerificationSecret reads EMAIL_VERIFICATION_SECRET. Without one a
+// random secret is used, and tokens mailed before a restart stop working.
+func emailVerificationSecret() []byte {
+	if secret := os.Getenv("EMAIL_VERIFICATION_SECRET"); secret != "" {
+		return []byte(secret)
+	}
+	log.Printf("EMAIL_VERIFICATION_SECRET is not set; verification tokens will not survive a restart")
+	secret := make([]byte, 32)
+	if _, err := rand.Read(secret); err != nil {
+		log.Fatalf("Failed to generate an email verification secret : %v", err)
+	}
+	return secret
+}
+
+// emailVerificationTTL reads EMAIL_VERIFICATION_TTL, 48h by default.
+func emailVerificationTTL() time.Duration {
+	value := os.Getenv("EMAIL_VERIFICATION_TTL")
+	if value == "" {
+		return verification.DefaultTokenTTL
+	}
+	ttl, err := time.ParseDuration(value)
+	if err != nil || ttl <= 0 {
+		log.Fatalf("Invalid EMAIL_VERIFICATION_TTL : %q", value)
+	}
+	return ttl
+}
+
+// notificationChannels sends email notifications with mailer and text messages
+// through the gateway at SMS_GATEWAY_URL, authenticated with SMS_GATEWAY_TOKEN
+// and sent as SMS_FROM. Without a gateway text messages are dead-lettered.
+// NOTIFICATION_FILE replaces both with a file every notification is appended
+// to.
+func notificationChannels(mailer mail.Mailer) map[entities.NotificationChannel]notification.Channel {
+	if path := os.Getenv("NOTIFICATION_FILE"); path != "" {
+		fileChannel, err := notification.NewFileChannel(path)
+		if err != nil {
+			log.Fatalf("Failed to open notification file : %v", err)
+		}
+		return map[entities.NotificationChannel]notification.Channel{
+			entities.NotificationChannelEmail: fileChannel,
+			entities.NotificationChannelSMS:   fileChannel,
+		}
+	}
+
+	channels := map[entities.NotificationChannel]notification.Channel{
+		entities.NotificationChannelEmail: notification.NewEmailChannel(mailer),
+	}
+	if url := os.Getenv("SMS_GATEWAY_URL"); url != "" {
+		channels[entities.NotificationChannelSMS] = notification.NewSMSGateway(url, os.Getenv("SMS_GATEWAY_TOKEN"), os.Getenv("SMS_FROM"), nil)
+	}
+	return channels
+}
+
+// notificationDefaultLocale reads NOTIFICATION_DEFAULT_LOCALE, the language
+// students without a locale of their own are written to in; "en" by default.
+func notificationDefaultLocale() string {
+	value := os.Getenv("NOTIFICATION_DEFAULT_LOCALE")
+	if value == "" {
+		return "en"
+	}
+	locale := entities.NormalizeLocale(value)
+	if !entities.IsLocale(locale) {
+		log.Fatalf("Invalid NOTIFICATION_DEFAULT_LOCALE : %q", value)
+	}
+	return locale
+}
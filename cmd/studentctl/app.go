@@ -0,0 +1,108 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+
+	"gorm.io/gorm"
+
+	"github.com/tranvu1111/go-students-new/internal/application/interfaces"
+	"github.com/tranvu1111/go-students-new/internal/application/services"
+	"github.com/tranvu1111/go-students-new/internal/infrastructure/db/postgres"
+)
+
+type app struct {
+	// connect opens the database on first use, see database.
+	connect func() (*gorm.DB, error)
+	db      *gorm.DB
+	stdout  io.Writer
+	stderr  io.Writer
+	output  string
+	dryRun  bool
+}
+
+// errDryRun rolls back the transaction of a dry run.
+var errDryRun = errors.New("dry run")
+
+func (a *app) studentService() (interfaces.StudentService, error) {
+	db, err := a.database()
+	if err != nil {
+		return nil, err
+	}
+	return newStudentService(db), nil
+}
+
+func newStudentService(db *gorm.DB) interfaces.StudentService {
+	return services.NewStudentService(postgres.NewGormStudentRepo(db), postgres.NewGormIdempotencyRepository(db), postgres.NewGormAuditRepo(db), postgres.NewGormStudentStatusRepo(db), postgres.NewGormProgramRepo(db))
+}
+
+func newProgramService(db *gorm.DB) interfaces.ProgramService {
+	return services.NewProgramService(postgres.NewGormProgramRepo(db), postgres.NewGormStudentRepo(db))
+}
+
+// database connects on first use, so that commands which never touch the
+// database run without one.
+func (a *app) database() (*gorm.DB, error) {
+	if a.db == nil {
+		db, err := a.connect()
+		if err != nil {
+			return nil, fmt.Errorf("cannot connect to the database: %w", err)
+		}
+		a.db = db
+	}
+	return a.db, nil
+}
+
+// write runs fn in a transaction, which is rolled back under --dry-run.
+func (a *app) write(ctx context.Context, fn func(db *gorm.DB) error) error {
+	db, err := a.database()
+	if err != nil {
+		return err
+	}
+	err = db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := fn(tx); err != nil {
+			return err
+		}
+		if a.dryRun {
+			return errDryRun
+		}
+		return nil
+	})
+	if errors.Is(err, errDryRun) {
+		fmt.Fprintln(a.stderr, "dry run: no changes were saved")
+		return nil
+	}
+	return err
+}
+
+// flagSet returns a flag set that also accepts the global --output and
+// --dry-run flags, so that they may follow the command.
+func (a *app) flagSet(name string) *flag.FlagSet {
+	fs := flag.NewFlagSet(name, flag.ContinueOnError)
+	fs.SetOutput(a.stderr)
+	if a.output == "" {
+		a.output = "table"
+	}
+	fs.StringVar(&a.output, "output", a.output, "output format: table or json")
+	fs.BoolVar(&a.dryRun, "dry-run", a.dryRun, "roll back every change instead of saving it")
+	return fs
+}
+
+// parseFlags parses flags wherever they appear among args and returns the
+// positional arguments.
+func parseFlags(fs *flag.FlagSet, args []string) ([]string, error) {
+	var positional []string
+	for {
+		if err := fs.Parse(args); err != nil {
+			return nil, err
+		}
+		if fs.NArg() == 0 {
+			return positional, nil
+		}
+		positional = append(positional, fs.Arg(0))
+		args = fs.Args()[1:]
+	}
+}
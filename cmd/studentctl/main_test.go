@@ -0,0 +1,241 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/glebarez/sqlite"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+
+	"github.com/tranvu1111/go-students-new/internal/application/common"
+	"github.com/tranvu1111/go-students-new/internal/infrastructure/db/postgres"
+)
+
+// studentctl runs the CLI against an in-memory SQLite database shared by the
+// calls of one test.
+type studentctl struct {
+	t  *testing.T
+	db *gorm.DB
+}
+
+func newStudentctl(t *testing.T) *studentctl {
+	db, err := gorm.Open(sqlite.Open(fmt.Sprintf("file:%s?mode=memory&cache=shared", uuid.NewString())), &gorm.Config{})
+	require.NoError(t, err)
+	require.NoError(t, postgres.AutoMigrate(db))
+	return &studentctl{t: t, db: db}
+}
+
+func (s *studentctl) run(args ...string) (int, string, string) {
+	var stdout, stderr bytes.Buffer
+	code := run(context.Background(), args, &stdout, &stderr, func(string, string) (*gorm.DB, error) {
+		return s.db, nil
+	})
+	return code, stdout.String(), stderr.String()
+}
+
+func (s *studentctl) createStudent(email string) common.StudentResult {
+	code, stdout, stderr := s.run("--output", "json", "student", "create",
+		"--first-name", "tran", "--last-name", "vu", "--email", email, "--enrollment-date", "2023-09-01", "--major", "CNTT")
+	require.Equal(s.t, 0, code, stderr)
+
+	var created common.StudentResult
+	require.NoError(s.t, json.Unmarshal([]byte(stdout), &created))
+	return created
+}
+
+func TestStudentctl_CreateGetAndList(t *testing.T) {
+	ctl := newStudentctl(t)
+	created := ctl.createStudent("tranvu@example.com")
+	assert.Equal(t, 1, created.Version)
+
+	code, stdout, _ := ctl.run("student", "get", created.StudentID.String())
+	require.Equal(t, 0, code)
+	assert.Contains(t, stdout, "STUDENT ID")
+	assert.Contains(t, stdout, "tranvu@example.com")
+
+	code, stdout, _ = ctl.run("student", "list", "--major", "CNTT", "--output", "json")
+	require.Equal(t, 0, code)
+	var listed []common.StudentResult
+	require.NoError(t, json.Unmarshal([]byte(stdout), &listed))
+	require.Len(t, listed, 1)
+	assert.Equal(t, created.StudentID, listed[0].StudentID)
+}
+
+func TestStudentctl_DryRunSavesNothing(t *testing.T) {
+	ctl := newStudentctl(t)
+
+	code, stdout, stderr := ctl.run("--dry-run", "student", "create",
+		"--first-name", "tran", "--last-name", "vu", "--email", "tranvu@example.com", "--enrollment-date", "2023-09-01")
+	require.Equal(t, 0, code, stderr)
+	assert.Contains(t, stdout, "tranvu@example.com")
+	assert.Contains(t, stderr, "dry run")
+
+	created := ctl.createStudent("saved@example.com")
+	code, _, stderr = ctl.run("student", "delete", created.StudentID.String(), "--dry-run")
+	require.Equal(t, 0, code, stderr)
+
+	code, stdout, _ = ctl.run("--output", "json", "student", "list")
+	require.Equal(t, 0, code)
+	var listed []common.StudentResult
+	require.NoError(t, json.Unmarshal([]byte(stdout), &listed))
+	require.Len(t, listed, 1)
+	assert.Equal(t, "saved@example.com", listed[0].Email)
+}
+
+func TestStudentctl_UpdateDeleteRestore(t *testing.T) {
+	ctl := newStudentctl(t)
+	created := ctl.createStudent("tranvu@example.com")
+	id := created.StudentID.String()
+
+	code, _, stderr := ctl.run("student", "update", id, "--major", "Physics")
+	assert.Equal(t, 2, code)
+	assert.Contains(t, stderr, "--version is required")
+
+	code, stdout, stderr := ctl.run("student", "update", id, "--version", "1", "--major", "Physics")
+	require.Equal(t, 0, code, stderr)
+	assert.Contains(t, stdout, "Physics")
+
+	code, _, stderr = ctl.run("student", "delete", id, "--version", "1")
+	assert.Equal(t, 1, code)
+	assert.Contains(t, stderr, "expected version 1, current version 2")
+
+	code, stdout, _ = ctl.run("student", "delete", id, "--version", "2")
+	require.Equal(t, 0, code)
+	assert.Contains(t, stdout, "Deleted student "+id)
+
+	code, stdout, stderr = ctl.run("student", "restore", id)
+	require.Equal(t, 0, code, stderr)
+	assert.Contains(t, stdout, "tranvu@example.com")
+}
+
+func TestStudentctl_ImportAndExport(t *testing.T) {
+	ctl := newStudentctl(t)
+	file := filepath.Join(t.TempDir(), "students.ndjson")
+	rows := `{"FirstName":"tran","LastName":"vu","Email":"tran@example.com","EnrollmentDate":"2023-09-01"}
+{"FirstName":"an","LastName":"le","Email":"an@example.com","EnrollmentDate":"2023-09-01"}
+`
+	require.NoError(t, os.WriteFile(file, []byte(rows), 0o600))
+
+	code, stdout, stderr := ctl.run("import", file, "--dry-run")
+	require.Equal(t, 0, code, stderr)
+	assert.Contains(t, stdout, "2 rows, 0 created")
+
+	code, stdout, stderr = ctl.run("import", file)
+	require.Equal(t, 0, code, stderr)
+	assert.Contains(t, stdout, "2 rows, 2 created, 0 failed")
+
+	code, stdout, stderr = ctl.run("export", "--columns", "Email")
+	require.Equal(t, 0, code, stderr)
+	assert.Equal(t, []string{"Email", "tran@example.com", "an@example.com"}, strings.Fields(stdout))
+}
+
+func TestStudentctl_PurgeIdempotencyAndMigrate(t *testing.T) {
+	ctl := newStudentctl(t)
+
+	code, stdout, stderr := ctl.run("idempotency", "purge", "--older-than", "1h")
+	require.Equal(t, 0, code, stderr)
+	assert.Contains(t, stdout, "Deleted 0 idempotency records")
+
+	code, stdout, stderr = ctl.run("migrate")
+	require.Equal(t, 0, code, stderr)
+	assert.Contains(t, stdout, "Schema is up to date")
+
+	code, _, _ = ctl.run("frobnicate")
+	assert.Equal(t, 2, code)
+}
+
+func TestStudentctl_Seed(t *testing.T) {
+	ctl := newStudentctl(t)
+
+	code, first, stderr := ctl.run("seed", "--count", "5", "--seed", "9", "--ndjson")
+	require.Equal(t, 0, code, stderr)
+	_, second, _ := ctl.run("seed", "--count", "5", "--seed", "9", "--ndjson")
+	assert.Equal(t, first, second)
+	assert.Len(t, strings.Split(strings.TrimSpace(first), "\n"), 5)
+
+	code, stdout, stderr := ctl.run("seed", "--count", "5", "--dry-run")
+	require.Equal(t, 0, code, stderr)
+	assert.Contains(t, stdout, "Created 5 students")
+
+	code, stdout, stderr = ctl.run("seed", "--count", "12", "--majors", "Physics")
+	require.Equal(t, 0, code, stderr)
+	assert.Contains(t, stdout, "Created 12 students from seed 1")
+
+	code, stdout, _ = ctl.run("--output", "json", "student", "list")
+	require.Equal(t, 0, code)
+	var listed []common.StudentResult
+	require.NoError(t, json.Unmarshal([]byte(stdout), &listed))
+	assert.Len(t, listed, 12)
+}
+
+func TestStudentctl_SeedNDJSONNeedsNoDatabase(t *testing.T) {
+	var stdout, stderr bytes.Buffer
+	code := run(context.Background(), []string{"seed", "--count", "2", "--ndjson"}, &stdout, &stderr, func(string, string) (*gorm.DB, error) {
+		return nil, fmt.Errorf("no database")
+	})
+	require.Equal(t, 0, code, stderr.String())
+	assert.Len(t, strings.Split(strings.TrimSpace(stdout.String()), "\n"), 2)
+}
+
+func TestStudentctl_MapMajors(t *testing.T) {
+	ctl := newStudentctl(t)
+	created := ctl.createStudent("tranvu@example.com")
+	code, _, stderr := ctl.run("program", "create", "--code", "it", "--name", "Information Technology", "--department", "Computing", "--degree-level", "bachelor")
+	require.Equal(t, 0, code, stderr)
+
+	code, stdout, stderr := ctl.run("program", "map-majors", "--dry-run", "--alias", "CNTT=IT")
+	require.Equal(t, 0, code, stderr)
+	assert.Contains(t, stdout, "Mapped 1 students")
+
+	code, stdout, _ = ctl.run("program", "map-majors", "--output", "json")
+	require.Equal(t, 0, code)
+	assert.JSONEq(t, `{"Mapped":0,"Unmapped":{"CNTT":1}}`, stdout, "no alias, no match")
+
+	code, _, stderr = ctl.run("program", "map-majors", "--alias", "cntt=it")
+	require.Equal(t, 0, code, stderr)
+
+	code, stdout, _ = ctl.run("student", "get", created.StudentID.String(), "--output", "json")
+	require.Equal(t, 0, code)
+	var mapped common.StudentResult
+	require.NoError(t, json.Unmarshal([]byte(stdout), &mapped))
+	require.Len(t, mapped.Programs, 1)
+	assert.Equal(t, "major", mapped.Programs[0].Kind)
+	assert.True(t, created.EnrollmentDate.Equal(mapped.Programs[0].DeclaredAt))
+
+	code, stdout, _ = ctl.run("program", "map-majors", "--alias", "CNTT=IT")
+	require.Equal(t, 0, code)
+	assert.Contains(t, stdout, "Mapped 0 students", "declared majors are not mapped twice")
+}
+
+func TestStudentctl_NormalizePhones(t *testing.T) {
+	ctl := newStudentctl(t)
+	national := ctl.createStudent("tranvu@example.com")
+	short := ctl.createStudent("anle@example.com")
+	// Stored before phone numbers had to be E.164.
+	require.NoError(t, ctl.db.Model(&postgres.DBStudent{}).Where("student_id = ?", national.StudentID).Update("phone", "090 123 4567").Error)
+	require.NoError(t, ctl.db.Model(&postgres.DBStudent{}).Where("student_id = ?", short.StudentID).Update("phone", "12345").Error)
+
+	code, _, _ := ctl.run("student", "normalize-phones")
+	assert.Equal(t, 2, code, "--country-code is required")
+
+	code, stdout, stderr := ctl.run("--output", "json", "student", "normalize-phones", "--country-code", "+84")
+	require.Equal(t, 0, code, stderr)
+	assert.JSONEq(t, fmt.Sprintf(`{"Normalized":1,"Unconvertible":{%q:"12345"}}`, short.StudentID), stdout)
+
+	code, stdout, _ = ctl.run("--output", "json", "student", "get", national.StudentID.String())
+	require.Equal(t, 0, code)
+	var normalized common.StudentResult
+	require.NoError(t, json.Unmarshal([]byte(stdout), &normalized))
+	require.NotNil(t, normalized.Phone)
+	assert.Equal(t, "+84901234567", *normalized.Phone)
+	assert.Equal(t, national.Version+1, normalized.Version)
+}
@@ -0,0 +1,45 @@
+package main
+
+import (
+	"context"
+	"time"
+
+	"gorm.io/gorm"
+
+	"github.com/tranvu1111/go-students-new/internal/infrastructure/db/postgres"
+)
+
+// purgeIdempotency deletes idempotency records old enough that no client
+// will retry the request they belong to.
+func (a *app) purgeIdempotency(ctx context.Context, args []string) error {
+	fs := a.flagSet("idempotency purge")
+	olderThan := fs.Duration("older-than", 30*24*time.Hour, "delete records created longer ago than this")
+	if _, err := parseFlags(fs, args); err != nil {
+		return err
+	}
+	if *olderThan <= 0 {
+		return usagef("--older-than must be positive")
+	}
+
+	cutoff := time.Now().Add(-*olderThan)
+	return a.write(ctx, func(db *gorm.DB) error {
+		deleted, err := postgres.NewGormIdempotencyRepository(db).DeleteOlderThan(ctx, cutoff)
+		if err != nil {
+			return err
+		}
+		return a.printMessage(map[string]interface{}{"Deleted": deleted, "Cutoff": cutoff}, "Deleted %d idempotency records created before %s", deleted, cutoff.Format(time.RFC3339))
+	})
+}
+
+func (a *app) migrate(ctx context.Context, args []string) error {
+	if _, err := parseFlags(a.flagSet("migrate"), args); err != nil {
+		return err
+	}
+
+	return a.write(ctx, func(db *gorm.DB) error {
+		if err := postgres.AutoMigrate(db); err != nil {
+			return err
+		}
+		return a.printMessage(map[string]interface{}{"Migrated": true}, "Schema is up to date")
+	})
+}
@@ -0,0 +1,68 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"text/tabwriter"
+
+	"github.com/tranvu1111/go-students-new/internal/application/common"
+)
+
+func (a *app) printJSON(value interface{}) error {
+	encoder := json.NewEncoder(a.stdout)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(value)
+}
+
+func (a *app) printStudent(student *common.StudentResult) error {
+	if a.output == "json" {
+		return a.printJSON(student)
+	}
+	return a.printStudentTable([]*common.StudentResult{student})
+}
+
+func (a *app) printStudentList(students []*common.StudentResult) error {
+	if a.output == "json" {
+		if students == nil {
+			students = []*common.StudentResult{}
+		}
+		return a.printJSON(students)
+	}
+	return a.printStudentTable(students)
+}
+
+func (a *app) printStudentTable(students []*common.StudentResult) error {
+
+	w := tabwriter.NewWriter(a.stdout, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(w, "STUDENT ID\tFIRST NAME\tLAST NAME\tEMAIL\tMAJOR\tSTATUS\tENROLLED\tVERSION")
+	for _, student := range students {
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\t%s\t%d\n",
+			student.StudentID,
+			student.FirstName,
+			student.LastName,
+			student.Email,
+			valueOrDash(student.Major),
+			student.Status,
+			student.EnrollmentDate.Format("2006-01-02"),
+			student.Version,
+		)
+	}
+	return w.Flush()
+}
+
+// printMessage prints value under --output json and the formatted message
+// otherwise.
+func (a *app) printMessage(value interface{}, format string, args ...interface{}) error {
+	if a.output == "json" {
+		return a.printJSON(value)
+	}
+	_, err := fmt.Fprintf(a.stdout, format+"\n", args...)
+	return err
+}
+
+func valueOrDash(value *string) string {
+	if value == nil {
+		return "-"
+	}
+	return *value
+}
@@ -0,0 +1,151 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"text/tabwriter"
+
+	"gorm.io/gorm"
+
+	"github.com/tranvu1111/go-students-new/internal/application/command"
+	"github.com/tranvu1111/go-students-new/internal/application/common"
+	"github.com/tranvu1111/go-students-new/internal/application/query"
+)
+
+func (a *app) program(ctx context.Context, action string, args []string) error {
+	switch action {
+	case "list":
+		return a.listPrograms(ctx, args)
+	case "create":
+		return a.createProgram(ctx, args)
+	case "map-majors":
+		return a.mapMajors(ctx, args)
+	default:
+		return usagef("unknown program command %q", action)
+	}
+}
+
+func (a *app) listPrograms(ctx context.Context, args []string) error {
+	fs := a.flagSet("program list")
+	department := fs.String("department", "", "only programs of this department")
+	degreeLevel := fs.String("degree-level", "", "only programs of this degree level")
+	activeOnly := fs.Bool("active", false, "only programs still offered")
+	if _, err := parseFlags(fs, args); err != nil {
+		return err
+	}
+
+	listQuery := &query.ProgramListQuery{Department: *department, DegreeLevel: *degreeLevel}
+	if *activeOnly {
+		listQuery.Active = activeOnly
+	}
+
+	db, err := a.database()
+	if err != nil {
+		return err
+	}
+	programs, err := newProgramService(db).FindAllPrograms(ctx, listQuery)
+	if err != nil {
+		return err
+	}
+	return a.printProgramList(programs.Result)
+}
+
+func (a *app) createProgram(ctx context.Context, args []string) error {
+	fs := a.flagSet("program create")
+	code := fs.String("code", "", "program code, e.g. CS (required)")
+	name := fs.String("name", "", "program name (required)")
+	department := fs.String("department", "", "department (required)")
+	degreeLevel := fs.String("degree-level", "", "certificate, associate, bachelor, master or doctorate (required)")
+	if _, err := parseFlags(fs, args); err != nil {
+		return err
+	}
+
+	createCommand := &command.CreateProgramCommand{
+		Code:        *code,
+		Name:        *name,
+		Department:  *department,
+		DegreeLevel: *degreeLevel,
+	}
+
+	return a.write(ctx, func(db *gorm.DB) error {
+		result, err := newProgramService(db).CreateProgram(ctx, createCommand)
+		if err != nil {
+			return err
+		}
+		return a.printProgramList([]*common.ProgramResult{result.Result})
+	})
+}
+
+// mapMajors declares catalog majors for the students whose free-text major
+// names a program. Run it with --dry-run first to see what stays unmapped.
+func (a *app) mapMajors(ctx context.Context, args []string) error {
+	fs := a.flagSet("program map-majors")
+	aliases := aliasFlag{}
+	fs.Var(aliases, "alias", `another spelling of a program, "Text=CODE"; may be repeated`)
+	if _, err := parseFlags(fs, args); err != nil {
+		return err
+	}
+
+	return a.write(ctx, func(db *gorm.DB) error {
+		result, err := newProgramService(db).MapFreeTextMajors(ctx, &command.MapFreeTextMajorsCommand{Aliases: aliases})
+		if err != nil {
+			return err
+		}
+		return a.printMapMajorsResult(result)
+	})
+}
+
+// aliasFlag collects repeated --alias Text=CODE flags.
+type aliasFlag map[string]string
+
+func (f aliasFlag) String() string {
+	return ""
+}
+
+func (f aliasFlag) Set(value string) error {
+	text, code, ok := strings.Cut(value, "=")
+	if !ok || strings.TrimSpace(text) == "" || strings.TrimSpace(code) == "" {
+		return fmt.Errorf("want Text=CODE, got %q", value)
+	}
+	f[strings.TrimSpace(text)] = strings.TrimSpace(code)
+	return nil
+}
+
+func (a *app) printProgramList(programs []*common.ProgramResult) error {
+	if a.output == "json" {
+		return a.printJSON(programs)
+	}
+
+	w := tabwriter.NewWriter(a.stdout, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(w, "PROGRAM ID\tCODE\tNAME\tDEPARTMENT\tDEGREE LEVEL\tACTIVE")
+	for _, program := range programs {
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%t\n",
+			program.ID,
+			program.Code,
+			program.Name,
+			program.Department,
+			program.DegreeLevel,
+			program.Active,
+		)
+	}
+	return w.Flush()
+}
+
+func (a *app) printMapMajorsResult(result *command.MapFreeTextMajorsCommandResult) error {
+	if a.output == "json" {
+		return a.printJSON(result)
+	}
+
+	fmt.Fprintf(a.stdout, "Mapped %d students\n", result.Mapped)
+	majors := make([]string, 0, len(result.Unmapped))
+	for major := range result.Unmapped {
+		majors = append(majors, major)
+	}
+	sort.Strings(majors)
+	for _, major := range majors {
+		fmt.Fprintf(a.stdout, "unmapped %q: %d students\n", major, result.Unmapped[major])
+	}
+	return nil
+}
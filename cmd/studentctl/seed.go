@@ -0,0 +1,64 @@
+package main
+
+import (
+	"context"
+	"io"
+	"os"
+	"strings"
+
+	"gorm.io/gorm"
+
+	"github.com/tranvu1111/go-students-new/internal/infrastructure/db/postgres"
+	"github.com/tranvu1111/go-students-new/internal/infrastructure/seed"
+)
+
+// seed generates synthetic students and stores them, or with --ndjson writes
+// them in the import format to --out or standard output without connecting
+// to the database.
+func (a *app) seed(ctx context.Context, args []string) error {
+	fs := a.flagSet("seed")
+	count := fs.Int("count", 100, "number of students")
+	seedValue := fs.Int64("seed", 1, "the same seed generates the same students")
+	majors := fs.String("majors", "", "comma separated majors to draw from")
+	emailDomain := fs.String("email-domain", seed.DefaultEmailDomain, "domain of the generated email addresses")
+	batchSize := fs.Int("batch-size", seed.DefaultBatchSize, "rows inserted per statement")
+	ndjson := fs.Bool("ndjson", false, "write NDJSON for import instead of saving")
+	out := fs.String("out", "", "NDJSON output file, standard output by default")
+	if _, err := parseFlags(fs, args); err != nil {
+		return err
+	}
+	if *count <= 0 {
+		return usagef("--count must be positive")
+	}
+	if *out != "" && !*ndjson {
+		return usagef("--out requires --ndjson")
+	}
+
+	options := seed.Options{Seed: *seedValue, EmailDomain: *emailDomain}
+	for _, major := range strings.Split(*majors, ",") {
+		if major = strings.TrimSpace(major); major != "" {
+			options.Majors = append(options.Majors, major)
+		}
+	}
+	students := seed.NewGenerator(options).Generate(*count)
+
+	if *ndjson {
+		var target io.Writer = a.stdout
+		if *out != "" {
+			file, err := os.Create(*out)
+			if err != nil {
+				return err
+			}
+			defer file.Close()
+			target = file
+		}
+		return seed.WriteNDJSON(target, students)
+	}
+
+	return a.write(ctx, func(db *gorm.DB) error {
+		if err := seed.Insert(ctx, postgres.NewGormStudentRepo(db), students, *batchSize); err != nil {
+			return err
+		}
+		return a.printMessage(map[string]interface{}{"Created": len(students), "Seed": *seedValue}, "Created %d students from seed %d", len(students), *seedValue)
+	})
+}
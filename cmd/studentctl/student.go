@@ -0,0 +1,325 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+
+	"github.com/tranvu1111/go-students-new/internal/application/command"
+	"github.com/tranvu1111/go-students-new/internal/application/query"
+	"github.com/tranvu1111/go-students-new/internal/domain/entities"
+	"github.com/tranvu1111/go-students-new/internal/domain/repositories"
+	"github.com/tranvu1111/go-students-new/internal/infrastructure/db/postgres"
+)
+
+func (a *app) student(ctx context.Context, action string, args []string) error {
+	switch action {
+	case "get":
+		return a.getStudent(ctx, args)
+	case "list":
+		return a.listStudents(ctx, args)
+	case "create":
+		return a.createStudent(ctx, args)
+	case "update":
+		return a.updateStudent(ctx, args)
+	case "delete":
+		return a.deleteStudent(ctx, args)
+	case "restore":
+		return a.restoreStudent(ctx, args)
+	case "normalize-phones":
+		return a.normalizePhones(ctx, args)
+	default:
+		return usagef("unknown student command %q", action)
+	}
+}
+
+func (a *app) getStudent(ctx context.Context, args []string) error {
+	id, err := a.studentIDArg(a.flagSet("student get"), args)
+	if err != nil {
+		return err
+	}
+
+	service, err := a.studentService()
+	if err != nil {
+		return err
+	}
+	student, err := service.FindStudentById(ctx, id)
+	if err != nil {
+		return err
+	}
+	return a.printStudent(student.Result)
+}
+
+func (a *app) listStudents(ctx context.Context, args []string) error {
+	fs := a.flagSet("student list")
+	major := fs.String("major", "", "only students of this major")
+	status := fs.String("status", "", "only students with this status")
+	enrolledFrom := fs.String("enrolled-from", "", "only students enrolled on or after this date")
+	enrolledTo := fs.String("enrolled-to", "", "only students enrolled on or before this date")
+	offset := fs.Int("offset", 0, "students to skip")
+	limit := fs.Int("limit", 0, "maximum number of students, 0 for all")
+	if _, err := parseFlags(fs, args); err != nil {
+		return err
+	}
+
+	listQuery := &query.StudentListQuery{Offset: *offset, Limit: *limit}
+	if *major != "" {
+		listQuery.Major = major
+	}
+	if *status != "" {
+		parsed, err := entities.ParseStudentStatus(*status)
+		if err != nil {
+			return usagef("invalid --status: want one of %s", joinStatuses())
+		}
+		listQuery.Status = &parsed
+	}
+	var err error
+	if listQuery.EnrolledFrom, err = parseOptionalDate("enrolled-from", *enrolledFrom); err != nil {
+		return err
+	}
+	if listQuery.EnrolledTo, err = parseOptionalDate("enrolled-to", *enrolledTo); err != nil {
+		return err
+	}
+
+	service, err := a.studentService()
+	if err != nil {
+		return err
+	}
+	students, err := service.FindAllStudent(ctx, listQuery)
+	if err != nil {
+		return err
+	}
+	return a.printStudentList(students.Result)
+}
+
+func (a *app) createStudent(ctx context.Context, args []string) error {
+	fs := a.flagSet("student create")
+	firstName := fs.String("first-name", "", "first name (required)")
+	lastName := fs.String("last-name", "", "last name (required)")
+	email := fs.String("email", "", "email (required)")
+	enrollmentDate := fs.String("enrollment-date", "", "enrollment date, YYYY-MM-DD (required)")
+	dateOfBirth := fs.String("date-of-birth", "", "date of birth, YYYY-MM-DD")
+	phone := fs.String("phone", "", "phone number")
+	major := fs.String("major", "", "major")
+	idempotencyKey := fs.String("idempotency-key", "", "makes retries of this create safe")
+	if _, err := parseFlags(fs, args); err != nil {
+		return err
+	}
+
+	enrolled, err := parseOptionalDate("enrollment-date", *enrollmentDate)
+	if err != nil {
+		return err
+	}
+	if enrolled == nil {
+		return usagef("--enrollment-date is required")
+	}
+	born, err := parseOptionalDate("date-of-birth", *dateOfBirth)
+	if err != nil {
+		return err
+	}
+
+	createCommand := &command.CreateStudentCommand{
+		IdempotencyKey: *idempotencyKey,
+		FirstName:      *firstName,
+		LastName:       *lastName,
+		DateOfBirth:    born,
+		Email:          *email,
+		Phone:          optionalFlag(*phone),
+		Major:          optionalFlag(*major),
+		EnrollmentDate: *enrolled,
+	}
+
+	return a.write(ctx, func(db *gorm.DB) error {
+		result, err := newStudentService(db).CreateStudent(ctx, createCommand)
+		if err != nil {
+			return err
+		}
+		return a.printStudent(result.Result)
+	})
+}
+
+func (a *app) updateStudent(ctx context.Context, args []string) error {
+	fs := a.flagSet("student update")
+	version := fs.Int("version", 0, "the version being changed (required)")
+	dateOfBirth := fs.String("date-of-birth", "", "new date of birth, YYYY-MM-DD")
+	phone := fs.String("phone", "", "new phone number")
+	major := fs.String("major", "", "new major")
+	id, err := a.studentIDArg(fs, args)
+	if err != nil {
+		return err
+	}
+	if *version <= 0 {
+		return usagef("--version is required; see the Version of student get")
+	}
+	born, err := parseOptionalDate("date-of-birth", *dateOfBirth)
+	if err != nil {
+		return err
+	}
+
+	updateCommand := &command.UpdateStudentCommand{
+		StudentId:       id,
+		DateOfBirth:     born,
+		Phone:           optionalFlag(*phone),
+		Major:           optionalFlag(*major),
+		ExpectedVersion: *version,
+	}
+
+	return a.write(ctx, func(db *gorm.DB) error {
+		result, err := newStudentService(db).UpdateStudent(ctx, updateCommand)
+		if err != nil {
+			return err
+		}
+		return a.printStudent(result.Result)
+	})
+}
+
+func (a *app) deleteStudent(ctx context.Context, args []string) error {
+	fs := a.flagSet("student delete")
+	version := fs.Int("version", 0, "the version being deleted; 0 deletes whatever is stored")
+	id, err := a.studentIDArg(fs, args)
+	if err != nil {
+		return err
+	}
+
+	return a.write(ctx, func(db *gorm.DB) error {
+		if err := newStudentService(db).DeleteStudent(ctx, id, *version); err != nil {
+			return err
+		}
+		return a.printMessage(map[string]interface{}{"StudentID": id, "Deleted": true}, "Deleted student %s", id)
+	})
+}
+
+func (a *app) restoreStudent(ctx context.Context, args []string) error {
+	id, err := a.studentIDArg(a.flagSet("student restore"), args)
+	if err != nil {
+		return err
+	}
+
+	return a.write(ctx, func(db *gorm.DB) error {
+		result, err := newStudentService(db).RestoreStudent(ctx, id)
+		if err != nil {
+			return err
+		}
+		return a.printStudent(result.Result)
+	})
+}
+
+// studentIDArg parses fs and the single student ID argument.
+func (a *app) studentIDArg(fs *flag.FlagSet, args []string) (uuid.UUID, error) {
+	positional, err := parseFlags(fs, args)
+	if err != nil {
+		return uuid.Nil, err
+	}
+	if len(positional) != 1 {
+		return uuid.Nil, usagef("expected exactly one student ID")
+	}
+
+	id, err := uuid.Parse(positional[0])
+	if err != nil {
+		return uuid.Nil, usagef("invalid student ID: %v", err)
+	}
+	return id, nil
+}
+
+func joinStatuses() string {
+	statuses := make([]string, len(entities.StudentStatuses))
+	for i, status := range entities.StudentStatuses {
+		statuses[i] = string(status)
+	}
+	return strings.Join(statuses, ", ")
+}
+
+func parseOptionalDate(name string, value string) (*time.Time, error) {
+	if value == "" {
+		return nil, nil
+	}
+	parsed, err := time.Parse("2006-01-02", value)
+	if err != nil {
+		if parsed, err = time.Parse(time.RFC3339, value); err != nil {
+			return nil, usagef("invalid --%s: want YYYY-MM-DD", name)
+		}
+	}
+	return &parsed, nil
+}
+
+func optionalFlag(value string) *string {
+	if value == "" {
+		return nil
+	}
+	return &value
+}
+
+var countryCallingCodeRegex = regexp.MustCompile(`^[1-9][0-9]{0,2}$`)
+
+// normalizePhones rewrites the phone numbers stored before numbers had to be
+// E.164. A national number such as 0901234567 gets the country code in place
+// of its leading 0; numbers that still do not validate are only reported.
+func (a *app) normalizePhones(ctx context.Context, args []string) error {
+	fs := a.flagSet("student normalize-phones")
+	countryCode := fs.String("country-code", "", "calling code of national numbers, e.g. 84 (required)")
+	if _, err := parseFlags(fs, args); err != nil {
+		return err
+	}
+	*countryCode = strings.TrimPrefix(*countryCode, "+")
+	if !countryCallingCodeRegex.MatchString(*countryCode) {
+		return usagef("--country-code must be a calling code such as 84")
+	}
+
+	type legacyPhone struct {
+		id      uuid.UUID
+		version int
+		phone   string
+	}
+	return a.write(ctx, func(db *gorm.DB) error {
+		// Collect first and write after, like program map-majors.
+		var legacy []legacyPhone
+		err := postgres.NewGormStudentRepo(db).Stream(ctx, repositories.StudentFilter{}, func(student *entities.Student) error {
+			if student.Phone != nil && !entities.IsE164(*student.Phone) {
+				legacy = append(legacy, legacyPhone{student.StudentID, student.Version, *student.Phone})
+			}
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+
+		service := newStudentService(db)
+		normalized, unconvertible := 0, map[string]string{}
+		for _, student := range legacy {
+			phone := entities.NormalizePhoneNumber(student.phone)
+			if strings.HasPrefix(phone, "0") {
+				phone = "+" + *countryCode + phone[1:]
+			}
+			if !entities.IsE164(phone) {
+				unconvertible[student.id.String()] = student.phone
+				continue
+			}
+
+			_, err := service.PatchStudent(ctx, &command.PatchStudentCommand{
+				StudentId:       student.id,
+				Patch:           entities.StudentPatch{Phone: entities.Replace(phone)},
+				ExpectedVersion: student.version,
+			})
+			if err != nil {
+				return err
+			}
+			normalized++
+		}
+
+		if err := a.printMessage(map[string]interface{}{"Normalized": normalized, "Unconvertible": unconvertible}, "Normalized %d phone numbers", normalized); err != nil {
+			return err
+		}
+		if a.output != "json" {
+			for id, phone := range unconvertible {
+				fmt.Fprintf(a.stdout, "cannot convert %q of student %s\n", phone, id)
+			}
+		}
+		return nil
+	})
+}
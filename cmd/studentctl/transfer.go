@@ -0,0 +1,152 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/tranvu1111/go-students-new/internal/application/command"
+	"github.com/tranvu1111/go-students-new/internal/application/common"
+	"github.com/tranvu1111/go-students-new/internal/application/query"
+	"github.com/tranvu1111/go-students-new/internal/interface/api/rest/dto/mapper"
+	"github.com/tranvu1111/go-students-new/internal/interface/api/rest/dto/request"
+	"github.com/tranvu1111/go-students-new/internal/interface/api/rest/export"
+)
+
+// importStudents reads the same CSV and NDJSON files as POST
+// /api/v2/students/import. --dry-run validates every row without saving.
+func (a *app) importStudents(ctx context.Context, args []string) error {
+	fs := a.flagSet("import")
+	format := fs.String("format", "", "csv or ndjson; defaults to the file extension")
+	mode := fs.String("mode", string(command.ImportModeAllOrNothing), "all_or_nothing or best_effort")
+	batchSize := fs.Int("batch-size", 0, "rows inserted per statement")
+	positional, err := parseFlags(fs, args)
+	if err != nil {
+		return err
+	}
+	if len(positional) != 1 {
+		return usagef("usage: studentctl import <file> [--format csv|ndjson]")
+	}
+
+	file, err := os.Open(positional[0])
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	if *format == "" {
+		*format = strings.TrimPrefix(strings.ToLower(filepath.Ext(positional[0])), ".")
+	}
+
+	var rows command.StudentRowReader
+	switch *format {
+	case "csv":
+		if rows, err = request.NewCSVStudentReader(file); err != nil {
+			return err
+		}
+	case "ndjson", "jsonl":
+		rows = request.NewNDJSONStudentReader(file)
+	default:
+		return usagef("import format must be csv or ndjson")
+	}
+
+	service, err := a.studentService()
+	if err != nil {
+		return err
+	}
+	result, err := service.ImportStudents(ctx, &command.ImportStudentsCommand{
+		Rows:      rows,
+		Mode:      command.ImportMode(*mode),
+		DryRun:    a.dryRun,
+		BatchSize: *batchSize,
+	})
+	if err != nil {
+		return err
+	}
+	if err := a.printImportResult(result); err != nil {
+		return err
+	}
+	if !result.DryRun && !result.Committed {
+		return fmt.Errorf("import rolled back: %d of %d rows failed", result.Failed, result.Total)
+	}
+	return nil
+}
+
+func (a *app) printImportResult(result *command.ImportStudentsCommandResult) error {
+	if a.output == "json" {
+		return a.printJSON(mapper.ToImportStudentsResponse(result))
+	}
+
+	fmt.Fprintf(a.stdout, "%d rows, %d created, %d failed (mode %s, dry run %t, committed %t)\n",
+		result.Total, result.Created, result.Failed, result.Mode, result.DryRun, result.Committed)
+	if result.Failed == 0 {
+		return nil
+	}
+
+	w := tabwriter.NewWriter(a.stdout, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(w, "ROW\tERROR")
+	for _, row := range result.Rows {
+		if row.Error != "" {
+			fmt.Fprintf(w, "%d\t%s\n", row.Row, row.Error)
+		}
+	}
+	return w.Flush()
+}
+
+// exportStudents writes the same files as GET /api/v2/students/export, to
+// --out or else to standard output.
+func (a *app) exportStudents(ctx context.Context, args []string) error {
+	fs := a.flagSet("export")
+	formatName := fs.String("format", "csv", "csv, ndjson or xlsx")
+	columnNames := fs.String("columns", "", "comma separated columns, all by default")
+	major := fs.String("major", "", "only students of this major")
+	out := fs.String("out", "", "output file, standard output by default")
+	if _, err := parseFlags(fs, args); err != nil {
+		return err
+	}
+
+	format, err := export.LookupFormat(*formatName)
+	if err != nil {
+		return usagef("%v", err)
+	}
+	columns, err := export.SelectColumns(*columnNames)
+	if err != nil {
+		return usagef("%v", err)
+	}
+
+	service, err := a.studentService()
+	if err != nil {
+		return err
+	}
+
+	var target io.Writer = a.stdout
+	if *out != "" {
+		file, err := os.Create(*out)
+		if err != nil {
+			return err
+		}
+		defer file.Close()
+		target = file
+	}
+
+	listQuery := &query.StudentListQuery{}
+	if *major != "" {
+		listQuery.Major = major
+	}
+
+	writer := format.NewWriter(target)
+	if err := writer.WriteHeader(columns); err != nil {
+		return err
+	}
+	err = service.ExportStudents(ctx, listQuery, func(student *common.StudentResult) error {
+		return writer.WriteRow(export.RowValues(mapper.ToStudentResponse(student), columns))
+	})
+	if err != nil {
+		return err
+	}
+	return writer.Close()
+}
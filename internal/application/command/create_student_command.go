@@ -5,6 +5,7 @@ import (
 
 	"github.com/google/uuid"
 	"github.com/tranvu1111/go-students-new/internal/application/common"
+	"github.com/tranvu1111/go-students-new/internal/domain/entities"
 )
 
 type CreateStudentCommand struct {
@@ -16,7 +17,9 @@ type CreateStudentCommand struct {
 	Email 			string 	
 	Phone 			*string 
 	Major 			*string 
+	Locale 			*string
 	EnrollmentDate 	time.Time 
+	Programs 		[]entities.StudentProgram
 }
 
 
@@ -0,0 +1,52 @@
+package command
+
+import (
+	"errors"
+
+	"github.com/google/uuid"
+)
+
+type ImportMode string
+
+const (
+	// ImportModeAllOrNothing creates no student unless every row is valid and stored.
+	ImportModeAllOrNothing ImportMode = "all_or_nothing"
+	// ImportModeBestEffort creates every valid row and reports the others.
+	ImportModeBestEffort ImportMode = "best_effort"
+)
+
+// ErrImportUnreadable is wrapped by a StudentRowReader that cannot read past
+// the current row, e.g. because a line is too long or the body broke off.
+var ErrImportUnreadable = errors.New("the rest of the import cannot be read")
+
+// StudentRowReader streams import rows. Next returns io.EOF after the last
+// row. An error wrapping ErrImportUnreadable is reported against that row and
+// ends the import; any other error is reported against that row and reading
+// continues.
+type StudentRowReader interface {
+	Next() (*CreateStudentCommand, error)
+}
+
+type ImportStudentsCommand struct {
+	Rows 		StudentRowReader
+	Mode 		ImportMode
+	DryRun 		bool
+	BatchSize 	int
+}
+
+type ImportRowResult struct {
+	Row 		int
+	StudentID 	*uuid.UUID
+	Error 		string
+}
+
+type ImportStudentsCommandResult struct {
+	Mode 		ImportMode
+	DryRun 		bool
+	// Committed is false when an all-or-nothing import was rolled back.
+	Committed 	bool
+	Total 		int
+	Created 	int
+	Failed 		int
+	Rows 		[]ImportRowResult
+}
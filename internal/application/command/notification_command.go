@@ -0,0 +1,31 @@
+package command
+
+import (
+	"github.com/google/uuid"
+	"github.com/tranvu1111/go-students-new/internal/application/common"
+)
+
+type CreateNotificationTemplateCommand struct {
+	EventType 	string
+	Channel 	string
+	Locale 		string
+	Subject 	string
+	Body 		string
+	HTMLBody 	string
+}
+
+type UpdateNotificationTemplateCommand struct {
+	TemplateID 	uuid.UUID
+	Subject 	string
+	Body 		string
+	HTMLBody 	string
+	Active 		bool
+}
+
+type NotificationTemplateCommandResult struct {
+	Result *common.NotificationTemplateResult
+}
+
+type NotificationCommandResult struct {
+	Result *common.NotificationResult
+}
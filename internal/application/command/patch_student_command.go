@@ -0,0 +1,14 @@
+package command
+
+import (
+	"github.com/google/uuid"
+	"github.com/tranvu1111/go-students-new/internal/domain/entities"
+)
+
+// PatchStudentCommand changes only the fields set in Patch.
+type PatchStudentCommand struct {
+	StudentId 	uuid.UUID
+	Patch 		entities.StudentPatch
+	// ExpectedVersion is the version the caller last read; 0 skips the check.
+	ExpectedVersion int
+}
@@ -0,0 +1,38 @@
+package command
+
+import (
+	"github.com/google/uuid"
+	"github.com/tranvu1111/go-students-new/internal/application/common"
+)
+
+type CreateProgramCommand struct {
+	Code 		string
+	Name 		string
+	Department 	string
+	DegreeLevel string
+}
+
+type UpdateProgramCommand struct {
+	ProgramID 	uuid.UUID
+	Name 		string
+	Department 	string
+	DegreeLevel string
+	Active 		bool
+}
+
+type ProgramCommandResult struct {
+	Result *common.ProgramResult
+}
+
+// MapFreeTextMajorsCommand declares a major for every student whose free-text
+// major names a catalog program, by code or name, ignoring case. Aliases add
+// other spellings, from the text to a program code.
+type MapFreeTextMajorsCommand struct {
+	Aliases map[string]string
+}
+
+type MapFreeTextMajorsCommandResult struct {
+	Mapped 		int
+	// Unmapped counts the students of every major no program matched.
+	Unmapped 	map[string]int
+}
@@ -0,0 +1,26 @@
+package command
+
+import (
+	"github.com/google/uuid"
+	"github.com/tranvu1111/go-students-new/internal/domain/entities"
+)
+
+// SaveStudentContactCommand adds or replaces one contact of a student. Exactly
+// one of Address, Email, Phone and EmergencyContact is set; a nil ID adds it.
+type SaveStudentContactCommand struct {
+	StudentId 			uuid.UUID
+	Address 			*entities.Address
+	Email 				*entities.ContactEmail
+	Phone 				*entities.ContactPhone
+	EmergencyContact 	*entities.EmergencyContact
+	// ExpectedVersion is the version the caller last read; 0 skips the check.
+	ExpectedVersion 	int
+}
+
+type RemoveStudentContactCommand struct {
+	StudentId 		uuid.UUID
+	Kind 			entities.ContactKind
+	ContactId 		uuid.UUID
+	// ExpectedVersion is the version the caller last read; 0 skips the check.
+	ExpectedVersion int
+}
@@ -0,0 +1,19 @@
+package command
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/tranvu1111/go-students-new/internal/domain/entities"
+)
+
+// TransitionStudentStatusCommand moves a student to Status; the student
+// entity decides whether the transition is allowed.
+type TransitionStudentStatusCommand struct {
+	StudentId 		uuid.UUID
+	Status 			entities.StudentStatus
+	Reason 			string
+	EffectiveDate 	time.Time
+	// ExpectedVersion is the version the caller last read; 0 skips the check.
+	ExpectedVersion int
+}
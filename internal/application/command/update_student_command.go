@@ -5,6 +5,7 @@ import (
 
 	"github.com/google/uuid"
 	"github.com/tranvu1111/go-students-new/internal/application/common"
+	"github.com/tranvu1111/go-students-new/internal/domain/entities"
 )
 
 type UpdateStudentCommand struct {
@@ -13,7 +14,10 @@ type UpdateStudentCommand struct {
 	DateOfBirth 	*time.Time 	 	
 	Phone 			*string 
 	Major 			*string 
-	
+	// Programs replaces the declared programs; nil keeps them.
+	Programs 		*[]entities.StudentProgram
+	// ExpectedVersion is the version the caller last read; 0 skips the check.
+	ExpectedVersion int
 }
 
 type UpdateStudentCommandResult struct {
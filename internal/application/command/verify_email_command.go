@@ -0,0 +1,7 @@
+package command
+
+// VerifyEmailCommand proves ownership of a student's email with the token
+// mailed to it.
+type VerifyEmailCommand struct {
+	Token 	string
+}
@@ -0,0 +1,25 @@
+package command
+
+import (
+	"github.com/google/uuid"
+	"github.com/tranvu1111/go-students-new/internal/application/common"
+)
+
+type CreateWebhookSubscriptionCommand struct {
+	URL 		string
+	EventTypes 	[]string
+	Secret 		string
+}
+
+type UpdateWebhookSubscriptionCommand struct {
+	SubscriptionID 	uuid.UUID
+	URL 			string
+	EventTypes 		[]string
+	// Secret is kept when empty.
+	Secret 			string
+	Active 			bool
+}
+
+type WebhookSubscriptionCommandResult struct {
+	Result *common.WebhookSubscriptionResult
+}
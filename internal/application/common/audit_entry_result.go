@@ -0,0 +1,22 @@
+package common
+
+import (
+	"time"
+	"github.com/google/uuid"
+)
+
+type FieldChangeResult struct {
+	Field 	string
+	Before 	*string
+	After 	*string
+}
+
+type AuditEntryResult struct {
+	ID 			uuid.UUID
+	StudentID 	uuid.UUID
+	Actor 		string
+	RequestID 	string
+	Operation 	string
+	Changes 	[]FieldChangeResult
+	OccurredAt 	time.Time
+}
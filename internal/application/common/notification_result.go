@@ -0,0 +1,40 @@
+package common
+
+import (
+	"time"
+	"github.com/google/uuid"
+)
+
+type NotificationTemplateResult struct {
+	ID 			uuid.UUID
+	EventType 	string
+	Channel 	string
+	Locale 		string
+	Subject 	string
+	Body 		string
+	HTMLBody 	string
+	Active 		bool
+	CreatedAt 	time.Time
+	UpdatedAt 	time.Time
+}
+
+type NotificationResult struct {
+	ID 				uuid.UUID
+	TemplateID 		uuid.UUID
+	EventID 		uuid.UUID
+	EventType 		string
+	StudentID 		uuid.UUID
+	Channel 		string
+	Locale 			string
+	Recipient 		string
+	Subject 		string
+	Text 			string
+	HTML 			string
+	Status 			string
+	Attempts 		int
+	NextAttemptAt 	time.Time
+	LastError 		string
+	SentAt 			*time.Time
+	CreatedAt 		time.Time
+	UpdatedAt 		time.Time
+}
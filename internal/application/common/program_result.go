@@ -0,0 +1,23 @@
+package common
+
+import (
+	"time"
+	"github.com/google/uuid"
+)
+
+type ProgramResult struct {
+	ID 			uuid.UUID
+	Code 		string
+	Name 		string
+	Department 	string
+	DegreeLevel string
+	Active 		bool
+	CreatedAt 	time.Time
+	UpdatedAt 	time.Time
+}
+
+type StudentProgramResult struct {
+	ProgramID 	uuid.UUID
+	Kind 		string
+	DeclaredAt 	time.Time
+}
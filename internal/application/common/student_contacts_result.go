@@ -0,0 +1,50 @@
+package common
+
+import (
+	"time"
+	"github.com/google/uuid"
+)
+
+type StudentContactsResult struct {
+	Addresses 			[]AddressResult
+	Emails 				[]ContactEmailResult
+	Phones 				[]ContactPhoneResult
+	EmergencyContacts 	[]EmergencyContactResult
+}
+
+type AddressResult struct {
+	ID 			uuid.UUID
+	Type 		string
+	Line1 		string
+	Line2 		string
+	City 		string
+	Region 		string
+	PostalCode 	string
+	Country 	string
+	ValidFrom 	time.Time
+	ValidTo 	*time.Time
+}
+
+type ContactEmailResult struct {
+	ID 				uuid.UUID
+	Address 		string
+	Primary 		bool
+	Verification 	string
+	VerifiedAt 		*time.Time
+}
+
+type ContactPhoneResult struct {
+	ID 				uuid.UUID
+	Number 			string
+	Primary 		bool
+	Verification 	string
+	VerifiedAt 		*time.Time
+}
+
+type EmergencyContactResult struct {
+	ID 				uuid.UUID
+	Name 			string
+	Relationship 	string
+	Phone 			string
+	Email 			*string
+}
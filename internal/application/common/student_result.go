@@ -11,9 +11,17 @@ type StudentResult struct {
 	LastName 		string 
 	DateOfBirth 	*time.Time 
 	Email 			string 	
+	EmailVerifiedAt *time.Time
 	Phone 			*string 
 	Major 			*string
+	Locale 			*string
+	Programs 		[]StudentProgramResult
+	Contacts 		StudentContactsResult
 	CreatedAt 		time.Time
 	UpdatedAt 		time.Time 
 	EnrollmentDate 	time.Time 
+	Status 			string
+	StatusReason 	string
+	StatusEffectiveDate time.Time
+	Version 		int
 }
\ No newline at end of file
@@ -0,0 +1,9 @@
+package common
+
+// StudentSearchHitResult is one ranked search result. Highlights holds the
+// matching fields with the matched words wrapped in <em> tags.
+type StudentSearchHitResult struct {
+	Student 	*StudentResult
+	Score 		float64
+	Highlights 	map[string]string
+}
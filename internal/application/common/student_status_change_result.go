@@ -0,0 +1,18 @@
+package common
+
+import (
+	"time"
+	"github.com/google/uuid"
+)
+
+type StudentStatusChangeResult struct {
+	ID 				uuid.UUID
+	StudentID 		uuid.UUID
+	From 			string
+	To 				string
+	Reason 			string
+	EffectiveDate 	time.Time
+	Actor 			string
+	RequestID 		string
+	RecordedAt 		time.Time
+}
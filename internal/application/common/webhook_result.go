@@ -0,0 +1,30 @@
+package common
+
+import (
+	"time"
+	"github.com/google/uuid"
+)
+
+// WebhookSubscriptionResult never carries the secret back out.
+type WebhookSubscriptionResult struct {
+	ID 			uuid.UUID
+	URL 		string
+	EventTypes 	[]string
+	Active 		bool
+	CreatedAt 	time.Time
+	UpdatedAt 	time.Time
+}
+
+type WebhookDeliveryResult struct {
+	ID 				uuid.UUID
+	SubscriptionID 	uuid.UUID
+	EventID 		uuid.UUID
+	EventType 		string
+	Status 			string
+	Attempts 		int
+	NextAttemptAt 	time.Time
+	LastStatusCode 	int
+	LastError 		string
+	CreatedAt 		time.Time
+	UpdatedAt 		time.Time
+}
@@ -0,0 +1,11 @@
+package interfaces
+
+import (
+	"context"
+
+	"github.com/tranvu1111/go-students-new/internal/application/command"
+)
+
+type EmailVerificationService interface {
+	VerifyEmail(ctx context.Context, verifyCommand *command.VerifyEmailCommand)(*command.UpdateStudentCommandResult, error)
+}
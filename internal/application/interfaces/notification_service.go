@@ -0,0 +1,19 @@
+package interfaces
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/tranvu1111/go-students-new/internal/application/command"
+	"github.com/tranvu1111/go-students-new/internal/application/query"
+)
+
+type NotificationService interface {
+	CreateTemplate(ctx context.Context, createCommand *command.CreateNotificationTemplateCommand)(*command.NotificationTemplateCommandResult, error)
+	FindAllTemplates(ctx context.Context, listQuery *query.NotificationTemplateListQuery)(*query.NotificationTemplateQueryListResult, error)
+	FindTemplateById(ctx context.Context, id uuid.UUID)(*query.NotificationTemplateQueryResult, error)
+	UpdateTemplate(ctx context.Context, updateCommand *command.UpdateNotificationTemplateCommand)(*command.NotificationTemplateCommandResult, error)
+	DeleteTemplate(ctx context.Context, id uuid.UUID)(error)
+	FindNotifications(ctx context.Context, listQuery *query.NotificationListQuery)(*query.NotificationQueryListResult, error)
+	RetryNotification(ctx context.Context, id uuid.UUID)(*command.NotificationCommandResult, error)
+}
@@ -0,0 +1,17 @@
+package interfaces
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/tranvu1111/go-students-new/internal/application/command"
+	"github.com/tranvu1111/go-students-new/internal/application/query"
+)
+
+type ProgramService interface {
+	CreateProgram(ctx context.Context, createCommand *command.CreateProgramCommand)(*command.ProgramCommandResult, error)
+	FindAllPrograms(ctx context.Context, listQuery *query.ProgramListQuery)(*query.ProgramQueryListResult, error)
+	FindProgramById(ctx context.Context, id uuid.UUID)(*query.ProgramQueryResult, error)
+	UpdateProgram(ctx context.Context, updateCommand *command.UpdateProgramCommand)(*command.ProgramCommandResult, error)
+	MapFreeTextMajors(ctx context.Context, mapCommand *command.MapFreeTextMajorsCommand)(*command.MapFreeTextMajorsCommandResult, error)
+}
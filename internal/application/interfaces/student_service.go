@@ -1,15 +1,29 @@
 package interfaces
 
 import (
+	"context"
+
 	"github.com/google/uuid"
 	"github.com/tranvu1111/go-students-new/internal/application/command"
+	"github.com/tranvu1111/go-students-new/internal/application/common"
 	"github.com/tranvu1111/go-students-new/internal/application/query"
 )
 
 type StudentService interface {
-	CreateStudent(studentCommand *command.CreateStudentCommand)(*command.CreateStudentCommandResult, error)
-	FindAllStudent()(*query.StudentQueryListResult, error)
-	FindStudentById(id uuid.UUID)(*query.StudentQueryResult, error)
-	UpdateStudent(updateCommand *command.UpdateStudentCommand)(*command.UpdateStudentCommandResult, error)
-	DeleteStudent(id uuid.UUID)(error)
-}
\ No newline at end of file
+	CreateStudent(ctx context.Context, studentCommand *command.CreateStudentCommand)(*command.CreateStudentCommandResult, error)
+	FindAllStudent(ctx context.Context, listQuery *query.StudentListQuery)(*query.StudentQueryListResult, error)
+	ExportStudents(ctx context.Context, listQuery *query.StudentListQuery, visit func(*common.StudentResult) error)(error)
+	FindStudentById(ctx context.Context, id uuid.UUID)(*query.StudentQueryResult, error)
+	FindStudentsByIds(ctx context.Context, ids []uuid.UUID)(*query.StudentQueryListResult, error)
+	UpdateStudent(ctx context.Context, updateCommand *command.UpdateStudentCommand)(*command.UpdateStudentCommandResult, error)
+	PatchStudent(ctx context.Context, patchCommand *command.PatchStudentCommand)(*command.UpdateStudentCommandResult, error)
+	DeleteStudent(ctx context.Context, id uuid.UUID, expectedVersion int)(error)
+	RestoreStudent(ctx context.Context, id uuid.UUID)(*query.StudentQueryResult, error)
+	ImportStudents(ctx context.Context, importCommand *command.ImportStudentsCommand)(*command.ImportStudentsCommandResult, error)
+	SearchStudents(ctx context.Context, searchQuery *query.StudentSearchQuery)(*query.StudentSearchQueryResult, error)
+	FindStudentHistory(ctx context.Context, id uuid.UUID, page int, pageSize int)(*query.StudentHistoryQueryResult, error)
+	TransitionStudentStatus(ctx context.Context, transitionCommand *command.TransitionStudentStatusCommand)(*command.UpdateStudentCommandResult, error)
+	FindStudentStatusHistory(ctx context.Context, id uuid.UUID, page int, pageSize int)(*query.StudentStatusHistoryQueryResult, error)
+	SaveStudentContact(ctx context.Context, contactCommand *command.SaveStudentContactCommand)(*command.UpdateStudentCommandResult, error)
+	RemoveStudentContact(ctx context.Context, contactCommand *command.RemoveStudentContactCommand)(*command.UpdateStudentCommandResult, error)
+}
@@ -0,0 +1,18 @@
+package interfaces
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/tranvu1111/go-students-new/internal/application/command"
+	"github.com/tranvu1111/go-students-new/internal/application/query"
+)
+
+type WebhookService interface {
+	CreateSubscription(ctx context.Context, createCommand *command.CreateWebhookSubscriptionCommand)(*command.WebhookSubscriptionCommandResult, error)
+	FindAllSubscriptions(ctx context.Context)(*query.WebhookSubscriptionQueryListResult, error)
+	FindSubscriptionById(ctx context.Context, id uuid.UUID)(*query.WebhookSubscriptionQueryResult, error)
+	UpdateSubscription(ctx context.Context, updateCommand *command.UpdateWebhookSubscriptionCommand)(*command.WebhookSubscriptionCommandResult, error)
+	DeleteSubscription(ctx context.Context, id uuid.UUID)(error)
+	FindDeliveries(ctx context.Context, subscriptionID uuid.UUID, page int, pageSize int)(*query.WebhookDeliveryQueryListResult, error)
+}
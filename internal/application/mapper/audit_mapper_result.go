@@ -0,0 +1,31 @@
+package mapper
+
+import (
+	"github.com/tranvu1111/go-students-new/internal/application/common"
+	"github.com/tranvu1111/go-students-new/internal/domain/entities"
+)
+
+func NewAuditEntryResultFromEntity(entry *entities.AuditEntry) *common.AuditEntryResult {
+	if entry == nil {
+		return nil
+	}
+
+	changes := make([]common.FieldChangeResult, len(entry.Changes))
+	for i, change := range entry.Changes {
+		changes[i] = common.FieldChangeResult{
+			Field: change.Field,
+			Before: change.Before,
+			After: change.After,
+		}
+	}
+
+	return &common.AuditEntryResult{
+		ID: entry.ID,
+		StudentID: entry.StudentID,
+		Actor: entry.Actor,
+		RequestID: entry.RequestID,
+		Operation: string(entry.Operation),
+		Changes: changes,
+		OccurredAt: entry.OccurredAt,
+	}
+}
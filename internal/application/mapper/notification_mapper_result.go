@@ -0,0 +1,52 @@
+package mapper
+
+import (
+	"github.com/tranvu1111/go-students-new/internal/application/common"
+	"github.com/tranvu1111/go-students-new/internal/domain/entities"
+)
+
+func NewNotificationTemplateResultFromEntity(template *entities.NotificationTemplate) *common.NotificationTemplateResult {
+	if template == nil {
+		return nil
+	}
+
+	return &common.NotificationTemplateResult{
+		ID: template.ID,
+		EventType: template.EventType,
+		Channel: string(template.Channel),
+		Locale: template.Locale,
+		Subject: template.Subject,
+		Body: template.Body,
+		HTMLBody: template.HTMLBody,
+		Active: template.Active,
+		CreatedAt: template.CreatedAt,
+		UpdatedAt: template.UpdatedAt,
+	}
+}
+
+func NewNotificationResultFromEntity(notification *entities.Notification) *common.NotificationResult {
+	if notification == nil {
+		return nil
+	}
+
+	return &common.NotificationResult{
+		ID: notification.ID,
+		TemplateID: notification.TemplateID,
+		EventID: notification.EventID,
+		EventType: notification.EventType,
+		StudentID: notification.StudentID,
+		Channel: string(notification.Channel),
+		Locale: notification.Locale,
+		Recipient: notification.Recipient,
+		Subject: notification.Subject,
+		Text: notification.Text,
+		HTML: notification.HTML,
+		Status: string(notification.Status),
+		Attempts: notification.Attempts,
+		NextAttemptAt: notification.NextAttemptAt,
+		LastError: notification.LastError,
+		SentAt: notification.SentAt,
+		CreatedAt: notification.CreatedAt,
+		UpdatedAt: notification.UpdatedAt,
+	}
+}
@@ -0,0 +1,35 @@
+package mapper
+
+import (
+	"github.com/tranvu1111/go-students-new/internal/application/common"
+	"github.com/tranvu1111/go-students-new/internal/domain/entities"
+)
+
+func NewProgramResultFromEntity(program *entities.Program) *common.ProgramResult {
+	if program == nil {
+		return nil
+	}
+
+	return &common.ProgramResult{
+		ID: program.ID,
+		Code: program.Code,
+		Name: program.Name,
+		Department: program.Department,
+		DegreeLevel: string(program.DegreeLevel),
+		Active: program.Active,
+		CreatedAt: program.CreatedAt,
+		UpdatedAt: program.UpdatedAt,
+	}
+}
+
+func NewStudentProgramResultsFromEntities(programs []entities.StudentProgram) []common.StudentProgramResult {
+	results := make([]common.StudentProgramResult, 0, len(programs))
+	for _, program := range programs {
+		results = append(results, common.StudentProgramResult{
+			ProgramID: program.ProgramID,
+			Kind: string(program.Kind),
+			DeclaredAt: program.DeclaredAt,
+		})
+	}
+	return results
+}
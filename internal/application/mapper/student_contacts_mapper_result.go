@@ -0,0 +1,57 @@
+package mapper
+
+import (
+	"github.com/tranvu1111/go-students-new/internal/application/common"
+	"github.com/tranvu1111/go-students-new/internal/domain/entities"
+)
+
+func NewStudentContactsResultFromEntity(contacts entities.StudentContacts) common.StudentContactsResult {
+	result := common.StudentContactsResult{
+		Addresses: make([]common.AddressResult, 0, len(contacts.Addresses)),
+		Emails: make([]common.ContactEmailResult, 0, len(contacts.Emails)),
+		Phones: make([]common.ContactPhoneResult, 0, len(contacts.Phones)),
+		EmergencyContacts: make([]common.EmergencyContactResult, 0, len(contacts.EmergencyContacts)),
+	}
+	for _, address := range contacts.Addresses {
+		result.Addresses = append(result.Addresses, common.AddressResult{
+			ID: address.ID,
+			Type: string(address.Type),
+			Line1: address.Line1,
+			Line2: address.Line2,
+			City: address.City,
+			Region: address.Region,
+			PostalCode: address.PostalCode,
+			Country: address.Country,
+			ValidFrom: address.ValidFrom,
+			ValidTo: address.ValidTo,
+		})
+	}
+	for _, email := range contacts.Emails {
+		result.Emails = append(result.Emails, common.ContactEmailResult{
+			ID: email.ID,
+			Address: email.Address,
+			Primary: email.Primary,
+			Verification: string(email.Verification),
+			VerifiedAt: email.VerifiedAt,
+		})
+	}
+	for _, phone := range contacts.Phones {
+		result.Phones = append(result.Phones, common.ContactPhoneResult{
+			ID: phone.ID,
+			Number: phone.Number,
+			Primary: phone.Primary,
+			Verification: string(phone.Verification),
+			VerifiedAt: phone.VerifiedAt,
+		})
+	}
+	for _, contact := range contacts.EmergencyContacts {
+		result.EmergencyContacts = append(result.EmergencyContacts, common.EmergencyContactResult{
+			ID: contact.ID,
+			Name: contact.Name,
+			Relationship: contact.Relationship,
+			Phone: contact.Phone,
+			Email: contact.Email,
+		})
+	}
+	return result
+}
@@ -20,10 +20,18 @@ func NewStudentResultFromEntity(student *entities.Student) *common.StudentResult
 		LastName: student.LastName,
 		DateOfBirth: student.DateOfBirth,
 		Email: student.Email,
+		EmailVerifiedAt: student.EmailVerifiedAt,
 		Phone: student.Phone,
 		Major: student.Major,
+		Locale: student.Locale,
+		Programs: NewStudentProgramResultsFromEntities(student.Programs),
+		Contacts: NewStudentContactsResultFromEntity(student.Contacts),
 		CreatedAt: student.CreatedAt,
 		UpdatedAt: student.UpdatedAt,
 		EnrollmentDate: student.EnrollmentDate,
+		Status: string(student.Status),
+		StatusReason: student.StatusReason,
+		StatusEffectiveDate: student.StatusEffectiveDate,
+		Version: student.Version,
 	}
 }
\ No newline at end of file
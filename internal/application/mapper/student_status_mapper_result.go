@@ -0,0 +1,24 @@
+package mapper
+
+import (
+	"github.com/tranvu1111/go-students-new/internal/application/common"
+	"github.com/tranvu1111/go-students-new/internal/domain/entities"
+)
+
+func NewStudentStatusChangeResultFromEntity(change *entities.StudentStatusChange) *common.StudentStatusChangeResult {
+	if change == nil {
+		return nil
+	}
+
+	return &common.StudentStatusChangeResult{
+		ID: change.ID,
+		StudentID: change.StudentID,
+		From: string(change.From),
+		To: string(change.To),
+		Reason: change.Reason,
+		EffectiveDate: change.EffectiveDate,
+		Actor: change.Actor,
+		RequestID: change.RequestID,
+		RecordedAt: change.RecordedAt,
+	}
+}
@@ -0,0 +1,41 @@
+package mapper
+
+import (
+	"github.com/tranvu1111/go-students-new/internal/application/common"
+	"github.com/tranvu1111/go-students-new/internal/domain/entities"
+)
+
+func NewWebhookSubscriptionResultFromEntity(subscription *entities.WebhookSubscription) *common.WebhookSubscriptionResult {
+	if subscription == nil {
+		return nil
+	}
+
+	return &common.WebhookSubscriptionResult{
+		ID: subscription.ID,
+		URL: subscription.URL,
+		EventTypes: subscription.EventTypes,
+		Active: subscription.Active,
+		CreatedAt: subscription.CreatedAt,
+		UpdatedAt: subscription.UpdatedAt,
+	}
+}
+
+func NewWebhookDeliveryResultFromEntity(delivery *entities.WebhookDelivery) *common.WebhookDeliveryResult {
+	if delivery == nil {
+		return nil
+	}
+
+	return &common.WebhookDeliveryResult{
+		ID: delivery.ID,
+		SubscriptionID: delivery.SubscriptionID,
+		EventID: delivery.EventID,
+		EventType: delivery.EventType,
+		Status: string(delivery.Status),
+		Attempts: delivery.Attempts,
+		NextAttemptAt: delivery.NextAttemptAt,
+		LastStatusCode: delivery.LastStatusCode,
+		LastError: delivery.LastError,
+		CreatedAt: delivery.CreatedAt,
+		UpdatedAt: delivery.UpdatedAt,
+	}
+}
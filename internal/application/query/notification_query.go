@@ -0,0 +1,41 @@
+package query
+
+import (
+	"github.com/google/uuid"
+	"github.com/tranvu1111/go-students-new/internal/application/common"
+)
+
+// NotificationTemplateListQuery filters the templates; empty fields do not
+// filter.
+type NotificationTemplateListQuery struct {
+	EventType 	string
+	Channel 	string
+	Locale 		string
+}
+
+type NotificationTemplateQueryResult struct {
+
+	Result *common.NotificationTemplateResult
+}
+
+type NotificationTemplateQueryListResult struct {
+
+	Result []*common.NotificationTemplateResult
+}
+
+// NotificationListQuery pages through the send log; empty fields do not
+// filter.
+type NotificationListQuery struct {
+	StudentID 	*uuid.UUID
+	Status 		string
+	Page 		int
+	PageSize 	int
+}
+
+type NotificationQueryListResult struct {
+
+	Result 		[]*common.NotificationResult
+	Page 		int
+	PageSize 	int
+	Total 		int64
+}
@@ -0,0 +1,22 @@
+package query
+
+import (
+	"github.com/tranvu1111/go-students-new/internal/application/common"
+)
+
+// ProgramListQuery filters the catalog; empty fields do not filter.
+type ProgramListQuery struct {
+	Active 		*bool
+	Department 	string
+	DegreeLevel string
+}
+
+type ProgramQueryResult struct {
+
+	Result *common.ProgramResult
+}
+
+type ProgramQueryListResult struct {
+
+	Result []*common.ProgramResult
+}
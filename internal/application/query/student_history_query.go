@@ -0,0 +1,13 @@
+package query
+
+import (
+	"github.com/tranvu1111/go-students-new/internal/application/common"
+)
+
+type StudentHistoryQueryResult struct {
+
+	Result 		[]*common.AuditEntryResult
+	Page 		int
+	PageSize 	int
+	Total 		int64
+}
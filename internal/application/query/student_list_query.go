@@ -0,0 +1,19 @@
+package query
+
+import (
+	"time"
+
+	"github.com/tranvu1111/go-students-new/internal/domain/entities"
+)
+
+// StudentListQuery holds the filters shared by the listing and the export.
+type StudentListQuery struct {
+
+	Major 			*string
+	Status 			*entities.StudentStatus
+	EnrolledFrom 	*time.Time
+	EnrolledTo 		*time.Time
+	// Offset and Limit page the listing; a zero Limit returns every student.
+	Offset 			int
+	Limit 			int
+}
@@ -0,0 +1,17 @@
+package query
+
+import (
+	"github.com/tranvu1111/go-students-new/internal/application/common"
+)
+
+type StudentSearchQuery struct {
+
+	Query 	string
+	Limit 	int
+}
+
+type StudentSearchQueryResult struct {
+
+	Result 	[]*common.StudentSearchHitResult
+	Query 	string
+}
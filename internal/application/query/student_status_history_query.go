@@ -0,0 +1,13 @@
+package query
+
+import (
+	"github.com/tranvu1111/go-students-new/internal/application/common"
+)
+
+type StudentStatusHistoryQueryResult struct {
+
+	Result 		[]*common.StudentStatusChangeResult
+	Page 		int
+	PageSize 	int
+	Total 		int64
+}
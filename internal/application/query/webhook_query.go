@@ -0,0 +1,23 @@
+package query
+
+import (
+	"github.com/tranvu1111/go-students-new/internal/application/common"
+)
+
+type WebhookSubscriptionQueryResult struct {
+
+	Result *common.WebhookSubscriptionResult
+}
+
+type WebhookSubscriptionQueryListResult struct {
+
+	Result []*common.WebhookSubscriptionResult
+}
+
+type WebhookDeliveryQueryListResult struct {
+
+	Result 		[]*common.WebhookDeliveryResult
+	Page 		int
+	PageSize 	int
+	Total 		int64
+}
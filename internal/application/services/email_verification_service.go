@@ -0,0 +1,69 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/tranvu1111/go-students-new/internal/application/command"
+	"github.com/tranvu1111/go-students-new/internal/application/interfaces"
+	"github.com/tranvu1111/go-students-new/internal/application/mapper"
+	"github.com/tranvu1111/go-students-new/internal/domain/entities"
+	"github.com/tranvu1111/go-students-new/internal/domain/repositories"
+)
+
+// EmailVerificationService checks the tokens that the verification outbox
+// sink mails; both must share the secret.
+type EmailVerificationService struct {
+	repo 	repositories.StudentRepository
+	secret 	[]byte
+}
+
+func NewEmailVerificationService(repo repositories.StudentRepository, secret []byte) interfaces.EmailVerificationService {
+	return &EmailVerificationService{
+		repo: repo,
+		secret: secret,
+	}
+}
+
+func(s *EmailVerificationService) VerifyEmail(ctx context.Context, verifyCommand *command.VerifyEmailCommand)(*command.UpdateStudentCommandResult, error) {
+	now := time.Now()
+	token, err := entities.ParseEmailVerificationToken(verifyCommand.Token, s.secret, now)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx = repositories.ContextForWrite(ctx)
+	storedStudent, err := s.repo.FindById(ctx, token.StudentID)
+	if errors.Is(err, repositories.ErrStudentNotFound) {
+		// A token for a deleted student is as good as no token.
+		return nil, fmt.Errorf("%w: %w", entities.ErrInvalidVerificationToken, err)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if err := storedStudent.VerifyEmail(token, now); err != nil {
+		return nil, err
+	}
+	if len(storedStudent.Events()) == 0 {
+		return &command.UpdateStudentCommandResult{
+			Result: mapper.NewStudentResultFromEntity(storedStudent),
+		}, nil
+	}
+
+	validStudent, err := entities.NewValidatedStudent(storedStudent)
+	if err != nil {
+		return nil, err
+	}
+
+	updatedStudent, err := s.repo.Update(ctx, validStudent)
+	if err != nil {
+		return nil, err
+	}
+
+	return &command.UpdateStudentCommandResult{
+		Result: mapper.NewStudentResultFromEntity(updatedStudent),
+	}, nil
+}
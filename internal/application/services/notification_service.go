@@ -0,0 +1,158 @@
+package services
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/tranvu1111/go-students-new/internal/application/command"
+	"github.com/tranvu1111/go-students-new/internal/application/common"
+	"github.com/tranvu1111/go-students-new/internal/application/interfaces"
+	"github.com/tranvu1111/go-students-new/internal/application/mapper"
+	"github.com/tranvu1111/go-students-new/internal/application/query"
+	"github.com/tranvu1111/go-students-new/internal/domain/entities"
+	"github.com/tranvu1111/go-students-new/internal/domain/repositories"
+)
+
+// NotificationService manages the templates students are notified with and
+// the send log. Notifications themselves are rendered by the outbox sink when
+// StudentService raises an event.
+type NotificationService struct {
+	templateRepo 		repositories.NotificationTemplateRepository
+	notificationRepo 	repositories.NotificationRepository
+}
+
+func NewNotificationService(tr repositories.NotificationTemplateRepository, nr repositories.NotificationRepository) interfaces.NotificationService {
+	return &NotificationService{
+		templateRepo: tr,
+		notificationRepo: nr,
+	}
+}
+
+func (s *NotificationService) CreateTemplate(ctx context.Context, createCommand *command.CreateNotificationTemplateCommand)(*command.NotificationTemplateCommandResult, error) {
+	template, err := entities.NewNotificationTemplate(createCommand.EventType, entities.NotificationChannel(createCommand.Channel),
+		createCommand.Locale, createCommand.Subject, createCommand.Body, createCommand.HTMLBody)
+	if err != nil {
+		return nil, err
+	}
+
+	created, err := s.templateRepo.Create(ctx, template)
+	if err != nil {
+		return nil, err
+	}
+
+	return &command.NotificationTemplateCommandResult{
+		Result: mapper.NewNotificationTemplateResultFromEntity(created),
+	}, nil
+}
+
+func (s *NotificationService) FindAllTemplates(ctx context.Context, listQuery *query.NotificationTemplateListQuery)(*query.NotificationTemplateQueryListResult, error) {
+	var filter repositories.NotificationTemplateFilter
+	if listQuery != nil {
+		filter.EventType = listQuery.EventType
+		filter.Channel = entities.NotificationChannel(listQuery.Channel)
+		if listQuery.Locale != "" {
+			filter.Locale = entities.NormalizeLocale(listQuery.Locale)
+		}
+	}
+
+	templates, err := s.templateRepo.FindAll(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	queryResult := query.NotificationTemplateQueryListResult{Result: make([]*common.NotificationTemplateResult, 0, len(templates))}
+	for _, template := range templates {
+		queryResult.Result = append(queryResult.Result, mapper.NewNotificationTemplateResultFromEntity(template))
+	}
+	return &queryResult, nil
+}
+
+func (s *NotificationService) FindTemplateById(ctx context.Context, id uuid.UUID)(*query.NotificationTemplateQueryResult, error) {
+	template, err := s.templateRepo.FindById(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	return &query.NotificationTemplateQueryResult{Result: mapper.NewNotificationTemplateResultFromEntity(template)}, nil
+}
+
+func (s *NotificationService) UpdateTemplate(ctx context.Context, updateCommand *command.UpdateNotificationTemplateCommand)(*command.NotificationTemplateCommandResult, error) {
+	template, err := s.templateRepo.FindById(ctx, updateCommand.TemplateID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := template.Update(updateCommand.Subject, updateCommand.Body, updateCommand.HTMLBody, updateCommand.Active); err != nil {
+		return nil, err
+	}
+
+	updated, err := s.templateRepo.Update(ctx, template)
+	if err != nil {
+		return nil, err
+	}
+
+	return &command.NotificationTemplateCommandResult{
+		Result: mapper.NewNotificationTemplateResultFromEntity(updated),
+	}, nil
+}
+
+func (s *NotificationService) DeleteTemplate(ctx context.Context, id uuid.UUID)(error) {
+	return s.templateRepo.Delete(ctx, id)
+}
+
+func (s *NotificationService) FindNotifications(ctx context.Context, listQuery *query.NotificationListQuery)(*query.NotificationQueryListResult, error) {
+	page, pageSize := listQuery.Page, listQuery.PageSize
+	if page < 1 {
+		page = 1
+	}
+	if pageSize < 1 {
+		pageSize = defaultHistoryPageSize
+	}
+	if pageSize > maxHistoryPageSize {
+		pageSize = maxHistoryPageSize
+	}
+
+	filter := repositories.NotificationFilter{StudentID: listQuery.StudentID}
+	if listQuery.Status != "" {
+		status := entities.NotificationStatus(listQuery.Status)
+		filter.Status = &status
+	}
+
+	notifications, total, err := s.notificationRepo.FindAll(ctx, filter, (page-1)*pageSize, pageSize)
+	if err != nil {
+		return nil, err
+	}
+
+	queryResult := query.NotificationQueryListResult{
+		Result: make([]*common.NotificationResult, 0, len(notifications)),
+		Page: page,
+		PageSize: pageSize,
+		Total: total,
+	}
+	for _, notification := range notifications {
+		queryResult.Result = append(queryResult.Result, mapper.NewNotificationResultFromEntity(notification))
+	}
+	return &queryResult, nil
+}
+
+// RetryNotification queues a dead notification again as it was rendered; the
+// dispatcher picks it up on its next round.
+func (s *NotificationService) RetryNotification(ctx context.Context, id uuid.UUID)(*command.NotificationCommandResult, error) {
+	notification, err := s.notificationRepo.FindById(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := notification.Retry(time.Now()); err != nil {
+		return nil, err
+	}
+
+	if err := s.notificationRepo.Update(ctx, notification); err != nil {
+		return nil, err
+	}
+
+	return &command.NotificationCommandResult{
+		Result: mapper.NewNotificationResultFromEntity(notification),
+	}, nil
+}
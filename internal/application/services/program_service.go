@@ -0,0 +1,194 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/tranvu1111/go-students-new/internal/application/command"
+	"github.com/tranvu1111/go-students-new/internal/application/common"
+	"github.com/tranvu1111/go-students-new/internal/application/interfaces"
+	"github.com/tranvu1111/go-students-new/internal/application/mapper"
+	"github.com/tranvu1111/go-students-new/internal/application/query"
+	"github.com/tranvu1111/go-students-new/internal/domain/entities"
+	"github.com/tranvu1111/go-students-new/internal/domain/repositories"
+)
+
+type ProgramService struct {
+	programRepo 	repositories.ProgramRepository
+	studentRepo 	repositories.StudentRepository
+}
+
+func NewProgramService(pr repositories.ProgramRepository, sr repositories.StudentRepository) interfaces.ProgramService {
+	return &ProgramService{
+		programRepo: pr,
+		studentRepo: sr,
+	}
+}
+
+func (s *ProgramService) CreateProgram(ctx context.Context, createCommand *command.CreateProgramCommand)(*command.ProgramCommandResult, error) {
+	program, err := entities.NewProgram(createCommand.Code, createCommand.Name, createCommand.Department, entities.DegreeLevel(createCommand.DegreeLevel))
+	if err != nil {
+		return nil, err
+	}
+
+	created, err := s.programRepo.Create(ctx, program)
+	if err != nil {
+		return nil, err
+	}
+
+	return &command.ProgramCommandResult{
+		Result: mapper.NewProgramResultFromEntity(created),
+	}, nil
+}
+
+func (s *ProgramService) FindAllPrograms(ctx context.Context, listQuery *query.ProgramListQuery)(*query.ProgramQueryListResult, error) {
+	var filter repositories.ProgramFilter
+	if listQuery != nil {
+		filter.Active = listQuery.Active
+		if listQuery.Department != "" {
+			filter.Department = &listQuery.Department
+		}
+		if listQuery.DegreeLevel != "" {
+			level := entities.DegreeLevel(listQuery.DegreeLevel)
+			filter.DegreeLevel = &level
+		}
+	}
+
+	programs, err := s.programRepo.FindAll(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	queryResult := query.ProgramQueryListResult{Result: make([]*common.ProgramResult, 0, len(programs))}
+	for _, program := range programs {
+		queryResult.Result = append(queryResult.Result, mapper.NewProgramResultFromEntity(program))
+	}
+	return &queryResult, nil
+}
+
+func (s *ProgramService) FindProgramById(ctx context.Context, id uuid.UUID)(*query.ProgramQueryResult, error) {
+	program, err := s.programRepo.FindById(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	return &query.ProgramQueryResult{Result: mapper.NewProgramResultFromEntity(program)}, nil
+}
+
+func (s *ProgramService) UpdateProgram(ctx context.Context, updateCommand *command.UpdateProgramCommand)(*command.ProgramCommandResult, error) {
+	program, err := s.programRepo.FindById(ctx, updateCommand.ProgramID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := program.Update(updateCommand.Name, updateCommand.Department, entities.DegreeLevel(updateCommand.DegreeLevel), updateCommand.Active); err != nil {
+		return nil, err
+	}
+
+	updated, err := s.programRepo.Update(ctx, program)
+	if err != nil {
+		return nil, err
+	}
+
+	return &command.ProgramCommandResult{
+		Result: mapper.NewProgramResultFromEntity(updated),
+	}, nil
+}
+
+// MapFreeTextMajors runs once the catalog is filled in, to carry the legacy
+// free-text majors over. A student is left alone when the major matches no
+// program, when the student already declared that program, or when the
+// enrollment date lies ahead, since a declaration cannot precede it. Running
+// it again maps only what changed since.
+func (s *ProgramService) MapFreeTextMajors(ctx context.Context, mapCommand *command.MapFreeTextMajorsCommand)(*command.MapFreeTextMajorsCommandResult, error) {
+	programs, err := s.programRepo.FindAll(ctx, repositories.ProgramFilter{})
+	if err != nil {
+		return nil, err
+	}
+
+	byText := make(map[string]*entities.Program, 2*len(programs))
+	byCode := make(map[string]*entities.Program, len(programs))
+	for _, program := range programs {
+		byText[majorKey(program.Code)] = program
+		byText[majorKey(program.Name)] = program
+		byCode[program.Code] = program
+	}
+	if mapCommand != nil {
+		for alias, code := range mapCommand.Aliases {
+			program, ok := byCode[entities.NormalizeProgramCode(code)]
+			if !ok {
+				return nil, fmt.Errorf("%w: alias %q names code %q", repositories.ErrProgramNotFound, alias, code)
+			}
+			byText[majorKey(alias)] = program
+		}
+	}
+
+	// Collect first and write after: the stream may hold the store or a
+	// database cursor that the updates would wait on.
+	ctx = repositories.ContextForWrite(ctx)
+	result := command.MapFreeTextMajorsCommandResult{Unmapped: map[string]int{}}
+	var matched []uuid.UUID
+	err = s.studentRepo.Stream(ctx, repositories.StudentFilter{}, func(student *entities.Student) error {
+		if student.Major == nil || strings.TrimSpace(*student.Major) == "" {
+			return nil
+		}
+		if _, ok := byText[majorKey(*student.Major)]; !ok {
+			result.Unmapped[*student.Major]++
+			return nil
+		}
+		matched = append(matched, student.StudentID)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	for _, id := range matched {
+		student, err := s.studentRepo.FindById(ctx, id)
+		if err != nil {
+			return nil, err
+		}
+		if student.Major == nil || student.EnrollmentDate.After(time.Now()) {
+			continue
+		}
+		program, ok := byText[majorKey(*student.Major)]
+		if !ok || declaresProgram(student, program.ID) {
+			continue
+		}
+
+		programs := append(append([]entities.StudentProgram{}, student.Programs...), entities.StudentProgram{
+			ProgramID: program.ID,
+			Kind: entities.ProgramKindMajor,
+			DeclaredAt: student.EnrollmentDate,
+		})
+		if err := student.ApplyPatch(entities.StudentPatch{Programs: entities.Replace(programs)}); err != nil {
+			return nil, err
+		}
+		validStudent, err := entities.NewValidatedStudent(student)
+		if err != nil {
+			return nil, err
+		}
+		if _, err := s.studentRepo.Update(ctx, validStudent); err != nil {
+			return nil, err
+		}
+		result.Mapped++
+	}
+
+	return &result, nil
+}
+
+func majorKey(text string) string {
+	return strings.ToLower(strings.Join(strings.Fields(text), " "))
+}
+
+func declaresProgram(student *entities.Student, programID uuid.UUID) bool {
+	for _, program := range student.Programs {
+		if program.ProgramID == programID {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,163 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"io"
+
+	"github.com/tranvu1111/go-students-new/internal/application/command"
+	"github.com/tranvu1111/go-students-new/internal/domain/entities"
+)
+
+const (
+	defaultImportBatchSize = 500
+	maxImportBatchSize = 5000
+)
+
+type pendingImportRow struct {
+	index 	int
+	student *entities.ValidatedStudent
+}
+
+// ImportStudents validates each row through NewValidatedStudent and stores the
+// valid ones in batches. In best-effort mode a failed batch is retried row by
+// row so that the failure is reported against the offending rows only.
+func (s *StudentService) ImportStudents(ctx context.Context, importCommand *command.ImportStudentsCommand)(*command.ImportStudentsCommandResult, error) {
+	mode := importCommand.Mode
+	if mode == "" {
+		mode = command.ImportModeAllOrNothing
+	}
+	if mode != command.ImportModeAllOrNothing && mode != command.ImportModeBestEffort {
+		return nil, errors.New("unknown import mode " + string(mode))
+	}
+
+	batchSize := importCommand.BatchSize
+	if batchSize < 1 {
+		batchSize = defaultImportBatchSize
+	}
+	if batchSize > maxImportBatchSize {
+		batchSize = maxImportBatchSize
+	}
+
+	result := &command.ImportStudentsCommandResult{
+		Mode: mode,
+		DryRun: importCommand.DryRun,
+	}
+
+	var pending []pendingImportRow
+	for {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
+		studentCommand, err := importCommand.Rows.Next()
+		if err == io.EOF {
+			break
+		}
+
+		result.Rows = append(result.Rows, command.ImportRowResult{Row: len(result.Rows) + 1})
+		row := &result.Rows[len(result.Rows)-1]
+		if errors.Is(err, command.ErrImportUnreadable) {
+			row.Error = err.Error()
+			break
+		}
+		if err != nil {
+			row.Error = err.Error()
+			continue
+		}
+
+		student, err := entities.NewValidatedStudent(entities.NewStudentFromProfile(entities.StudentProfile{
+			FirstName: 		studentCommand.FirstName,
+			LastName: 		studentCommand.LastName,
+			DateOfBirth: 	studentCommand.DateOfBirth,
+			Email: 			studentCommand.Email,
+			Phone: 			studentCommand.Phone,
+			Major: 			studentCommand.Major,
+			Locale: 		studentCommand.Locale,
+			EnrollmentDate: studentCommand.EnrollmentDate,
+			Programs: 		studentCommand.Programs,
+		}))
+		if err != nil {
+			row.Error = err.Error()
+			continue
+		}
+		if err := s.checkPrograms(ctx, student.Programs, nil); err != nil {
+			row.Error = err.Error()
+			continue
+		}
+
+		id := student.StudentID
+		row.StudentID = &id
+		pending = append(pending, pendingImportRow{index: len(result.Rows) - 1, student: student})
+
+		if !importCommand.DryRun && mode == command.ImportModeBestEffort && len(pending) >= batchSize {
+			s.storeBestEffort(ctx, pending, batchSize, result)
+			pending = pending[:0]
+		}
+	}
+
+	switch {
+	case importCommand.DryRun:
+		// Nothing is stored; StudentID shows the ID each valid row would get.
+	case mode == command.ImportModeBestEffort:
+		s.storeBestEffort(ctx, pending, batchSize, result)
+		result.Committed = true
+	default:
+		result.Committed = s.storeAllOrNothing(ctx, pending, batchSize, result)
+	}
+
+	result.Total = len(result.Rows)
+	for _, row := range result.Rows {
+		if row.Error != "" {
+			result.Failed++
+		} else if result.Committed && row.StudentID != nil {
+			result.Created++
+		}
+	}
+
+	return result, nil
+}
+
+func (s *StudentService) storeAllOrNothing(ctx context.Context, pending []pendingImportRow, batchSize int, result *command.ImportStudentsCommandResult) bool {
+	if len(pending) != len(result.Rows) {
+		clearImportedIds(pending, result)
+		return false
+	}
+
+	students := make([]*entities.ValidatedStudent, len(pending))
+	for i, row := range pending {
+		students[i] = row.student
+	}
+
+	if err := s.repo.CreateBatch(ctx, students, batchSize); err != nil {
+		clearImportedIds(pending, result)
+		for _, row := range pending {
+			result.Rows[row.index].Error = "import rolled back: " + err.Error()
+		}
+		return false
+	}
+	return true
+}
+
+func (s *StudentService) storeBestEffort(ctx context.Context, pending []pendingImportRow, batchSize int, result *command.ImportStudentsCommandResult) {
+	students := make([]*entities.ValidatedStudent, len(pending))
+	for i, row := range pending {
+		students[i] = row.student
+	}
+	if err := s.repo.CreateBatch(ctx, students, batchSize); err == nil {
+		return
+	}
+
+	for _, row := range pending {
+		if _, err := s.repo.Create(ctx, row.student); err != nil {
+			result.Rows[row.index].StudentID = nil
+			result.Rows[row.index].Error = err.Error()
+		}
+	}
+}
+
+func clearImportedIds(pending []pendingImportRow, result *command.ImportStudentsCommandResult) {
+	for _, row := range pending {
+		result.Rows[row.index].StudentID = nil
+	}
+}
@@ -0,0 +1,49 @@
+package services
+
+import (
+	"context"
+	"strings"
+
+	"github.com/tranvu1111/go-students-new/internal/application/common"
+	"github.com/tranvu1111/go-students-new/internal/application/mapper"
+	"github.com/tranvu1111/go-students-new/internal/application/query"
+	"github.com/tranvu1111/go-students-new/internal/domain/search"
+)
+
+const (
+	defaultSearchLimit = 20
+	maxSearchLimit = 100
+)
+
+// SearchStudents ranks students against the free-text query. The repository
+// decides the ranking; highlighting is done here so it reads the same on
+// every database.
+func (s *StudentService) SearchStudents(ctx context.Context, searchQuery *query.StudentSearchQuery) (*query.StudentSearchQueryResult, error) {
+	text := strings.TrimSpace(searchQuery.Query)
+	limit := searchQuery.Limit
+	if limit < 1 {
+		limit = defaultSearchLimit
+	}
+	if limit > maxSearchLimit {
+		limit = maxSearchLimit
+	}
+
+	hits, err := s.repo.Search(ctx, text, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	queryResult := query.StudentSearchQueryResult{
+		Result: make([]*common.StudentSearchHitResult, 0, len(hits)),
+		Query: text,
+	}
+	for _, hit := range hits {
+		queryResult.Result = append(queryResult.Result, &common.StudentSearchHitResult{
+			Student: mapper.NewStudentResultFromEntity(hit.Student),
+			Score: hit.Score,
+			Highlights: search.Highlight(text, hit.Student),
+		})
+	}
+
+	return &queryResult, nil
+}
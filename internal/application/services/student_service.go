@@ -4,32 +4,43 @@ import (
 	"context"
 	"encoding/json"
 	"errors"
+	"fmt"
 	"github.com/google/uuid"
 	"github.com/tranvu1111/go-students-new/internal/application/command"
 	"github.com/tranvu1111/go-students-new/internal/application/mapper"
 	"github.com/tranvu1111/go-students-new/internal/application/query"
 
-	// "github.com/tranvu1111/go-students-new/internal/application/common"
+	"github.com/tranvu1111/go-students-new/internal/application/common"
 	"github.com/tranvu1111/go-students-new/internal/application/interfaces"
 	"github.com/tranvu1111/go-students-new/internal/domain/entities"
 	"github.com/tranvu1111/go-students-new/internal/domain/repositories"
 )
 
 
+const (
+	defaultHistoryPageSize = 20
+	maxHistoryPageSize = 100
+)
+
 type StudentService struct {
 	repo				repositories.StudentRepository
 	idempotencyRepo 	repositories.IdempotencyRepository
+	auditRepo 			repositories.AuditRepository
+	statusRepo 			repositories.StudentStatusRepository
+	programRepo 		repositories.ProgramRepository
 }
 
-func NewStudentService(	sr repositories.StudentRepository,	ir repositories.IdempotencyRepository) interfaces.StudentService  {
+func NewStudentService(	sr repositories.StudentRepository,	ir repositories.IdempotencyRepository, ar repositories.AuditRepository, str repositories.StudentStatusRepository, pr repositories.ProgramRepository) interfaces.StudentService  {
 	return  &StudentService{
 		repo: sr,
 		idempotencyRepo: ir,
+		auditRepo: ar,
+		statusRepo: str,
+		programRepo: pr,
 	}
 }
 
-func (s *StudentService) CreateStudent(studentCommand *command.CreateStudentCommand)(*command.CreateStudentCommandResult, error){
-	ctx := context.Background()
+func (s *StudentService) CreateStudent(ctx context.Context, studentCommand *command.CreateStudentCommand)(*command.CreateStudentCommandResult, error){
 
 	if studentCommand.IdempotencyKey != "" {
 		existingRecord , err := s.idempotencyRepo.FindByKey(ctx, studentCommand.IdempotencyKey)
@@ -52,22 +63,28 @@ func (s *StudentService) CreateStudent(studentCommand *command.CreateStudentComm
 		idempotencyRecord = entities.NewIdempotencyRecord(studentCommand.IdempotencyKey,string(requestJSON))
 	}
 
-	var newStudent = entities.NewStudent(
-		studentCommand.FirstName,
-		studentCommand.LastName,
-		studentCommand.DateOfBirth,
-		studentCommand.Email,
-		studentCommand.Phone,
-		studentCommand.Major,
-		studentCommand.EnrollmentDate,
-	)
+	var newStudent = entities.NewStudentFromProfile(entities.StudentProfile{
+		FirstName: 		studentCommand.FirstName,
+		LastName: 		studentCommand.LastName,
+		DateOfBirth: 	studentCommand.DateOfBirth,
+		Email: 			studentCommand.Email,
+		Phone: 			studentCommand.Phone,
+		Major: 			studentCommand.Major,
+		Locale: 		studentCommand.Locale,
+		EnrollmentDate: studentCommand.EnrollmentDate,
+		Programs: 		studentCommand.Programs,
+	})
 
 	validatedStudent, err := entities.NewValidatedStudent(newStudent)
 	if err != nil {
 		return nil, err
 	}
 
-	_, err = s.repo.Create(validatedStudent)
+	if err := s.checkPrograms(ctx, newStudent.Programs, nil); err != nil {
+		return nil, err
+	}
+
+	_, err = s.repo.Create(ctx, validatedStudent)
 	if err != nil {
 		return nil, err
 	}
@@ -90,8 +107,8 @@ func (s *StudentService) CreateStudent(studentCommand *command.CreateStudentComm
 	return &result, nil
 }
 
-func (s *StudentService) FindAllStudent() (*query.StudentQueryListResult, error) {
-	storedStudents ,err := s.repo.FindAll()
+func (s *StudentService) FindAllStudent(ctx context.Context, listQuery *query.StudentListQuery) (*query.StudentQueryListResult, error) {
+	storedStudents ,err := s.repo.FindAll(ctx, toStudentFilter(listQuery))
 	if err != nil {
 		return nil, err
 	}
@@ -104,8 +121,44 @@ func (s *StudentService) FindAllStudent() (*query.StudentQueryListResult, error)
 	return &queryResult, nil
 }
 
-func(s *StudentService) FindStudentById(id uuid.UUID)(*query.StudentQueryResult, error){
-	student , err := s.repo.FindById(id)
+// ExportStudents streams the filtered students to visit one at a time.
+func (s *StudentService) ExportStudents(ctx context.Context, listQuery *query.StudentListQuery, visit func(*common.StudentResult) error) error {
+	return s.repo.Stream(ctx, toStudentFilter(listQuery), func(student *entities.Student) error {
+		return visit(mapper.NewStudentResultFromEntity(student))
+	})
+}
+
+func toStudentFilter(listQuery *query.StudentListQuery) repositories.StudentFilter {
+	if listQuery == nil {
+		return repositories.StudentFilter{}
+	}
+	return repositories.StudentFilter{
+		Major: listQuery.Major,
+		Status: listQuery.Status,
+		EnrolledFrom: listQuery.EnrolledFrom,
+		EnrolledTo: listQuery.EnrolledTo,
+		Offset: listQuery.Offset,
+		Limit: listQuery.Limit,
+	}
+}
+
+// FindStudentsByIds looks up several students at once; IDs with no live
+// student are left out of the result.
+func (s *StudentService) FindStudentsByIds(ctx context.Context, ids []uuid.UUID) (*query.StudentQueryListResult, error) {
+	students, err := s.repo.FindByIds(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+
+	var queryResult query.StudentQueryListResult
+	for _, student := range students {
+		queryResult.Result = append(queryResult.Result, mapper.NewStudentResultFromEntity(student))
+	}
+	return &queryResult, nil
+}
+
+func(s *StudentService) FindStudentById(ctx context.Context, id uuid.UUID)(*query.StudentQueryResult, error){
+	student , err := s.repo.FindById(ctx, id)
 	if err != nil {
 		return nil, err
 	}
@@ -115,8 +168,7 @@ func(s *StudentService) FindStudentById(id uuid.UUID)(*query.StudentQueryResult,
 	return &queryResult, nil
 }
 
-func(s *StudentService) UpdateStudent(updateCommand *command.UpdateStudentCommand)(*command.UpdateStudentCommandResult, error){
-	ctx := context.Background()
+func(s *StudentService) UpdateStudent(ctx context.Context, updateCommand *command.UpdateStudentCommand)(*command.UpdateStudentCommandResult, error){
 
 	if updateCommand.IdempotencyKey != "" {
 		existingRecord , err := s.idempotencyRepo.FindByKey(ctx, updateCommand.IdempotencyKey)
@@ -139,7 +191,9 @@ func(s *StudentService) UpdateStudent(updateCommand *command.UpdateStudentComman
 		idempotencyRecord = entities.NewIdempotencyRecord(updateCommand.IdempotencyKey,string(requestJSON))
 	}
 
-	storedStudent , err := s.repo.FindById(updateCommand.StudentId)
+	// The version read must be current, not that of a lagging replica.
+	ctx = repositories.ContextForWrite(ctx)
+	storedStudent , err := s.repo.FindById(ctx, updateCommand.StudentId)
 	if err != nil {
 		return nil, err
 	}
@@ -148,26 +202,32 @@ func(s *StudentService) UpdateStudent(updateCommand *command.UpdateStudentComman
 		return nil, errors.New("not found this student in order to update")
 	}
 
-	validUpdateStudent, err := entities.NewValidatedStudent(storedStudent)
-	if err != nil {
-		return nil, errors.New("this error came from find by ID")
+	if updateCommand.ExpectedVersion != 0 {
+		storedStudent.Version = updateCommand.ExpectedVersion
 	}
 
-	if err := storedStudent.UpdateNewFields(updateCommand.DateOfBirth , updateCommand.Phone , updateCommand.Major) ; err != nil {
+	previousPrograms := storedStudent.Programs
+	if err := storedStudent.UpdateNewFieldsAndPrograms(updateCommand.DateOfBirth , updateCommand.Phone , updateCommand.Major, updateCommand.Programs) ; err != nil {
 		return nil, err
 	}
 
-	validUpdateStudent, err = entities.NewValidatedStudent(storedStudent)
+	if updateCommand.Programs != nil {
+		if err := s.checkPrograms(ctx, storedStudent.Programs, previousPrograms); err != nil {
+			return nil, err
+		}
+	}
+
+	validUpdateStudent, err := entities.NewValidatedStudent(storedStudent)
 	if err != nil {
-		return nil, errors.New("this error came from update fields in storedStudent")
+		return nil, err
 	}
-	_, err = s.repo.Update(validUpdateStudent)
+	updatedStudent, err := s.repo.Update(ctx, validUpdateStudent)
 	if err != nil {
 		return nil, err
 	}
 
 	result := command.UpdateStudentCommandResult{
-		Result: mapper.NewStudentResultFromValidatedEntity(validUpdateStudent),
+		Result: mapper.NewStudentResultFromEntity(updatedStudent),
 	}
 
 	if idempotencyRecord != nil {
@@ -183,7 +243,253 @@ func(s *StudentService) UpdateStudent(updateCommand *command.UpdateStudentComman
 	return &result, nil
 }
 
-func(s *StudentService)DeleteStudent(id uuid.UUID)(error) {
-	return s.repo.Delete(id)
+func(s *StudentService) PatchStudent(ctx context.Context, patchCommand *command.PatchStudentCommand)(*command.UpdateStudentCommandResult, error) {
+	ctx = repositories.ContextForWrite(ctx)
+	storedStudent, err := s.repo.FindById(ctx, patchCommand.StudentId)
+	if err != nil {
+		return nil, err
+	}
+
+	if patchCommand.ExpectedVersion != 0 {
+		if storedStudent.Version != patchCommand.ExpectedVersion {
+			return nil, &repositories.VersionConflictError{
+				StudentID: storedStudent.StudentID,
+				ExpectedVersion: patchCommand.ExpectedVersion,
+				CurrentVersion: storedStudent.Version,
+			}
+		}
+	}
+
+	previousPrograms := storedStudent.Programs
+	if err := storedStudent.ApplyPatch(patchCommand.Patch); err != nil {
+		return nil, err
+	}
+
+	if patchCommand.Patch.Programs.Set {
+		if err := s.checkPrograms(ctx, storedStudent.Programs, previousPrograms); err != nil {
+			return nil, err
+		}
+	}
+
+	// A patch that changes nothing raises no event; skip the write so the
+	// history does not record an empty update.
+	if len(storedStudent.Events()) == 0 {
+		return &command.UpdateStudentCommandResult{
+			Result: mapper.NewStudentResultFromEntity(storedStudent),
+		}, nil
+	}
+
+	validStudent, err := entities.NewValidatedStudent(storedStudent)
+	if err != nil {
+		return nil, err
+	}
+
+	updatedStudent, err := s.repo.Update(ctx, validStudent)
+	if err != nil {
+		return nil, err
+	}
+
+	return &command.UpdateStudentCommandResult{
+		Result: mapper.NewStudentResultFromEntity(updatedStudent),
+	}, nil
+}
+
+// checkPrograms looks the declared programs up in the catalog; previous are
+// those the student had before the change.
+func(s *StudentService) checkPrograms(ctx context.Context, declared []entities.StudentProgram, previous []entities.StudentProgram) error {
+	if len(declared) == 0 {
+		return nil
+	}
+
+	ids := make([]uuid.UUID, 0, len(declared))
+	for _, program := range declared {
+		ids = append(ids, program.ProgramID)
+	}
+	programs, err := s.programRepo.FindByIds(ctx, ids)
+	if err != nil {
+		return err
+	}
+
+	catalog := make(map[uuid.UUID]*entities.Program, len(programs))
+	for _, program := range programs {
+		catalog[program.ID] = program
+	}
+	return entities.CheckProgramCatalog(declared, previous, catalog)
+}
+
+func(s *StudentService)DeleteStudent(ctx context.Context, id uuid.UUID, expectedVersion int)(error) {
+	ctx = repositories.ContextForWrite(ctx)
+	if expectedVersion == 0 {
+		storedStudent, err := s.repo.FindById(ctx, id)
+		if err != nil {
+			return err
+		}
+		expectedVersion = storedStudent.Version
+	}
+	return s.repo.Delete(ctx, id, expectedVersion)
+}
+
+func(s *StudentService) RestoreStudent(ctx context.Context, id uuid.UUID)(*query.StudentQueryResult, error) {
+	student, err := s.repo.Restore(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	return &query.StudentQueryResult{Result: mapper.NewStudentResultFromEntity(student)}, nil
+}
+
+func(s *StudentService) FindStudentHistory(ctx context.Context, id uuid.UUID, page int, pageSize int)(*query.StudentHistoryQueryResult, error) {
+	if page < 1 {
+		page = 1
+	}
+	if pageSize < 1 {
+		pageSize = defaultHistoryPageSize
+	}
+	if pageSize > maxHistoryPageSize {
+		pageSize = maxHistoryPageSize
+	}
+
+	entries, total, err := s.auditRepo.FindByStudentId(ctx, id, (page-1)*pageSize, pageSize)
+	if err != nil {
+		return nil, err
+	}
+
+	queryResult := query.StudentHistoryQueryResult{
+		Result: make([]*common.AuditEntryResult, 0, len(entries)),
+		Page: page,
+		PageSize: pageSize,
+		Total: total,
+	}
+	for _, entry := range entries {
+		queryResult.Result = append(queryResult.Result, mapper.NewAuditEntryResultFromEntity(entry))
+	}
+
+	return &queryResult, nil
+}
+
+func(s *StudentService) TransitionStudentStatus(ctx context.Context, transitionCommand *command.TransitionStudentStatusCommand)(*command.UpdateStudentCommandResult, error) {
+	ctx = repositories.ContextForWrite(ctx)
+	storedStudent, err := s.repo.FindById(ctx, transitionCommand.StudentId)
+	if err != nil {
+		return nil, err
+	}
+
+	if transitionCommand.ExpectedVersion != 0 {
+		if storedStudent.Version != transitionCommand.ExpectedVersion {
+			return nil, &repositories.VersionConflictError{
+				StudentID: storedStudent.StudentID,
+				ExpectedVersion: transitionCommand.ExpectedVersion,
+				CurrentVersion: storedStudent.Version,
+			}
+		}
+	}
+
+	if err := storedStudent.TransitionTo(transitionCommand.Status, transitionCommand.Reason, transitionCommand.EffectiveDate); err != nil {
+		return nil, err
+	}
+
+	validStudent, err := entities.NewValidatedStudent(storedStudent)
+	if err != nil {
+		return nil, err
+	}
+
+	updatedStudent, err := s.repo.Update(ctx, validStudent)
+	if err != nil {
+		return nil, err
+	}
+
+	return &command.UpdateStudentCommandResult{
+		Result: mapper.NewStudentResultFromEntity(updatedStudent),
+	}, nil
+}
+
+// FindStudentStatusHistory pages through the student's transitions, latest
+// first, like FindStudentHistory.
+func(s *StudentService) FindStudentStatusHistory(ctx context.Context, id uuid.UUID, page int, pageSize int)(*query.StudentStatusHistoryQueryResult, error) {
+	if page < 1 {
+		page = 1
+	}
+	if pageSize < 1 {
+		pageSize = defaultHistoryPageSize
+	}
+	if pageSize > maxHistoryPageSize {
+		pageSize = maxHistoryPageSize
+	}
+
+	changes, total, err := s.statusRepo.FindByStudentId(ctx, id, (page-1)*pageSize, pageSize)
+	if err != nil {
+		return nil, err
+	}
+
+	queryResult := query.StudentStatusHistoryQueryResult{
+		Result: make([]*common.StudentStatusChangeResult, 0, len(changes)),
+		Page: page,
+		PageSize: pageSize,
+		Total: total,
+	}
+	for _, change := range changes {
+		queryResult.Result = append(queryResult.Result, mapper.NewStudentStatusChangeResultFromEntity(change))
+	}
+
+	return &queryResult, nil
+}
+
+// SaveStudentContact adds or replaces one contact of the student; the entity
+// keeps the verification state and the primary flags consistent.
+func(s *StudentService) SaveStudentContact(ctx context.Context, contactCommand *command.SaveStudentContactCommand)(*command.UpdateStudentCommandResult, error) {
+	return s.changeContacts(ctx, contactCommand.StudentId, contactCommand.ExpectedVersion, func(student *entities.Student) error {
+		switch {
+		case contactCommand.Address != nil:
+			return student.SaveAddress(*contactCommand.Address)
+		case contactCommand.Email != nil:
+			return student.SaveEmail(*contactCommand.Email)
+		case contactCommand.Phone != nil:
+			return student.SavePhone(*contactCommand.Phone)
+		case contactCommand.EmergencyContact != nil:
+			return student.SaveEmergencyContact(*contactCommand.EmergencyContact)
+		}
+		return fmt.Errorf("%w: no contact to save", entities.ErrInvalidContact)
+	})
 }
 
+func(s *StudentService) RemoveStudentContact(ctx context.Context, contactCommand *command.RemoveStudentContactCommand)(*command.UpdateStudentCommandResult, error) {
+	return s.changeContacts(ctx, contactCommand.StudentId, contactCommand.ExpectedVersion, func(student *entities.Student) error {
+		return student.RemoveContact(contactCommand.Kind, contactCommand.ContactId)
+	})
+}
+
+func(s *StudentService) changeContacts(ctx context.Context, id uuid.UUID, expectedVersion int, change func(student *entities.Student) error)(*command.UpdateStudentCommandResult, error) {
+	ctx = repositories.ContextForWrite(ctx)
+	storedStudent, err := s.repo.FindById(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if expectedVersion != 0 {
+		if storedStudent.Version != expectedVersion {
+			return nil, &repositories.VersionConflictError{
+				StudentID: storedStudent.StudentID,
+				ExpectedVersion: expectedVersion,
+				CurrentVersion: storedStudent.Version,
+			}
+		}
+	}
+
+	if err := change(storedStudent); err != nil {
+		return nil, err
+	}
+
+	validStudent, err := entities.NewValidatedStudent(storedStudent)
+	if err != nil {
+		return nil, err
+	}
+
+	updatedStudent, err := s.repo.Update(ctx, validStudent)
+	if err != nil {
+		return nil, err
+	}
+
+	return &command.UpdateStudentCommandResult{
+		Result: mapper.NewStudentResultFromEntity(updatedStudent),
+	}, nil
+}
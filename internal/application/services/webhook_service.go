@@ -0,0 +1,116 @@
+package services
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/tranvu1111/go-students-new/internal/application/command"
+	"github.com/tranvu1111/go-students-new/internal/application/common"
+	"github.com/tranvu1111/go-students-new/internal/application/interfaces"
+	"github.com/tranvu1111/go-students-new/internal/application/mapper"
+	"github.com/tranvu1111/go-students-new/internal/application/query"
+	"github.com/tranvu1111/go-students-new/internal/domain/entities"
+	"github.com/tranvu1111/go-students-new/internal/domain/repositories"
+)
+
+type WebhookService struct {
+	subscriptionRepo 	repositories.WebhookSubscriptionRepository
+	deliveryRepo 		repositories.WebhookDeliveryRepository
+}
+
+func NewWebhookService(sr repositories.WebhookSubscriptionRepository, dr repositories.WebhookDeliveryRepository) interfaces.WebhookService {
+	return &WebhookService{
+		subscriptionRepo: sr,
+		deliveryRepo: dr,
+	}
+}
+
+func (s *WebhookService) CreateSubscription(ctx context.Context, createCommand *command.CreateWebhookSubscriptionCommand)(*command.WebhookSubscriptionCommandResult, error) {
+	subscription, err := entities.NewWebhookSubscription(createCommand.URL, createCommand.EventTypes, createCommand.Secret)
+	if err != nil {
+		return nil, err
+	}
+
+	created, err := s.subscriptionRepo.Create(ctx, subscription)
+	if err != nil {
+		return nil, err
+	}
+
+	return &command.WebhookSubscriptionCommandResult{
+		Result: mapper.NewWebhookSubscriptionResultFromEntity(created),
+	}, nil
+}
+
+func (s *WebhookService) FindAllSubscriptions(ctx context.Context)(*query.WebhookSubscriptionQueryListResult, error) {
+	subscriptions, err := s.subscriptionRepo.FindAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	queryResult := query.WebhookSubscriptionQueryListResult{Result: make([]*common.WebhookSubscriptionResult, 0, len(subscriptions))}
+	for _, subscription := range subscriptions {
+		queryResult.Result = append(queryResult.Result, mapper.NewWebhookSubscriptionResultFromEntity(subscription))
+	}
+	return &queryResult, nil
+}
+
+func (s *WebhookService) FindSubscriptionById(ctx context.Context, id uuid.UUID)(*query.WebhookSubscriptionQueryResult, error) {
+	subscription, err := s.subscriptionRepo.FindById(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	return &query.WebhookSubscriptionQueryResult{Result: mapper.NewWebhookSubscriptionResultFromEntity(subscription)}, nil
+}
+
+func (s *WebhookService) UpdateSubscription(ctx context.Context, updateCommand *command.UpdateWebhookSubscriptionCommand)(*command.WebhookSubscriptionCommandResult, error) {
+	subscription, err := s.subscriptionRepo.FindById(ctx, updateCommand.SubscriptionID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := subscription.Update(updateCommand.URL, updateCommand.EventTypes, updateCommand.Secret, updateCommand.Active); err != nil {
+		return nil, err
+	}
+
+	updated, err := s.subscriptionRepo.Update(ctx, subscription)
+	if err != nil {
+		return nil, err
+	}
+
+	return &command.WebhookSubscriptionCommandResult{
+		Result: mapper.NewWebhookSubscriptionResultFromEntity(updated),
+	}, nil
+}
+
+func (s *WebhookService) DeleteSubscription(ctx context.Context, id uuid.UUID)(error) {
+	return s.subscriptionRepo.Delete(ctx, id)
+}
+
+func (s *WebhookService) FindDeliveries(ctx context.Context, subscriptionID uuid.UUID, page int, pageSize int)(*query.WebhookDeliveryQueryListResult, error) {
+	if page < 1 {
+		page = 1
+	}
+	if pageSize < 1 {
+		pageSize = defaultHistoryPageSize
+	}
+	if pageSize > maxHistoryPageSize {
+		pageSize = maxHistoryPageSize
+	}
+
+	deliveries, total, err := s.deliveryRepo.FindBySubscriptionId(ctx, subscriptionID, (page-1)*pageSize, pageSize)
+	if err != nil {
+		return nil, err
+	}
+
+	queryResult := query.WebhookDeliveryQueryListResult{
+		Result: make([]*common.WebhookDeliveryResult, 0, len(deliveries)),
+		Page: page,
+		PageSize: pageSize,
+		Total: total,
+	}
+	for _, delivery := range deliveries {
+		queryResult.Result = append(queryResult.Result, mapper.NewWebhookDeliveryResultFromEntity(delivery))
+	}
+	return &queryResult, nil
+}
@@ -0,0 +1,168 @@
+package services_test
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/glebarez/sqlite"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tranvu1111/go-students-new/internal/application/command"
+	"github.com/tranvu1111/go-students-new/internal/application/interfaces"
+	"github.com/tranvu1111/go-students-new/internal/application/services"
+	"github.com/tranvu1111/go-students-new/internal/infrastructure/db/postgres"
+	"gorm.io/gorm"
+)
+
+// setupStudentService wires the real StudentService to an in-memory SQLite
+// database private to the test.
+func setupStudentService(t *testing.T) (interfaces.StudentService, *gorm.DB) {
+	db, err := gorm.Open(sqlite.Open(fmt.Sprintf("file:%s?mode=memory&cache=shared", t.Name())), &gorm.Config{})
+	if err != nil {
+		t.Fatalf("Failed to connect to in-memory database: %v", err)
+	}
+	if err := postgres.AutoMigrate(db); err != nil {
+		t.Fatalf("Failed to auto-migrate schema: %v", err)
+	}
+
+	service := services.NewStudentService(
+		postgres.NewGormStudentRepo(db),
+		postgres.NewGormIdempotencyRepository(db),
+		postgres.NewGormAuditRepo(db),
+		postgres.NewGormStudentStatusRepo(db),
+		postgres.NewGormProgramRepo(db),
+	)
+	return service, db
+}
+
+type sliceRowReader struct {
+	rows []*command.CreateStudentCommand
+}
+
+func (r *sliceRowReader) Next() (*command.CreateStudentCommand, error) {
+	if len(r.rows) == 0 {
+		return nil, io.EOF
+	}
+	row := r.rows[0]
+	r.rows = r.rows[1:]
+	return row, nil
+}
+
+func importRows(invalidAt int, count int) *sliceRowReader {
+	reader := &sliceRowReader{}
+	for i := 1; i <= count; i++ {
+		row := &command.CreateStudentCommand{
+			FirstName:      "Student",
+			LastName:       fmt.Sprintf("Number%d", i),
+			Email:          fmt.Sprintf("student%d@example.com", i),
+			EnrollmentDate: time.Date(2024, 9, 1, 0, 0, 0, 0, time.UTC),
+		}
+		if i == invalidAt {
+			row.Email = "not-an-email"
+		}
+		reader.rows = append(reader.rows, row)
+	}
+	return reader
+}
+
+func countStudents(t *testing.T, db *gorm.DB) int64 {
+	var count int64
+	require.NoError(t, db.Model(&postgres.DBStudent{}).Count(&count).Error)
+	return count
+}
+
+func TestImportStudents_AllOrNothing(t *testing.T) {
+	service, db := setupStudentService(t)
+	ctx := context.Background()
+
+	result, err := service.ImportStudents(ctx, &command.ImportStudentsCommand{Rows: importRows(3, 5), BatchSize: 2})
+	require.NoError(t, err)
+	assert.False(t, result.Committed)
+	assert.Equal(t, 5, result.Total)
+	assert.Equal(t, 0, result.Created)
+	assert.Equal(t, 1, result.Failed)
+	assert.Equal(t, "Invalid email", result.Rows[2].Error)
+	assert.Nil(t, result.Rows[0].StudentID)
+	assert.Equal(t, int64(0), countStudents(t, db))
+
+	result, err = service.ImportStudents(ctx, &command.ImportStudentsCommand{Rows: importRows(0, 5), BatchSize: 2})
+	require.NoError(t, err)
+	assert.True(t, result.Committed)
+	assert.Equal(t, 5, result.Created)
+	assert.Equal(t, int64(5), countStudents(t, db))
+
+	stored, err := service.FindStudentById(ctx, *result.Rows[4].StudentID)
+	require.NoError(t, err)
+	assert.Equal(t, "student5@example.com", stored.Result.Email)
+}
+
+func TestImportStudents_BestEffort(t *testing.T) {
+	service, db := setupStudentService(t)
+
+	result, err := service.ImportStudents(context.Background(), &command.ImportStudentsCommand{
+		Rows:      importRows(2, 5),
+		Mode:      command.ImportModeBestEffort,
+		BatchSize: 2,
+	})
+	require.NoError(t, err)
+	assert.True(t, result.Committed)
+	assert.Equal(t, 4, result.Created)
+	assert.Equal(t, 1, result.Failed)
+	assert.Nil(t, result.Rows[1].StudentID)
+	assert.NotNil(t, result.Rows[4].StudentID)
+	assert.Equal(t, int64(4), countStudents(t, db))
+}
+
+func TestImportStudents_DryRun(t *testing.T) {
+	service, db := setupStudentService(t)
+
+	result, err := service.ImportStudents(context.Background(), &command.ImportStudentsCommand{
+		Rows:   importRows(1, 3),
+		Mode:   command.ImportModeBestEffort,
+		DryRun: true,
+	})
+	require.NoError(t, err)
+	assert.True(t, result.DryRun)
+	assert.False(t, result.Committed)
+	assert.Equal(t, 0, result.Created)
+	assert.Equal(t, 1, result.Failed)
+	assert.NotNil(t, result.Rows[1].StudentID, "dry run reports the rows that would be created")
+	assert.Equal(t, int64(0), countStudents(t, db))
+}
+
+// brokenRowReader returns its rows, then fails to read any further.
+type brokenRowReader struct {
+	sliceRowReader
+}
+
+func (r *brokenRowReader) Next() (*command.CreateStudentCommand, error) {
+	if len(r.rows) == 0 {
+		return nil, fmt.Errorf("%w: connection reset", command.ErrImportUnreadable)
+	}
+	return r.sliceRowReader.Next()
+}
+
+func TestImportStudents_StopsAtUnreadableRow(t *testing.T) {
+	service, db := setupStudentService(t)
+
+	result, err := service.ImportStudents(context.Background(), &command.ImportStudentsCommand{
+		Rows: &brokenRowReader{sliceRowReader: *importRows(0, 2)},
+		Mode: command.ImportModeBestEffort,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, 3, result.Total)
+	assert.Equal(t, 2, result.Created)
+	assert.Equal(t, 1, result.Failed)
+	assert.Contains(t, result.Rows[2].Error, "connection reset")
+	assert.Equal(t, int64(2), countStudents(t, db))
+}
+
+func TestImportStudents_UnknownMode(t *testing.T) {
+	service, _ := setupStudentService(t)
+
+	_, err := service.ImportStudents(context.Background(), &command.ImportStudentsCommand{Rows: importRows(0, 1), Mode: "sometimes"})
+	assert.Error(t, err)
+}
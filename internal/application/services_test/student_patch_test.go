@@ -0,0 +1,59 @@
+package services_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tranvu1111/go-students-new/internal/application/command"
+	"github.com/tranvu1111/go-students-new/internal/domain/entities"
+	"github.com/tranvu1111/go-students-new/internal/domain/repositories"
+)
+
+func TestPatchStudent(t *testing.T) {
+	service, _ := setupStudentService(t)
+	ctx := context.Background()
+
+	phone := "+84947531799"
+	created, err := service.CreateStudent(ctx, &command.CreateStudentCommand{
+		FirstName:      "tran",
+		LastName:       "vu",
+		Email:          "tranvu@example.com",
+		Phone:          &phone,
+		EnrollmentDate: time.Date(2023, 9, 1, 0, 0, 0, 0, time.UTC),
+	})
+	require.NoError(t, err)
+	id := created.Result.StudentID
+
+	patched, err := service.PatchStudent(ctx, &command.PatchStudentCommand{
+		StudentId:       id,
+		Patch:           entities.StudentPatch{Major: entities.Replace("Physics")},
+		ExpectedVersion: 1,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, 2, patched.Result.Version)
+	assert.Equal(t, "Physics", *patched.Result.Major)
+	require.NotNil(t, patched.Result.Phone, "an absent field must be kept")
+
+	unchanged, err := service.PatchStudent(ctx, &command.PatchStudentCommand{
+		StudentId:       id,
+		Patch:           entities.StudentPatch{Major: entities.Replace("Physics")},
+		ExpectedVersion: 2,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, 2, unchanged.Result.Version, "a no-op patch must not bump the version")
+
+	_, err = service.PatchStudent(ctx, &command.PatchStudentCommand{
+		StudentId:       id,
+		Patch:           entities.StudentPatch{Phone: entities.Clear[string]()},
+		ExpectedVersion: 1,
+	})
+	var conflict *repositories.VersionConflictError
+	assert.ErrorAs(t, err, &conflict)
+
+	history, err := service.FindStudentHistory(ctx, id, 1, 10)
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), history.Total)
+}
@@ -0,0 +1,70 @@
+package services_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tranvu1111/go-students-new/internal/application/command"
+	"github.com/tranvu1111/go-students-new/internal/domain/entities"
+	"github.com/tranvu1111/go-students-new/internal/infrastructure/db/postgres"
+)
+
+func TestUpdateStudent_ReturnsValidationErrors(t *testing.T) {
+	service, _ := setupStudentService(t)
+	ctx := context.Background()
+
+	created, err := service.CreateStudent(ctx, &command.CreateStudentCommand{
+		FirstName:      "tran",
+		LastName:       "vu",
+		Email:          "tranvu@example.com",
+		EnrollmentDate: time.Date(2023, 9, 1, 0, 0, 0, 0, time.UTC),
+	})
+	require.NoError(t, err)
+
+	future := time.Now().AddDate(1, 0, 0)
+	_, err = service.UpdateStudent(ctx, &command.UpdateStudentCommand{
+		StudentId:   created.Result.StudentID,
+		DateOfBirth: &future,
+	})
+	var invalid *entities.ValidationError
+	require.ErrorAs(t, err, &invalid)
+	assert.Equal(t, "student.date_of_birth_invalid", invalid.Code)
+	assert.ErrorIs(t, err, entities.ErrInvalidPatch, "the REST layer answers 422 for it")
+}
+
+func TestUpdateStudent_KeepsLegacyPhone(t *testing.T) {
+	service, db := setupStudentService(t)
+	ctx := context.Background()
+
+	created, err := service.CreateStudent(ctx, &command.CreateStudentCommand{
+		FirstName:      "tran",
+		LastName:       "vu",
+		Email:          "tranvu@example.com",
+		EnrollmentDate: time.Date(2023, 9, 1, 0, 0, 0, 0, time.UTC),
+	})
+	require.NoError(t, err)
+	id := created.Result.StudentID
+	// Stored before phone numbers had to be E.164.
+	require.NoError(t, db.Model(&postgres.DBStudent{}).Where("student_id = ?", id).Update("phone", "090 123 4567").Error)
+
+	major := "Physics"
+	updated, err := service.UpdateStudent(ctx, &command.UpdateStudentCommand{StudentId: id, Major: &major})
+	require.NoError(t, err)
+	assert.Equal(t, "Physics", *updated.Result.Major)
+	require.NotNil(t, updated.Result.Phone)
+	assert.Equal(t, "090 123 4567", *updated.Result.Phone)
+
+	national := "0907654321"
+	_, err = service.UpdateStudent(ctx, &command.UpdateStudentCommand{StudentId: id, Phone: &national})
+	var invalid *entities.ValidationError
+	require.ErrorAs(t, err, &invalid, "a new phone must be E.164")
+	assert.Equal(t, "student.phone_invalid", invalid.Code)
+
+	phone := "+84907654321"
+	updated, err = service.UpdateStudent(ctx, &command.UpdateStudentCommand{StudentId: id, Phone: &phone})
+	require.NoError(t, err)
+	assert.Equal(t, "+84907654321", *updated.Result.Phone)
+}
@@ -0,0 +1,97 @@
+// Package config reads the settings shared by the API server and studentctl
+// from the environment.
+package config
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"time"
+)
+
+const (
+	defaultDatabaseDSN       = "host=localhost user=postgres password=tranvu123@ dbname=demodb port=5432 sslmode=disable"
+	defaultSQLiteDatabaseDSN = "students.db"
+)
+
+type Config struct {
+	// DatabaseDriver is "postgres" or "sqlite", from DATABASE_DRIVER.
+	DatabaseDriver string
+	// DatabaseDSN is the connection string, from DATABASE_DSN; for SQLite it
+	// is the path of the database file.
+	DatabaseDSN string
+	// HTTPAddr is where the REST and GraphQL APIs listen, from HTTP_PORT.
+	HTTPAddr string
+	// GRPCAddr is where the gRPC API listens, from GRPC_PORT.
+	GRPCAddr string
+
+	// The connection pool, from DATABASE_MAX_OPEN_CONNS,
+	// DATABASE_MAX_IDLE_CONNS, DATABASE_CONN_MAX_LIFETIME and
+	// DATABASE_CONN_MAX_IDLE_TIME. Zero keeps the database/sql default.
+	DatabaseMaxOpenConns    int
+	DatabaseMaxIdleConns    int
+	DatabaseConnMaxLifetime time.Duration
+	DatabaseConnMaxIdleTime time.Duration
+}
+
+// Load reads the environment; it fails on malformed numbers and durations.
+func Load() (Config, error) {
+	driver := getenv("DATABASE_DRIVER", "postgres")
+	fallbackDSN := defaultDatabaseDSN
+	if driver == "sqlite" {
+		fallbackDSN = defaultSQLiteDatabaseDSN
+	}
+
+	cfg := Config{
+		DatabaseDriver: driver,
+		DatabaseDSN:    getenv("DATABASE_DSN", fallbackDSN),
+		HTTPAddr:       getenv("HTTP_PORT", ":8080"),
+		GRPCAddr:       getenv("GRPC_PORT", ":9090"),
+	}
+
+	var err error
+	if cfg.DatabaseMaxOpenConns, err = getenvInt("DATABASE_MAX_OPEN_CONNS"); err != nil {
+		return Config{}, err
+	}
+	if cfg.DatabaseMaxIdleConns, err = getenvInt("DATABASE_MAX_IDLE_CONNS"); err != nil {
+		return Config{}, err
+	}
+	if cfg.DatabaseConnMaxLifetime, err = getenvDuration("DATABASE_CONN_MAX_LIFETIME"); err != nil {
+		return Config{}, err
+	}
+	if cfg.DatabaseConnMaxIdleTime, err = getenvDuration("DATABASE_CONN_MAX_IDLE_TIME"); err != nil {
+		return Config{}, err
+	}
+	return cfg, nil
+}
+
+func getenv(key string, fallback string) string {
+	if value := os.Getenv(key); value != "" {
+		return value
+	}
+	return fallback
+}
+
+func getenvInt(key string) (int, error) {
+	value := os.Getenv(key)
+	if value == "" {
+		return 0, nil
+	}
+	n, err := strconv.Atoi(value)
+	if err != nil || n < 0 {
+		return 0, fmt.Errorf("invalid %s %q: want a non-negative integer", key, value)
+	}
+	return n, nil
+}
+
+func getenvDuration(key string) (time.Duration, error) {
+	value := os.Getenv(key)
+	if value == "" {
+		return 0, nil
+	}
+	d, err := time.ParseDuration(value)
+	if err != nil || d < 0 {
+		return 0, fmt.Errorf("invalid %s %q: want a non-negative duration such as 30m", key, value)
+	}
+	return d, nil
+}
@@ -0,0 +1,158 @@
+package entities
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type AuditOperation string
+
+const (
+	AuditOperationCreate  AuditOperation = "create"
+	AuditOperationUpdate  AuditOperation = "update"
+	AuditOperationDelete  AuditOperation = "delete"
+	AuditOperationRestore AuditOperation = "restore"
+)
+
+const SystemActor = "system"
+
+// FieldChange is the before/after value of one student field, rendered as text.
+// A nil Before or After means the field was unset on that side of the change.
+type FieldChange struct {
+	Field  string
+	Before *string
+	After  *string
+}
+
+type AuditEntry struct {
+	ID         uuid.UUID
+	StudentID  uuid.UUID
+	Actor      string
+	RequestID  string
+	Operation  AuditOperation
+	Changes    []FieldChange
+	OccurredAt time.Time
+}
+
+func NewAuditEntry(ctx context.Context, studentID uuid.UUID, operation AuditOperation, changes []FieldChange) *AuditEntry {
+	actor, requestID := AuditActorFromContext(ctx)
+	return &AuditEntry{
+		ID:         uuid.New(),
+		StudentID:  studentID,
+		Actor:      actor,
+		RequestID:  requestID,
+		Operation:  operation,
+		Changes:    changes,
+		OccurredAt: time.Now(),
+	}
+}
+
+type auditActorKey struct{}
+
+type auditActor struct {
+	actor     string
+	requestID string
+}
+
+// ContextWithAuditActor attaches who is performing the change, and the request
+// it belongs to, so that repositories can stamp their audit entries.
+func ContextWithAuditActor(ctx context.Context, actor string, requestID string) context.Context {
+	return context.WithValue(ctx, auditActorKey{}, auditActor{actor: actor, requestID: requestID})
+}
+
+func AuditActorFromContext(ctx context.Context) (string, string) {
+	value, ok := ctx.Value(auditActorKey{}).(auditActor)
+	if !ok || value.actor == "" {
+		return SystemActor, ""
+	}
+	return value.actor, value.requestID
+}
+
+// DiffStudents lists the fields that differ between before and after.
+// Passing a nil before yields every set field of after (a create); a nil after
+// yields every set field of before (a delete).
+func DiffStudents(before *Student, after *Student) []FieldChange {
+	beforeFields := studentAuditFields(before)
+	afterFields := studentAuditFields(after)
+
+	var changes []FieldChange
+	for _, name := range studentAuditFieldNames {
+		b, a := beforeFields[name], afterFields[name]
+		if equalFieldValues(b, a) {
+			continue
+		}
+		changes = append(changes, FieldChange{Field: name, Before: b, After: a})
+	}
+	return changes
+}
+
+var studentAuditFieldNames = []string{
+	"FirstName",
+	"LastName",
+	"DateOfBirth",
+	"Email",
+	"EmailVerifiedAt",
+	"Phone",
+	"Major",
+	"Locale",
+	"Programs",
+	"Addresses",
+	"ContactEmails",
+	"ContactPhones",
+	"EmergencyContacts",
+	"EnrollmentDate",
+	"Status",
+	"StatusReason",
+	"StatusEffectiveDate",
+}
+
+func studentAuditFields(s *Student) map[string]*string {
+	fields := map[string]*string{}
+	if s == nil {
+		return fields
+	}
+
+	fields["FirstName"] = stringValue(s.FirstName)
+	fields["LastName"] = stringValue(s.LastName)
+	fields["DateOfBirth"] = timeValue(s.DateOfBirth)
+	fields["Email"] = stringValue(s.Email)
+	fields["EmailVerifiedAt"] = timeValue(s.EmailVerifiedAt)
+	fields["Phone"] = s.Phone
+	fields["Major"] = s.Major
+	fields["Locale"] = s.Locale
+	fields["Programs"] = programsValue(s.Programs)
+	fields["Addresses"] = addressesValue(s.Contacts.Addresses)
+	fields["ContactEmails"] = contactEmailsValue(s.Contacts.Emails)
+	fields["ContactPhones"] = contactPhonesValue(s.Contacts.Phones)
+	fields["EmergencyContacts"] = emergencyContactsValue(s.Contacts.EmergencyContacts)
+	fields["EnrollmentDate"] = timeValue(&s.EnrollmentDate)
+	fields["Status"] = stringValue(string(s.Status))
+	fields["StatusReason"] = stringValue(s.StatusReason)
+	fields["StatusEffectiveDate"] = timeValue(&s.StatusEffectiveDate)
+	return fields
+}
+
+func stringValue(v string) *string {
+	if v == "" {
+		return nil
+	}
+	return &v
+}
+
+func timeValue(t *time.Time) *string {
+	if t == nil || t.IsZero() {
+		return nil
+	}
+	v := t.UTC().Format(time.RFC3339)
+	return &v
+}
+
+func equalFieldValues(a *string, b *string) bool {
+	if a == nil || b == nil {
+		return a == nil && b == nil
+	}
+	return *a == *b
+}
+
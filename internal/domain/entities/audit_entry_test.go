@@ -0,0 +1,69 @@
+package entities
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+func TestDiffStudents(t *testing.T) {
+	phone := "+84947531799"
+	oldMajor := "CS"
+	newMajor := "Computer Science"
+	now := time.Now()
+
+	before := &Student{
+		StudentID:      uuid.New(),
+		FirstName:      "tran",
+		LastName:       "vu",
+		Email:          "tranvu123@gmail.com",
+		Phone:          &phone,
+		Major:          &oldMajor,
+		EnrollmentDate: now,
+	}
+	after := *before
+	after.Phone = nil
+	after.Major = &newMajor
+
+	changes := DiffStudents(before, &after)
+	if len(changes) != 2 {
+		t.Fatalf("Expected 2 changes but got %d: %+v", len(changes), changes)
+	}
+
+	if changes[0].Field != "Phone" || *changes[0].Before != phone || changes[0].After != nil {
+		t.Errorf("Unexpected phone change %+v", changes[0])
+	}
+
+	if changes[1].Field != "Major" || *changes[1].Before != oldMajor || *changes[1].After != newMajor {
+		t.Errorf("Unexpected major change %+v", changes[1])
+	}
+
+	created := DiffStudents(nil, before)
+	for _, change := range created {
+		if change.Before != nil {
+			t.Errorf("Expected no before value on create, got %+v", change)
+		}
+	}
+	if len(created) != 6 {
+		t.Errorf("Expected every set field on create, got %d", len(created))
+	}
+
+	if changes := DiffStudents(before, before); len(changes) != 0 {
+		t.Errorf("Expected no changes, got %+v", changes)
+	}
+}
+
+func TestAuditActorFromContext(t *testing.T) {
+	actor, requestID := AuditActorFromContext(context.Background())
+	if actor != SystemActor || requestID != "" {
+		t.Errorf("Expected system actor without request ID, got %q %q", actor, requestID)
+	}
+
+	ctx := ContextWithAuditActor(context.Background(), "advisor@uni.edu", "req-1")
+	entry := NewAuditEntry(ctx, uuid.New(), AuditOperationUpdate, nil)
+	if entry.Actor != "advisor@uni.edu" || entry.RequestID != "req-1" {
+		t.Errorf("Expected actor and request ID from context, got %q %q", entry.Actor, entry.RequestID)
+	}
+}
@@ -0,0 +1,105 @@
+package entities
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ErrInvalidVerificationToken is returned for a token that was not signed
+// with the secret, has expired, or names an email the student no longer has.
+var ErrInvalidVerificationToken = errors.New("invalid email verification token")
+
+// EmailVerificationToken proves that whoever holds it received mail at Email.
+// It is signed rather than stored, so issuing one needs no write.
+type EmailVerificationToken struct {
+	StudentID uuid.UUID `json:"sid"`
+	Email     string    `json:"email"`
+	ExpiresAt time.Time `json:"exp"`
+}
+
+func NewEmailVerificationToken(student *Student, ttl time.Duration) EmailVerificationToken {
+	return EmailVerificationToken{
+		StudentID: student.StudentID,
+		Email:     student.Email,
+		ExpiresAt: time.Now().Add(ttl).UTC().Truncate(time.Second),
+	}
+}
+
+// Sign returns "<payload>.<signature>", both base64url encoded, where the
+// signature is the HMAC-SHA256 of the payload keyed with secret.
+func (t EmailVerificationToken) Sign(secret []byte) string {
+	payload, _ := json.Marshal(t)
+	encoded := base64.RawURLEncoding.EncodeToString(payload)
+	return encoded + "." + base64.RawURLEncoding.EncodeToString(signVerificationPayload(secret, encoded))
+}
+
+// ParseEmailVerificationToken checks the signature and expiry of a token
+// produced by Sign.
+func ParseEmailVerificationToken(token string, secret []byte, now time.Time) (EmailVerificationToken, error) {
+	encoded, signature, found := strings.Cut(strings.TrimSpace(token), ".")
+	if !found {
+		return EmailVerificationToken{}, newValidationError(ErrInvalidVerificationToken, "verification.token_malformed", "malformed token")
+	}
+	mac, err := base64.RawURLEncoding.DecodeString(signature)
+	if err != nil || !hmac.Equal(mac, signVerificationPayload(secret, encoded)) {
+		return EmailVerificationToken{}, newValidationError(ErrInvalidVerificationToken, "verification.signature_mismatch", "signature mismatch")
+	}
+
+	var parsed EmailVerificationToken
+	payload, err := base64.RawURLEncoding.DecodeString(encoded)
+	if err != nil {
+		return EmailVerificationToken{}, newValidationError(ErrInvalidVerificationToken, "verification.token_malformed", "malformed token")
+	}
+	if err := json.Unmarshal(payload, &parsed); err != nil {
+		return EmailVerificationToken{}, newValidationError(ErrInvalidVerificationToken, "verification.token_malformed", "malformed token")
+	}
+	if !now.Before(parsed.ExpiresAt) {
+		return EmailVerificationToken{}, newValidationError(ErrInvalidVerificationToken, "verification.token_expired", "the token expired at {expires_at}", "expires_at", parsed.ExpiresAt.Format(time.RFC3339))
+	}
+	return parsed, nil
+}
+
+func signVerificationPayload(secret []byte, encoded string) []byte {
+	mac := hmac.New(sha256.New, secret)
+	mac.Write([]byte(encoded))
+	return mac.Sum(nil)
+}
+
+// VerifyEmail marks Email verified by token. A token issued for an email the
+// student has since changed is rejected; verifying twice changes nothing.
+func (s *Student) VerifyEmail(token EmailVerificationToken, at time.Time) error {
+	if token.StudentID != s.StudentID || !strings.EqualFold(token.Email, s.Email) {
+		return newValidationError(ErrInvalidVerificationToken, "verification.email_mismatch", "the token was issued for another email")
+	}
+	if s.EmailVerifiedAt != nil {
+		return nil
+	}
+
+	before := *s
+	verifiedAt := at.UTC()
+	s.EmailVerifiedAt = &verifiedAt
+	s.UpdatedAt = time.Now()
+	s.raise(StudentUpdated, DiffStudents(&before, s))
+	return nil
+}
+
+// VerificationEmail returns the email a StudentCreated or StudentUpdated
+// event asks to verify, if any.
+func (e StudentEvent) VerificationEmail() (string, bool) {
+	if e.Type != StudentCreated && e.Type != StudentUpdated {
+		return "", false
+	}
+	for _, change := range e.Changes {
+		if change.Field == "Email" && change.After != nil {
+			return *change.After, true
+		}
+	}
+	return "", false
+}
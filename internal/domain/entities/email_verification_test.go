@@ -0,0 +1,76 @@
+package entities
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestEmailVerificationToken_SignAndParse(t *testing.T) {
+	secret := []byte("secret")
+	student := newEnrolledStudent(t)
+	token := NewEmailVerificationToken(student, time.Hour)
+	signed := token.Sign(secret)
+
+	parsed, err := ParseEmailVerificationToken(signed, secret, time.Now())
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if parsed.StudentID != student.StudentID || parsed.Email != student.Email || !parsed.ExpiresAt.Equal(token.ExpiresAt) {
+		t.Errorf("Expected %+v, got %+v", token, parsed)
+	}
+
+	tests := []struct {
+		name   string
+		token  string
+		secret []byte
+		now    time.Time
+	}{
+		{"other secret", signed, []byte("other"), time.Now()},
+		{"expired", signed, secret, token.ExpiresAt},
+		{"tampered", "x" + signed, secret, time.Now()},
+		{"malformed", "not-a-token", secret, time.Now()},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if _, err := ParseEmailVerificationToken(test.token, test.secret, test.now); !errors.Is(err, ErrInvalidVerificationToken) {
+				t.Errorf("Expected ErrInvalidVerificationToken, got %v", err)
+			}
+		})
+	}
+}
+
+func TestStudentVerifyEmail(t *testing.T) {
+	student := newEnrolledStudent(t)
+	token := NewEmailVerificationToken(student, time.Hour)
+	verifiedAt := time.Date(2024, time.March, 1, 8, 30, 0, 0, time.UTC)
+
+	if err := student.VerifyEmail(token, verifiedAt); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if student.EmailVerifiedAt == nil || !student.EmailVerifiedAt.Equal(verifiedAt) {
+		t.Fatalf("Expected the email verified at %s, got %v", verifiedAt, student.EmailVerifiedAt)
+	}
+	events := student.Events()
+	if len(events) != 1 || len(events[0].Changes) != 1 || events[0].Changes[0].Field != "EmailVerifiedAt" {
+		t.Errorf("Expected one StudentUpdated event for EmailVerifiedAt, got %+v", events)
+	}
+
+	student.ClearEvents()
+	if err := student.VerifyEmail(token, verifiedAt.Add(time.Hour)); err != nil || len(student.Events()) != 0 || !student.EmailVerifiedAt.Equal(verifiedAt) {
+		t.Errorf("Expected verifying twice to change nothing, got %v and %+v", err, student.Events())
+	}
+
+	if err := student.ApplyPatch(StudentPatch{Email: Replace("vu.tran@example.com")}); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if student.EmailVerifiedAt != nil {
+		t.Errorf("Expected a new email to be unverified, got %v", student.EmailVerifiedAt)
+	}
+	if email, ok := student.Events()[0].VerificationEmail(); !ok || email != "vu.tran@example.com" {
+		t.Errorf("Expected the event to ask to verify the new email, got %q", email)
+	}
+	if err := student.VerifyEmail(token, verifiedAt); !errors.Is(err, ErrInvalidVerificationToken) {
+		t.Errorf("Expected a token for the old email to be rejected, got %v", err)
+	}
+}
@@ -0,0 +1,49 @@
+package entities
+
+import (
+	"strings"
+
+	"golang.org/x/text/language"
+)
+
+// NormalizeLocale returns the canonical BCP 47 form of a language tag, such as
+// "vi" or "en-US". Anything that does not parse is returned trimmed, for
+// validation to reject.
+func NormalizeLocale(locale string) string {
+	locale = strings.TrimSpace(locale)
+	tag, err := language.Parse(locale)
+	if err != nil {
+		return locale
+	}
+	return tag.String()
+}
+
+// IsLocale reports whether locale is a well-formed language tag naming a
+// language.
+func IsLocale(locale string) bool {
+	tag, err := language.Parse(locale)
+	if err != nil || tag == language.Und {
+		return false
+	}
+	return tag.String() == locale
+}
+
+// LocaleFallbacks lists locale followed by its less specific parents, "vi-VN"
+// then "vi", for picking the closest of several localized variants.
+func LocaleFallbacks(locale string) []string {
+	var fallbacks []string
+	tag, err := language.Parse(locale)
+	for err == nil && tag != language.Und {
+		fallbacks = append(fallbacks, tag.String())
+		tag = tag.Parent()
+	}
+	return fallbacks
+}
+
+func normalizedLocale(locale *string) *string {
+	if locale == nil {
+		return nil
+	}
+	normalized := NormalizeLocale(*locale)
+	return &normalized
+}
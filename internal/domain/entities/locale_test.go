@@ -0,0 +1,49 @@
+package entities
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestLocaleFallbacks(t *testing.T) {
+	tests := []struct {
+		locale string
+		want   []string
+	}{
+		{"vi-VN", []string{"vi-VN", "vi"}},
+		{"en", []string{"en"}},
+		{"", nil},
+		{"not a tag", nil},
+	}
+	for _, test := range tests {
+		if got := LocaleFallbacks(test.locale); !reflect.DeepEqual(got, test.want) {
+			t.Errorf("LocaleFallbacks(%q): expected %v, got %v", test.locale, test.want, got)
+		}
+	}
+}
+
+func TestNewStudentFromProfile_NormalizesLocale(t *testing.T) {
+	locale := " vi-vn "
+	student := NewStudentFromProfile(StudentProfile{
+		FirstName:      "tran",
+		LastName:       "vu",
+		Email:          "tranvu@example.com",
+		Locale:         &locale,
+		EnrollmentDate: time.Now(),
+	})
+
+	if student.Locale == nil || *student.Locale != "vi-VN" {
+		t.Fatalf("Expected the locale in canonical form, got %v", student.Locale)
+	}
+	if _, err := NewValidatedStudent(student); err != nil {
+		t.Errorf("Expected the student to validate, got %v", err)
+	}
+
+	if err := student.ApplyPatch(StudentPatch{Locale: Replace("vietnamese")}); err == nil {
+		t.Errorf("Expected an invalid locale to be rejected")
+	}
+	if err := student.ApplyPatch(StudentPatch{Locale: Clear[string]()}); err != nil || student.Locale != nil {
+		t.Errorf("Expected the locale to be cleared, got %v, %v", student.Locale, err)
+	}
+}
@@ -0,0 +1,119 @@
+package entities
+
+import (
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ErrNotificationNotDead is returned when retrying a notification that is
+// still pending or was sent.
+var ErrNotificationNotDead = errors.New("only a dead notification can be retried")
+
+type NotificationStatus string
+
+const (
+	NotificationPending NotificationStatus = "pending"
+	NotificationSent    NotificationStatus = "sent"
+	NotificationDead    NotificationStatus = "dead"
+)
+
+func (s NotificationStatus) IsValid() bool {
+	return s == NotificationPending || s == NotificationSent || s == NotificationDead
+}
+
+var DefaultNotificationRetryPolicy = RetryPolicy{
+	MaxAttempts: 6,
+	BaseDelay:   time.Minute,
+	MaxDelay:    time.Hour,
+}
+
+// Notification is one rendered message to one student, and its send log: it
+// is kept after sending, or after giving up.
+type Notification struct {
+	ID            uuid.UUID
+	TemplateID    uuid.UUID
+	EventID       uuid.UUID
+	EventType     string
+	StudentID     uuid.UUID
+	Channel       NotificationChannel
+	Locale        string
+	Recipient     string
+	Subject       string
+	Text          string
+	HTML          string
+	Status        NotificationStatus
+	Attempts      int
+	NextAttemptAt time.Time
+	LastError     string
+	SentAt        *time.Time
+	CreatedAt     time.Time
+	UpdatedAt     time.Time
+}
+
+// NewNotification queues a rendered template for recipient. eventID is the
+// outbox message that triggered it.
+func NewNotification(template *NotificationTemplate, eventID uuid.UUID, studentID uuid.UUID, recipient string, rendered RenderedNotification) *Notification {
+	now := time.Now()
+	return &Notification{
+		ID:            uuid.New(),
+		TemplateID:    template.ID,
+		EventID:       eventID,
+		EventType:     template.EventType,
+		StudentID:     studentID,
+		Channel:       template.Channel,
+		Locale:        template.Locale,
+		Recipient:     recipient,
+		Subject:       rendered.Subject,
+		Text:          rendered.Text,
+		HTML:          rendered.HTML,
+		Status:        NotificationPending,
+		NextAttemptAt: now,
+		CreatedAt:     now,
+		UpdatedAt:     now,
+	}
+}
+
+func (n *Notification) RecordSuccess(now time.Time) {
+	n.Attempts++
+	n.Status = NotificationSent
+	n.LastError = ""
+	n.SentAt = &now
+	n.UpdatedAt = now
+}
+
+// RecordFailure schedules the next attempt, or dead-letters the notification
+// once the policy's attempts are used up.
+func (n *Notification) RecordFailure(reason string, now time.Time, policy RetryPolicy) {
+	n.Attempts++
+	n.LastError = reason
+	n.UpdatedAt = now
+
+	if n.Attempts >= policy.MaxAttempts {
+		n.Status = NotificationDead
+		return
+	}
+	n.NextAttemptAt = now.Add(policy.NextDelay(n.Attempts))
+}
+
+// DeadLetter stops retrying regardless of the policy, e.g. when no channel
+// is configured to send it.
+func (n *Notification) DeadLetter(reason string, now time.Time) {
+	n.Status = NotificationDead
+	n.LastError = reason
+	n.UpdatedAt = now
+}
+
+// Retry queues a dead notification again with a fresh set of attempts,
+// keeping the last error until the next attempt.
+func (n *Notification) Retry(now time.Time) error {
+	if n.Status != NotificationDead {
+		return ErrNotificationNotDead
+	}
+	n.Status = NotificationPending
+	n.Attempts = 0
+	n.NextAttemptAt = now
+	n.UpdatedAt = now
+	return nil
+}
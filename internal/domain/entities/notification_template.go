@@ -0,0 +1,208 @@
+package entities
+
+import (
+	"bytes"
+	"errors"
+	htmltemplate "html/template"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ErrInvalidNotificationTemplate wraps the validation error of a rejected
+// notification template.
+var ErrInvalidNotificationTemplate = errors.New("invalid notification template")
+
+type NotificationChannel string
+
+const (
+	NotificationChannelEmail NotificationChannel = "email"
+	NotificationChannelSMS   NotificationChannel = "sms"
+)
+
+func (c NotificationChannel) IsValid() bool {
+	return c == NotificationChannelEmail || c == NotificationChannelSMS
+}
+
+// NotificationTemplate is what a student is sent through one channel when an
+// event happens to them, in one locale. Subject and Body are text/template
+// templates and HTMLBody an html/template template, all executed with a
+// NotificationData. Only email has a subject and an HTML body.
+type NotificationTemplate struct {
+	ID        uuid.UUID
+	EventType string
+	Channel   NotificationChannel
+	Locale    string
+	Subject   string
+	Body      string
+	HTMLBody  string
+	Active    bool
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// NotificationData is what templates can refer to, e.g. {{.FirstName}} or
+// {{.StatusEffectiveDate.Format "02/01/2006"}}.
+type NotificationData struct {
+	EventType           string
+	OccurredAt          time.Time
+	StudentID           string
+	FirstName           string
+	LastName            string
+	Email               string
+	Phone               string
+	Major               string
+	EnrollmentDate      time.Time
+	Status              string
+	StatusReason        string
+	StatusEffectiveDate time.Time
+}
+
+func NewNotificationData(student *Student, event StudentEvent) NotificationData {
+	data := NotificationData{
+		EventType:           string(event.Type),
+		OccurredAt:          event.OccurredAt,
+		StudentID:           student.StudentID.String(),
+		FirstName:           student.FirstName,
+		LastName:            student.LastName,
+		Email:               student.Email,
+		EnrollmentDate:      student.EnrollmentDate,
+		Status:              string(student.Status),
+		StatusReason:        student.StatusReason,
+		StatusEffectiveDate: student.StatusEffectiveDate,
+	}
+	if student.Phone != nil {
+		data.Phone = *student.Phone
+	}
+	if student.Major != nil {
+		data.Major = *student.Major
+	}
+	return data
+}
+
+// RenderedNotification is a template executed for one student.
+type RenderedNotification struct {
+	Subject string
+	Text    string
+	HTML    string
+}
+
+func NewNotificationTemplate(eventType string, channel NotificationChannel, locale string, subject string, body string, htmlBody string) (*NotificationTemplate, error) {
+	notificationTemplate := &NotificationTemplate{
+		ID:        uuid.New(),
+		EventType: eventType,
+		Channel:   channel,
+		Locale:    NormalizeLocale(locale),
+		Subject:   subject,
+		Body:      body,
+		HTMLBody:  htmlBody,
+		Active:    true,
+		CreatedAt: time.Now(),
+		UpdatedAt: time.Now(),
+	}
+
+	if err := notificationTemplate.validate(); err != nil {
+		return nil, err
+	}
+	return notificationTemplate, nil
+}
+
+// Update replaces the content; the event, channel and locale a template is
+// for never change.
+func (t *NotificationTemplate) Update(subject string, body string, htmlBody string, active bool) error {
+	updated := *t
+	updated.Subject = subject
+	updated.Body = body
+	updated.HTMLBody = htmlBody
+	updated.Active = active
+	updated.UpdatedAt = time.Now()
+
+	if err := updated.validate(); err != nil {
+		return err
+	}
+	*t = updated
+	return nil
+}
+
+func (t *NotificationTemplate) validate() error {
+	switch StudentEventType(t.EventType) {
+	case StudentCreated, StudentUpdated, StudentStatusChanged:
+	default:
+		return newValidationError(ErrInvalidNotificationTemplate, "notification_template.event_type_invalid", `students are not notified of "{event_type}"`, "event_type", t.EventType)
+	}
+
+	if !t.Channel.IsValid() {
+		return newValidationError(ErrInvalidNotificationTemplate, "notification_template.channel_invalid", `unknown channel "{channel}"`, "channel", string(t.Channel))
+	}
+
+	if !IsLocale(t.Locale) {
+		return newValidationError(ErrInvalidNotificationTemplate, "notification_template.locale_invalid", "locale must be a language tag such as vi or en-US")
+	}
+
+	if strings.TrimSpace(t.Body) == "" {
+		return newValidationError(ErrInvalidNotificationTemplate, "notification_template.body_required", "body is required")
+	}
+
+	if t.Channel == NotificationChannelEmail && strings.TrimSpace(t.Subject) == "" {
+		return newValidationError(ErrInvalidNotificationTemplate, "notification_template.subject_required", "an email needs a subject")
+	}
+
+	if t.Channel == NotificationChannelSMS && (t.Subject != "" || t.HTMLBody != "") {
+		return newValidationError(ErrInvalidNotificationTemplate, "notification_template.sms_body_only", "an SMS has neither subject nor HTML body")
+	}
+
+	// Rendering a sample catches both syntax errors and references to fields
+	// NotificationData does not have, which would otherwise only fail when a
+	// student is notified.
+	if _, err := t.Render(NotificationData{}); err != nil {
+		return newValidationError(ErrInvalidNotificationTemplate, "notification_template.render_failed", "{reason}", "reason", err.Error())
+	}
+
+	return nil
+}
+
+func (t *NotificationTemplate) Render(data NotificationData) (RenderedNotification, error) {
+	var rendered RenderedNotification
+	var err error
+
+	if rendered.Subject, err = renderText("Subject", t.Subject, data); err != nil {
+		return RenderedNotification{}, err
+	}
+	// A subject is a single line, whatever the template produced.
+	rendered.Subject = strings.Join(strings.Fields(rendered.Subject), " ")
+
+	if rendered.Text, err = renderText("Body", t.Body, data); err != nil {
+		return RenderedNotification{}, err
+	}
+
+	if t.HTMLBody != "" {
+		parsed, err := htmltemplate.New("HTMLBody").Option("missingkey=error").Parse(t.HTMLBody)
+		if err != nil {
+			return RenderedNotification{}, err
+		}
+		var html bytes.Buffer
+		if err := parsed.Execute(&html, data); err != nil {
+			return RenderedNotification{}, err
+		}
+		rendered.HTML = html.String()
+	}
+
+	return rendered, nil
+}
+
+func renderText(name string, text string, data NotificationData) (string, error) {
+	if text == "" {
+		return "", nil
+	}
+	parsed, err := template.New(name).Option("missingkey=error").Parse(text)
+	if err != nil {
+		return "", err
+	}
+	var rendered bytes.Buffer
+	if err := parsed.Execute(&rendered, data); err != nil {
+		return "", err
+	}
+	return rendered.String(), nil
+}
@@ -0,0 +1,108 @@
+package entities
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+func TestNewNotificationTemplate_Validates(t *testing.T) {
+	tests := []struct {
+		name     string
+		event    string
+		channel  NotificationChannel
+		locale   string
+		subject  string
+		body     string
+		htmlBody string
+	}{
+		{"deleted event", string(StudentDeleted), NotificationChannelEmail, "en", "Bye", "Bye", ""},
+		{"unknown channel", string(StudentCreated), "fax", "en", "", "Hi", ""},
+		{"bad locale", string(StudentCreated), NotificationChannelEmail, "english", "Hi", "Hi", ""},
+		{"email without subject", string(StudentCreated), NotificationChannelEmail, "en", "", "Hi", ""},
+		{"sms with subject", string(StudentCreated), NotificationChannelSMS, "en", "Hi", "Hi", ""},
+		{"syntax error", string(StudentCreated), NotificationChannelEmail, "en", "Hi", "Hi {{.FirstName", ""},
+		{"unknown field", string(StudentCreated), NotificationChannelEmail, "en", "Hi", "Hi {{.Nickname}}", ""},
+		{"unknown HTML field", string(StudentCreated), NotificationChannelEmail, "en", "Hi", "Hi", "<p>{{.Nickname}}</p>"},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := NewNotificationTemplate(test.event, test.channel, test.locale, test.subject, test.body, test.htmlBody)
+			if !errors.Is(err, ErrInvalidNotificationTemplate) {
+				t.Errorf("Expected ErrInvalidNotificationTemplate, got %v", err)
+			}
+		})
+	}
+}
+
+func TestNotificationTemplateRender(t *testing.T) {
+	template, err := NewNotificationTemplate(string(StudentStatusChanged), NotificationChannelEmail, "vi-vn",
+		"Trạng thái của {{.FirstName}}\n đã thay đổi",
+		"Chào {{.FirstName}} {{.LastName}}, trạng thái mới: {{.Status}} từ {{.StatusEffectiveDate.Format \"02/01/2006\"}}.",
+		"<p>Chào {{.FirstName}}</p>")
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if template.Locale != "vi-VN" {
+		t.Errorf("Expected the locale in canonical form, got %s", template.Locale)
+	}
+
+	student := newEnrolledStudent(t)
+	student.FirstName = "<Vu>"
+	if err := student.TransitionTo(StudentStatusOnLeave, "medical", time.Date(2024, time.March, 4, 0, 0, 0, 0, time.UTC)); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	events := student.Events()
+	rendered, err := template.Render(NewNotificationData(student, events[len(events)-1]))
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if rendered.Subject != "Trạng thái của <Vu> đã thay đổi" {
+		t.Errorf("Expected a one line subject, got %q", rendered.Subject)
+	}
+	if !strings.Contains(rendered.Text, "<Vu> "+student.LastName) || !strings.Contains(rendered.Text, "on_leave từ 04/03/2024") {
+		t.Errorf("Expected the text body to render the student, got %q", rendered.Text)
+	}
+	if rendered.HTML != "<p>Chào &lt;Vu&gt;</p>" {
+		t.Errorf("Expected the HTML body to be escaped, got %q", rendered.HTML)
+	}
+}
+
+func TestNotificationRetry(t *testing.T) {
+	template, err := NewNotificationTemplate(string(StudentCreated), NotificationChannelSMS, "en", "", "Welcome {{.FirstName}}", "")
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	notification := NewNotification(template, uuid.New(), uuid.New(), "+84901234567", RenderedNotification{Text: "Welcome Vu"})
+	policy := RetryPolicy{MaxAttempts: 2, BaseDelay: time.Minute, MaxDelay: time.Hour}
+	now := time.Now()
+
+	if err := notification.Retry(now); !errors.Is(err, ErrNotificationNotDead) {
+		t.Errorf("Expected a pending notification not to be retried, got %v", err)
+	}
+
+	notification.RecordFailure("gateway down", now, policy)
+	if notification.Status != NotificationPending || !notification.NextAttemptAt.Equal(now.Add(time.Minute)) {
+		t.Errorf("Expected another attempt in a minute, got %+v", notification)
+	}
+	notification.RecordFailure("gateway down", now, policy)
+	if notification.Status != NotificationDead {
+		t.Fatalf("Expected the notification to be dead after 2 attempts, got %s", notification.Status)
+	}
+
+	if err := notification.Retry(now); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if notification.Status != NotificationPending || notification.Attempts != 0 {
+		t.Errorf("Expected a fresh pending notification, got %+v", notification)
+	}
+
+	notification.RecordSuccess(now)
+	if notification.Status != NotificationSent || notification.SentAt == nil || notification.LastError != "" {
+		t.Errorf("Expected a sent notification, got %+v", notification)
+	}
+}
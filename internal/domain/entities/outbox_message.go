@@ -0,0 +1,61 @@
+package entities
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+var DefaultOutboxRetryPolicy = RetryPolicy{
+	MaxAttempts: 10,
+	BaseDelay:   5 * time.Second,
+	MaxDelay:    30 * time.Minute,
+}
+
+// OutboxMessage is a domain event waiting to be published to other systems.
+// It is stored in the same transaction as the change that raised it.
+//
+// A message that keeps failing is retried with backoff until NextAttemptAt,
+// and given up on at DeadAt, so that it does not hold up the messages after it.
+type OutboxMessage struct {
+	ID            uuid.UUID
+	EventType     string
+	AggregateID   uuid.UUID
+	Payload       string
+	OccurredAt    time.Time
+	PublishedAt   *time.Time
+	Attempts      int
+	LastError     string
+	NextAttemptAt time.Time
+	DeadAt        *time.Time
+}
+
+func NewOutboxMessage(event StudentEvent) (*OutboxMessage, error) {
+	payload, err := json.Marshal(event)
+	if err != nil {
+		return nil, err
+	}
+
+	return &OutboxMessage{
+		ID:            event.ID,
+		EventType:     string(event.Type),
+		AggregateID:   event.StudentID,
+		Payload:       string(payload),
+		OccurredAt:    event.OccurredAt,
+		NextAttemptAt: event.OccurredAt,
+	}, nil
+}
+
+// RecordFailure schedules the next attempt, or gives up on the message once
+// the policy's attempts are used up.
+func (m *OutboxMessage) RecordFailure(reason string, now time.Time, policy RetryPolicy) {
+	m.Attempts++
+	m.LastError = reason
+
+	if m.Attempts >= policy.MaxAttempts {
+		m.DeadAt = &now
+		return
+	}
+	m.NextAttemptAt = now.Add(policy.NextDelay(m.Attempts))
+}
@@ -0,0 +1,43 @@
+package entities
+
+import (
+	"regexp"
+	"strings"
+)
+
+// e164Regex matches an E.164 number: a plus, a country code that does not
+// start with 0 and at most 15 digits in all. Shorter than 7 digits is no
+// reachable number.
+var e164Regex = regexp.MustCompile(`^\+[1-9][0-9]{6,14}$`)
+
+// phoneSeparators are the characters people group digits with.
+var phoneSeparators = strings.NewReplacer(" ", "", "-", "", ".", "", "(", "", ")", "")
+
+// NormalizePhoneNumber drops the separators of a written number and reads a
+// leading 00 as the international prefix, so that "+84 90-123 4567" and
+// "0084901234567" both become "+84901234567". It does not guess the country
+// of a national number.
+func NormalizePhoneNumber(number string) string {
+	normalized := phoneSeparators.Replace(strings.TrimSpace(number))
+	if strings.HasPrefix(normalized, "00") {
+		normalized = "+" + normalized[2:]
+	}
+	return normalized
+}
+
+// IsE164 reports whether number is already in E.164 form.
+func IsE164(number string) bool {
+	return e164Regex.MatchString(number)
+}
+
+// normalizedPhoneNumber returns number itself when it is already normalized.
+func normalizedPhoneNumber(number *string) *string {
+	if number == nil {
+		return nil
+	}
+	normalized := NormalizePhoneNumber(*number)
+	if normalized == *number {
+		return number
+	}
+	return &normalized
+}
@@ -0,0 +1,73 @@
+package entities
+
+import (
+	"testing"
+	"time"
+)
+
+func TestNormalizePhoneNumber(t *testing.T) {
+	tests := []struct {
+		number string
+		want   string
+		e164   bool
+	}{
+		{"+84901234567", "+84901234567", true},
+		{" +84 90-123 4567 ", "+84901234567", true},
+		{"0084 (90) 123.4567", "+84901234567", true},
+		{"0901234567", "0901234567", false},
+		{"+0901234567", "+0901234567", false},
+		{"+84123", "+84123", false},
+		{"+8490123456789012", "+8490123456789012", false},
+	}
+	for _, test := range tests {
+		t.Run(test.number, func(t *testing.T) {
+			got := NormalizePhoneNumber(test.number)
+			if got != test.want {
+				t.Errorf("Expected %q, got %q", test.want, got)
+			}
+			if IsE164(got) != test.e164 {
+				t.Errorf("Expected IsE164(%q) to be %v", got, test.e164)
+			}
+		})
+	}
+}
+
+func TestNewStudent_NormalizesPhone(t *testing.T) {
+	enrolledAt := time.Date(2023, time.September, 1, 0, 0, 0, 0, time.UTC)
+	phone := "+84 90 123 4567"
+	student := NewStudent("tran", "vu", nil, "tranvu@example.com", &phone, nil, enrolledAt)
+
+	if student.Phone == nil || *student.Phone != "+84901234567" {
+		t.Fatalf("Expected the phone in E.164 form, got %v", student.Phone)
+	}
+	if _, err := NewValidatedStudent(student); err != nil {
+		t.Errorf("Expected the student to validate, got %v", err)
+	}
+
+	national := "0901234567"
+	student = NewStudent("tran", "vu", nil, "tranvu@example.com", &national, nil, enrolledAt)
+	if _, err := NewValidatedStudent(student); err == nil {
+		t.Errorf("Expected a national number to be rejected")
+	}
+}
+
+func TestStudent_AcceptStoredPhone(t *testing.T) {
+	student := newEnrolledStudent(t)
+	legacy := "090 123 4567"
+	student.Phone = &legacy
+	student.AcceptStoredPhone()
+
+	if err := student.ApplyPatch(StudentPatch{Major: Replace("Physics")}); err != nil {
+		t.Fatalf("Expected a stored phone to be kept while other fields change, got %v", err)
+	}
+	if _, err := NewValidatedStudent(student); err != nil {
+		t.Errorf("Expected the student to validate, got %v", err)
+	}
+
+	if err := student.ApplyPatch(StudentPatch{Phone: Replace("0907654321")}); err == nil {
+		t.Errorf("Expected a changed phone to be E.164")
+	}
+	if err := student.ApplyPatch(StudentPatch{Phone: Replace("0907 654 321")}); err == nil {
+		t.Errorf("Expected a changed phone to be E.164 even if it normalizes like the stored one")
+	}
+}
@@ -0,0 +1,112 @@
+package entities
+
+import (
+	"errors"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// DegreeLevel is the award a program leads to.
+type DegreeLevel string
+
+const (
+	DegreeLevelCertificate DegreeLevel = "certificate"
+	DegreeLevelAssociate   DegreeLevel = "associate"
+	DegreeLevelBachelor    DegreeLevel = "bachelor"
+	DegreeLevelMaster      DegreeLevel = "master"
+	DegreeLevelDoctorate   DegreeLevel = "doctorate"
+)
+
+var DegreeLevels = []DegreeLevel{
+	DegreeLevelCertificate,
+	DegreeLevelAssociate,
+	DegreeLevelBachelor,
+	DegreeLevelMaster,
+	DegreeLevelDoctorate,
+}
+
+func (l DegreeLevel) IsValid() bool {
+	for _, level := range DegreeLevels {
+		if l == level {
+			return true
+		}
+	}
+	return false
+}
+
+// ErrInvalidProgram wraps the validation error of a rejected program.
+var ErrInvalidProgram = errors.New("invalid program")
+
+var programCodeRegex = regexp.MustCompile(`^[A-Z0-9][A-Z0-9-]{1,15}$`)
+
+// Program is an entry of the catalog of academic programs that students
+// declare as majors or minors. Its code never changes once created; a program
+// that is no longer offered is deactivated, not deleted, so that the students
+// who declared it keep a valid reference.
+type Program struct {
+	ID          uuid.UUID
+	Code        string
+	Name        string
+	Department  string
+	DegreeLevel DegreeLevel
+	Active      bool
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+}
+
+func NewProgram(code string, name string, department string, degreeLevel DegreeLevel) (*Program, error) {
+	program := &Program{
+		ID:          uuid.New(),
+		Code:        NormalizeProgramCode(code),
+		Name:        strings.TrimSpace(name),
+		Department:  strings.TrimSpace(department),
+		DegreeLevel: degreeLevel,
+		Active:      true,
+		CreatedAt:   time.Now(),
+		UpdatedAt:   time.Now(),
+	}
+
+	if err := program.validate(); err != nil {
+		return nil, err
+	}
+	return program, nil
+}
+
+// NormalizeProgramCode is the form codes are stored and looked up in.
+func NormalizeProgramCode(code string) string {
+	return strings.ToUpper(strings.TrimSpace(code))
+}
+
+func (p *Program) validate() error {
+	switch {
+	case !programCodeRegex.MatchString(p.Code):
+		return newValidationError(ErrInvalidProgram, "program.code_invalid", "the code must be 2 to 16 letters, digits or dashes")
+	case p.Name == "":
+		return newValidationError(ErrInvalidProgram, "program.name_required", "the name is required")
+	case p.Department == "":
+		return newValidationError(ErrInvalidProgram, "program.department_required", "the department is required")
+	case !p.DegreeLevel.IsValid():
+		return newValidationError(ErrInvalidProgram, "program.degree_level_invalid", `unknown degree level "{degree_level}"`, "degree_level", string(p.DegreeLevel))
+	}
+	return nil
+}
+
+// Update replaces everything but the code. On error the program is left
+// untouched.
+func (p *Program) Update(name string, department string, degreeLevel DegreeLevel, active bool) error {
+	updated := *p
+	updated.Name = strings.TrimSpace(name)
+	updated.Department = strings.TrimSpace(department)
+	updated.DegreeLevel = degreeLevel
+	updated.Active = active
+	updated.UpdatedAt = time.Now()
+
+	if err := updated.validate(); err != nil {
+		return err
+	}
+	*p = updated
+	return nil
+}
@@ -0,0 +1,102 @@
+package entities
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+func TestNewProgram(t *testing.T) {
+	program, err := NewProgram(" cs-bs ", " Computer Science ", "Computing", DegreeLevelBachelor)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if program.Code != "CS-BS" || program.Name != "Computer Science" || !program.Active {
+		t.Errorf("Expected an active program CS-BS named Computer Science, got %+v", program)
+	}
+
+	for _, tc := range []struct {
+		name                    string
+		code, title, department string
+		level                   DegreeLevel
+	}{
+		{"code too short", "C", "Computer Science", "Computing", DegreeLevelBachelor},
+		{"code with spaces", "CS BS", "Computer Science", "Computing", DegreeLevelBachelor},
+		{"no name", "CS", " ", "Computing", DegreeLevelBachelor},
+		{"no department", "CS", "Computer Science", "", DegreeLevelBachelor},
+		{"unknown level", "CS", "Computer Science", "Computing", DegreeLevel("phd")},
+	} {
+		if _, err := NewProgram(tc.code, tc.title, tc.department, tc.level); !errors.Is(err, ErrInvalidProgram) {
+			t.Errorf("%s: expected ErrInvalidProgram, got %v", tc.name, err)
+		}
+	}
+}
+
+func TestProgramUpdateLeavesProgramOnError(t *testing.T) {
+	program, err := NewProgram("CS", "Computer Science", "Computing", DegreeLevelBachelor)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if err := program.Update("Computer Science", "Computing", DegreeLevel("phd"), false); !errors.Is(err, ErrInvalidProgram) {
+		t.Fatalf("Expected ErrInvalidProgram, got %v", err)
+	}
+	if !program.Active || program.DegreeLevel != DegreeLevelBachelor {
+		t.Errorf("Expected the program unchanged, got %+v", program)
+	}
+
+	if err := program.Update("Computer Science", "Engineering", DegreeLevelMaster, false); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if program.Active || program.Department != "Engineering" || program.Code != "CS" {
+		t.Errorf("Expected an inactive CS program of Engineering, got %+v", program)
+	}
+}
+
+func TestStudentProgramsAreValidated(t *testing.T) {
+	id := uuid.New()
+	enrolled := time.Date(2023, time.September, 1, 0, 0, 0, 0, time.UTC)
+	for _, tc := range []struct {
+		name     string
+		programs []StudentProgram
+	}{
+		{"no program ID", []StudentProgram{{Kind: ProgramKindMajor, DeclaredAt: enrolled}}},
+		{"declared twice", []StudentProgram{{id, ProgramKindMajor, enrolled}, {id, ProgramKindMinor, enrolled}}},
+		{"unknown kind", []StudentProgram{{id, ProgramKind("double"), enrolled}}},
+		{"no declaration date", []StudentProgram{{ProgramID: id, Kind: ProgramKindMajor}}},
+		{"declared before enrollment", []StudentProgram{{id, ProgramKindMajor, enrolled.AddDate(0, 0, -1)}}},
+		{"declared in the future", []StudentProgram{{id, ProgramKindMajor, time.Now().Add(time.Hour)}}},
+	} {
+		student := NewStudentWithPrograms("tran", "vu", nil, "tranvu@example.com", nil, nil, enrolled, tc.programs)
+		if _, err := NewValidatedStudent(student); !errors.Is(err, ErrInvalidProgramDeclaration) {
+			t.Errorf("%s: expected ErrInvalidProgramDeclaration, got %v", tc.name, err)
+		}
+	}
+}
+
+func TestCheckProgramCatalog(t *testing.T) {
+	enrolled := time.Date(2023, time.September, 1, 0, 0, 0, 0, time.UTC)
+	active, _ := NewProgram("CS", "Computer Science", "Computing", DegreeLevelBachelor)
+	retired, _ := NewProgram("MATH", "Mathematics", "Mathematics", DegreeLevelBachelor)
+	retired.Active = false
+	catalog := map[uuid.UUID]*Program{active.ID: active, retired.ID: retired}
+
+	major := StudentProgram{ProgramID: active.ID, Kind: ProgramKindMajor, DeclaredAt: enrolled}
+	minor := StudentProgram{ProgramID: retired.ID, Kind: ProgramKindMinor, DeclaredAt: enrolled}
+	unknown := StudentProgram{ProgramID: uuid.New(), Kind: ProgramKindMinor, DeclaredAt: enrolled}
+
+	if err := CheckProgramCatalog([]StudentProgram{major}, nil, catalog); err != nil {
+		t.Errorf("Expected an active program to be accepted, got %v", err)
+	}
+	if err := CheckProgramCatalog([]StudentProgram{unknown}, nil, catalog); !errors.Is(err, ErrInvalidProgramDeclaration) {
+		t.Errorf("Expected a program missing from the catalog to be rejected, got %v", err)
+	}
+	if err := CheckProgramCatalog([]StudentProgram{major, minor}, nil, catalog); !errors.Is(err, ErrInvalidProgramDeclaration) {
+		t.Errorf("Expected an inactive program to be rejected, got %v", err)
+	}
+	if err := CheckProgramCatalog([]StudentProgram{major, minor}, []StudentProgram{minor}, catalog); err != nil {
+		t.Errorf("Expected an inactive program declared before to be kept, got %v", err)
+	}
+}
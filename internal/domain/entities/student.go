@@ -1,87 +1,169 @@
 package entities
 
 import (
-	"errors"
 	"regexp"
 	"time"
 
 	"github.com/google/uuid"
 )
 
+var emailRegex = regexp.MustCompile(`^[a-z0-9._%+\-]+@[a-z0-9.\-]+\.[a-z]{2,4}$`)
+
 type Student struct {
 	StudentID 		uuid.UUID 
 	FirstName 		string 
 	LastName 		string 
 	DateOfBirth 	*time.Time 
 	Email 			string 	
+	// EmailVerifiedAt is when the student proved they own Email; a new Email
+	// is unverified again.
+	EmailVerifiedAt *time.Time
 	Phone 			*string 
+	// Major is free text kept for existing clients; Programs references the
+	// catalog.
 	Major 			*string 
+	// Locale is the BCP 47 language tag the student is written to in, such as
+	// "vi"; nil leaves the choice to the sender.
+	Locale 			*string
+	Programs 		[]StudentProgram
+	// Contacts changes only through SaveAddress, SaveEmail, SavePhone,
+	// SaveEmergencyContact and RemoveContact.
+	Contacts 		StudentContacts
 	EnrollmentDate 	time.Time 
+	// Status changes only through TransitionTo, which records why and since
+	// when in StatusReason and StatusEffectiveDate.
+	Status 				StudentStatus
+	StatusReason 		string
+	StatusEffectiveDate time.Time
 	CreatedAt 		time.Time
 	UpdatedAt 		time.Time
+	// Version grows by one with every stored change; writers send back the
+	// version they read so that concurrent edits are detected.
+	Version 		int
+
+	events 			[]StudentEvent
+	statusChanges 	[]StudentStatusChange
+	// storedPhone is the phone the student was read with; see AcceptStoredPhone.
+	storedPhone 	*string
 }
 
 func NewStudent(first_name string, last_name string, date_of_birth *time.Time, email string,
 	phone *string, major *string, enrollment_date time.Time ) *Student {
-	return &Student	{	
+	return NewStudentWithPrograms(first_name, last_name, date_of_birth, email, phone, major, enrollment_date, nil)
+}
+
+// NewStudentWithPrograms is NewStudent for a student who declares programs on
+// enrollment.
+func NewStudentWithPrograms(first_name string, last_name string, date_of_birth *time.Time, email string,
+	phone *string, major *string, enrollment_date time.Time, programs []StudentProgram) *Student {
+	return NewStudentFromProfile(StudentProfile{
+		FirstName: 		first_name,
+		LastName: 		last_name,
+		DateOfBirth: 	date_of_birth,
+		Email: 			email,
+		Phone: 			phone,
+		Major: 			major,
+		EnrollmentDate: enrollment_date,
+		Programs: 		programs,
+	})
+}
+
+// StudentProfile holds what is known about a student on enrollment.
+type StudentProfile struct {
+	FirstName 		string
+	LastName 		string
+	DateOfBirth 	*time.Time
+	Email 			string
+	Phone 			*string
+	Major 			*string
+	Locale 			*string
+	EnrollmentDate 	time.Time
+	Programs 		[]StudentProgram
+}
+
+// NewStudentFromProfile enrolls a new active student.
+func NewStudentFromProfile(profile StudentProfile) *Student {
+	student := &Student	{	
 		StudentID:			uuid.New() ,
-		FirstName:			first_name ,
-		LastName:			last_name ,
-		DateOfBirth:		date_of_birth ,
-		Email:				email 	,
-		Phone:				phone ,
-		Major:				major ,
-		EnrollmentDate:		enrollment_date,
+		FirstName:			profile.FirstName ,
+		LastName:			profile.LastName ,
+		DateOfBirth:		profile.DateOfBirth ,
+		Email:				profile.Email 	,
+		Phone:				normalizedPhoneNumber(profile.Phone) ,
+		Major:				profile.Major ,
+		Locale: 			normalizedLocale(profile.Locale),
+		Programs: 			profile.Programs,
+		EnrollmentDate:		profile.EnrollmentDate,
+		Status: 			StudentStatusActive,
+		StatusEffectiveDate: profile.EnrollmentDate,
 		CreatedAt: 			time.Now(),
 		UpdatedAt: 			time.Now(),
+		Version: 			1,
 	}	
+	student.raise(StudentCreated, DiffStudents(nil, student))
+	return student
 }
 
 func (s *Student) validate() error {	
 	if s.FirstName == "" {
-		return errors.New("Must have first name.")
+		return newValidationError(nil, "student.first_name_required", "Must have first name.")
 	}
 
 	if s.LastName == "" {
-		return errors.New("Must have last name.")
+		return newValidationError(nil, "student.last_name_required", "Must have last name.")
 
 	}
 
 	if s.StudentID == uuid.Nil {
-		return errors.New("Student ID can't be nil")
+		return newValidationError(nil, "student.id_required", "Student ID can't be nil")
 	}
 
 	if s.Email == ""{
-		return errors.New("Email can't be empty")
+		return newValidationError(nil, "student.email_required", "Email can't be empty")
 
 	}
 
-	emailRegex := regexp.MustCompile(`^[a-z0-9._%+\-]+@[a-z0-9.\-]+\.[a-z]{2,4}$`)
 	if !emailRegex.MatchString(s.Email) {
-		return errors.New("Invalid email")
+		return newValidationError(nil, "student.email_invalid", "Invalid email")
 	}
 
 	if s.EnrollmentDate.IsZero() {
-		return errors.New("The enrollment date can't be zero")
+		return newValidationError(nil, "student.enrollment_date_required", "The enrollment date can't be zero")
+	}
+
+	if s.Status != "" && !s.Status.IsValid() {
+		return newValidationError(nil, "student.status_invalid", "Invalid status")
 	}
 
 	if s.DateOfBirth != nil && s.DateOfBirth.After(time.Now()) {
-		return errors.New("Invalid date of birth")
+		return newValidationError(nil, "student.date_of_birth_invalid", "Invalid date of birth")
 	}
 
-	if s.Phone != nil && *s.Phone == "" {
-		return errors.New("Phone cannot be an empty string if provided")
+	if s.Phone != nil && !IsE164(*s.Phone) && !s.phoneIsStored() {
+		return newValidationError(nil, "student.phone_invalid", "Phone must be an E.164 number such as +84901234567")
 	}
 
 	if s.Major != nil && *s.Major == "" {
-		return errors.New("The major cannot be an empty string if provided")
+		return newValidationError(nil, "student.major_empty", "The major cannot be an empty string if provided")
+	}
+
+	if s.Locale != nil && !IsLocale(*s.Locale) {
+		return newValidationError(nil, "student.locale_invalid", "Locale must be a language tag such as vi or en-US")
+	}
+
+	if err := validatePrograms(s.Programs, s.EnrollmentDate); err != nil {
+		return err
+	}
+
+	if err := s.Contacts.validate(); err != nil {
+		return err
 	}
 
 	if s.CreatedAt.IsZero() {
-		return errors.New("CreatedAt is required and cannot be zero")
+		return newValidationError(nil, "student.created_at_required", "CreatedAt is required and cannot be zero")
 	}
 	if s.UpdatedAt.IsZero() {
-		return errors.New("UpdatedAt is required and cannot be zero")
+		return newValidationError(nil, "student.updated_at_required", "UpdatedAt is required and cannot be zero")
 	}
 
 	return nil
@@ -89,13 +171,43 @@ func (s *Student) validate() error {
 
 } 
 
+// UpdateNewFields replaces the given fields; a nil argument keeps the stored
+// value. Use ApplyPatch to clear a field.
 func (s *Student) UpdateNewFields(dob *time.Time, phone *string, major *string) error {
-	s.DateOfBirth = dob
-	s.Phone = phone
-	s.Major = major
-	s.UpdatedAt = time.Now()
+	return s.UpdateNewFieldsAndPrograms(dob, phone, major, nil)
+}
+
+// UpdateNewFieldsAndPrograms is UpdateNewFields that also replaces the
+// declared programs, unless programs is nil.
+func (s *Student) UpdateNewFieldsAndPrograms(dob *time.Time, phone *string, major *string, programs *[]StudentProgram) error {
+	return s.ApplyPatch(StudentPatch{
+		DateOfBirth: 	patchValueOf(dob),
+		Phone: 			patchValueOf(phone),
+		Major: 			patchValueOf(major),
+		Programs: 		patchValueOf(programs),
+	})
+}
 
-	return s.validate()
+// AcceptStoredPhone lets the phone a student was stored with pass validation
+// until it changes, so that students stored before phones had to be E.164
+// can still be updated. Repositories call it on the students they read;
+// studentctl student normalize-phones converts such phones.
+func (s *Student) AcceptStoredPhone() {
+	s.storedPhone = s.Phone
+}
+
+func (s *Student) phoneIsStored() bool {
+	return s.storedPhone != nil && s.Phone != nil && *s.storedPhone == *s.Phone
+}
+
+// MarkDeleted raises StudentDeleted; the repository removes the record.
+func (s *Student) MarkDeleted() {
+	s.raise(StudentDeleted, DiffStudents(s, nil))
+}
 
+// MarkRestored raises StudentRestored with every set field, like
+// StudentCreated, for consumers that dropped the student when it was deleted.
+func (s *Student) MarkRestored() {
+	s.raise(StudentRestored, DiffStudents(nil, s))
 }
 
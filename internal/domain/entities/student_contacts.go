@@ -0,0 +1,463 @@
+package entities
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// AddressType says what an address is used for.
+type AddressType string
+
+const (
+	AddressTypeHome    AddressType = "home"
+	AddressTypeMailing AddressType = "mailing"
+	AddressTypeCampus  AddressType = "campus"
+)
+
+var AddressTypes = []AddressType{AddressTypeHome, AddressTypeMailing, AddressTypeCampus}
+
+func (t AddressType) IsValid() bool {
+	for _, known := range AddressTypes {
+		if t == known {
+			return true
+		}
+	}
+	return false
+}
+
+// VerificationState tells how far the student got in proving they own an
+// email or a phone.
+type VerificationState string
+
+const (
+	VerificationUnverified VerificationState = "unverified"
+	VerificationPending    VerificationState = "pending"
+	VerificationVerified   VerificationState = "verified"
+)
+
+// ErrInvalidContact wraps the validation error of a rejected contact change.
+var ErrInvalidContact = errors.New("invalid contact")
+
+// ErrContactNotFound is returned when the student has no contact with the ID.
+var ErrContactNotFound = errors.New("contact not found")
+
+var countryCodeRegex = regexp.MustCompile(`^[A-Z]{2}$`)
+
+// ContactKind names one of the collections in StudentContacts.
+type ContactKind string
+
+const (
+	ContactKindAddress          ContactKind = "address"
+	ContactKindEmail            ContactKind = "email"
+	ContactKindPhone            ContactKind = "phone"
+	ContactKindEmergencyContact ContactKind = "emergency_contact"
+)
+
+// Address is a postal address the student uses between ValidFrom and ValidTo;
+// a nil ValidTo has no end.
+type Address struct {
+	ID         uuid.UUID
+	Type       AddressType
+	Line1      string
+	Line2      string
+	City       string
+	Region     string
+	PostalCode string
+	// Country is an ISO 3166-1 alpha-2 code, e.g. VN.
+	Country   string
+	ValidFrom time.Time
+	ValidTo   *time.Time
+}
+
+// CurrentAt reports whether the address is in use at t.
+func (a Address) CurrentAt(t time.Time) bool {
+	return !t.Before(a.ValidFrom) && (a.ValidTo == nil || t.Before(*a.ValidTo))
+}
+
+func (a Address) overlaps(other Address) bool {
+	endsAfter := func(address Address, t time.Time) bool {
+		return address.ValidTo == nil || address.ValidTo.After(t)
+	}
+	return endsAfter(a, other.ValidFrom) && endsAfter(other, a.ValidFrom)
+}
+
+type ContactEmail struct {
+	ID           uuid.UUID
+	Address      string
+	Primary      bool
+	Verification VerificationState
+	VerifiedAt   *time.Time
+}
+
+type ContactPhone struct {
+	ID uuid.UUID
+	// Number is in E.164 form, e.g. +84901234567.
+	Number       string
+	Primary      bool
+	Verification VerificationState
+	VerifiedAt   *time.Time
+}
+
+type EmergencyContact struct {
+	ID           uuid.UUID
+	Name         string
+	Relationship string
+	Phone        string
+	Email        *string
+}
+
+// StudentContacts are the ways to reach a student besides the account Email
+// and Phone. Among emails and among phones exactly one is primary, unless
+// there are none.
+type StudentContacts struct {
+	Addresses         []Address
+	Emails            []ContactEmail
+	Phones            []ContactPhone
+	EmergencyContacts []EmergencyContact
+}
+
+// IsEmpty reports whether no contact is recorded.
+func (c StudentContacts) IsEmpty() bool {
+	return len(c.Addresses) == 0 && len(c.Emails) == 0 && len(c.Phones) == 0 && len(c.EmergencyContacts) == 0
+}
+
+// Clone returns a copy that shares nothing with c.
+func (c StudentContacts) Clone() StudentContacts {
+	cloned := StudentContacts{
+		Addresses:         append([]Address(nil), c.Addresses...),
+		Emails:            append([]ContactEmail(nil), c.Emails...),
+		Phones:            append([]ContactPhone(nil), c.Phones...),
+		EmergencyContacts: append([]EmergencyContact(nil), c.EmergencyContacts...),
+	}
+	for i, address := range cloned.Addresses {
+		cloned.Addresses[i].ValidTo = copyTime(address.ValidTo)
+	}
+	for i, email := range cloned.Emails {
+		cloned.Emails[i].VerifiedAt = copyTime(email.VerifiedAt)
+	}
+	for i, phone := range cloned.Phones {
+		cloned.Phones[i].VerifiedAt = copyTime(phone.VerifiedAt)
+	}
+	for i, contact := range cloned.EmergencyContacts {
+		if contact.Email != nil {
+			email := *contact.Email
+			cloned.EmergencyContacts[i].Email = &email
+		}
+	}
+	return cloned
+}
+
+func copyTime(t *time.Time) *time.Time {
+	if t == nil {
+		return nil
+	}
+	copied := *t
+	return &copied
+}
+
+func (c StudentContacts) validate() error {
+	if err := validateAddresses(c.Addresses); err != nil {
+		return err
+	}
+
+	seen := map[string]bool{}
+	primaries := 0
+	for _, email := range c.Emails {
+		switch {
+		case !emailRegex.MatchString(email.Address):
+			return newValidationError(ErrInvalidContact, "contact.email_invalid", `invalid email "{email}"`, "email", email.Address)
+		case seen[email.Address]:
+			return newValidationError(ErrInvalidContact, "contact.email_duplicate", "email {email} is listed twice", "email", email.Address)
+		}
+		seen[email.Address] = true
+		if email.Primary {
+			primaries++
+		}
+	}
+	if len(c.Emails) > 0 && primaries != 1 {
+		return newValidationError(ErrInvalidContact, "contact.email_primary", "exactly one email must be primary")
+	}
+
+	primaries = 0
+	for _, phone := range c.Phones {
+		switch {
+		case !IsE164(phone.Number):
+			return newValidationError(ErrInvalidContact, "contact.phone_invalid", `phone "{phone}" is not an E.164 number such as +84901234567`, "phone", phone.Number)
+		case seen[phone.Number]:
+			return newValidationError(ErrInvalidContact, "contact.phone_duplicate", "phone {phone} is listed twice", "phone", phone.Number)
+		}
+		seen[phone.Number] = true
+		if phone.Primary {
+			primaries++
+		}
+	}
+	if len(c.Phones) > 0 && primaries != 1 {
+		return newValidationError(ErrInvalidContact, "contact.phone_primary", "exactly one phone must be primary")
+	}
+
+	for _, contact := range c.EmergencyContacts {
+		switch {
+		case contact.Name == "":
+			return newValidationError(ErrInvalidContact, "contact.emergency_name_required", "the name of an emergency contact is required")
+		case contact.Relationship == "":
+			return newValidationError(ErrInvalidContact, "contact.emergency_relationship_required", "the relationship of emergency contact {name} is required", "name", contact.Name)
+		case !IsE164(contact.Phone):
+			return newValidationError(ErrInvalidContact, "contact.emergency_phone_invalid", "the phone of emergency contact {name} is not an E.164 number such as +84901234567", "name", contact.Name)
+		case contact.Email != nil && !emailRegex.MatchString(*contact.Email):
+			return newValidationError(ErrInvalidContact, "contact.emergency_email_invalid", "invalid email of emergency contact {name}", "name", contact.Name)
+		}
+	}
+	return nil
+}
+
+// validateAddresses also keeps two addresses of one type from being valid at
+// the same time, so that there is one current home address at most.
+func validateAddresses(addresses []Address) error {
+	for i, address := range addresses {
+		switch {
+		case !address.Type.IsValid():
+			return newValidationError(ErrInvalidContact, "contact.address_type_invalid", `unknown address type "{type}"`, "type", string(address.Type))
+		case address.Line1 == "":
+			return newValidationError(ErrInvalidContact, "contact.address_line1_required", "the first line of the address is required")
+		case address.City == "":
+			return newValidationError(ErrInvalidContact, "contact.address_city_required", "the city of the address is required")
+		case !countryCodeRegex.MatchString(address.Country):
+			return newValidationError(ErrInvalidContact, "contact.address_country_invalid", `the country must be a two-letter ISO code, got "{country}"`, "country", address.Country)
+		case address.ValidFrom.IsZero():
+			return newValidationError(ErrInvalidContact, "contact.address_valid_from_required", "the address needs a date it is valid from")
+		case address.ValidTo != nil && !address.ValidTo.After(address.ValidFrom):
+			return newValidationError(ErrInvalidContact, "contact.address_valid_to_invalid", "the address must be valid until after it is valid from")
+		}
+		for _, other := range addresses[:i] {
+			if other.Type == address.Type && other.overlaps(address) {
+				return newValidationError(ErrInvalidContact, "contact.address_overlap", "two {type} addresses are valid at the same time", "type", string(address.Type))
+			}
+		}
+	}
+	return nil
+}
+
+// SaveAddress adds address when its ID is nil and otherwise replaces the
+// address with that ID.
+func (s *Student) SaveAddress(address Address) error {
+	address.Line1 = strings.TrimSpace(address.Line1)
+	address.Line2 = strings.TrimSpace(address.Line2)
+	address.City = strings.TrimSpace(address.City)
+	address.Region = strings.TrimSpace(address.Region)
+	address.PostalCode = strings.TrimSpace(address.PostalCode)
+	address.Country = strings.ToUpper(strings.TrimSpace(address.Country))
+
+	return s.changeContacts(func(contacts *StudentContacts) error {
+		return saveContact(&contacts.Addresses, address, func(a Address) uuid.UUID { return a.ID }, func(a *Address, id uuid.UUID) { a.ID = id })
+	})
+}
+
+// SaveEmail adds or replaces an email like SaveAddress. The verification
+// state cannot be set this way: it is kept while the address stays the same
+// and starts over otherwise. A primary email demotes the others.
+func (s *Student) SaveEmail(email ContactEmail) error {
+	email.Address = strings.ToLower(strings.TrimSpace(email.Address))
+
+	return s.changeContacts(func(contacts *StudentContacts) error {
+		email.Verification, email.VerifiedAt = VerificationUnverified, nil
+		for _, stored := range contacts.Emails {
+			if stored.ID == email.ID && stored.Address == email.Address {
+				email.Verification, email.VerifiedAt = stored.Verification, copyTime(stored.VerifiedAt)
+			}
+		}
+		if email.Primary {
+			for i := range contacts.Emails {
+				contacts.Emails[i].Primary = false
+			}
+		}
+		return saveContact(&contacts.Emails, email, func(e ContactEmail) uuid.UUID { return e.ID }, func(e *ContactEmail, id uuid.UUID) { e.ID = id })
+	})
+}
+
+// SavePhone adds or replaces a phone like SaveEmail.
+func (s *Student) SavePhone(phone ContactPhone) error {
+	phone.Number = NormalizePhoneNumber(phone.Number)
+
+	return s.changeContacts(func(contacts *StudentContacts) error {
+		phone.Verification, phone.VerifiedAt = VerificationUnverified, nil
+		for _, stored := range contacts.Phones {
+			if stored.ID == phone.ID && stored.Number == phone.Number {
+				phone.Verification, phone.VerifiedAt = stored.Verification, copyTime(stored.VerifiedAt)
+			}
+		}
+		if phone.Primary {
+			for i := range contacts.Phones {
+				contacts.Phones[i].Primary = false
+			}
+		}
+		return saveContact(&contacts.Phones, phone, func(p ContactPhone) uuid.UUID { return p.ID }, func(p *ContactPhone, id uuid.UUID) { p.ID = id })
+	})
+}
+
+// SaveEmergencyContact adds or replaces an emergency contact like SaveAddress.
+func (s *Student) SaveEmergencyContact(contact EmergencyContact) error {
+	contact.Name = strings.TrimSpace(contact.Name)
+	contact.Relationship = strings.TrimSpace(contact.Relationship)
+	contact.Phone = NormalizePhoneNumber(contact.Phone)
+	if contact.Email != nil {
+		email := strings.ToLower(strings.TrimSpace(*contact.Email))
+		contact.Email = &email
+	}
+
+	return s.changeContacts(func(contacts *StudentContacts) error {
+		return saveContact(&contacts.EmergencyContacts, contact, func(c EmergencyContact) uuid.UUID { return c.ID }, func(c *EmergencyContact, id uuid.UUID) { c.ID = id })
+	})
+}
+
+// RemoveContact removes the contact of the kind with the ID. Removing the
+// primary email or phone promotes the first one left.
+func (s *Student) RemoveContact(kind ContactKind, id uuid.UUID) error {
+	return s.changeContacts(func(contacts *StudentContacts) error {
+		var removed bool
+		switch kind {
+		case ContactKindAddress:
+			removed = removeContact(&contacts.Addresses, id, func(a Address) uuid.UUID { return a.ID })
+		case ContactKindEmail:
+			removed = removeContact(&contacts.Emails, id, func(e ContactEmail) uuid.UUID { return e.ID })
+		case ContactKindPhone:
+			removed = removeContact(&contacts.Phones, id, func(p ContactPhone) uuid.UUID { return p.ID })
+		case ContactKindEmergencyContact:
+			removed = removeContact(&contacts.EmergencyContacts, id, func(c EmergencyContact) uuid.UUID { return c.ID })
+		default:
+			return newValidationError(ErrInvalidContact, "contact.kind_invalid", `unknown contact kind "{kind}"`, "kind", string(kind))
+		}
+		if !removed {
+			return ErrContactNotFound
+		}
+		return nil
+	})
+}
+
+// changeContacts applies change to a copy of the contacts and keeps it when
+// it validates, raising StudentUpdated like ApplyPatch.
+func (s *Student) changeContacts(change func(contacts *StudentContacts) error) error {
+	before := *s
+	changed := *s
+	changed.Contacts = s.Contacts.Clone()
+	if err := change(&changed.Contacts); err != nil {
+		return err
+	}
+	changed.Contacts.promotePrimaries()
+
+	if err := changed.Contacts.validate(); err != nil {
+		return err
+	}
+
+	changes := DiffStudents(&before, &changed)
+	if len(changes) == 0 {
+		return nil
+	}
+
+	changed.UpdatedAt = time.Now()
+	*s = changed
+	s.raise(StudentUpdated, changes)
+	return nil
+}
+
+// promotePrimaries makes the first email and phone primary when none is.
+func (c *StudentContacts) promotePrimaries() {
+	hasPrimary := false
+	for _, email := range c.Emails {
+		hasPrimary = hasPrimary || email.Primary
+	}
+	if !hasPrimary && len(c.Emails) > 0 {
+		c.Emails[0].Primary = true
+	}
+
+	hasPrimary = false
+	for _, phone := range c.Phones {
+		hasPrimary = hasPrimary || phone.Primary
+	}
+	if !hasPrimary && len(c.Phones) > 0 {
+		c.Phones[0].Primary = true
+	}
+}
+
+func saveContact[T any](contacts *[]T, contact T, idOf func(T) uuid.UUID, setID func(*T, uuid.UUID)) error {
+	if idOf(contact) == uuid.Nil {
+		setID(&contact, uuid.New())
+		*contacts = append(*contacts, contact)
+		return nil
+	}
+	for i, stored := range *contacts {
+		if idOf(stored) == idOf(contact) {
+			(*contacts)[i] = contact
+			return nil
+		}
+	}
+	return ErrContactNotFound
+}
+
+func removeContact[T any](contacts *[]T, id uuid.UUID, idOf func(T) uuid.UUID) bool {
+	for i, stored := range *contacts {
+		if idOf(stored) == id {
+			*contacts = append((*contacts)[:i:i], (*contacts)[i+1:]...)
+			return true
+		}
+	}
+	return false
+}
+
+// Audit values render each kind of contact as a sorted list, one entry per
+// contact, so that a change shows up as the entries that differ.
+
+func addressesValue(addresses []Address) *string {
+	return contactsValue(len(addresses), func(i int) string {
+		a := addresses[i]
+		validTo := "open"
+		if a.ValidTo != nil {
+			validTo = a.ValidTo.UTC().Format("2006-01-02")
+		}
+		return fmt.Sprintf("%s %s, %s, %s %s %s (%s to %s)", a.Type, a.Line1, a.City, a.Region, a.PostalCode, a.Country, a.ValidFrom.UTC().Format("2006-01-02"), validTo)
+	})
+}
+
+func contactEmailsValue(emails []ContactEmail) *string {
+	return contactsValue(len(emails), func(i int) string {
+		return contactValue(emails[i].Address, emails[i].Primary, emails[i].Verification)
+	})
+}
+
+func contactPhonesValue(phones []ContactPhone) *string {
+	return contactsValue(len(phones), func(i int) string {
+		return contactValue(phones[i].Number, phones[i].Primary, phones[i].Verification)
+	})
+}
+
+func emergencyContactsValue(contacts []EmergencyContact) *string {
+	return contactsValue(len(contacts), func(i int) string {
+		return fmt.Sprintf("%s (%s) %s", contacts[i].Name, contacts[i].Relationship, contacts[i].Phone)
+	})
+}
+
+func contactValue(value string, primary bool, verification VerificationState) string {
+	if primary {
+		return fmt.Sprintf("%s (primary, %s)", value, verification)
+	}
+	return fmt.Sprintf("%s (%s)", value, verification)
+}
+
+func contactsValue(n int, render func(i int) string) *string {
+	if n == 0 {
+		return nil
+	}
+
+	rendered := make([]string, n)
+	for i := range rendered {
+		rendered[i] = render(i)
+	}
+	sort.Strings(rendered)
+	value := strings.Join(rendered, "; ")
+	return &value
+}
@@ -0,0 +1,143 @@
+package entities
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+func TestStudentSaveAddress(t *testing.T) {
+	student := newEnrolledStudent(t)
+	from := time.Date(2023, time.September, 1, 0, 0, 0, 0, time.UTC)
+	moved := time.Date(2024, time.June, 1, 0, 0, 0, 0, time.UTC)
+
+	err := student.SaveAddress(Address{Type: AddressTypeHome, Line1: " 1 Le Loi ", City: "Hue", Country: "vn", ValidFrom: from, ValidTo: &moved})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(student.Contacts.Addresses) != 1 {
+		t.Fatalf("Expected one address, got %+v", student.Contacts.Addresses)
+	}
+	saved := student.Contacts.Addresses[0]
+	if saved.ID == uuid.Nil || saved.Line1 != "1 Le Loi" || saved.Country != "VN" {
+		t.Errorf("Expected a trimmed address with a new ID, got %+v", saved)
+	}
+	if saved.CurrentAt(moved) || !saved.CurrentAt(from) {
+		t.Errorf("Expected the address to be current from %s until %s", from, moved)
+	}
+	events := student.Events()
+	if len(events) != 1 || events[0].Type != StudentUpdated || len(events[0].Changes) != 1 || events[0].Changes[0].Field != "Addresses" {
+		t.Errorf("Expected one StudentUpdated event for Addresses, got %+v", events)
+	}
+
+	err = student.SaveAddress(Address{Type: AddressTypeHome, Line1: "2 Tran Hung Dao", City: "Hanoi", Country: "VN", ValidFrom: moved})
+	if err != nil {
+		t.Fatalf("Expected a home address after the move, got %v", err)
+	}
+
+	err = student.SaveAddress(Address{Type: AddressTypeHome, Line1: "3 Hai Ba Trung", City: "Hanoi", Country: "VN", ValidFrom: moved.AddDate(0, 1, 0)})
+	if !errors.Is(err, ErrInvalidContact) {
+		t.Fatalf("Expected two current home addresses to be rejected, got %v", err)
+	}
+
+	saved.City = "Da Nang"
+	if err := student.SaveAddress(saved); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(student.Contacts.Addresses) != 2 || student.Contacts.Addresses[0].City != "Da Nang" {
+		t.Errorf("Expected the first address to be replaced, got %+v", student.Contacts.Addresses)
+	}
+
+	if err := student.SaveAddress(Address{ID: uuid.New(), Type: AddressTypeCampus, Line1: "Dorm A", City: "Hue", Country: "VN", ValidFrom: from}); !errors.Is(err, ErrContactNotFound) {
+		t.Errorf("Expected ErrContactNotFound for an unknown ID, got %v", err)
+	}
+}
+
+func TestStudentSaveEmail_Primary(t *testing.T) {
+	student := newEnrolledStudent(t)
+
+	if err := student.SaveEmail(ContactEmail{Address: "Vu@Example.com"}); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if err := student.SaveEmail(ContactEmail{Address: "vu@work.example.com", Primary: true}); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	emails := student.Contacts.Emails
+	if len(emails) != 2 || emails[0].Address != "vu@example.com" || emails[0].Primary || !emails[1].Primary {
+		t.Fatalf("Expected the second email to take over as primary, got %+v", emails)
+	}
+	if emails[0].Verification != VerificationUnverified {
+		t.Errorf("Expected a new email to be unverified, got %s", emails[0].Verification)
+	}
+
+	if err := student.SaveEmail(ContactEmail{Address: "VU@example.com "}); !errors.Is(err, ErrInvalidContact) {
+		t.Errorf("Expected a duplicate email to be rejected, got %v", err)
+	}
+
+	if err := student.RemoveContact(ContactKindEmail, emails[1].ID); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(student.Contacts.Emails) != 1 || !student.Contacts.Emails[0].Primary {
+		t.Errorf("Expected the email left to become primary, got %+v", student.Contacts.Emails)
+	}
+}
+
+func TestStudentSavePhone_KeepsVerification(t *testing.T) {
+	student := newEnrolledStudent(t)
+	if err := student.SavePhone(ContactPhone{Number: "+84 90 123 4567"}); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	verifiedAt := time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC)
+	student.Contacts.Phones[0].Verification = VerificationVerified
+	student.Contacts.Phones[0].VerifiedAt = &verifiedAt
+	phone := student.Contacts.Phones[0]
+
+	phone.Verification = VerificationUnverified
+	if err := student.SavePhone(phone); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if got := student.Contacts.Phones[0]; got.Number != "+84901234567" || got.Verification != VerificationVerified || !got.Primary {
+		t.Errorf("Expected the unchanged number to stay verified and primary, got %+v", got)
+	}
+
+	phone.Number = "+84907654321"
+	if err := student.SavePhone(phone); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if got := student.Contacts.Phones[0]; got.Verification != VerificationUnverified || got.VerifiedAt != nil {
+		t.Errorf("Expected a new number to need verification again, got %+v", got)
+	}
+
+	if err := student.SavePhone(ContactPhone{Number: "0901234567"}); !errors.Is(err, ErrInvalidContact) {
+		t.Errorf("Expected a national number to be rejected, got %v", err)
+	}
+}
+
+func TestStudentRemoveContact(t *testing.T) {
+	student := newEnrolledStudent(t)
+	email := "mai@example.com"
+	if err := student.SaveEmergencyContact(EmergencyContact{Name: "Tran Mai", Relationship: "mother", Phone: "0084901234567", Email: &email}); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	contact := student.Contacts.EmergencyContacts[0]
+	if contact.Phone != "+84901234567" {
+		t.Errorf("Expected the phone in E.164 form, got %s", contact.Phone)
+	}
+
+	if err := student.RemoveContact(ContactKindAddress, contact.ID); !errors.Is(err, ErrContactNotFound) {
+		t.Errorf("Expected an emergency contact not to be removed as an address, got %v", err)
+	}
+	if err := student.RemoveContact(ContactKindEmergencyContact, contact.ID); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if !student.Contacts.IsEmpty() {
+		t.Errorf("Expected no contacts left, got %+v", student.Contacts)
+	}
+
+	if err := student.SaveEmergencyContact(EmergencyContact{Name: "Tran Mai", Phone: "+84901234567"}); !errors.Is(err, ErrInvalidContact) {
+		t.Errorf("Expected a missing relationship to be rejected, got %v", err)
+	}
+}
@@ -0,0 +1,53 @@
+package entities
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type StudentEventType string
+
+const (
+	StudentCreated StudentEventType = "StudentCreated"
+	StudentUpdated StudentEventType = "StudentUpdated"
+	StudentDeleted StudentEventType = "StudentDeleted"
+	StudentRestored StudentEventType = "StudentRestored"
+	StudentStatusChanged StudentEventType = "StudentStatusChanged"
+)
+
+// StudentEvent is a domain event raised when a student's lifecycle changes.
+// Changes holds the affected fields; for StudentCreated these are all set fields.
+type StudentEvent struct {
+	ID         uuid.UUID
+	Type       StudentEventType
+	StudentID  uuid.UUID
+	Changes    []FieldChange
+	OccurredAt time.Time
+}
+
+func NewStudentEvent(eventType StudentEventType, studentID uuid.UUID, changes []FieldChange) StudentEvent {
+	return StudentEvent{
+		ID:         uuid.New(),
+		Type:       eventType,
+		StudentID:  studentID,
+		Changes:    changes,
+		OccurredAt: time.Now(),
+	}
+}
+
+// Events returns the events raised since the student was loaded or last cleared.
+func (s *Student) Events() []StudentEvent {
+	return s.events
+}
+
+// ClearEvents also clears the pending StatusChanges; the repository calls it
+// once both are stored.
+func (s *Student) ClearEvents() {
+	s.events = nil
+	s.statusChanges = nil
+}
+
+func (s *Student) raise(eventType StudentEventType, changes []FieldChange) {
+	s.events = append(s.events, NewStudentEvent(eventType, s.StudentID, changes))
+}
@@ -0,0 +1,46 @@
+package entities
+
+import (
+	"testing"
+	"time"
+)
+
+func TestStudentEvents(t *testing.T) {
+	phone := "+84947531799"
+	student := NewStudent("tran", "vu", nil, "tranvu123@gmail.com", &phone, nil, time.Now())
+
+	events := student.Events()
+	if len(events) != 1 || events[0].Type != StudentCreated {
+		t.Fatalf("Expected a StudentCreated event, got %+v", events)
+	}
+	if events[0].StudentID != student.StudentID {
+		t.Errorf("Expected event for student %s, got %s", student.StudentID, events[0].StudentID)
+	}
+
+	student.ClearEvents()
+	major := "Computer Science"
+	if err := student.UpdateNewFields(nil, &phone, &major); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	events = student.Events()
+	if len(events) != 1 || events[0].Type != StudentUpdated {
+		t.Fatalf("Expected a StudentUpdated event, got %+v", events)
+	}
+	if len(events[0].Changes) != 1 || events[0].Changes[0].Field != "Major" {
+		t.Errorf("Expected only Major to change, got %+v", events[0].Changes)
+	}
+
+	student.ClearEvents()
+	if err := student.UpdateNewFields(nil, &phone, &major); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(student.Events()) != 0 {
+		t.Errorf("Expected no event when nothing changed, got %+v", student.Events())
+	}
+
+	student.MarkDeleted()
+	if events := student.Events(); len(events) != 1 || events[0].Type != StudentDeleted {
+		t.Errorf("Expected a StudentDeleted event, got %+v", events)
+	}
+}
@@ -0,0 +1,105 @@
+package entities
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// ErrInvalidPatch wraps the validation error of a rejected patch.
+var ErrInvalidPatch = errors.New("invalid student patch")
+
+// PatchValue is one field of a partial update. An unset value leaves the field
+// alone; a set value with a nil Value clears it; otherwise Value replaces it.
+type PatchValue[T any] struct {
+	Set 	bool
+	Value 	*T
+}
+
+// Replace returns a set PatchValue holding v.
+func Replace[T any](v T) PatchValue[T] {
+	return PatchValue[T]{Set: true, Value: &v}
+}
+
+// Clear returns a set PatchValue that clears the field.
+func Clear[T any]() PatchValue[T] {
+	return PatchValue[T]{Set: true}
+}
+
+// StudentPatch lists the fields a client may change after enrollment.
+type StudentPatch struct {
+	FirstName 		PatchValue[string]
+	LastName 		PatchValue[string]
+	DateOfBirth 	PatchValue[time.Time]
+	Email 			PatchValue[string]
+	Phone 			PatchValue[string]
+	Major 			PatchValue[string]
+	Locale 			PatchValue[string]
+	Programs 		PatchValue[[]StudentProgram]
+}
+
+// ApplyPatch changes the set fields and validates the result. On error the
+// student is left untouched. Names and email are required, so clearing them
+// fails validation like an empty value would.
+func (s *Student) ApplyPatch(patch StudentPatch) error {
+	before := *s
+	patched := *s
+
+	if patch.FirstName.Set {
+		patched.FirstName = strings.TrimSpace(requiredValue(patch.FirstName))
+	}
+	if patch.LastName.Set {
+		patched.LastName = strings.TrimSpace(requiredValue(patch.LastName))
+	}
+	if patch.Email.Set {
+		patched.Email = strings.TrimSpace(requiredValue(patch.Email))
+		if patched.Email != s.Email {
+			patched.EmailVerifiedAt = nil
+		}
+	}
+	if patch.DateOfBirth.Set {
+		patched.DateOfBirth = patch.DateOfBirth.Value
+	}
+	if patch.Phone.Set {
+		patched.Phone = normalizedPhoneNumber(patch.Phone.Value)
+	}
+	if patch.Major.Set {
+		patched.Major = patch.Major.Value
+	}
+	if patch.Locale.Set {
+		patched.Locale = normalizedLocale(patch.Locale.Value)
+	}
+	if patch.Programs.Set {
+		patched.Programs = nil
+		if patch.Programs.Value != nil {
+			patched.Programs = append([]StudentProgram(nil), *patch.Programs.Value...)
+		}
+	}
+
+	if err := patched.validate(); err != nil {
+		return fmt.Errorf("%w: %w", ErrInvalidPatch, err)
+	}
+
+	changes := DiffStudents(&before, &patched)
+	if len(changes) == 0 {
+		return nil
+	}
+
+	patched.UpdatedAt = time.Now()
+	*s = patched
+	s.raise(StudentUpdated, changes)
+	return nil
+}
+
+func requiredValue(v PatchValue[string]) string {
+	if v.Value == nil {
+		return ""
+	}
+	return *v.Value
+}
+
+// patchValueOf treats nil as "leave unchanged".
+func patchValueOf[T any](v *T) PatchValue[T] {
+	return PatchValue[T]{Set: v != nil, Value: v}
+}
@@ -0,0 +1,76 @@
+package entities
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestStudentApplyPatch(t *testing.T) {
+	phone, major := "+84947531799", "CNTT"
+	student := NewStudent("tran", "vu", nil, "tranvu@example.com", &phone, &major, time.Now())
+	student.ClearEvents()
+
+	dob := time.Date(2001, time.February, 3, 0, 0, 0, 0, time.UTC)
+	err := student.ApplyPatch(StudentPatch{
+		FirstName: 		Replace(" Linh "),
+		DateOfBirth: 	Replace(dob),
+		Phone: 			Clear[string](),
+	})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if student.FirstName != "Linh" || student.Phone != nil || !student.DateOfBirth.Equal(dob) {
+		t.Errorf("Expected patched fields, got %+v", student)
+	}
+	if student.Major == nil || *student.Major != "CNTT" || student.Email != "tranvu@example.com" {
+		t.Errorf("Expected unset fields to be kept, got %+v", student)
+	}
+	if events := student.Events(); len(events) != 1 || len(events[0].Changes) != 3 {
+		t.Errorf("Expected one StudentUpdated event with 3 changes, got %+v", events)
+	}
+}
+
+func TestStudentApplyPatch_RejectsInvalidResultAtomically(t *testing.T) {
+	student := NewStudent("tran", "vu", nil, "tranvu@example.com", nil, nil, time.Now())
+	student.ClearEvents()
+
+	tests := []struct {
+		name  string
+		patch StudentPatch
+	}{
+		{"clear email", StudentPatch{Major: Replace("Physics"), Email: Clear[string]()}},
+		{"blank last name", StudentPatch{LastName: Replace("   ")}},
+		{"invalid email", StudentPatch{Email: Replace("not-an-email")}},
+		{"empty phone", StudentPatch{Phone: Replace("")}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := student.ApplyPatch(tt.patch)
+			if !errors.Is(err, ErrInvalidPatch) {
+				t.Fatalf("Expected ErrInvalidPatch, got %v", err)
+			}
+			if student.Major != nil || student.Email != "tranvu@example.com" || student.LastName != "vu" {
+				t.Errorf("Expected the student to be unchanged, got %+v", student)
+			}
+			if len(student.Events()) != 0 {
+				t.Errorf("Expected no event, got %+v", student.Events())
+			}
+		})
+	}
+}
+
+func TestStudentUpdateNewFields_KeepsOmittedFields(t *testing.T) {
+	phone := "+84947531799"
+	student := NewStudent("tran", "vu", nil, "tranvu@example.com", &phone, nil, time.Now())
+
+	major := "Physics"
+	if err := student.UpdateNewFields(nil, nil, &major); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if student.Phone == nil || *student.Phone != phone {
+		t.Errorf("Expected the phone to be kept, got %v", student.Phone)
+	}
+}
@@ -0,0 +1,96 @@
+package entities
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ProgramKind says how a student follows a program.
+type ProgramKind string
+
+const (
+	ProgramKindMajor ProgramKind = "major"
+	ProgramKindMinor ProgramKind = "minor"
+)
+
+func (k ProgramKind) IsValid() bool {
+	return k == ProgramKindMajor || k == ProgramKindMinor
+}
+
+// ErrInvalidProgramDeclaration wraps the error of programs a student cannot
+// declare: malformed declarations, or programs missing from the catalog.
+var ErrInvalidProgramDeclaration = errors.New("invalid program declaration")
+
+// StudentProgram is a program of the catalog that a student declared, as a
+// major or a minor, on DeclaredAt.
+type StudentProgram struct {
+	ProgramID  uuid.UUID
+	Kind       ProgramKind
+	DeclaredAt time.Time
+}
+
+// validatePrograms checks the declarations on their own; CheckProgramCatalog
+// checks them against the catalog.
+func validatePrograms(programs []StudentProgram, enrollmentDate time.Time) error {
+	seen := make(map[uuid.UUID]bool, len(programs))
+	for _, program := range programs {
+		switch {
+		case program.ProgramID == uuid.Nil:
+			return newValidationError(ErrInvalidProgramDeclaration, "program_declaration.id_required", "a program ID is required")
+		case seen[program.ProgramID]:
+			return newValidationError(ErrInvalidProgramDeclaration, "program_declaration.duplicate", "program {program} is declared twice", "program", program.ProgramID.String())
+		case !program.Kind.IsValid():
+			return newValidationError(ErrInvalidProgramDeclaration, "program_declaration.kind_invalid", `unknown kind "{kind}", want major or minor`, "kind", string(program.Kind))
+		case program.DeclaredAt.IsZero():
+			return newValidationError(ErrInvalidProgramDeclaration, "program_declaration.date_required", "the declaration date of program {program} is required", "program", program.ProgramID.String())
+		case program.DeclaredAt.After(time.Now()):
+			return newValidationError(ErrInvalidProgramDeclaration, "program_declaration.date_in_future", "program {program} is declared in the future", "program", program.ProgramID.String())
+		case program.DeclaredAt.Before(enrollmentDate):
+			return newValidationError(ErrInvalidProgramDeclaration, "program_declaration.date_before_enrollment", "program {program} is declared before the enrollment date", "program", program.ProgramID.String())
+		}
+		seen[program.ProgramID] = true
+	}
+	return nil
+}
+
+// CheckProgramCatalog checks declared against the catalog entries of its
+// programs. A program declared anew must be in the catalog and active; one
+// already in previous stays valid when it is deactivated later.
+func CheckProgramCatalog(declared []StudentProgram, previous []StudentProgram, catalog map[uuid.UUID]*Program) error {
+	kept := make(map[uuid.UUID]bool, len(previous))
+	for _, program := range previous {
+		kept[program.ProgramID] = true
+	}
+
+	for _, program := range declared {
+		entry, ok := catalog[program.ProgramID]
+		switch {
+		case !ok:
+			return newValidationError(ErrInvalidProgramDeclaration, "program_declaration.not_in_catalog", "program {program} is not in the catalog", "program", program.ProgramID.String())
+		case !entry.Active && !kept[program.ProgramID]:
+			return newValidationError(ErrInvalidProgramDeclaration, "program_declaration.not_offered", "program {program} is no longer offered", "program", entry.Code)
+		}
+	}
+	return nil
+}
+
+// programsValue renders programs for the audit trail, in a stable order, e.g.
+// "major 6f69...@2023-09-01, minor 0a1b...@2024-02-01".
+func programsValue(programs []StudentProgram) *string {
+	if len(programs) == 0 {
+		return nil
+	}
+
+	rendered := make([]string, len(programs))
+	for i, program := range programs {
+		rendered[i] = fmt.Sprintf("%s %s@%s", program.Kind, program.ProgramID, program.DeclaredAt.UTC().Format("2006-01-02"))
+	}
+	sort.Strings(rendered)
+	value := strings.Join(rendered, ", ")
+	return &value
+}
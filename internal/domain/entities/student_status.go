@@ -0,0 +1,144 @@
+package entities
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// StudentStatus is where a student stands in their studies.
+type StudentStatus string
+
+const (
+	StudentStatusActive    StudentStatus = "active"
+	StudentStatusOnLeave   StudentStatus = "on_leave"
+	StudentStatusSuspended StudentStatus = "suspended"
+	StudentStatusWithdrawn StudentStatus = "withdrawn"
+	StudentStatusGraduated StudentStatus = "graduated"
+)
+
+// StudentStatuses lists every status, in lifecycle order.
+var StudentStatuses = []StudentStatus{
+	StudentStatusActive,
+	StudentStatusOnLeave,
+	StudentStatusSuspended,
+	StudentStatusWithdrawn,
+	StudentStatusGraduated,
+}
+
+// studentStatusTransitions lists where each status may go. Withdrawn students
+// may be readmitted; graduation is final.
+var studentStatusTransitions = map[StudentStatus][]StudentStatus{
+	StudentStatusActive:    {StudentStatusOnLeave, StudentStatusSuspended, StudentStatusWithdrawn, StudentStatusGraduated},
+	StudentStatusOnLeave:   {StudentStatusActive, StudentStatusWithdrawn},
+	StudentStatusSuspended: {StudentStatusActive, StudentStatusWithdrawn},
+	StudentStatusWithdrawn: {StudentStatusActive},
+}
+
+const maxStatusReasonLength = 500
+
+// ErrStatusTransitionNotAllowed is returned, wrapped, when the student's
+// current status cannot change to the requested one.
+var ErrStatusTransitionNotAllowed = errors.New("status transition not allowed")
+
+// ErrInvalidStatusChange wraps the error of a transition request that is
+// malformed whatever the student's status: an unknown status, a missing
+// reason or an impossible effective date.
+var ErrInvalidStatusChange = errors.New("invalid status change")
+
+func ParseStudentStatus(value string) (StudentStatus, error) {
+	status := StudentStatus(value)
+	if !status.IsValid() {
+		return "", newValidationError(ErrInvalidStatusChange, "status.unknown", `unknown status "{status}"`, "status", value)
+	}
+	return status, nil
+}
+
+func (s StudentStatus) IsValid() bool {
+	for _, status := range StudentStatuses {
+		if s == status {
+			return true
+		}
+	}
+	return false
+}
+
+// CanTransitionTo reports whether a student may go from s to next.
+func (s StudentStatus) CanTransitionTo(next StudentStatus) bool {
+	for _, allowed := range studentStatusTransitions[s] {
+		if allowed == next {
+			return true
+		}
+	}
+	return false
+}
+
+// StudentStatusChange is one entry of a student's status history. Actor and
+// RequestID are stamped by the repository that stores it.
+type StudentStatusChange struct {
+	ID            uuid.UUID
+	StudentID     uuid.UUID
+	From          StudentStatus
+	To            StudentStatus
+	Reason        string
+	EffectiveDate time.Time
+	Actor         string
+	RequestID     string
+	RecordedAt    time.Time
+}
+
+// TransitionTo moves the student to status next as of effectiveDate. The
+// reason is required. The effective date may not lie in the future, nor
+// before the enrollment or the current status took effect. On error the
+// student is left untouched.
+func (s *Student) TransitionTo(next StudentStatus, reason string, effectiveDate time.Time) error {
+	if !next.IsValid() {
+		return newValidationError(ErrInvalidStatusChange, "status.unknown", `unknown status "{status}"`, "status", string(next))
+	}
+	if !s.Status.CanTransitionTo(next) {
+		return newValidationError(ErrStatusTransitionNotAllowed, "status.transition_not_allowed", "a student who is {from} cannot become {to}", "from", string(s.Status), "to", string(next))
+	}
+
+	reason = strings.TrimSpace(reason)
+	switch {
+	case reason == "":
+		return newValidationError(ErrInvalidStatusChange, "status.reason_required", "a reason is required")
+	case len(reason) > maxStatusReasonLength:
+		return newValidationError(ErrInvalidStatusChange, "status.reason_too_long", "the reason is longer than {max} characters", "max", strconv.Itoa(maxStatusReasonLength))
+	case effectiveDate.IsZero():
+		return newValidationError(ErrInvalidStatusChange, "status.effective_date_required", "an effective date is required")
+	case effectiveDate.After(time.Now()):
+		return newValidationError(ErrInvalidStatusChange, "status.effective_date_in_future", "the effective date is in the future")
+	case effectiveDate.Before(s.EnrollmentDate):
+		return newValidationError(ErrInvalidStatusChange, "status.effective_date_before_enrollment", "the effective date is before the enrollment date")
+	case effectiveDate.Before(s.StatusEffectiveDate):
+		return newValidationError(ErrInvalidStatusChange, "status.effective_date_before_current", "the effective date is before the current status took effect")
+	}
+
+	before := *s
+	s.Status = next
+	s.StatusReason = reason
+	s.StatusEffectiveDate = effectiveDate
+	s.UpdatedAt = time.Now()
+
+	s.statusChanges = append(s.statusChanges, StudentStatusChange{
+		ID:            uuid.New(),
+		StudentID:     s.StudentID,
+		From:          before.Status,
+		To:            next,
+		Reason:        reason,
+		EffectiveDate: effectiveDate,
+		RecordedAt:    s.UpdatedAt,
+	})
+	s.raise(StudentStatusChanged, DiffStudents(&before, s))
+	return nil
+}
+
+// StatusChanges returns the transitions made since the student was loaded or
+// its events were last cleared, for the repository to add to the history.
+func (s *Student) StatusChanges() []StudentStatusChange {
+	return s.statusChanges
+}
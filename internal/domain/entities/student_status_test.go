@@ -0,0 +1,95 @@
+package entities
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func newEnrolledStudent(t *testing.T) *Student {
+	t.Helper()
+	enrolled := time.Date(2023, time.September, 1, 0, 0, 0, 0, time.UTC)
+	student := NewStudent("tran", "vu", nil, "tranvu@example.com", nil, nil, enrolled)
+	student.ClearEvents()
+	return student
+}
+
+func TestStudentTransitionTo(t *testing.T) {
+	student := newEnrolledStudent(t)
+	if student.Status != StudentStatusActive || !student.StatusEffectiveDate.Equal(student.EnrollmentDate) {
+		t.Fatalf("Expected a new student to be active since enrollment, got %s since %s", student.Status, student.StatusEffectiveDate)
+	}
+
+	onLeave := time.Date(2024, time.January, 10, 0, 0, 0, 0, time.UTC)
+	if err := student.TransitionTo(StudentStatusOnLeave, " medical leave ", onLeave); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if student.Status != StudentStatusOnLeave || student.StatusReason != "medical leave" || !student.StatusEffectiveDate.Equal(onLeave) {
+		t.Errorf("Expected the student on leave, got %+v", student)
+	}
+	changes := student.StatusChanges()
+	if len(changes) != 1 || changes[0].From != StudentStatusActive || changes[0].To != StudentStatusOnLeave {
+		t.Errorf("Expected one change from active to on_leave, got %+v", changes)
+	}
+	events := student.Events()
+	if len(events) != 1 || events[0].Type != StudentStatusChanged {
+		t.Fatalf("Expected one StudentStatusChanged event, got %+v", events)
+	}
+	if len(events[0].Changes) != 3 {
+		t.Errorf("Expected Status, StatusReason and StatusEffectiveDate to change, got %+v", events[0].Changes)
+	}
+
+	student.ClearEvents()
+	if len(student.StatusChanges()) != 0 {
+		t.Errorf("Expected ClearEvents to clear the pending status changes")
+	}
+}
+
+func TestStudentTransitionTo_Rejected(t *testing.T) {
+	yesterday := time.Now().AddDate(0, 0, -1)
+	tests := []struct {
+		name          string
+		from          StudentStatus
+		to            StudentStatus
+		reason        string
+		effectiveDate time.Time
+		want          error
+	}{
+		{"graduation is final", StudentStatusGraduated, StudentStatusActive, "readmitted", yesterday, ErrStatusTransitionNotAllowed},
+		{"same status", StudentStatusActive, StudentStatusActive, "again", yesterday, ErrStatusTransitionNotAllowed},
+		{"on leave cannot graduate", StudentStatusOnLeave, StudentStatusGraduated, "done", yesterday, ErrStatusTransitionNotAllowed},
+		{"unknown status", StudentStatusActive, StudentStatus("expelled"), "rules", yesterday, ErrInvalidStatusChange},
+		{"missing reason", StudentStatusActive, StudentStatusSuspended, "  ", yesterday, ErrInvalidStatusChange},
+		{"missing effective date", StudentStatusActive, StudentStatusSuspended, "unpaid fees", time.Time{}, ErrInvalidStatusChange},
+		{"future effective date", StudentStatusActive, StudentStatusSuspended, "unpaid fees", time.Now().AddDate(0, 0, 1), ErrInvalidStatusChange},
+		{"before enrollment", StudentStatusActive, StudentStatusSuspended, "unpaid fees", time.Date(2023, time.August, 1, 0, 0, 0, 0, time.UTC), ErrInvalidStatusChange},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			student := newEnrolledStudent(t)
+			student.Status = test.from
+			before := *student
+
+			err := student.TransitionTo(test.to, test.reason, test.effectiveDate)
+			if !errors.Is(err, test.want) {
+				t.Fatalf("Expected %v, got %v", test.want, err)
+			}
+			if student.Status != before.Status || len(student.Events()) != 0 || len(student.StatusChanges()) != 0 {
+				t.Errorf("Expected the student to be left untouched, got %+v", student)
+			}
+		})
+	}
+}
+
+func TestStudentTransitionTo_NotBeforeCurrentStatus(t *testing.T) {
+	student := newEnrolledStudent(t)
+	if err := student.TransitionTo(StudentStatusSuspended, "unpaid fees", time.Date(2024, time.March, 1, 0, 0, 0, 0, time.UTC)); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	err := student.TransitionTo(StudentStatusActive, "fees paid", time.Date(2024, time.February, 1, 0, 0, 0, 0, time.UTC))
+	if !errors.Is(err, ErrInvalidStatusChange) {
+		t.Errorf("Expected %v, got %v", ErrInvalidStatusChange, err)
+	}
+}
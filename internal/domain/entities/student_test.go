@@ -12,7 +12,7 @@ import (
 func TestNewStudent(t *testing.T) {
 	date_of_birth := time.Date(2003, time.March,11,02,0,0,0,time.UTC)
 	enrollment_date := time.Now()
-	phone := "0947531799"
+	phone := "+84947531799"
 	major := "Computer Science"
 
 
@@ -55,7 +55,7 @@ func TestNewStudent(t *testing.T) {
 	}
 
 	if s.Phone ==  nil ||  s.Phone != &phone {
-		t.Errorf("Expected Phone '0947531799' but got %v", *s.Phone)
+		t.Errorf("Expected Phone '+84947531799' but got %v", *s.Phone)
 	}
 
 	if s.Major == nil || s.Major != &major {
@@ -80,7 +80,7 @@ func TestNewStudent(t *testing.T) {
 }
 
 func TestStudent_Validate(t *testing.T) {
-	validPhone := "0442312300"
+	validPhone := "+84442312300"
 	validMajor := "ECM"
 	emptyPhone := ""
 
@@ -214,7 +214,7 @@ func TestStudent_Validate(t *testing.T) {
 					CreatedAt:      time.Now(),
 					UpdatedAt:      time.Now(),
 				},
-			expectedErr: errors.New("Phone cannot be an empty string if provided"),
+			expectedErr: errors.New("Phone must be an E.164 number such as +84901234567"),
 		},
 		{
 			name_case: "Create At is zero",
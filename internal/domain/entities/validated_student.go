@@ -1,6 +1,9 @@
 package entities
 
+import "errors"
 
+// ErrInvalidStudent matches every error NewValidatedStudent returns.
+var ErrInvalidStudent = errors.New("invalid student")
 
 type ValidatedStudent struct {
 	Student
@@ -11,13 +14,40 @@ func (vs *ValidatedStudent) IsValid() bool {
 	return  vs.isValidated
 }
 
+// NewValidatedStudent checks student and returns a copy that is safe to
+// store. A student built without a status is active since enrollment.
 func NewValidatedStudent(student *Student) (*ValidatedStudent , error) {
 	if err := student.validate(); err != nil{
-		return nil, err
+		return nil, &invalidStudentError{err: err}
 
 	}
-	return &ValidatedStudent{
+	validated := &ValidatedStudent{
 		Student: *student,
 		isValidated: true,
-	}, nil
+	}
+	if validated.Status == "" {
+		validated.Status = StudentStatusActive
+	}
+	if validated.StatusEffectiveDate.IsZero() {
+		validated.StatusEffectiveDate = validated.EnrollmentDate
+	}
+	return validated, nil
+}
+
+// invalidStudentError keeps the message of the failed rule, so that callers
+// reporting it see the same text, while matching ErrInvalidStudent.
+type invalidStudentError struct {
+	err error
+}
+
+func (e *invalidStudentError) Error() string {
+	return e.err.Error()
+}
+
+func (e *invalidStudentError) Is(target error) bool {
+	return target == ErrInvalidStudent
+}
+
+func (e *invalidStudentError) Unwrap() error {
+	return e.err
 }
@@ -0,0 +1,55 @@
+package entities
+
+import "strings"
+
+// ValidationError is a broken rule, identified by Code so that it can be
+// reported in the reader's language. Message is the English text with Params
+// filled in; catalogs keyed by Code use the same {name} placeholders.
+//
+// The error wraps its kind, e.g. ErrInvalidContact, and keeps the text the
+// rule had before it got a code: "invalid contact: invalid email ...".
+type ValidationError struct {
+	Code    string
+	Message string
+	Params  map[string]string
+	kind    error
+}
+
+// newValidationError fills the {name} placeholders of message from params,
+// given as name, value pairs. kind may be nil.
+func newValidationError(kind error, code string, message string, params ...string) *ValidationError {
+	values := make(map[string]string, len(params)/2)
+	for i := 0; i+1 < len(params); i += 2 {
+		values[params[i]] = params[i+1]
+	}
+	return &ValidationError{
+		Code:    code,
+		Message: FormatMessage(message, values),
+		Params:  values,
+		kind:    kind,
+	}
+}
+
+func (e *ValidationError) Error() string {
+	if e.kind == nil {
+		return e.Message
+	}
+	return e.kind.Error() + ": " + e.Message
+}
+
+func (e *ValidationError) Unwrap() error {
+	return e.kind
+}
+
+// FormatMessage replaces each {name} in message with params[name]. Unknown
+// placeholders are left as they are.
+func FormatMessage(message string, params map[string]string) string {
+	if len(params) == 0 {
+		return message
+	}
+	pairs := make([]string, 0, 2*len(params))
+	for name, value := range params {
+		pairs = append(pairs, "{"+name+"}", value)
+	}
+	return strings.NewReplacer(pairs...).Replace(message)
+}
@@ -0,0 +1,46 @@
+package entities
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestValidationError_KeepsTextAndKind(t *testing.T) {
+	student := newEnrolledStudent(t)
+	err := student.SaveEmail(ContactEmail{Address: "not-an-email", Primary: true})
+
+	var invalid *ValidationError
+	if !errors.As(err, &invalid) {
+		t.Fatalf("Expected a ValidationError, got %v", err)
+	}
+	if !errors.Is(err, ErrInvalidContact) {
+		t.Errorf("Expected the error to match ErrInvalidContact, got %v", err)
+	}
+	if invalid.Code != "contact.email_invalid" || invalid.Params["email"] != "not-an-email" {
+		t.Errorf("Expected the code and email of the rule, got %+v", invalid)
+	}
+	if err.Error() != `invalid contact: invalid email "not-an-email"` {
+		t.Errorf("Expected the text of the rule, got %q", err.Error())
+	}
+}
+
+func TestValidationError_OfInvalidStudent(t *testing.T) {
+	student := NewStudent("", "Tran", nil, "vu@example.com", nil, nil, time.Now())
+	_, err := NewValidatedStudent(student)
+
+	var invalid *ValidationError
+	if !errors.As(err, &invalid) || invalid.Code != "student.first_name_required" {
+		t.Fatalf("Expected the first name rule, got %v", err)
+	}
+	if !errors.Is(err, ErrInvalidStudent) || err.Error() != "Must have first name." {
+		t.Errorf("Expected the text of the rule matching ErrInvalidStudent, got %q", err.Error())
+	}
+}
+
+func TestFormatMessage(t *testing.T) {
+	got := FormatMessage("a student who is {from} cannot become {to}; {unknown}", map[string]string{"from": "graduated", "to": "active"})
+	if got != "a student who is graduated cannot become active; {unknown}" {
+		t.Errorf("Unexpected message %q", got)
+	}
+}
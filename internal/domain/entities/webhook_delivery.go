@@ -0,0 +1,86 @@
+package entities
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type WebhookDeliveryStatus string
+
+const (
+	WebhookDeliveryPending   WebhookDeliveryStatus = "pending"
+	WebhookDeliverySucceeded WebhookDeliveryStatus = "succeeded"
+	WebhookDeliveryDead      WebhookDeliveryStatus = "dead"
+)
+
+// WebhookRetryPolicy is the RetryPolicy of webhook deliveries.
+type WebhookRetryPolicy = RetryPolicy
+
+var DefaultWebhookRetryPolicy = WebhookRetryPolicy{
+	MaxAttempts: 8,
+	BaseDelay:   30 * time.Second,
+	MaxDelay:    time.Hour,
+}
+
+// WebhookDelivery is one event on its way to one subscription.
+type WebhookDelivery struct {
+	ID             uuid.UUID
+	SubscriptionID uuid.UUID
+	EventID        uuid.UUID
+	EventType      string
+	Payload        string
+	Status         WebhookDeliveryStatus
+	Attempts       int
+	NextAttemptAt  time.Time
+	LastStatusCode int
+	LastError      string
+	CreatedAt      time.Time
+	UpdatedAt      time.Time
+}
+
+func NewWebhookDelivery(subscriptionID uuid.UUID, message *OutboxMessage) *WebhookDelivery {
+	now := time.Now()
+	return &WebhookDelivery{
+		ID:             uuid.New(),
+		SubscriptionID: subscriptionID,
+		EventID:        message.ID,
+		EventType:      message.EventType,
+		Payload:        message.Payload,
+		Status:         WebhookDeliveryPending,
+		NextAttemptAt:  now,
+		CreatedAt:      now,
+		UpdatedAt:      now,
+	}
+}
+
+func (d *WebhookDelivery) RecordSuccess(statusCode int, now time.Time) {
+	d.Attempts++
+	d.Status = WebhookDeliverySucceeded
+	d.LastStatusCode = statusCode
+	d.LastError = ""
+	d.UpdatedAt = now
+}
+
+// RecordFailure schedules the next attempt, or dead-letters the delivery once
+// the policy's attempts are used up.
+func (d *WebhookDelivery) RecordFailure(statusCode int, reason string, now time.Time, policy WebhookRetryPolicy) {
+	d.Attempts++
+	d.LastStatusCode = statusCode
+	d.LastError = reason
+	d.UpdatedAt = now
+
+	if d.Attempts >= policy.MaxAttempts {
+		d.Status = WebhookDeliveryDead
+		return
+	}
+	d.NextAttemptAt = now.Add(policy.NextDelay(d.Attempts))
+}
+
+// DeadLetter stops retrying regardless of the policy, e.g. when the
+// subscription was removed.
+func (d *WebhookDelivery) DeadLetter(reason string, now time.Time) {
+	d.Status = WebhookDeliveryDead
+	d.LastError = reason
+	d.UpdatedAt = now
+}
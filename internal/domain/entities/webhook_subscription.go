@@ -0,0 +1,92 @@
+package entities
+
+import (
+	"net/url"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// WildcardEventType subscribes to every student event.
+const WildcardEventType = "*"
+
+type WebhookSubscription struct {
+	ID         uuid.UUID
+	URL        string
+	EventTypes []string
+	Secret     string
+	Active     bool
+	CreatedAt  time.Time
+	UpdatedAt  time.Time
+}
+
+func NewWebhookSubscription(targetURL string, eventTypes []string, secret string) (*WebhookSubscription, error) {
+	subscription := &WebhookSubscription{
+		ID:         uuid.New(),
+		URL:        targetURL,
+		EventTypes: eventTypes,
+		Secret:     secret,
+		Active:     true,
+		CreatedAt:  time.Now(),
+		UpdatedAt:  time.Now(),
+	}
+
+	if err := subscription.validate(); err != nil {
+		return nil, err
+	}
+	return subscription, nil
+}
+
+func (w *WebhookSubscription) validate() error {
+	parsed, err := url.Parse(w.URL)
+	if err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Host == "" {
+		return newValidationError(nil, "webhook.url_invalid", "Webhook URL must be an absolute http or https URL")
+	}
+
+	if len(w.EventTypes) == 0 {
+		return newValidationError(nil, "webhook.event_types_required", "Webhook must subscribe to at least one event type")
+	}
+
+	for _, eventType := range w.EventTypes {
+		switch StudentEventType(eventType) {
+		case StudentCreated, StudentUpdated, StudentDeleted, StudentRestored, StudentStatusChanged, WildcardEventType:
+		default:
+			return newValidationError(nil, "webhook.event_type_invalid", "Unknown webhook event type {event_type}", "event_type", eventType)
+		}
+	}
+
+	if len(w.Secret) < 16 {
+		return newValidationError(nil, "webhook.secret_too_short", "Webhook secret must be at least 16 characters")
+	}
+
+	return nil
+}
+
+func (w *WebhookSubscription) Update(targetURL string, eventTypes []string, secret string, active bool) error {
+	updated := *w
+	updated.URL = targetURL
+	updated.EventTypes = eventTypes
+	if secret != "" {
+		updated.Secret = secret
+	}
+	updated.Active = active
+	updated.UpdatedAt = time.Now()
+
+	if err := updated.validate(); err != nil {
+		return err
+	}
+	*w = updated
+	return nil
+}
+
+func (w *WebhookSubscription) Matches(eventType string) bool {
+	if !w.Active {
+		return false
+	}
+	for _, subscribed := range w.EventTypes {
+		if subscribed == WildcardEventType || subscribed == eventType {
+			return true
+		}
+	}
+	return false
+}
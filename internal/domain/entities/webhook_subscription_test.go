@@ -0,0 +1,85 @@
+package entities
+
+import (
+	"testing"
+	"time"
+)
+
+func TestNewWebhookSubscription(t *testing.T) {
+	testCases := []struct {
+		name_case  string
+		url        string
+		eventTypes []string
+		secret     string
+		wantErr    bool
+	}{
+		{"Valid subscription", "https://lms.example.com/hooks", []string{"StudentCreated"}, "0123456789abcdef", false},
+		{"Wildcard", "http://localhost:9000", []string{"*"}, "0123456789abcdef", false},
+		{"Relative URL", "/hooks", []string{"StudentCreated"}, "0123456789abcdef", true},
+		{"Unsupported scheme", "ftp://lms.example.com", []string{"StudentCreated"}, "0123456789abcdef", true},
+		{"No event types", "https://lms.example.com/hooks", nil, "0123456789abcdef", true},
+		{"Unknown event type", "https://lms.example.com/hooks", []string{"StudentEnrolled"}, "0123456789abcdef", true},
+		{"Short secret", "https://lms.example.com/hooks", []string{"StudentCreated"}, "short", true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name_case, func(t *testing.T) {
+			_, err := NewWebhookSubscription(tc.url, tc.eventTypes, tc.secret)
+			if tc.wantErr && err == nil {
+				t.Errorf("Expected an error but got nil")
+			}
+			if !tc.wantErr && err != nil {
+				t.Errorf("Expected no error but got %v", err)
+			}
+		})
+	}
+}
+
+func TestWebhookSubscription_Matches(t *testing.T) {
+	subscription, err := NewWebhookSubscription("https://lms.example.com/hooks", []string{"StudentCreated"}, "0123456789abcdef")
+	if err != nil {
+		t.Fatalf("Expected no error but got %v", err)
+	}
+
+	if !subscription.Matches("StudentCreated") || subscription.Matches("StudentDeleted") {
+		t.Errorf("Expected to match only StudentCreated")
+	}
+
+	if err := subscription.Update(subscription.URL, []string{"*"}, "", false); err != nil {
+		t.Fatalf("Expected no error but got %v", err)
+	}
+	if subscription.Matches("StudentCreated") {
+		t.Errorf("Expected an inactive subscription to match nothing")
+	}
+	if subscription.Secret != "0123456789abcdef" {
+		t.Errorf("Expected an empty secret to keep the existing one")
+	}
+
+	if err := subscription.Update("not a url", []string{"*"}, "", true); err == nil {
+		t.Errorf("Expected an invalid update to fail")
+	}
+	if subscription.URL != "https://lms.example.com/hooks" {
+		t.Errorf("Expected a failed update to leave the subscription unchanged, got %s", subscription.URL)
+	}
+}
+
+func TestWebhookDelivery_RecordFailure(t *testing.T) {
+	policy := WebhookRetryPolicy{MaxAttempts: 3, BaseDelay: time.Minute, MaxDelay: 90 * time.Second}
+	delivery := &WebhookDelivery{Status: WebhookDeliveryPending}
+	now := time.Now()
+
+	delivery.RecordFailure(500, "boom", now, policy)
+	if delivery.Status != WebhookDeliveryPending || !delivery.NextAttemptAt.Equal(now.Add(time.Minute)) {
+		t.Errorf("Expected a retry after one minute, got %+v", delivery)
+	}
+
+	delivery.RecordFailure(500, "boom", now, policy)
+	if !delivery.NextAttemptAt.Equal(now.Add(90 * time.Second)) {
+		t.Errorf("Expected the delay to be capped at MaxDelay, got %v", delivery.NextAttemptAt.Sub(now))
+	}
+
+	delivery.RecordFailure(500, "boom", now, policy)
+	if delivery.Status != WebhookDeliveryDead {
+		t.Errorf("Expected the delivery to be dead-lettered, got %s", delivery.Status)
+	}
+}
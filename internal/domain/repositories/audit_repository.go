@@ -0,0 +1,14 @@
+package repositories
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/tranvu1111/go-students-new/internal/domain/entities"
+)
+
+// AuditRepository reads the audit trail. Entries are written by the
+// StudentRepository in the same transaction as the change they describe.
+type AuditRepository interface {
+	FindByStudentId(ctx context.Context, studentID uuid.UUID, offset int, limit int) ([]*entities.AuditEntry, int64, error)
+}
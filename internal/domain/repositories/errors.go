@@ -0,0 +1,8 @@
+package repositories
+
+import "errors"
+
+// ErrUnavailable is returned, possibly wrapped, when the store cannot be
+// reached, or is not tried because it kept failing. The same call may succeed
+// later.
+var ErrUnavailable = errors.New("storage is unavailable")
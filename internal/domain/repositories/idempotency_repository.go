@@ -2,12 +2,23 @@ package repositories
 
 import (
 	"context"
+	"errors"
+	"time"
 
 	"github.com/tranvu1111/go-students-new/internal/domain/entities"
 )
 
+// ErrDuplicateIdempotencyKey is returned, possibly wrapped, by Create when a
+// record with the same key exists.
+var ErrDuplicateIdempotencyKey = errors.New("idempotency key already exists")
+
 type IdempotencyRepository interface {
+	// FindByKey returns nil and no error when no record has the key.
 	FindByKey(ctx context.Context, key string) (*entities.IdempotencyRecord, error)
 	Create(ctx context.Context, record *entities.IdempotencyRecord) (*entities.IdempotencyRecord, error)
+	// Update saves the record by ID, inserting it when it does not exist.
 	Update(ctx context.Context, record *entities.IdempotencyRecord) (*entities.IdempotencyRecord, error)
+	// DeleteOlderThan removes the records created before cutoff and returns
+	// how many there were.
+	DeleteOlderThan(ctx context.Context, cutoff time.Time) (int64, error)
 }
\ No newline at end of file
@@ -0,0 +1,56 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/tranvu1111/go-students-new/internal/domain/entities"
+)
+
+// ErrNotificationTemplateNotFound is returned, possibly wrapped, when no
+// template has the ID.
+var ErrNotificationTemplateNotFound = errors.New("notification template not found")
+
+// ErrDuplicateNotificationTemplate is returned, possibly wrapped, when another
+// template covers the same event, channel and locale.
+var ErrDuplicateNotificationTemplate = errors.New("a template for this event, channel and locale already exists")
+
+// ErrNotificationNotFound is returned, possibly wrapped, when no notification
+// has the ID.
+var ErrNotificationNotFound = errors.New("notification not found")
+
+// NotificationTemplateFilter narrows FindAll; zero fields match everything.
+type NotificationTemplateFilter struct {
+	EventType string
+	Channel   entities.NotificationChannel
+	Locale    string
+}
+
+type NotificationTemplateRepository interface {
+	Create(ctx context.Context, template *entities.NotificationTemplate) (*entities.NotificationTemplate, error)
+	FindById(ctx context.Context, id uuid.UUID) (*entities.NotificationTemplate, error)
+	// FindAll returns the matching templates ordered by event, channel and
+	// locale.
+	FindAll(ctx context.Context, filter NotificationTemplateFilter) ([]*entities.NotificationTemplate, error)
+	Update(ctx context.Context, template *entities.NotificationTemplate) (*entities.NotificationTemplate, error)
+	Delete(ctx context.Context, id uuid.UUID) error
+}
+
+// NotificationFilter narrows the send log; nil fields match everything.
+type NotificationFilter struct {
+	StudentID *uuid.UUID
+	Status    *entities.NotificationStatus
+}
+
+type NotificationRepository interface {
+	// Create skips a template already rendered for the event, so that the
+	// outbox relay may publish the same event twice.
+	Create(ctx context.Context, notifications []*entities.Notification) error
+	FindById(ctx context.Context, id uuid.UUID) (*entities.Notification, error)
+	FindDue(ctx context.Context, now time.Time, limit int) ([]*entities.Notification, error)
+	Update(ctx context.Context, notification *entities.Notification) error
+	// FindAll returns a page of the send log, newest first, and its total.
+	FindAll(ctx context.Context, filter NotificationFilter, offset int, limit int) ([]*entities.Notification, int64, error)
+}
@@ -0,0 +1,21 @@
+package repositories
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/tranvu1111/go-students-new/internal/domain/entities"
+)
+
+// OutboxRepository is used by the relay; messages are written by the
+// StudentRepository alongside the change that raised them.
+type OutboxRepository interface {
+	// FindUnpublished returns the oldest messages due at now, leaving out
+	// those waiting for a retry and those given up on.
+	FindUnpublished(ctx context.Context, now time.Time, limit int) ([]*entities.OutboxMessage, error)
+	MarkPublished(ctx context.Context, id uuid.UUID, publishedAt time.Time) error
+	// MarkFailed stores the attempts, error and next attempt recorded on
+	// message by RecordFailure.
+	MarkFailed(ctx context.Context, message *entities.OutboxMessage) error
+}
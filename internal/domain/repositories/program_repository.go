@@ -0,0 +1,34 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+
+	"github.com/google/uuid"
+	"github.com/tranvu1111/go-students-new/internal/domain/entities"
+)
+
+// ErrProgramNotFound is returned, possibly wrapped, when no program has the ID.
+var ErrProgramNotFound = errors.New("program not found")
+
+// ErrDuplicateProgramCode is returned, possibly wrapped, when another program
+// has the same code.
+var ErrDuplicateProgramCode = errors.New("program code is already used by another program")
+
+type ProgramRepository interface {
+	Create(ctx context.Context, program *entities.Program) (*entities.Program, error)
+	FindById(ctx context.Context, id uuid.UUID) (*entities.Program, error)
+	// FindByIds returns the programs among ids, in no particular order. IDs
+	// with no program are skipped.
+	FindByIds(ctx context.Context, ids []uuid.UUID) ([]*entities.Program, error)
+	// FindAll returns the matching programs ordered by code.
+	FindAll(ctx context.Context, filter ProgramFilter) ([]*entities.Program, error)
+	Update(ctx context.Context, program *entities.Program) (*entities.Program, error)
+}
+
+// ProgramFilter narrows FindAll; nil fields do not filter.
+type ProgramFilter struct {
+	Active      *bool
+	Department  *string
+	DegreeLevel *entities.DegreeLevel
+}
@@ -0,0 +1,47 @@
+package repositories
+
+import (
+	"context"
+	"sync/atomic"
+)
+
+type readSessionKey struct{}
+
+// readSession follows the reads and writes of one request.
+type readSession struct {
+	consistent bool
+	wrote      atomic.Bool
+}
+
+// ContextWithReadSession starts the read session of a request. Repositories
+// that serve reads from replicas read from the primary instead once the
+// request has written, so that it sees its own writes, or throughout when
+// consistent is set.
+func ContextWithReadSession(ctx context.Context, consistent bool) context.Context {
+	return context.WithValue(ctx, readSessionKey{}, &readSession{consistent: consistent})
+}
+
+// MarkWrite records that the request of ctx wrote. It does nothing outside a
+// read session.
+func MarkWrite(ctx context.Context) {
+	if session, ok := ctx.Value(readSessionKey{}).(*readSession); ok {
+		session.wrote.Store(true)
+	}
+}
+
+// ContextForWrite returns the context of a write whose reads, such as the
+// read of the version it updates, must see every committed write.
+func ContextForWrite(ctx context.Context) context.Context {
+	if session, ok := ctx.Value(readSessionKey{}).(*readSession); ok {
+		session.wrote.Store(true)
+		return ctx
+	}
+	return ContextWithReadSession(ctx, true)
+}
+
+// NeedsConsistentRead reports whether reads under ctx must see every
+// committed write, which a lagging replica cannot promise.
+func NeedsConsistentRead(ctx context.Context) bool {
+	session, ok := ctx.Value(readSessionKey{}).(*readSession)
+	return ok && (session.consistent || session.wrote.Load())
+}
@@ -1,16 +1,73 @@
 package repositories
 
 import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
 	"github.com/google/uuid"
 	"github.com/tranvu1111/go-students-new/internal/domain/entities"
 )
 
+// ErrStudentNotFound is returned, possibly wrapped, when no live student has the ID.
+var ErrStudentNotFound = errors.New("student not found")
+
+// ErrDuplicateEmail is returned, possibly wrapped, when another live student
+// has the same email, compared case-insensitively.
+var ErrDuplicateEmail = errors.New("email is already used by another student")
+
 type StudentRepository interface {
 
-	Create(student *entities.ValidatedStudent) (*entities.Student, error)
-	FindById(id uuid.UUID) (*entities.Student, error)
-	FindAll() ([]*entities.Student, error)
-	Update(student *entities.ValidatedStudent) (*entities.Student, error)
-	Delete(id uuid.UUID) error
+	Create(ctx context.Context, student *entities.ValidatedStudent) (*entities.Student, error)
+	// CreateBatch stores all students in one transaction, inserting batchSize rows at a time.
+	CreateBatch(ctx context.Context, students []*entities.ValidatedStudent, batchSize int) error
+	FindById(ctx context.Context, id uuid.UUID) (*entities.Student, error)
+	// FindByIds returns the live students among ids in one query, in no
+	// particular order. IDs with no live student are skipped.
+	FindByIds(ctx context.Context, ids []uuid.UUID) ([]*entities.Student, error)
+	FindAll(ctx context.Context, filter StudentFilter) ([]*entities.Student, error)
+	// Stream visits every matching student from a database cursor, without
+	// loading the whole result set. It stops at the first error from visit.
+	Stream(ctx context.Context, filter StudentFilter, visit func(*entities.Student) error) error
+	// Update stores the student if its Version is still the stored one, and
+	// bumps the version. Otherwise it returns a *VersionConflictError. Its
+	// pending StatusChanges join the status history in the same transaction.
+	Update(ctx context.Context, student *entities.ValidatedStudent) (*entities.Student, error)
+	// Delete soft deletes the student if expectedVersion is still the stored one.
+	Delete(ctx context.Context, id uuid.UUID, expectedVersion int) error
+	Restore(ctx context.Context, id uuid.UUID) (*entities.Student, error)
+	// Search returns up to limit students matching the free-text query,
+	// best match first. Matching is typo tolerant.
+	Search(ctx context.Context, query string, limit int) ([]StudentSearchHit, error)
+
+}
+
+// StudentFilter narrows FindAll and Stream; nil fields do not filter.
+// FindAll also pages by Offset and Limit, oldest student first; a zero Limit
+// returns every match.
+type StudentFilter struct {
+	Major 			*string
+	Status 			*entities.StudentStatus
+	EnrolledFrom 	*time.Time
+	EnrolledTo 		*time.Time
+	Offset 			int
+	Limit 			int
+}
+
+// StudentSearchHit is one search result; a higher Score is a better match.
+type StudentSearchHit struct {
+	Student 	*entities.Student
+	Score 		float64
+}
+
+// VersionConflictError reports a write based on a stale version of the student.
+type VersionConflictError struct {
+	StudentID 		uuid.UUID
+	ExpectedVersion int
+	CurrentVersion 	int
+}
 
-}
\ No newline at end of file
+func (e *VersionConflictError) Error() string {
+	return fmt.Sprintf("student %s was modified: expected version %d, current version %d", e.StudentID, e.ExpectedVersion, e.CurrentVersion)
+}
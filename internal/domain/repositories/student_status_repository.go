@@ -0,0 +1,16 @@
+package repositories
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/tranvu1111/go-students-new/internal/domain/entities"
+)
+
+// StudentStatusRepository reads the status history. Entries are written by
+// the StudentRepository, with the transition they record.
+type StudentStatusRepository interface {
+	// FindByStudentId pages through the history, latest transition first,
+	// and returns the total number of entries.
+	FindByStudentId(ctx context.Context, studentID uuid.UUID, offset int, limit int) ([]*entities.StudentStatusChange, int64, error)
+}
@@ -0,0 +1,17 @@
+package repositories
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// VerificationEmailRepository remembers the outbox messages a verification
+// email was sent for, so that a relay retry does not mail the student again.
+type VerificationEmailRepository interface {
+	// WasSent reports whether an email was recorded for the outbox message.
+	WasSent(ctx context.Context, messageID uuid.UUID) (bool, error)
+	// RecordSent does nothing for a message already recorded.
+	RecordSent(ctx context.Context, messageID uuid.UUID, studentID uuid.UUID, sentAt time.Time) error
+}
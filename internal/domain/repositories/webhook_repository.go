@@ -0,0 +1,29 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/tranvu1111/go-students-new/internal/domain/entities"
+)
+
+// ErrWebhookSubscriptionNotFound is returned, possibly wrapped, when no
+// subscription has the ID.
+var ErrWebhookSubscriptionNotFound = errors.New("webhook subscription not found")
+
+type WebhookSubscriptionRepository interface {
+	Create(ctx context.Context, subscription *entities.WebhookSubscription) (*entities.WebhookSubscription, error)
+	FindById(ctx context.Context, id uuid.UUID) (*entities.WebhookSubscription, error)
+	FindAll(ctx context.Context) ([]*entities.WebhookSubscription, error)
+	Update(ctx context.Context, subscription *entities.WebhookSubscription) (*entities.WebhookSubscription, error)
+	Delete(ctx context.Context, id uuid.UUID) error
+}
+
+type WebhookDeliveryRepository interface {
+	Create(ctx context.Context, deliveries []*entities.WebhookDelivery) error
+	FindDue(ctx context.Context, now time.Time, limit int) ([]*entities.WebhookDelivery, error)
+	Update(ctx context.Context, delivery *entities.WebhookDelivery) error
+	FindBySubscriptionId(ctx context.Context, subscriptionID uuid.UUID, offset int, limit int) ([]*entities.WebhookDelivery, int64, error)
+}
@@ -0,0 +1,183 @@
+// Package search holds the portable student matching rules: tokenizing,
+// typo tolerant term matching, ranking and highlighting. Databases with
+// native full-text search rank on their own, but highlighting always uses
+// these rules so results look the same on every backend.
+package search
+
+import (
+	"strings"
+	"unicode"
+
+	"github.com/tranvu1111/go-students-new/internal/domain/entities"
+)
+
+const (
+	HighlightStart = "<em>"
+	HighlightEnd   = "</em>"
+)
+
+// Field is a searchable student field and its weight in the ranking.
+type Field struct {
+	Name   string
+	Weight float64
+	Value  func(*entities.Student) string
+}
+
+var Fields = []Field{
+	{Name: "FirstName", Weight: 1.0, Value: func(s *entities.Student) string { return s.FirstName }},
+	{Name: "LastName", Weight: 1.0, Value: func(s *entities.Student) string { return s.LastName }},
+	{Name: "Email", Weight: 0.8, Value: func(s *entities.Student) string { return s.Email }},
+	{Name: "Major", Weight: 0.5, Value: func(s *entities.Student) string {
+		if s.Major == nil {
+			return ""
+		}
+		return *s.Major
+	}},
+}
+
+// Tokenize lower-cases text and splits it on anything that is not a letter
+// or digit, so "tran.vu@uni.edu" yields tran, vu, uni, edu.
+func Tokenize(text string) []string {
+	return strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+}
+
+// MatchTerm scores how well a query term matches a token: 1 for an exact
+// match, 0.8 for a prefix, and less for near misses within the allowed
+// edit distance. Zero means no match.
+func MatchTerm(term string, token string) float64 {
+	switch {
+	case term == token:
+		return 1
+	case len(term) >= 2 && strings.HasPrefix(token, term):
+		return 0.8
+	}
+
+	allowed := allowedEdits(term)
+	if allowed == 0 {
+		return 0
+	}
+	distance := editDistance(term, token)
+	if distance > allowed {
+		return 0
+	}
+	return 0.6 - 0.2*float64(distance-1)
+}
+
+// allowedEdits grows with the term length so short terms must match exactly.
+func allowedEdits(term string) int {
+	switch n := len([]rune(term)); {
+	case n >= 8:
+		return 2
+	case n >= 4:
+		return 1
+	default:
+		return 0
+	}
+}
+
+// Score ranks a student against the query. Every query term contributes its
+// best weighted match across fields; terms that match nothing lower the score.
+func Score(query string, student *entities.Student) float64 {
+	terms := Tokenize(query)
+	if len(terms) == 0 {
+		return 0
+	}
+
+	total := 0.0
+	for _, term := range terms {
+		best := 0.0
+		for _, field := range Fields {
+			for _, token := range Tokenize(field.Value(student)) {
+				if score := MatchTerm(term, token) * field.Weight; score > best {
+					best = score
+				}
+			}
+		}
+		total += best
+	}
+	return total / float64(len(terms))
+}
+
+// Highlight wraps the words of each field that match a query term and returns
+// only the fields with at least one match.
+func Highlight(query string, student *entities.Student) map[string]string {
+	terms := Tokenize(query)
+	highlights := map[string]string{}
+	for _, field := range Fields {
+		if highlighted, ok := highlightText(field.Value(student), terms); ok {
+			highlights[field.Name] = highlighted
+		}
+	}
+	return highlights
+}
+
+func highlightText(text string, terms []string) (string, bool) {
+	var b strings.Builder
+	matched := false
+	runes := []rune(text)
+
+	for i := 0; i < len(runes); {
+		if !isWordRune(runes[i]) {
+			b.WriteRune(runes[i])
+			i++
+			continue
+		}
+
+		j := i
+		for j < len(runes) && isWordRune(runes[j]) {
+			j++
+		}
+		word := string(runes[i:j])
+		if matchesAny(strings.ToLower(word), terms) {
+			matched = true
+			b.WriteString(HighlightStart + word + HighlightEnd)
+		} else {
+			b.WriteString(word)
+		}
+		i = j
+	}
+	return b.String(), matched
+}
+
+func matchesAny(token string, terms []string) bool {
+	for _, term := range terms {
+		if MatchTerm(term, token) > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+func isWordRune(r rune) bool {
+	return unicode.IsLetter(r) || unicode.IsDigit(r)
+}
+
+// editDistance is the optimal string alignment distance: insertions,
+// deletions, substitutions and swaps of adjacent letters each cost one.
+func editDistance(a string, b string) int {
+	ra, rb := []rune(a), []rune(b)
+	d := make([][]int, len(ra)+1)
+	for i := range d {
+		d[i] = make([]int, len(rb)+1)
+		d[i][0] = i
+	}
+	for j := range d[0] {
+		d[0][j] = j
+	}
+
+	for i := 1; i <= len(ra); i++ {
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			d[i][j] = min(d[i-1][j]+1, d[i][j-1]+1, d[i-1][j-1]+cost)
+			if i > 1 && j > 1 && ra[i-1] == rb[j-2] && ra[i-2] == rb[j-1] {
+				d[i][j] = min(d[i][j], d[i-2][j-2]+1)
+			}
+		}
+	}
+	return d[len(ra)][len(rb)]
+}
@@ -0,0 +1,57 @@
+package search
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/tranvu1111/go-students-new/internal/domain/entities"
+)
+
+func TestMatchTerm(t *testing.T) {
+	tests := []struct {
+		term  string
+		token string
+		match bool
+	}{
+		{"tran", "tran", true},
+		{"ngu", "nguyen", true},
+		{"nguyne", "nguyen", true},
+		{"ngyuen", "nguyen", true},
+		{"nyugne", "nguyen", false},
+		{"nguyn", "nguyen", true},
+		{"johnatan", "jonathan", true},
+		{"le", "li", false},
+		{"vu", "vo", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.term+"/"+tt.token, func(t *testing.T) {
+			assert.Equal(t, tt.match, MatchTerm(tt.term, tt.token) > 0)
+		})
+	}
+}
+
+func TestScore_RanksExactAboveTypo(t *testing.T) {
+	major := "Computer Science"
+	exact := entities.NewStudent("Linh", "Tran", nil, "linh@example.com", nil, &major, time.Now())
+	typo := entities.NewStudent("Lin", "Tram", nil, "lin@example.com", nil, nil, time.Now())
+
+	assert.Greater(t, Score("linh tran", exact), Score("linh tran", typo))
+	assert.Greater(t, Score("linh tran", typo), 0.0)
+	assert.Zero(t, Score("physics", exact))
+	assert.Zero(t, Score("  ", exact))
+}
+
+func TestHighlight(t *testing.T) {
+	major := "Computer Science"
+	student := entities.NewStudent("Linh", "Tran", nil, "linh.tran@example.com", nil, &major, time.Now())
+
+	highlights := Highlight("tran scienec", student)
+
+	assert.Equal(t, map[string]string{
+		"LastName": "<em>Tran</em>",
+		"Email":    "linh.<em>tran</em>@example.com",
+		"Major":    "Computer <em>Science</em>",
+	}, highlights)
+}
@@ -0,0 +1,68 @@
+package cache
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/tranvu1111/go-students-new/internal/domain/entities"
+	"github.com/tranvu1111/go-students-new/internal/domain/repositories"
+)
+
+// CachedStudentRepo is a read-through cache for FindById. Writes made through
+// it evict the student; writes made by other processes are only seen once the
+// entry expires, so keep the TTL short when running several instances.
+type CachedStudentRepo struct {
+	repositories.StudentRepository
+	students *LRU[uuid.UUID, entities.Student]
+}
+
+func NewCachedStudentRepo(inner repositories.StudentRepository, capacity int, ttl time.Duration) repositories.StudentRepository {
+	return &CachedStudentRepo{
+		StudentRepository: inner,
+		students:          NewLRU[uuid.UUID, entities.Student](capacity, ttl),
+	}
+}
+
+// FindById returns a copy, so callers may change it without touching the cache.
+// Consistent reads skip the cache, which may lag behind other processes.
+func (repo *CachedStudentRepo) FindById(ctx context.Context, id uuid.UUID) (*entities.Student, error) {
+	if !repositories.NeedsConsistentRead(ctx) {
+		if cached, ok := repo.students.Get(id); ok {
+			return &cached, nil
+		}
+	}
+
+	student, err := repo.StudentRepository.FindById(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	repo.put(student)
+	return student, nil
+}
+
+// Writes evict before and after the call: a read racing with the write may
+// have cached the old row in between.
+func (repo *CachedStudentRepo) Update(ctx context.Context, student *entities.ValidatedStudent) (*entities.Student, error) {
+	repo.students.Remove(student.StudentID)
+	defer repo.students.Remove(student.StudentID)
+	return repo.StudentRepository.Update(ctx, student)
+}
+
+func (repo *CachedStudentRepo) Delete(ctx context.Context, id uuid.UUID, expectedVersion int) error {
+	repo.students.Remove(id)
+	defer repo.students.Remove(id)
+	return repo.StudentRepository.Delete(ctx, id, expectedVersion)
+}
+
+func (repo *CachedStudentRepo) Restore(ctx context.Context, id uuid.UUID) (*entities.Student, error) {
+	repo.students.Remove(id)
+	defer repo.students.Remove(id)
+	return repo.StudentRepository.Restore(ctx, id)
+}
+
+func (repo *CachedStudentRepo) put(student *entities.Student) {
+	cached := *student
+	cached.ClearEvents()
+	repo.students.Put(student.StudentID, cached)
+}
@@ -0,0 +1,114 @@
+package cache_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tranvu1111/go-students-new/internal/domain/entities"
+	"github.com/tranvu1111/go-students-new/internal/domain/repositories"
+	"github.com/tranvu1111/go-students-new/internal/infrastructure/cache"
+)
+
+// countingRepo serves FindById from a map and counts the lookups that reach it.
+type countingRepo struct {
+	repositories.StudentRepository
+	students map[uuid.UUID]*entities.Student
+	finds    int
+}
+
+func (r *countingRepo) FindById(ctx context.Context, id uuid.UUID) (*entities.Student, error) {
+	r.finds++
+	student, ok := r.students[id]
+	if !ok {
+		return nil, repositories.ErrStudentNotFound
+	}
+	copied := *student
+	return &copied, nil
+}
+
+func (r *countingRepo) Update(ctx context.Context, student *entities.ValidatedStudent) (*entities.Student, error) {
+	stored := student.Student
+	stored.Version++
+	r.students[student.StudentID] = &stored
+	return &stored, nil
+}
+
+func newCountingRepo(students ...*entities.Student) *countingRepo {
+	repo := &countingRepo{students: map[uuid.UUID]*entities.Student{}}
+	for _, student := range students {
+		repo.students[student.StudentID] = student
+	}
+	return repo
+}
+
+func TestCachedStudentRepo_ReadThroughAndInvalidate(t *testing.T) {
+	ctx := context.Background()
+	student := entities.NewStudent("tran", "vu", nil, "tranvu@example.com", nil, nil, time.Now())
+	inner := newCountingRepo(student)
+	repo := cache.NewCachedStudentRepo(inner, 10, time.Minute)
+
+	first, err := repo.FindById(ctx, student.StudentID)
+	require.NoError(t, err)
+	first.FirstName = "changed by the caller"
+
+	second, err := repo.FindById(ctx, student.StudentID)
+	require.NoError(t, err)
+	assert.Equal(t, 1, inner.finds, "the second read should be served from the cache")
+	assert.Equal(t, "tran", second.FirstName, "callers must not change the cached copy")
+
+	major := "CNTT"
+	require.NoError(t, second.UpdateNewFields(nil, nil, &major))
+	validStudent, err := entities.NewValidatedStudent(second)
+	require.NoError(t, err)
+	_, err = repo.Update(ctx, validStudent)
+	require.NoError(t, err)
+
+	third, err := repo.FindById(ctx, student.StudentID)
+	require.NoError(t, err)
+	assert.Equal(t, 2, inner.finds, "an update should evict the student")
+	assert.Equal(t, "CNTT", *third.Major)
+	assert.Equal(t, 2, third.Version)
+
+	_, err = repo.FindById(ctx, uuid.New())
+	assert.ErrorIs(t, err, repositories.ErrStudentNotFound)
+}
+
+func TestCachedStudentRepo_ExpiresEntries(t *testing.T) {
+	ctx := context.Background()
+	student := entities.NewStudent("tran", "vu", nil, "tranvu@example.com", nil, nil, time.Now())
+	inner := newCountingRepo(student)
+	repo := cache.NewCachedStudentRepo(inner, 10, 20*time.Millisecond)
+
+	_, err := repo.FindById(ctx, student.StudentID)
+	require.NoError(t, err)
+	time.Sleep(40 * time.Millisecond)
+	_, err = repo.FindById(ctx, student.StudentID)
+	require.NoError(t, err)
+
+	assert.Equal(t, 2, inner.finds)
+}
+
+func TestLRU_EvictsLeastRecentlyUsed(t *testing.T) {
+	lru := cache.NewLRU[string, int](2, time.Minute)
+	lru.Put("a", 1)
+	lru.Put("b", 2)
+	_, _ = lru.Get("a")
+	lru.Put("c", 3)
+
+	_, hasB := lru.Get("b")
+	a, hasA := lru.Get("a")
+	assert.False(t, hasB, "b was least recently used")
+	assert.True(t, hasA)
+	assert.Equal(t, 1, a)
+	assert.Equal(t, 2, lru.Len())
+
+	for i := 0; i < 10; i++ {
+		lru.Put(fmt.Sprint(i), i)
+	}
+	assert.Equal(t, 2, lru.Len())
+}
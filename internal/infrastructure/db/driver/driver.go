@@ -0,0 +1,87 @@
+// Package driver opens the database the repositories run on, Postgres or
+// SQLite, as chosen by configuration.
+package driver
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/glebarez/sqlite"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+)
+
+const (
+	Postgres = "postgres"
+	SQLite   = "sqlite"
+)
+
+// sqlitePragmas tune SQLite for an API server: WAL lets readers run next to
+// the single writer, busy_timeout makes a second writer wait instead of
+// failing at once, and immediate transactions take the write lock up front,
+// so that a transaction never fails halfway when upgrading its lock.
+var sqlitePragmas = []string{
+	"journal_mode(WAL)",
+	"busy_timeout(5000)",
+	"foreign_keys(1)",
+	"synchronous(NORMAL)",
+}
+
+// Open connects to dsn with the named driver. Unless config sets NowFunc,
+// timestamps are taken in UTC, so that both databases store and compare the
+// same values.
+func Open(name string, dsn string, config *gorm.Config) (*gorm.DB, error) {
+	if config == nil {
+		config = &gorm.Config{}
+	}
+	if config.NowFunc == nil {
+		config.NowFunc = func() time.Time { return time.Now().UTC() }
+	}
+
+	switch name {
+	case Postgres:
+		return gorm.Open(postgres.Open(dsn), config)
+	case SQLite:
+		sqliteDSN, err := SQLiteDSN(dsn)
+		if err != nil {
+			return nil, err
+		}
+		return gorm.Open(sqlite.Open(sqliteDSN), config)
+	default:
+		return nil, fmt.Errorf("unsupported database driver %q, use %s or %s", name, Postgres, SQLite)
+	}
+}
+
+// SQLiteDSN adds the pragmas and the transaction lock mode to dsn, keeping
+// any the caller already set. It leaves out _time_format, which makes the
+// driver ignore _txlock; times are written in UTC instead, so that their text
+// sorts in time order.
+func SQLiteDSN(dsn string) (string, error) {
+	path, rawQuery, _ := strings.Cut(dsn, "?")
+	query, err := url.ParseQuery(rawQuery)
+	if err != nil {
+		return "", fmt.Errorf("invalid SQLite DSN: %w", err)
+	}
+
+	set := make(map[string]bool)
+	for _, pragma := range query["_pragma"] {
+		name, _, _ := strings.Cut(pragma, "(")
+		set[strings.ToLower(strings.TrimSpace(name))] = true
+	}
+	for _, pragma := range sqlitePragmas {
+		name, _, _ := strings.Cut(pragma, "(")
+		if !set[name] {
+			query.Add("_pragma", pragma)
+		}
+	}
+	if query.Get("_txlock") == "" {
+		query.Set("_txlock", "immediate")
+	}
+
+	if !strings.HasPrefix(path, "file:") {
+		path = "file:" + path
+	}
+	return path + "?" + query.Encode(), nil
+}
@@ -0,0 +1,62 @@
+package driver
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"gorm.io/gorm"
+
+	"github.com/tranvu1111/go-students-new/internal/infrastructure/db/resilience"
+)
+
+// Pool sizes the connection pool of a database. Zero fields keep the
+// database/sql defaults.
+type Pool struct {
+	MaxOpenConns    int
+	MaxIdleConns    int
+	ConnMaxLifetime time.Duration
+	ConnMaxIdleTime time.Duration
+}
+
+func ConfigurePool(db *gorm.DB, pool Pool) error {
+	sqlDB, err := db.DB()
+	if err != nil {
+		return err
+	}
+	if pool.MaxOpenConns > 0 {
+		sqlDB.SetMaxOpenConns(pool.MaxOpenConns)
+	}
+	if pool.MaxIdleConns > 0 {
+		sqlDB.SetMaxIdleConns(pool.MaxIdleConns)
+	}
+	if pool.ConnMaxLifetime > 0 {
+		sqlDB.SetConnMaxLifetime(pool.ConnMaxLifetime)
+	}
+	if pool.ConnMaxIdleTime > 0 {
+		sqlDB.SetConnMaxIdleTime(pool.ConnMaxIdleTime)
+	}
+	return nil
+}
+
+// OpenWithRetry opens like Open, retrying with backoff while the database
+// cannot be reached, as when it is still starting. Other errors, such as bad
+// credentials, are returned at once.
+func OpenWithRetry(ctx context.Context, name string, dsn string, config *gorm.Config, policy resilience.RetryPolicy) (*gorm.DB, error) {
+	for attempt := 1; ; attempt++ {
+		db, err := Open(name, dsn, config)
+		if err == nil || attempt >= policy.MaxAttempts || !resilience.IsConnectionError(err) {
+			return db, err
+		}
+
+		delay := policy.NextDelay(attempt)
+		log.Printf("database is not reachable yet, retrying in %s: %v", delay, err)
+		timer := time.NewTimer(delay)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return nil, err
+		case <-timer.C:
+		}
+	}
+}
@@ -2,6 +2,8 @@ package postgres
 
 import (
 	"context"
+	"fmt"
+	"time"
 
 	"github.com/tranvu1111/go-students-new/internal/domain/entities"
 	"github.com/tranvu1111/go-students-new/internal/domain/repositories"
@@ -46,11 +48,14 @@ func (repo *GormIdempotencyRepo) Create(ctx context.Context, record *entities.Id
 		Request:    record.Request,
 		Response:   record.Response,
 		StatusCode: record.StatusCode,
-		CreatedAt:  record.CreatedAt,
+		CreatedAt:  record.CreatedAt.UTC(),
 	}
 
 	result := repo.db.WithContext(ctx).Create(&dbRecord)
 	if result.Error != nil {
+		if isDuplicateKey(repo.db, result.Error) {
+			return nil, fmt.Errorf("%w: %w", repositories.ErrDuplicateIdempotencyKey, result.Error)
+		}
 		return nil,result.Error
 	}
 
@@ -76,11 +81,14 @@ func (repo *GormIdempotencyRepo) Update(ctx context.Context, record *entities.Id
 		Request:    record.Request,
 		Response:   record.Response,
 		StatusCode: record.StatusCode,
-		CreatedAt:  record.CreatedAt,
+		CreatedAt:  record.CreatedAt.UTC(),
 	}
 
 	result := repo.db.WithContext(ctx).Save(&dbRecord)
 	if result.Error != nil {
+		if isDuplicateKey(repo.db, result.Error) {
+			return nil, fmt.Errorf("%w: %w", repositories.ErrDuplicateIdempotencyKey, result.Error)
+		}
 		return nil, result.Error
 	}
 
@@ -100,3 +108,7 @@ func (repo *GormIdempotencyRepo) Update(ctx context.Context, record *entities.Id
 	}, nil
 }
 
+func (repo *GormIdempotencyRepo) DeleteOlderThan(ctx context.Context, cutoff time.Time) (int64, error) {
+	result := repo.db.WithContext(ctx).Where("created_at < ?", cutoff.UTC()).Delete(&DBIdempotencyRecord{})
+	return result.RowsAffected, result.Error
+}
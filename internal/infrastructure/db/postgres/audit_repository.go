@@ -0,0 +1,44 @@
+package postgres
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/tranvu1111/go-students-new/internal/domain/entities"
+	"github.com/tranvu1111/go-students-new/internal/domain/repositories"
+	"gorm.io/gorm"
+)
+
+type GormAuditRepo struct {
+	db *gorm.DB
+}
+
+func NewGormAuditRepo(db *gorm.DB) repositories.AuditRepository {
+	return &GormAuditRepo{db: db}
+}
+
+// FindByStudentId returns one page of the student's history, newest first,
+// together with the total number of entries.
+func (repo *GormAuditRepo) FindByStudentId(ctx context.Context, studentID uuid.UUID, offset int, limit int) ([]*entities.AuditEntry, int64, error) {
+	query := repo.db.WithContext(ctx).Model(&DBAuditEntry{}).Where("student_id = ?", studentID).Session(&gorm.Session{})
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	var dbEntries []DBAuditEntry
+	if err := query.Order("occurred_at DESC").Offset(offset).Limit(limit).Find(&dbEntries).Error; err != nil {
+		return nil, 0, err
+	}
+
+	entries := make([]*entities.AuditEntry, len(dbEntries))
+	for i := range dbEntries {
+		entry, err := fromDBAuditEntry(&dbEntries[i])
+		if err != nil {
+			return nil, 0, err
+		}
+		entries[i] = entry
+	}
+	return entries, total, nil
+}
@@ -3,6 +3,7 @@ package postgres
 import (
 	"time"
 	"github.com/google/uuid"
+	"gorm.io/gorm"
 )
 
 type DBStudent struct {
@@ -11,9 +12,31 @@ type DBStudent struct {
 	LastName 		string 
 	DateOfBirth 	*time.Time 
 	Email 			string 	
+	EmailVerifiedAt *time.Time
 	Phone 			*string 
 	Major 			*string 
+	Locale 			*string
+	Programs 		DBStudentPrograms 	`gorm:"type:text"`
+	Contacts 		DBStudentContacts 	`gorm:"type:text"`
 	EnrollmentDate 	time.Time 
+	// Rows from before the status lifecycle are active; a missing effective
+	// date reads as the enrollment date.
+	Status 				string 		`gorm:"not null;default:active;index"`
+	StatusReason 		string
+	StatusEffectiveDate *time.Time
+	CreatedAt 		time.Time
+	UpdatedAt 		time.Time
+	Version 		int 			`gorm:"not null;default:1"`
+	DeletedAt 		gorm.DeletedAt 	`gorm:"index"`
+}
+
+type DBProgram struct {
+	ID 				uuid.UUID 	`gorm:"primaryKey"`
+	Code 			string 		`gorm:"uniqueIndex"`
+	Name 			string
+	Department 		string 		`gorm:"index"`
+	DegreeLevel 	string
+	Active 			bool
 	CreatedAt 		time.Time
 	UpdatedAt 		time.Time
 }
@@ -25,4 +48,111 @@ type DBIdempotencyRecord struct {
 	Response   string
 	StatusCode int
 	CreatedAt  time.Time
-}
\ No newline at end of file
+}
+
+// DBAuditEntry is append-only; Changes holds the JSON encoded field diff.
+type DBAuditEntry struct {
+	ID 			uuid.UUID 	`gorm:"primaryKey"`
+	StudentID 	uuid.UUID 	`gorm:"index"`
+	Actor 		string
+	RequestID 	string
+	Operation 	string
+	Changes 	string
+	OccurredAt 	time.Time 	`gorm:"index"`
+}
+
+// DBStudentStatusChange is append-only, like DBAuditEntry.
+type DBStudentStatusChange struct {
+	ID 				uuid.UUID 	`gorm:"primaryKey"`
+	StudentID 		uuid.UUID 	`gorm:"index"`
+	FromStatus 		string
+	ToStatus 		string
+	Reason 			string
+	EffectiveDate 	time.Time
+	Actor 			string
+	RequestID 		string
+	RecordedAt 		time.Time 	`gorm:"index"`
+}
+
+type DBOutboxMessage struct {
+	ID 				uuid.UUID 	`gorm:"primaryKey"`
+	EventType 		string
+	AggregateID 	uuid.UUID 	`gorm:"index"`
+	Payload 		string
+	OccurredAt 		time.Time 	`gorm:"index"`
+	PublishedAt 	*time.Time 	`gorm:"index"`
+	Attempts 		int
+	LastError 		string
+	// NextAttemptAt is nil for messages stored before retries were scheduled.
+	NextAttemptAt 	*time.Time 	`gorm:"index"`
+	DeadAt 			*time.Time 	`gorm:"index"`
+}
+
+// DBWebhookSubscription stores EventTypes as a comma separated list.
+type DBWebhookSubscription struct {
+	ID 			uuid.UUID 	`gorm:"primaryKey"`
+	URL 		string
+	EventTypes 	string
+	Secret 		string
+	Active 		bool
+	CreatedAt 	time.Time
+	UpdatedAt 	time.Time
+}
+
+type DBWebhookDelivery struct {
+	ID 				uuid.UUID 	`gorm:"primaryKey"`
+	SubscriptionID 	uuid.UUID 	`gorm:"index;uniqueIndex:idx_webhook_delivery_event"`
+	EventID 		uuid.UUID 	`gorm:"uniqueIndex:idx_webhook_delivery_event"`
+	EventType 		string
+	Payload 		string
+	Status 			string 		`gorm:"index:idx_webhook_delivery_due"`
+	Attempts 		int
+	NextAttemptAt 	time.Time 	`gorm:"index:idx_webhook_delivery_due"`
+	LastStatusCode 	int
+	LastError 		string
+	CreatedAt 		time.Time
+	UpdatedAt 		time.Time
+}
+
+// Only one template covers an event, channel and locale.
+type DBNotificationTemplate struct {
+	ID 			uuid.UUID 	`gorm:"primaryKey"`
+	EventType 	string 		`gorm:"uniqueIndex:idx_notification_template_variant"`
+	Channel 	string 		`gorm:"uniqueIndex:idx_notification_template_variant"`
+	Locale 		string 		`gorm:"uniqueIndex:idx_notification_template_variant"`
+	Subject 	string
+	Body 		string
+	HTMLBody 	string
+	Active 		bool
+	CreatedAt 	time.Time
+	UpdatedAt 	time.Time
+}
+
+// DBVerificationEmail records that the verification email of an outbox
+// message went out.
+type DBVerificationEmail struct {
+	MessageID 	uuid.UUID 	`gorm:"primaryKey"`
+	StudentID 	uuid.UUID 	`gorm:"index"`
+	SentAt 		time.Time
+}
+
+type DBNotification struct {
+	ID 				uuid.UUID 	`gorm:"primaryKey"`
+	TemplateID 		uuid.UUID 	`gorm:"uniqueIndex:idx_notification_event"`
+	EventID 		uuid.UUID 	`gorm:"uniqueIndex:idx_notification_event"`
+	EventType 		string
+	StudentID 		uuid.UUID 	`gorm:"index"`
+	Channel 		string
+	Locale 			string
+	Recipient 		string
+	Subject 		string
+	Text 			string
+	HTML 			string
+	Status 			string 		`gorm:"index:idx_notification_due"`
+	Attempts 		int
+	NextAttemptAt 	time.Time 	`gorm:"index:idx_notification_due"`
+	LastError 		string
+	SentAt 			*time.Time
+	CreatedAt 		time.Time
+	UpdatedAt 		time.Time
+}
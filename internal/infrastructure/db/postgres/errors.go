@@ -0,0 +1,31 @@
+package postgres
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/tranvu1111/go-students-new/internal/domain/repositories"
+	"gorm.io/gorm"
+)
+
+// studentWriteError reports a unique violation on db_students as
+// ErrDuplicateEmail; the email index is the table's only unique constraint
+// besides the generated primary key. The dialect translates its own error
+// codes, whether or not the connection enables TranslateError.
+func studentWriteError(db *gorm.DB, err error) error {
+	if isDuplicateKey(db, err) {
+		return fmt.Errorf("%w: %w", repositories.ErrDuplicateEmail, err)
+	}
+	return err
+}
+
+func isDuplicateKey(db *gorm.DB, err error) bool {
+	if err == nil {
+		return false
+	}
+	translated := err
+	if translator, ok := db.Dialector.(gorm.ErrorTranslator); ok {
+		translated = translator.Translate(err)
+	}
+	return errors.Is(translated, gorm.ErrDuplicatedKey)
+}
@@ -0,0 +1,55 @@
+package postgres
+
+import (
+	"fmt"
+
+	"gorm.io/gorm"
+)
+
+// studentEmailIndex keeps emails unique among live students, ignoring case.
+// Postgres and SQLite both support this partial expression index; emails only
+// hold ASCII, which lower() folds the same way on both.
+const studentEmailIndex = `CREATE UNIQUE INDEX IF NOT EXISTS idx_db_students_email_lower ON db_students (lower(email)) WHERE deleted_at IS NULL`
+
+// AutoMigrate creates or updates every table owned by the Gorm repositories,
+// then runs the migrations specific to the dialect.
+func AutoMigrate(db *gorm.DB) error {
+	err := db.AutoMigrate(
+		&DBStudent{},
+		&DBProgram{},
+		&DBIdempotencyRecord{},
+		&DBAuditEntry{},
+		&DBStudentStatusChange{},
+		&DBOutboxMessage{},
+		&DBWebhookSubscription{},
+		&DBWebhookDelivery{},
+		&DBNotificationTemplate{},
+		&DBNotification{},
+		&DBVerificationEmail{},
+	)
+	if err != nil {
+		return err
+	}
+
+	switch db.Dialector.Name() {
+	case "postgres":
+		return migratePostgres(db)
+	case "sqlite":
+		return migrateSQLite(db)
+	default:
+		return fmt.Errorf("unsupported database dialect %q", db.Dialector.Name())
+	}
+}
+
+func migratePostgres(db *gorm.DB) error {
+	if err := db.Exec(studentEmailIndex).Error; err != nil {
+		return err
+	}
+	return migratePostgresSearch(db)
+}
+
+// migrateSQLite has no search indexes to build: Search scans the students in
+// Go on SQLite.
+func migrateSQLite(db *gorm.DB) error {
+	return db.Exec(studentEmailIndex).Error
+}
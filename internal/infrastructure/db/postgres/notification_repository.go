@@ -0,0 +1,180 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/tranvu1111/go-students-new/internal/domain/entities"
+	"github.com/tranvu1111/go-students-new/internal/domain/repositories"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type GormNotificationTemplateRepo struct {
+	db *gorm.DB
+}
+
+func NewGormNotificationTemplateRepo(db *gorm.DB) repositories.NotificationTemplateRepository {
+	return &GormNotificationTemplateRepo{db: db}
+}
+
+// Create reports a variant that already has a template as
+// ErrDuplicateNotificationTemplate; the variant index is the table's only
+// unique constraint besides the generated primary key.
+func (repo *GormNotificationTemplateRepo) Create(ctx context.Context, template *entities.NotificationTemplate) (*entities.NotificationTemplate, error) {
+	db := repo.db.WithContext(ctx)
+	dbTemplate := toDBNotificationTemplate(template)
+	if err := db.Create(dbTemplate).Error; err != nil {
+		if isDuplicateKey(db, err) {
+			return nil, fmt.Errorf("%w: %w", repositories.ErrDuplicateNotificationTemplate, err)
+		}
+		return nil, err
+	}
+	return repo.FindById(ctx, dbTemplate.ID)
+}
+
+func (repo *GormNotificationTemplateRepo) FindById(ctx context.Context, id uuid.UUID) (*entities.NotificationTemplate, error) {
+	var dbTemplate DBNotificationTemplate
+	if err := repo.db.WithContext(ctx).First(&dbTemplate, id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, repositories.ErrNotificationTemplateNotFound
+		}
+		return nil, err
+	}
+	return fromDBNotificationTemplate(&dbTemplate), nil
+}
+
+func (repo *GormNotificationTemplateRepo) FindAll(ctx context.Context, filter repositories.NotificationTemplateFilter) ([]*entities.NotificationTemplate, error) {
+	db := repo.db.WithContext(ctx)
+	if filter.EventType != "" {
+		db = db.Where("event_type = ?", filter.EventType)
+	}
+	if filter.Channel != "" {
+		db = db.Where("channel = ?", string(filter.Channel))
+	}
+	if filter.Locale != "" {
+		db = db.Where("locale = ?", filter.Locale)
+	}
+
+	var dbTemplates []DBNotificationTemplate
+	if err := db.Order("event_type ASC, channel ASC, locale ASC").Find(&dbTemplates).Error; err != nil {
+		return nil, err
+	}
+
+	templates := make([]*entities.NotificationTemplate, len(dbTemplates))
+	for i := range dbTemplates {
+		templates[i] = fromDBNotificationTemplate(&dbTemplates[i])
+	}
+	return templates, nil
+}
+
+func (repo *GormNotificationTemplateRepo) Update(ctx context.Context, template *entities.NotificationTemplate) (*entities.NotificationTemplate, error) {
+	dbTemplate := toDBNotificationTemplate(template)
+	values := map[string]interface{}{
+		"subject": 		dbTemplate.Subject,
+		"body": 		dbTemplate.Body,
+		"html_body": 	dbTemplate.HTMLBody,
+		"active": 		dbTemplate.Active,
+		"updated_at": 	dbTemplate.UpdatedAt,
+	}
+	result := repo.db.WithContext(ctx).Model(&DBNotificationTemplate{}).Where("id = ?", template.ID).Updates(values)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	if result.RowsAffected == 0 {
+		return nil, repositories.ErrNotificationTemplateNotFound
+	}
+	return repo.FindById(ctx, template.ID)
+}
+
+func (repo *GormNotificationTemplateRepo) Delete(ctx context.Context, id uuid.UUID) error {
+	result := repo.db.WithContext(ctx).Delete(&DBNotificationTemplate{}, id)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return repositories.ErrNotificationTemplateNotFound
+	}
+	return nil
+}
+
+type GormNotificationRepo struct {
+	db *gorm.DB
+}
+
+func NewGormNotificationRepo(db *gorm.DB) repositories.NotificationRepository {
+	return &GormNotificationRepo{db: db}
+}
+
+// Create enqueues notifications. A template already rendered for an event is
+// skipped, so the outbox relay may safely publish the same event twice.
+func (repo *GormNotificationRepo) Create(ctx context.Context, notifications []*entities.Notification) error {
+	if len(notifications) == 0 {
+		return nil
+	}
+
+	dbNotifications := make([]*DBNotification, len(notifications))
+	for i, notification := range notifications {
+		dbNotifications[i] = toDBNotification(notification)
+	}
+	return repo.db.WithContext(ctx).Clauses(clause.OnConflict{DoNothing: true}).Create(dbNotifications).Error
+}
+
+func (repo *GormNotificationRepo) FindById(ctx context.Context, id uuid.UUID) (*entities.Notification, error) {
+	var dbNotification DBNotification
+	if err := repo.db.WithContext(ctx).First(&dbNotification, id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, repositories.ErrNotificationNotFound
+		}
+		return nil, err
+	}
+	return fromDBNotification(&dbNotification), nil
+}
+
+func (repo *GormNotificationRepo) FindDue(ctx context.Context, now time.Time, limit int) ([]*entities.Notification, error) {
+	var dbNotifications []DBNotification
+	err := repo.db.WithContext(ctx).
+		Where("status = ? AND next_attempt_at <= ?", string(entities.NotificationPending), now).
+		Order("next_attempt_at ASC").Limit(limit).Find(&dbNotifications).Error
+	if err != nil {
+		return nil, err
+	}
+	return fromDBNotifications(dbNotifications), nil
+}
+
+func (repo *GormNotificationRepo) Update(ctx context.Context, notification *entities.Notification) error {
+	return repo.db.WithContext(ctx).Save(toDBNotification(notification)).Error
+}
+
+func (repo *GormNotificationRepo) FindAll(ctx context.Context, filter repositories.NotificationFilter, offset int, limit int) ([]*entities.Notification, int64, error) {
+	query := repo.db.WithContext(ctx).Model(&DBNotification{})
+	if filter.StudentID != nil {
+		query = query.Where("student_id = ?", *filter.StudentID)
+	}
+	if filter.Status != nil {
+		query = query.Where("status = ?", string(*filter.Status))
+	}
+	query = query.Session(&gorm.Session{})
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	var dbNotifications []DBNotification
+	if err := query.Order("created_at DESC").Offset(offset).Limit(limit).Find(&dbNotifications).Error; err != nil {
+		return nil, 0, err
+	}
+	return fromDBNotifications(dbNotifications), total, nil
+}
+
+func fromDBNotifications(dbNotifications []DBNotification) []*entities.Notification {
+	notifications := make([]*entities.Notification, len(dbNotifications))
+	for i := range dbNotifications {
+		notifications[i] = fromDBNotification(&dbNotifications[i])
+	}
+	return notifications
+}
@@ -0,0 +1,69 @@
+package postgres
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/tranvu1111/go-students-new/internal/domain/entities"
+	"github.com/tranvu1111/go-students-new/internal/domain/repositories"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type GormOutboxRepo struct {
+	db *gorm.DB
+}
+
+func NewGormOutboxRepo(db *gorm.DB) repositories.OutboxRepository {
+	return &GormOutboxRepo{db: db}
+}
+
+// FindUnpublished returns the oldest messages that still have to be relayed.
+// Messages stored before retries were scheduled have no next attempt and are
+// due at once.
+func (repo *GormOutboxRepo) FindUnpublished(ctx context.Context, now time.Time, limit int) ([]*entities.OutboxMessage, error) {
+	var dbMessages []DBOutboxMessage
+	err := repo.db.WithContext(ctx).
+		Where("published_at IS NULL AND dead_at IS NULL AND (next_attempt_at IS NULL OR next_attempt_at <= ?)", now).
+		Order("occurred_at ASC").Limit(limit).Find(&dbMessages).Error
+	if err != nil {
+		return nil, err
+	}
+
+	messages := make([]*entities.OutboxMessage, len(dbMessages))
+	for i := range dbMessages {
+		messages[i] = fromDBOutboxMessage(&dbMessages[i])
+	}
+	return messages, nil
+}
+
+func (repo *GormOutboxRepo) MarkPublished(ctx context.Context, id uuid.UUID, publishedAt time.Time) error {
+	return repo.db.WithContext(ctx).Model(&DBOutboxMessage{}).Where("id = ?", id).
+		Updates(map[string]interface{}{"published_at": publishedAt, "attempts": gorm.Expr("attempts + 1"), "last_error": ""}).Error
+}
+
+func (repo *GormOutboxRepo) MarkFailed(ctx context.Context, message *entities.OutboxMessage) error {
+	return repo.db.WithContext(ctx).Model(&DBOutboxMessage{}).Where("id = ?", message.ID).
+		Updates(map[string]interface{}{
+			"attempts":        message.Attempts,
+			"last_error":      message.LastError,
+			"next_attempt_at": message.NextAttemptAt,
+			"dead_at":         message.DeadAt,
+		}).Error
+}
+
+// writeOutboxEvents stores the events inside the caller's transaction. Events
+// are keyed by their ID, so writing the same event twice is a no-op.
+func writeOutboxEvents(tx *gorm.DB, events []entities.StudentEvent) error {
+	for _, event := range events {
+		message, err := entities.NewOutboxMessage(event)
+		if err != nil {
+			return err
+		}
+		if err := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(toDBOutboxMessage(message)).Error; err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,103 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/tranvu1111/go-students-new/internal/domain/entities"
+	"github.com/tranvu1111/go-students-new/internal/domain/repositories"
+	"gorm.io/gorm"
+)
+
+type GormProgramRepo struct {
+	db *gorm.DB
+}
+
+func NewGormProgramRepo(db *gorm.DB) repositories.ProgramRepository {
+	return &GormProgramRepo{db: db}
+}
+
+// Create reports a taken code as ErrDuplicateProgramCode; the code index is
+// the table's only unique constraint besides the generated primary key.
+func (repo *GormProgramRepo) Create(ctx context.Context, program *entities.Program) (*entities.Program, error) {
+	db := repo.db.WithContext(ctx)
+	dbProgram := toDBProgram(program)
+	if err := db.Create(dbProgram).Error; err != nil {
+		if isDuplicateKey(db, err) {
+			return nil, fmt.Errorf("%w: %w", repositories.ErrDuplicateProgramCode, err)
+		}
+		return nil, err
+	}
+	return repo.FindById(ctx, dbProgram.ID)
+}
+
+func (repo *GormProgramRepo) FindById(ctx context.Context, id uuid.UUID) (*entities.Program, error) {
+	var dbProgram DBProgram
+	if err := repo.db.WithContext(ctx).First(&dbProgram, id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, repositories.ErrProgramNotFound
+		}
+		return nil, err
+	}
+	return fromDBProgram(&dbProgram), nil
+}
+
+func (repo *GormProgramRepo) FindByIds(ctx context.Context, ids []uuid.UUID) ([]*entities.Program, error) {
+	if len(ids) == 0 {
+		return []*entities.Program{}, nil
+	}
+
+	var dbPrograms []DBProgram
+	if err := repo.db.WithContext(ctx).Where("id IN ?", ids).Find(&dbPrograms).Error; err != nil {
+		return nil, err
+	}
+	return fromDBPrograms(dbPrograms), nil
+}
+
+func (repo *GormProgramRepo) FindAll(ctx context.Context, filter repositories.ProgramFilter) ([]*entities.Program, error) {
+	db := repo.db.WithContext(ctx)
+	if filter.Active != nil {
+		db = db.Where("active = ?", *filter.Active)
+	}
+	if filter.Department != nil {
+		db = db.Where("department = ?", *filter.Department)
+	}
+	if filter.DegreeLevel != nil {
+		db = db.Where("degree_level = ?", string(*filter.DegreeLevel))
+	}
+
+	var dbPrograms []DBProgram
+	if err := db.Order("code ASC").Find(&dbPrograms).Error; err != nil {
+		return nil, err
+	}
+	return fromDBPrograms(dbPrograms), nil
+}
+
+func (repo *GormProgramRepo) Update(ctx context.Context, program *entities.Program) (*entities.Program, error) {
+	dbProgram := toDBProgram(program)
+	values := map[string]interface{}{
+		"name": 		dbProgram.Name,
+		"department": 	dbProgram.Department,
+		"degree_level": dbProgram.DegreeLevel,
+		"active": 		dbProgram.Active,
+		"updated_at": 	dbProgram.UpdatedAt,
+	}
+	result := repo.db.WithContext(ctx).Model(&DBProgram{}).Where("id = ?", program.ID).Updates(values)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	if result.RowsAffected == 0 {
+		return nil, repositories.ErrProgramNotFound
+	}
+	return repo.FindById(ctx, program.ID)
+}
+
+func fromDBPrograms(dbPrograms []DBProgram) []*entities.Program {
+	programs := make([]*entities.Program, len(dbPrograms))
+	for i := range dbPrograms {
+		programs[i] = fromDBProgram(&dbPrograms[i])
+	}
+	return programs
+}
@@ -0,0 +1,89 @@
+package postgres
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// DBStudentContacts stores the contacts of a student as a JSON document in
+// one column, like DBStudentPrograms: they belong to the student, change with
+// its version and are always read with it.
+type DBStudentContacts struct {
+	Addresses         []DBAddress          `json:",omitempty"`
+	Emails            []DBContactEmail     `json:",omitempty"`
+	Phones            []DBContactPhone     `json:",omitempty"`
+	EmergencyContacts []DBEmergencyContact `json:",omitempty"`
+}
+
+type DBAddress struct {
+	ID         uuid.UUID
+	Type       string
+	Line1      string
+	Line2      string
+	City       string
+	Region     string
+	PostalCode string
+	Country    string
+	ValidFrom  time.Time
+	ValidTo    *time.Time
+}
+
+type DBContactEmail struct {
+	ID           uuid.UUID
+	Address      string
+	Primary      bool
+	Verification string
+	VerifiedAt   *time.Time
+}
+
+type DBContactPhone struct {
+	ID           uuid.UUID
+	Number       string
+	Primary      bool
+	Verification string
+	VerifiedAt   *time.Time
+}
+
+type DBEmergencyContact struct {
+	ID           uuid.UUID
+	Name         string
+	Relationship string
+	Phone        string
+	Email        *string
+}
+
+func (c DBStudentContacts) isEmpty() bool {
+	return len(c.Addresses) == 0 && len(c.Emails) == 0 && len(c.Phones) == 0 && len(c.EmergencyContacts) == 0
+}
+
+func (c DBStudentContacts) Value() (driver.Value, error) {
+	if c.isEmpty() {
+		return nil, nil
+	}
+	encoded, err := json.Marshal(c)
+	if err != nil {
+		return nil, err
+	}
+	return string(encoded), nil
+}
+
+func (c *DBStudentContacts) Scan(value interface{}) error {
+	var encoded []byte
+	switch v := value.(type) {
+	case nil:
+		*c = DBStudentContacts{}
+		return nil
+	case string:
+		encoded = []byte(v)
+	case []byte:
+		encoded = v
+	default:
+		return fmt.Errorf("cannot scan %T into DBStudentContacts", value)
+	}
+	*c = DBStudentContacts{}
+	return json.Unmarshal(encoded, c)
+}
@@ -0,0 +1,48 @@
+package postgres
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// DBStudentPrograms stores the programs a student declared as a JSON array in
+// one column, so that every query of db_students loads them without a join.
+// The catalog entries live in db_programs.
+type DBStudentPrograms []DBStudentProgram
+
+type DBStudentProgram struct {
+	ProgramID 	uuid.UUID
+	Kind 		string
+	DeclaredAt 	time.Time
+}
+
+func (p DBStudentPrograms) Value() (driver.Value, error) {
+	if len(p) == 0 {
+		return nil, nil
+	}
+	encoded, err := json.Marshal([]DBStudentProgram(p))
+	if err != nil {
+		return nil, err
+	}
+	return string(encoded), nil
+}
+
+func (p *DBStudentPrograms) Scan(value interface{}) error {
+	var encoded []byte
+	switch v := value.(type) {
+	case nil:
+		*p = nil
+		return nil
+	case string:
+		encoded = []byte(v)
+	case []byte:
+		encoded = v
+	default:
+		return fmt.Errorf("cannot scan %T into DBStudentPrograms", value)
+	}
+	return json.Unmarshal(encoded, (*[]DBStudentProgram)(p))
+}
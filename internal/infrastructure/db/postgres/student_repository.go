@@ -1,41 +1,114 @@
 package postgres
 
 import (
+	"context"
+	"errors"
 	"fmt"
-	"log"
 
 	"github.com/google/uuid"
 	"github.com/tranvu1111/go-students-new/internal/domain/entities"
 	"github.com/tranvu1111/go-students-new/internal/domain/repositories"
+	"github.com/tranvu1111/go-students-new/internal/infrastructure/db/replica"
 	"gorm.io/gorm"
 )
 
 type GormStudentRepo struct {
 	db *gorm.DB
+	// readers picks the database of reads; nil reads from db.
+	readers *replica.Router
 }
 
 func NewGormStudentRepo(db *gorm.DB) repositories.StudentRepository {
 	return &GormStudentRepo{db:db}
 }
 
+// NewRoutedGormStudentRepo writes to the primary of router and reads from its
+// replicas, except in requests that wrote or asked for consistent reads.
+func NewRoutedGormStudentRepo(router *replica.Router) repositories.StudentRepository {
+	return &GormStudentRepo{db: router.Primary(), readers: router}
+}
+
+// reader returns the database that reads under ctx go to.
+func (repo *GormStudentRepo) reader(ctx context.Context) *gorm.DB {
+	if repo.readers == nil {
+		return repo.db.WithContext(ctx)
+	}
+	return repo.readers.Reader(ctx).WithContext(ctx)
+}
+
 
-func (repo *GormStudentRepo) Create(student *entities.ValidatedStudent) (*entities.Student,error) {
+func (repo *GormStudentRepo) Create(ctx context.Context, student *entities.ValidatedStudent) (*entities.Student,error) {
 	dbStudent := toDBStudent(student)
 
-	if err := repo.db.AutoMigrate(&DBStudent{}); err != nil {
-		log.Fatalf("Fail to auto migrate Postgres schema: %v", err)
-	}
+	repositories.MarkWrite(ctx)
+	err := repo.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(dbStudent).Error; err != nil {
+			return studentWriteError(tx, err)
+		}
 
-	if err := repo.db.Create(dbStudent).Error; err != nil {
+		changes := entities.DiffStudents(nil, &student.Student)
+		if err := writeAuditEntry(tx, entities.NewAuditEntry(ctx, dbStudent.StudentID, entities.AuditOperationCreate, changes)); err != nil {
+			return err
+		}
+		return writeOutboxEvents(tx, student.Events())
+	})
+	if err != nil {
 		return nil, err
 	}
+	student.ClearEvents()
+
+	return findById(repo.db.WithContext(ctx), dbStudent.StudentID)
+}
+
+func (repo *GormStudentRepo) CreateBatch(ctx context.Context, students []*entities.ValidatedStudent, batchSize int) error {
+	if len(students) == 0 {
+		return nil
+	}
+
+	dbStudents := make([]*DBStudent, len(students))
+	for i, student := range students {
+		dbStudents[i] = toDBStudent(student)
+	}
+
+	repositories.MarkWrite(ctx)
+	err := repo.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.CreateInBatches(dbStudents, batchSize).Error; err != nil {
+			return studentWriteError(tx, err)
+		}
+
+		for _, student := range students {
+			changes := entities.DiffStudents(nil, &student.Student)
+			if err := writeAuditEntry(tx, entities.NewAuditEntry(ctx, student.StudentID, entities.AuditOperationCreate, changes)); err != nil {
+				return err
+			}
+			if err := writeOutboxEvents(tx, student.Events()); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	for _, student := range students {
+		student.ClearEvents()
+	}
+	return nil
+}
 
-	return repo.FindById(dbStudent.StudentID)
+func (repo *GormStudentRepo) FindById(ctx context.Context, id uuid.UUID) (*entities.Student, error) {
+	return findById(repo.reader(ctx), id)
 }
 
-func (repo *GormStudentRepo) FindById(id uuid.UUID) (*entities.Student, error) {
+// findById reads from db, which writes pass as the primary: a replica may not
+// have the row they just wrote yet.
+func findById(db *gorm.DB, id uuid.UUID) (*entities.Student, error) {
 	var dbStudent DBStudent
-	if err := repo.db.First(&dbStudent, id).Error; err != nil {
+	if err := db.First(&dbStudent, id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, fmt.Errorf("%w: %w", repositories.ErrStudentNotFound, err)
+		}
 		return nil, err
 	}
 
@@ -44,9 +117,30 @@ func (repo *GormStudentRepo) FindById(id uuid.UUID) (*entities.Student, error) {
 }
 
 
-func (repo *GormStudentRepo) FindAll() ([]*entities.Student , error) {
+func (repo *GormStudentRepo) FindByIds(ctx context.Context, ids []uuid.UUID) ([]*entities.Student, error) {
+	if len(ids) == 0 {
+		return nil, nil
+	}
+
+	var dbStudents []DBStudent
+	if err := repo.reader(ctx).Where("student_id IN ?", ids).Find(&dbStudents).Error; err != nil {
+		return nil, err
+	}
+
+	students := make([]*entities.Student, len(dbStudents))
+	for i := range dbStudents {
+		students[i] = fromDBStudent(&dbStudents[i])
+	}
+	return students, nil
+}
+
+func (repo *GormStudentRepo) FindAll(ctx context.Context, filter repositories.StudentFilter) ([]*entities.Student , error) {
 	var dbStudents []DBStudent
-	if err := repo.db.Find(&dbStudents).Error;err != nil {
+	db := applyStudentFilter(repo.reader(ctx), filter)
+	if filter.Limit > 0 {
+		db = db.Order("created_at ASC, student_id ASC").Offset(filter.Offset).Limit(filter.Limit)
+	}
+	if err := db.Find(&dbStudents).Error;err != nil {
 		return nil, err
 	}
 
@@ -54,27 +148,219 @@ func (repo *GormStudentRepo) FindAll() ([]*entities.Student , error) {
 
 	for i, dbStudent := range dbStudents {
 		students[i] = fromDBStudent(&dbStudent)
-		fmt.Println(students[i])
 	}
 	return students,nil
 }
 
-func (repo *GormStudentRepo) Update(student *entities.ValidatedStudent) (*entities.Student, error) {
+func (repo *GormStudentRepo) Stream(ctx context.Context, filter repositories.StudentFilter, visit func(*entities.Student) error) error {
+	db := repo.reader(ctx)
+	rows, err := applyStudentFilter(db.Model(&DBStudent{}), filter).Order("created_at ASC, student_id ASC").Rows()
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var dbStudent DBStudent
+		if err := db.ScanRows(rows, &dbStudent); err != nil {
+			return err
+		}
+		if err := visit(fromDBStudent(&dbStudent)); err != nil {
+			return err
+		}
+	}
+	return rows.Err()
+}
+
+func applyStudentFilter(db *gorm.DB, filter repositories.StudentFilter) *gorm.DB {
+	if filter.Major != nil {
+		db = db.Where("major = ?", *filter.Major)
+	}
+	if filter.Status != nil {
+		db = db.Where("status = ?", string(*filter.Status))
+	}
+	// Times are stored in UTC; SQLite compares them as text, so the bounds
+	// must be in UTC too.
+	if filter.EnrolledFrom != nil {
+		db = db.Where("enrollment_date >= ?", filter.EnrolledFrom.UTC())
+	}
+	if filter.EnrolledTo != nil {
+		db = db.Where("enrollment_date <= ?", filter.EnrolledTo.UTC())
+	}
+	return db
+}
+
+func (repo *GormStudentRepo) Update(ctx context.Context, student *entities.ValidatedStudent) (*entities.Student, error) {
 	dbStudent := *toDBStudent(student)
 
 	// if err := repo.db.AutoMigrate(&DBStudent{}); err != nil {
 	// 	log.Fatalf("Fail to auto migrate Postgres schema: %v", err)
 	// }
-	if err := repo.db.Model(&DBStudent{}).Where("student_id = ?", dbStudent.StudentID).Omit("student_id").Updates(dbStudent).Error; err != nil {
+	repositories.MarkWrite(ctx)
+	err := repo.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		before, err := findForWrite(tx, dbStudent.StudentID, dbStudent.Version)
+		if err != nil {
+			return err
+		}
+
+		// The version condition catches writers that committed after our read.
+		result := tx.Model(&DBStudent{}).Where("student_id = ? AND version = ?", dbStudent.StudentID, dbStudent.Version).Updates(studentUpdateValues(&dbStudent))
+		if result.Error != nil {
+			return studentWriteError(tx, result.Error)
+		}
+		if result.RowsAffected == 0 {
+			return versionConflict(tx, dbStudent.StudentID, dbStudent.Version)
+		}
+
+		var after DBStudent
+		if err := tx.First(&after, dbStudent.StudentID).Error; err != nil {
+			return err
+		}
+
+		changes := entities.DiffStudents(fromDBStudent(before), fromDBStudent(&after))
+		if err := writeAuditEntry(tx, entities.NewAuditEntry(ctx, dbStudent.StudentID, entities.AuditOperationUpdate, changes)); err != nil {
+			return err
+		}
+		if err := writeStatusChanges(ctx, tx, student.StatusChanges()); err != nil {
+			return err
+		}
+		return writeOutboxEvents(tx, student.Events())
+	})
+	if err != nil {
 		return nil, err
 	}
+	student.ClearEvents()
+
+	return findById(repo.db.WithContext(ctx), dbStudent.StudentID)
 
-	return repo.FindById(dbStudent.StudentID)
-		
 }
 
-func (repo *GormStudentRepo) Delete(id uuid.UUID) error {
-	return repo.db.Delete(&DBStudent{}, id).Error
+// Delete soft deletes the student so that it can be restored later.
+func (repo *GormStudentRepo) Delete(ctx context.Context, id uuid.UUID, expectedVersion int) error {
+	repositories.MarkWrite(ctx)
+	return repo.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		before, err := findForWrite(tx, id, expectedVersion)
+		if err != nil {
+			return err
+		}
+
+		result := tx.Where("version = ?", expectedVersion).Delete(&DBStudent{}, id)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return versionConflict(tx, id, expectedVersion)
+		}
+
+		deleted := fromDBStudent(before)
+		deleted.MarkDeleted()
+
+		changes := entities.DiffStudents(deleted, nil)
+		if err := writeAuditEntry(tx, entities.NewAuditEntry(ctx, id, entities.AuditOperationDelete, changes)); err != nil {
+			return err
+		}
+		return writeOutboxEvents(tx, deleted.Events())
+	})
 }
 
+func (repo *GormStudentRepo) Restore(ctx context.Context, id uuid.UUID) (*entities.Student, error) {
+	repositories.MarkWrite(ctx)
+	err := repo.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var deleted DBStudent
+		if err := tx.Unscoped().Where("deleted_at IS NOT NULL").First(&deleted, id).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return fmt.Errorf("%w: no deleted student %s: %w", repositories.ErrStudentNotFound, id, err)
+			}
+			return err
+		}
+
+		restore := map[string]interface{}{"deleted_at": nil, "version": gorm.Expr("version + 1")}
+		if err := tx.Unscoped().Model(&DBStudent{}).Where("student_id = ?", id).Updates(restore).Error; err != nil {
+			return studentWriteError(tx, err)
+		}
+
+		restored := fromDBStudent(&deleted)
+		restored.MarkRestored()
+
+		changes := entities.DiffStudents(nil, restored)
+		if err := writeAuditEntry(tx, entities.NewAuditEntry(ctx, id, entities.AuditOperationRestore, changes)); err != nil {
+			return err
+		}
+		return writeOutboxEvents(tx, restored.Events())
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return findById(repo.db.WithContext(ctx), id)
+}
+
+// findForWrite loads the live student and checks that the caller saw its
+// current version.
+func findForWrite(tx *gorm.DB, id uuid.UUID, expectedVersion int) (*DBStudent, error) {
+	var stored DBStudent
+	if err := tx.First(&stored, id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, fmt.Errorf("%w: %w", repositories.ErrStudentNotFound, err)
+		}
+		return nil, err
+	}
+	if stored.Version != expectedVersion {
+		return nil, &repositories.VersionConflictError{StudentID: id, ExpectedVersion: expectedVersion, CurrentVersion: stored.Version}
+	}
+	return &stored, nil
+}
+
+func versionConflict(tx *gorm.DB, id uuid.UUID, expectedVersion int) error {
+	conflict := &repositories.VersionConflictError{StudentID: id, ExpectedVersion: expectedVersion}
+	var current DBStudent
+	if err := tx.Unscoped().Select("version").First(&current, id).Error; err == nil {
+		conflict.CurrentVersion = current.Version
+	}
+	return conflict
+}
+
+// studentUpdateValues lists every updatable column, so that nil and empty
+// values are written too, which a plain Updates(struct) silently skips.
+func studentUpdateValues(dbStudent *DBStudent) map[string]interface{} {
+	return map[string]interface{}{
+		"first_name": 		dbStudent.FirstName,
+		"last_name": 		dbStudent.LastName,
+		"date_of_birth": 	dbStudent.DateOfBirth,
+		"email": 			dbStudent.Email,
+		"email_verified_at": dbStudent.EmailVerifiedAt,
+		"phone": 			dbStudent.Phone,
+		"major": 			dbStudent.Major,
+		"locale": 			dbStudent.Locale,
+		"programs": 		dbStudent.Programs,
+		"contacts": 		dbStudent.Contacts,
+		"enrollment_date": 	dbStudent.EnrollmentDate,
+		"status": 			dbStudent.Status,
+		"status_reason": 	dbStudent.StatusReason,
+		"status_effective_date": dbStudent.StatusEffectiveDate,
+		"updated_at": 		dbStudent.UpdatedAt,
+		"version": 			gorm.Expr("version + 1"),
+	}
+}
+
+// writeStatusChanges adds the transitions to the status history, attributed
+// like the audit entry written with them.
+func writeStatusChanges(ctx context.Context, tx *gorm.DB, changes []entities.StudentStatusChange) error {
+	actor, requestID := entities.AuditActorFromContext(ctx)
+	for _, change := range changes {
+		change.Actor, change.RequestID = actor, requestID
+		if err := tx.Create(toDBStudentStatusChange(&change)).Error; err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func writeAuditEntry(tx *gorm.DB, entry *entities.AuditEntry) error {
+	dbEntry, err := toDBAuditEntry(entry)
+	if err != nil {
+		return err
+	}
+	return tx.Create(dbEntry).Error
+}
 
@@ -0,0 +1,108 @@
+package postgres
+
+import (
+	"context"
+	"sort"
+
+	"github.com/tranvu1111/go-students-new/internal/domain/entities"
+	"github.com/tranvu1111/go-students-new/internal/domain/repositories"
+	"github.com/tranvu1111/go-students-new/internal/domain/search"
+	"gorm.io/gorm"
+)
+
+// studentSearchDocument must stay identical to the expression indexed in
+// migratePostgresSearch, otherwise Postgres cannot use the index.
+const studentSearchDocument = `to_tsvector('simple', coalesce(first_name, '') || ' ' || coalesce(last_name, '') || ' ' || coalesce(email, '') || ' ' || coalesce(major, ''))`
+
+// Full-text matches rank first; trigram word similarity catches typos and
+// partial words that the text search misses.
+const postgresStudentSearch = `
+SELECT *,
+	ts_rank(` + studentSearchDocument + `, plainto_tsquery('simple', @query))
+	+ greatest(
+		word_similarity(@query, first_name),
+		word_similarity(@query, last_name),
+		0.8 * word_similarity(@query, email),
+		0.5 * word_similarity(@query, coalesce(major, ''))
+	) AS score
+FROM db_students
+WHERE deleted_at IS NULL
+	AND (
+		` + studentSearchDocument + ` @@ plainto_tsquery('simple', @query)
+		OR @query <% first_name
+		OR @query <% last_name
+		OR @query <% email
+		OR @query <% coalesce(major, '')
+	)
+ORDER BY score DESC, student_id ASC
+LIMIT @limit`
+
+type dbStudentSearchRow struct {
+	DBStudent 	`gorm:"embedded"`
+	Score 		float64
+}
+
+func (repo *GormStudentRepo) Search(ctx context.Context, query string, limit int) ([]repositories.StudentSearchHit, error) {
+	if repo.db.Dialector.Name() == "postgres" {
+		return repo.searchPostgres(ctx, query, limit)
+	}
+	return repo.searchPortable(ctx, query, limit)
+}
+
+func (repo *GormStudentRepo) searchPostgres(ctx context.Context, query string, limit int) ([]repositories.StudentSearchHit, error) {
+	var rows []dbStudentSearchRow
+	err := repo.reader(ctx).Raw(postgresStudentSearch, map[string]interface{}{
+		"query": query,
+		"limit": limit,
+	}).Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+
+	hits := make([]repositories.StudentSearchHit, len(rows))
+	for i := range rows {
+		hits[i] = repositories.StudentSearchHit{Student: fromDBStudent(&rows[i].DBStudent), Score: rows[i].Score}
+	}
+	return hits, nil
+}
+
+// searchPortable scores every student in Go. It serves databases without
+// full-text search, such as SQLite, where tables are small enough to scan.
+func (repo *GormStudentRepo) searchPortable(ctx context.Context, query string, limit int) ([]repositories.StudentSearchHit, error) {
+	var hits []repositories.StudentSearchHit
+	err := repo.Stream(ctx, repositories.StudentFilter{}, func(student *entities.Student) error {
+		if score := search.Score(query, student); score > 0 {
+			hits = append(hits, repositories.StudentSearchHit{Student: student, Score: score})
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	sort.SliceStable(hits, func(i, j int) bool {
+		return hits[i].Score > hits[j].Score
+	})
+	if len(hits) > limit {
+		hits = hits[:limit]
+	}
+	return hits, nil
+}
+
+// migratePostgresSearch installs pg_trgm and the indexes behind Search.
+func migratePostgresSearch(db *gorm.DB) error {
+	statements := []string{
+		`CREATE EXTENSION IF NOT EXISTS pg_trgm`,
+		`CREATE INDEX IF NOT EXISTS idx_db_students_search ON db_students USING GIN (` + studentSearchDocument + `)`,
+		`CREATE INDEX IF NOT EXISTS idx_db_students_first_name_trgm ON db_students USING GIN (first_name gin_trgm_ops)`,
+		`CREATE INDEX IF NOT EXISTS idx_db_students_last_name_trgm ON db_students USING GIN (last_name gin_trgm_ops)`,
+		`CREATE INDEX IF NOT EXISTS idx_db_students_email_trgm ON db_students USING GIN (email gin_trgm_ops)`,
+		`CREATE INDEX IF NOT EXISTS idx_db_students_major_trgm ON db_students USING GIN (coalesce(major, '') gin_trgm_ops)`,
+	}
+	for _, statement := range statements {
+		if err := db.Exec(statement).Error; err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,38 @@
+package postgres
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/tranvu1111/go-students-new/internal/domain/entities"
+	"github.com/tranvu1111/go-students-new/internal/domain/repositories"
+	"gorm.io/gorm"
+)
+
+type GormStudentStatusRepo struct {
+	db *gorm.DB
+}
+
+func NewGormStudentStatusRepo(db *gorm.DB) repositories.StudentStatusRepository {
+	return &GormStudentStatusRepo{db: db}
+}
+
+func (repo *GormStudentStatusRepo) FindByStudentId(ctx context.Context, studentID uuid.UUID, offset int, limit int) ([]*entities.StudentStatusChange, int64, error) {
+	query := repo.db.WithContext(ctx).Model(&DBStudentStatusChange{}).Where("student_id = ?", studentID).Session(&gorm.Session{})
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	var dbChanges []DBStudentStatusChange
+	if err := query.Order("recorded_at DESC").Offset(offset).Limit(limit).Find(&dbChanges).Error; err != nil {
+		return nil, 0, err
+	}
+
+	changes := make([]*entities.StudentStatusChange, len(dbChanges))
+	for i := range dbChanges {
+		changes[i] = fromDBStudentStatusChange(&dbChanges[i])
+	}
+	return changes, total, nil
+}
@@ -1,19 +1,35 @@
 package postgres
 
-import "github.com/tranvu1111/go-students-new/internal/domain/entities"
+import (
+	"encoding/json"
+	"strings"
+	"time"
 
+	"github.com/tranvu1111/go-students-new/internal/domain/entities"
+)
+
+// Students are stored and read back in UTC, so that Postgres, which returns
+// local times, and SQLite, which returns the stored offset, agree.
 func toDBStudent(validStudent *entities.ValidatedStudent) *DBStudent {
 	return &DBStudent{
 		StudentID: 		validStudent.StudentID,
 		FirstName: 		validStudent.FirstName,
 		LastName: 		validStudent.LastName,
-		DateOfBirth: 	validStudent.DateOfBirth,
+		DateOfBirth: 	utcTime(validStudent.DateOfBirth),
 		Email: 			validStudent.Email,
+		EmailVerifiedAt: utcTime(validStudent.EmailVerifiedAt),
 		Phone: 			validStudent.Phone,
 		Major: 			validStudent.Major,
-		EnrollmentDate: validStudent.EnrollmentDate,
-		CreatedAt: 		validStudent.CreatedAt,
-		UpdatedAt: 		validStudent.UpdatedAt,
+		Locale: 		validStudent.Locale,
+		Programs: 		toDBStudentPrograms(validStudent.Programs),
+		Contacts: 		toDBStudentContacts(validStudent.Contacts),
+		EnrollmentDate: validStudent.EnrollmentDate.UTC(),
+		Status: 		string(validStudent.Status),
+		StatusReason: 	validStudent.StatusReason,
+		StatusEffectiveDate: utcTime(&validStudent.StatusEffectiveDate),
+		CreatedAt: 		validStudent.CreatedAt.UTC(),
+		UpdatedAt: 		validStudent.UpdatedAt.UTC(),
+		Version: 		validStudent.Version,
 	}
 }
 
@@ -22,13 +38,417 @@ func fromDBStudent(dbStudent *DBStudent) *entities.Student {
 		StudentID: dbStudent.StudentID,
 		FirstName: dbStudent.FirstName,
 		LastName: dbStudent.LastName,
-		DateOfBirth: dbStudent.DateOfBirth,
+		DateOfBirth: utcTime(dbStudent.DateOfBirth),
 		Email: dbStudent.Email,
+		EmailVerifiedAt: utcTime(dbStudent.EmailVerifiedAt),
 		Phone: dbStudent.Phone,
 		Major: dbStudent.Major,
-		EnrollmentDate: dbStudent.EnrollmentDate,
-		CreatedAt: dbStudent.CreatedAt,
-		UpdatedAt: dbStudent.UpdatedAt,
+		Locale: dbStudent.Locale,
+		Programs: fromDBStudentPrograms(dbStudent.Programs),
+		Contacts: fromDBStudentContacts(dbStudent.Contacts),
+		EnrollmentDate: dbStudent.EnrollmentDate.UTC(),
+		Status: entities.StudentStatus(dbStudent.Status),
+		StatusReason: dbStudent.StatusReason,
+		StatusEffectiveDate: dbStudent.EnrollmentDate.UTC(),
+		CreatedAt: dbStudent.CreatedAt.UTC(),
+		UpdatedAt: dbStudent.UpdatedAt.UTC(),
+		Version: dbStudent.Version,
+	}
+	if dbStudent.StatusEffectiveDate != nil {
+		s.StatusEffectiveDate = dbStudent.StatusEffectiveDate.UTC()
 	}
+	s.AcceptStoredPhone()
 	return s
-}
\ No newline at end of file
+}
+
+func toDBStudentPrograms(programs []entities.StudentProgram) DBStudentPrograms {
+	if len(programs) == 0 {
+		return nil
+	}
+	dbPrograms := make(DBStudentPrograms, len(programs))
+	for i, program := range programs {
+		dbPrograms[i] = DBStudentProgram{
+			ProgramID: 	program.ProgramID,
+			Kind: 		string(program.Kind),
+			DeclaredAt: program.DeclaredAt.UTC(),
+		}
+	}
+	return dbPrograms
+}
+
+func fromDBStudentPrograms(dbPrograms DBStudentPrograms) []entities.StudentProgram {
+	if len(dbPrograms) == 0 {
+		return nil
+	}
+	programs := make([]entities.StudentProgram, len(dbPrograms))
+	for i, dbProgram := range dbPrograms {
+		programs[i] = entities.StudentProgram{
+			ProgramID: dbProgram.ProgramID,
+			Kind: entities.ProgramKind(dbProgram.Kind),
+			DeclaredAt: dbProgram.DeclaredAt.UTC(),
+		}
+	}
+	return programs
+}
+
+func toDBStudentContacts(contacts entities.StudentContacts) DBStudentContacts {
+	var dbContacts DBStudentContacts
+	for _, address := range contacts.Addresses {
+		dbContacts.Addresses = append(dbContacts.Addresses, DBAddress{
+			ID: 		address.ID,
+			Type: 		string(address.Type),
+			Line1: 		address.Line1,
+			Line2: 		address.Line2,
+			City: 		address.City,
+			Region: 	address.Region,
+			PostalCode: address.PostalCode,
+			Country: 	address.Country,
+			ValidFrom: 	address.ValidFrom.UTC(),
+			ValidTo: 	utcTime(address.ValidTo),
+		})
+	}
+	for _, email := range contacts.Emails {
+		dbContacts.Emails = append(dbContacts.Emails, DBContactEmail{
+			ID: 			email.ID,
+			Address: 		email.Address,
+			Primary: 		email.Primary,
+			Verification: 	string(email.Verification),
+			VerifiedAt: 	utcTime(email.VerifiedAt),
+		})
+	}
+	for _, phone := range contacts.Phones {
+		dbContacts.Phones = append(dbContacts.Phones, DBContactPhone{
+			ID: 			phone.ID,
+			Number: 		phone.Number,
+			Primary: 		phone.Primary,
+			Verification: 	string(phone.Verification),
+			VerifiedAt: 	utcTime(phone.VerifiedAt),
+		})
+	}
+	for _, contact := range contacts.EmergencyContacts {
+		dbContacts.EmergencyContacts = append(dbContacts.EmergencyContacts, DBEmergencyContact{
+			ID: 			contact.ID,
+			Name: 			contact.Name,
+			Relationship: 	contact.Relationship,
+			Phone: 			contact.Phone,
+			Email: 			contact.Email,
+		})
+	}
+	return dbContacts
+}
+
+func fromDBStudentContacts(dbContacts DBStudentContacts) entities.StudentContacts {
+	var contacts entities.StudentContacts
+	for _, address := range dbContacts.Addresses {
+		contacts.Addresses = append(contacts.Addresses, entities.Address{
+			ID: address.ID,
+			Type: entities.AddressType(address.Type),
+			Line1: address.Line1,
+			Line2: address.Line2,
+			City: address.City,
+			Region: address.Region,
+			PostalCode: address.PostalCode,
+			Country: address.Country,
+			ValidFrom: address.ValidFrom.UTC(),
+			ValidTo: utcTime(address.ValidTo),
+		})
+	}
+	for _, email := range dbContacts.Emails {
+		contacts.Emails = append(contacts.Emails, entities.ContactEmail{
+			ID: email.ID,
+			Address: email.Address,
+			Primary: email.Primary,
+			Verification: entities.VerificationState(email.Verification),
+			VerifiedAt: utcTime(email.VerifiedAt),
+		})
+	}
+	for _, phone := range dbContacts.Phones {
+		contacts.Phones = append(contacts.Phones, entities.ContactPhone{
+			ID: phone.ID,
+			Number: phone.Number,
+			Primary: phone.Primary,
+			Verification: entities.VerificationState(phone.Verification),
+			VerifiedAt: utcTime(phone.VerifiedAt),
+		})
+	}
+	for _, contact := range dbContacts.EmergencyContacts {
+		contacts.EmergencyContacts = append(contacts.EmergencyContacts, entities.EmergencyContact{
+			ID: contact.ID,
+			Name: contact.Name,
+			Relationship: contact.Relationship,
+			Phone: contact.Phone,
+			Email: contact.Email,
+		})
+	}
+	return contacts
+}
+
+func toDBProgram(program *entities.Program) *DBProgram {
+	return &DBProgram{
+		ID: 			program.ID,
+		Code: 			program.Code,
+		Name: 			program.Name,
+		Department: 	program.Department,
+		DegreeLevel: 	string(program.DegreeLevel),
+		Active: 		program.Active,
+		CreatedAt: 		program.CreatedAt.UTC(),
+		UpdatedAt: 		program.UpdatedAt.UTC(),
+	}
+}
+
+func fromDBProgram(dbProgram *DBProgram) *entities.Program {
+	return &entities.Program{
+		ID: dbProgram.ID,
+		Code: dbProgram.Code,
+		Name: dbProgram.Name,
+		Department: dbProgram.Department,
+		DegreeLevel: entities.DegreeLevel(dbProgram.DegreeLevel),
+		Active: dbProgram.Active,
+		CreatedAt: dbProgram.CreatedAt.UTC(),
+		UpdatedAt: dbProgram.UpdatedAt.UTC(),
+	}
+}
+
+func utcTime(t *time.Time) *time.Time {
+	if t == nil {
+		return nil
+	}
+	utc := t.UTC()
+	return &utc
+}
+
+func toDBAuditEntry(entry *entities.AuditEntry) (*DBAuditEntry, error) {
+	changes, err := json.Marshal(entry.Changes)
+	if err != nil {
+		return nil, err
+	}
+
+	return &DBAuditEntry{
+		ID: 		entry.ID,
+		StudentID: 	entry.StudentID,
+		Actor: 		entry.Actor,
+		RequestID: 	entry.RequestID,
+		Operation: 	string(entry.Operation),
+		Changes: 	string(changes),
+		OccurredAt: entry.OccurredAt,
+	}, nil
+}
+
+func fromDBAuditEntry(dbEntry *DBAuditEntry) (*entities.AuditEntry, error) {
+	var changes []entities.FieldChange
+	if dbEntry.Changes != "" {
+		if err := json.Unmarshal([]byte(dbEntry.Changes), &changes); err != nil {
+			return nil, err
+		}
+	}
+
+	return &entities.AuditEntry{
+		ID: dbEntry.ID,
+		StudentID: dbEntry.StudentID,
+		Actor: dbEntry.Actor,
+		RequestID: dbEntry.RequestID,
+		Operation: entities.AuditOperation(dbEntry.Operation),
+		Changes: changes,
+		OccurredAt: dbEntry.OccurredAt,
+	}, nil
+}
+
+func toDBStudentStatusChange(change *entities.StudentStatusChange) *DBStudentStatusChange {
+	return &DBStudentStatusChange{
+		ID: 			change.ID,
+		StudentID: 		change.StudentID,
+		FromStatus: 	string(change.From),
+		ToStatus: 		string(change.To),
+		Reason: 		change.Reason,
+		EffectiveDate: 	change.EffectiveDate.UTC(),
+		Actor: 			change.Actor,
+		RequestID: 		change.RequestID,
+		RecordedAt: 	change.RecordedAt.UTC(),
+	}
+}
+
+func fromDBStudentStatusChange(dbChange *DBStudentStatusChange) *entities.StudentStatusChange {
+	return &entities.StudentStatusChange{
+		ID: dbChange.ID,
+		StudentID: dbChange.StudentID,
+		From: entities.StudentStatus(dbChange.FromStatus),
+		To: entities.StudentStatus(dbChange.ToStatus),
+		Reason: dbChange.Reason,
+		EffectiveDate: dbChange.EffectiveDate.UTC(),
+		Actor: dbChange.Actor,
+		RequestID: dbChange.RequestID,
+		RecordedAt: dbChange.RecordedAt.UTC(),
+	}
+}
+
+func toDBOutboxMessage(message *entities.OutboxMessage) *DBOutboxMessage {
+	return &DBOutboxMessage{
+		ID: 			message.ID,
+		EventType: 		message.EventType,
+		AggregateID: 	message.AggregateID,
+		Payload: 		message.Payload,
+		OccurredAt: 	message.OccurredAt,
+		PublishedAt: 	message.PublishedAt,
+		Attempts: 		message.Attempts,
+		LastError: 		message.LastError,
+		NextAttemptAt: 	&message.NextAttemptAt,
+		DeadAt: 		message.DeadAt,
+	}
+}
+
+func fromDBOutboxMessage(dbMessage *DBOutboxMessage) *entities.OutboxMessage {
+	message := &entities.OutboxMessage{
+		ID: dbMessage.ID,
+		EventType: dbMessage.EventType,
+		AggregateID: dbMessage.AggregateID,
+		Payload: dbMessage.Payload,
+		OccurredAt: dbMessage.OccurredAt,
+		PublishedAt: dbMessage.PublishedAt,
+		Attempts: dbMessage.Attempts,
+		LastError: dbMessage.LastError,
+		DeadAt: dbMessage.DeadAt,
+	}
+	if dbMessage.NextAttemptAt != nil {
+		message.NextAttemptAt = *dbMessage.NextAttemptAt
+	}
+	return message
+}
+
+func toDBWebhookSubscription(subscription *entities.WebhookSubscription) *DBWebhookSubscription {
+	return &DBWebhookSubscription{
+		ID: 		subscription.ID,
+		URL: 		subscription.URL,
+		EventTypes: strings.Join(subscription.EventTypes, ","),
+		Secret: 	subscription.Secret,
+		Active: 	subscription.Active,
+		CreatedAt: 	subscription.CreatedAt,
+		UpdatedAt: 	subscription.UpdatedAt,
+	}
+}
+
+func fromDBWebhookSubscription(dbSubscription *DBWebhookSubscription) *entities.WebhookSubscription {
+	var eventTypes []string
+	if dbSubscription.EventTypes != "" {
+		eventTypes = strings.Split(dbSubscription.EventTypes, ",")
+	}
+
+	return &entities.WebhookSubscription{
+		ID: dbSubscription.ID,
+		URL: dbSubscription.URL,
+		EventTypes: eventTypes,
+		Secret: dbSubscription.Secret,
+		Active: dbSubscription.Active,
+		CreatedAt: dbSubscription.CreatedAt,
+		UpdatedAt: dbSubscription.UpdatedAt,
+	}
+}
+
+func toDBWebhookDelivery(delivery *entities.WebhookDelivery) *DBWebhookDelivery {
+	return &DBWebhookDelivery{
+		ID: 			delivery.ID,
+		SubscriptionID: delivery.SubscriptionID,
+		EventID: 		delivery.EventID,
+		EventType: 		delivery.EventType,
+		Payload: 		delivery.Payload,
+		Status: 		string(delivery.Status),
+		Attempts: 		delivery.Attempts,
+		NextAttemptAt: 	delivery.NextAttemptAt,
+		LastStatusCode: delivery.LastStatusCode,
+		LastError: 		delivery.LastError,
+		CreatedAt: 		delivery.CreatedAt,
+		UpdatedAt: 		delivery.UpdatedAt,
+	}
+}
+
+func fromDBWebhookDelivery(dbDelivery *DBWebhookDelivery) *entities.WebhookDelivery {
+	return &entities.WebhookDelivery{
+		ID: dbDelivery.ID,
+		SubscriptionID: dbDelivery.SubscriptionID,
+		EventID: dbDelivery.EventID,
+		EventType: dbDelivery.EventType,
+		Payload: dbDelivery.Payload,
+		Status: entities.WebhookDeliveryStatus(dbDelivery.Status),
+		Attempts: dbDelivery.Attempts,
+		NextAttemptAt: dbDelivery.NextAttemptAt,
+		LastStatusCode: dbDelivery.LastStatusCode,
+		LastError: dbDelivery.LastError,
+		CreatedAt: dbDelivery.CreatedAt,
+		UpdatedAt: dbDelivery.UpdatedAt,
+	}
+}
+
+func toDBNotificationTemplate(template *entities.NotificationTemplate) *DBNotificationTemplate {
+	return &DBNotificationTemplate{
+		ID: 		template.ID,
+		EventType: 	template.EventType,
+		Channel: 	string(template.Channel),
+		Locale: 	template.Locale,
+		Subject: 	template.Subject,
+		Body: 		template.Body,
+		HTMLBody: 	template.HTMLBody,
+		Active: 	template.Active,
+		CreatedAt: 	template.CreatedAt,
+		UpdatedAt: 	template.UpdatedAt,
+	}
+}
+
+func fromDBNotificationTemplate(dbTemplate *DBNotificationTemplate) *entities.NotificationTemplate {
+	return &entities.NotificationTemplate{
+		ID: dbTemplate.ID,
+		EventType: dbTemplate.EventType,
+		Channel: entities.NotificationChannel(dbTemplate.Channel),
+		Locale: dbTemplate.Locale,
+		Subject: dbTemplate.Subject,
+		Body: dbTemplate.Body,
+		HTMLBody: dbTemplate.HTMLBody,
+		Active: dbTemplate.Active,
+		CreatedAt: dbTemplate.CreatedAt,
+		UpdatedAt: dbTemplate.UpdatedAt,
+	}
+}
+
+func toDBNotification(notification *entities.Notification) *DBNotification {
+	return &DBNotification{
+		ID: 			notification.ID,
+		TemplateID: 	notification.TemplateID,
+		EventID: 		notification.EventID,
+		EventType: 		notification.EventType,
+		StudentID: 		notification.StudentID,
+		Channel: 		string(notification.Channel),
+		Locale: 		notification.Locale,
+		Recipient: 		notification.Recipient,
+		Subject: 		notification.Subject,
+		Text: 			notification.Text,
+		HTML: 			notification.HTML,
+		Status: 		string(notification.Status),
+		Attempts: 		notification.Attempts,
+		NextAttemptAt: 	notification.NextAttemptAt,
+		LastError: 		notification.LastError,
+		SentAt: 		notification.SentAt,
+		CreatedAt: 		notification.CreatedAt,
+		UpdatedAt: 		notification.UpdatedAt,
+	}
+}
+
+func fromDBNotification(dbNotification *DBNotification) *entities.Notification {
+	return &entities.Notification{
+		ID: dbNotification.ID,
+		TemplateID: dbNotification.TemplateID,
+		EventID: dbNotification.EventID,
+		EventType: dbNotification.EventType,
+		StudentID: dbNotification.StudentID,
+		Channel: entities.NotificationChannel(dbNotification.Channel),
+		Locale: dbNotification.Locale,
+		Recipient: dbNotification.Recipient,
+		Subject: dbNotification.Subject,
+		Text: dbNotification.Text,
+		HTML: dbNotification.HTML,
+		Status: entities.NotificationStatus(dbNotification.Status),
+		Attempts: dbNotification.Attempts,
+		NextAttemptAt: dbNotification.NextAttemptAt,
+		LastError: dbNotification.LastError,
+		SentAt: dbNotification.SentAt,
+		CreatedAt: dbNotification.CreatedAt,
+		UpdatedAt: dbNotification.UpdatedAt,
+	}
+}
@@ -0,0 +1,35 @@
+package postgres
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/tranvu1111/go-students-new/internal/domain/repositories"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type GormVerificationEmailRepo struct {
+	db *gorm.DB
+}
+
+func NewGormVerificationEmailRepo(db *gorm.DB) repositories.VerificationEmailRepository {
+	return &GormVerificationEmailRepo{db: db}
+}
+
+func (repo *GormVerificationEmailRepo) WasSent(ctx context.Context, messageID uuid.UUID) (bool, error) {
+	var count int64
+	if err := repo.db.WithContext(ctx).Model(&DBVerificationEmail{}).Where("message_id = ?", messageID).Count(&count).Error; err != nil {
+		return false, err
+	}
+	return count > 0, nil
+}
+
+func (repo *GormVerificationEmailRepo) RecordSent(ctx context.Context, messageID uuid.UUID, studentID uuid.UUID, sentAt time.Time) error {
+	return repo.db.WithContext(ctx).Clauses(clause.OnConflict{DoNothing: true}).Create(&DBVerificationEmail{
+		MessageID: messageID,
+		StudentID: studentID,
+		SentAt:    sentAt,
+	}).Error
+}
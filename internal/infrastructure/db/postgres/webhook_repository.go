@@ -0,0 +1,135 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/tranvu1111/go-students-new/internal/domain/entities"
+	"github.com/tranvu1111/go-students-new/internal/domain/repositories"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type GormWebhookSubscriptionRepo struct {
+	db *gorm.DB
+}
+
+func NewGormWebhookSubscriptionRepo(db *gorm.DB) repositories.WebhookSubscriptionRepository {
+	return &GormWebhookSubscriptionRepo{db: db}
+}
+
+func (repo *GormWebhookSubscriptionRepo) Create(ctx context.Context, subscription *entities.WebhookSubscription) (*entities.WebhookSubscription, error) {
+	dbSubscription := toDBWebhookSubscription(subscription)
+	if err := repo.db.WithContext(ctx).Create(dbSubscription).Error; err != nil {
+		return nil, err
+	}
+	return repo.FindById(ctx, dbSubscription.ID)
+}
+
+func (repo *GormWebhookSubscriptionRepo) FindById(ctx context.Context, id uuid.UUID) (*entities.WebhookSubscription, error) {
+	var dbSubscription DBWebhookSubscription
+	if err := repo.db.WithContext(ctx).First(&dbSubscription, id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, fmt.Errorf("%w: %w", repositories.ErrWebhookSubscriptionNotFound, err)
+		}
+		return nil, err
+	}
+	return fromDBWebhookSubscription(&dbSubscription), nil
+}
+
+func (repo *GormWebhookSubscriptionRepo) FindAll(ctx context.Context) ([]*entities.WebhookSubscription, error) {
+	var dbSubscriptions []DBWebhookSubscription
+	if err := repo.db.WithContext(ctx).Order("created_at ASC").Find(&dbSubscriptions).Error; err != nil {
+		return nil, err
+	}
+
+	subscriptions := make([]*entities.WebhookSubscription, len(dbSubscriptions))
+	for i := range dbSubscriptions {
+		subscriptions[i] = fromDBWebhookSubscription(&dbSubscriptions[i])
+	}
+	return subscriptions, nil
+}
+
+func (repo *GormWebhookSubscriptionRepo) Update(ctx context.Context, subscription *entities.WebhookSubscription) (*entities.WebhookSubscription, error) {
+	if err := repo.db.WithContext(ctx).Save(toDBWebhookSubscription(subscription)).Error; err != nil {
+		return nil, err
+	}
+	return repo.FindById(ctx, subscription.ID)
+}
+
+func (repo *GormWebhookSubscriptionRepo) Delete(ctx context.Context, id uuid.UUID) error {
+	result := repo.db.WithContext(ctx).Delete(&DBWebhookSubscription{}, id)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return repositories.ErrWebhookSubscriptionNotFound
+	}
+	return nil
+}
+
+type GormWebhookDeliveryRepo struct {
+	db *gorm.DB
+}
+
+func NewGormWebhookDeliveryRepo(db *gorm.DB) repositories.WebhookDeliveryRepository {
+	return &GormWebhookDeliveryRepo{db: db}
+}
+
+// Create enqueues deliveries. An event already enqueued for a subscription is
+// skipped, so the outbox relay may safely publish the same event twice.
+func (repo *GormWebhookDeliveryRepo) Create(ctx context.Context, deliveries []*entities.WebhookDelivery) error {
+	if len(deliveries) == 0 {
+		return nil
+	}
+
+	dbDeliveries := make([]*DBWebhookDelivery, len(deliveries))
+	for i, delivery := range deliveries {
+		dbDeliveries[i] = toDBWebhookDelivery(delivery)
+	}
+	return repo.db.WithContext(ctx).Clauses(clause.OnConflict{DoNothing: true}).Create(dbDeliveries).Error
+}
+
+func (repo *GormWebhookDeliveryRepo) FindDue(ctx context.Context, now time.Time, limit int) ([]*entities.WebhookDelivery, error) {
+	var dbDeliveries []DBWebhookDelivery
+	err := repo.db.WithContext(ctx).
+		Where("status = ? AND next_attempt_at <= ?", string(entities.WebhookDeliveryPending), now).
+		Order("next_attempt_at ASC").Limit(limit).Find(&dbDeliveries).Error
+	if err != nil {
+		return nil, err
+	}
+
+	deliveries := make([]*entities.WebhookDelivery, len(dbDeliveries))
+	for i := range dbDeliveries {
+		deliveries[i] = fromDBWebhookDelivery(&dbDeliveries[i])
+	}
+	return deliveries, nil
+}
+
+func (repo *GormWebhookDeliveryRepo) Update(ctx context.Context, delivery *entities.WebhookDelivery) error {
+	return repo.db.WithContext(ctx).Save(toDBWebhookDelivery(delivery)).Error
+}
+
+// FindBySubscriptionId returns the delivery log, newest first.
+func (repo *GormWebhookDeliveryRepo) FindBySubscriptionId(ctx context.Context, subscriptionID uuid.UUID, offset int, limit int) ([]*entities.WebhookDelivery, int64, error) {
+	query := repo.db.WithContext(ctx).Model(&DBWebhookDelivery{}).Where("subscription_id = ?", subscriptionID).Session(&gorm.Session{})
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	var dbDeliveries []DBWebhookDelivery
+	if err := query.Order("created_at DESC").Offset(offset).Limit(limit).Find(&dbDeliveries).Error; err != nil {
+		return nil, 0, err
+	}
+
+	deliveries := make([]*entities.WebhookDelivery, len(dbDeliveries))
+	for i := range dbDeliveries {
+		deliveries[i] = fromDBWebhookDelivery(&dbDeliveries[i])
+	}
+	return deliveries, total, nil
+}
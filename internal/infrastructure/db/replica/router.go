@@ -0,0 +1,112 @@
+// Package replica routes reads to read replicas of the primary database,
+// falling back to the primary while no replica is healthy.
+package replica
+
+import (
+	"context"
+	"log"
+	"sync/atomic"
+	"time"
+
+	"gorm.io/gorm"
+
+	"github.com/tranvu1111/go-students-new/internal/domain/repositories"
+)
+
+const (
+	defaultCheckInterval = 10 * time.Second
+	pingTimeout          = 2 * time.Second
+)
+
+// Router hands out the primary for writes and a healthy replica, round robin,
+// for reads. Replicas count as healthy until a health check fails.
+type Router struct {
+	primary  *gorm.DB
+	replicas []*replica
+	next     atomic.Uint64
+}
+
+type replica struct {
+	db      *gorm.DB
+	healthy atomic.Bool
+}
+
+func NewRouter(primary *gorm.DB, replicas ...*gorm.DB) *Router {
+	router := &Router{primary: primary}
+	for _, db := range replicas {
+		r := &replica{db: db}
+		r.healthy.Store(true)
+		router.replicas = append(router.replicas, r)
+	}
+	return router
+}
+
+func (r *Router) Primary() *gorm.DB {
+	return r.primary
+}
+
+// Reader returns the database that reads under ctx go to: the primary when
+// the request needs a consistent read or no replica is healthy, a replica
+// otherwise.
+func (r *Router) Reader(ctx context.Context) *gorm.DB {
+	if len(r.replicas) == 0 || repositories.NeedsConsistentRead(ctx) {
+		return r.primary
+	}
+
+	start := r.next.Add(1)
+	for i := range r.replicas {
+		candidate := r.replicas[(start+uint64(i))%uint64(len(r.replicas))]
+		if candidate.healthy.Load() {
+			return candidate.db
+		}
+	}
+	return r.primary
+}
+
+// CheckHealth pings every replica and returns how many are healthy.
+func (r *Router) CheckHealth(ctx context.Context) int {
+	healthy := 0
+	for i, candidate := range r.replicas {
+		err := ping(ctx, candidate.db)
+		if was := candidate.healthy.Swap(err == nil); was != (err == nil) {
+			if err != nil {
+				log.Printf("read replica %d is unhealthy, reading from the primary instead: %v", i, err)
+			} else {
+				log.Printf("read replica %d is healthy again", i)
+			}
+		}
+		if err == nil {
+			healthy++
+		}
+	}
+	return healthy
+}
+
+// Run checks the replicas every interval until ctx is cancelled.
+func (r *Router) Run(ctx context.Context, interval time.Duration) {
+	if interval <= 0 {
+		interval = defaultCheckInterval
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		r.CheckHealth(ctx)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func ping(ctx context.Context, db *gorm.DB) error {
+	sqlDB, err := db.DB()
+	if err != nil {
+		return err
+	}
+	ctx, cancel := context.WithTimeout(ctx, pingTimeout)
+	defer cancel()
+	return sqlDB.PingContext(ctx)
+}
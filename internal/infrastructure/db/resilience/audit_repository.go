@@ -0,0 +1,28 @@
+package resilience
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/tranvu1111/go-students-new/internal/domain/entities"
+	"github.com/tranvu1111/go-students-new/internal/domain/repositories"
+)
+
+type AuditRepo struct {
+	inner repositories.AuditRepository
+	guard *Guard
+}
+
+func NewAuditRepo(inner repositories.AuditRepository, guard *Guard) repositories.AuditRepository {
+	return &AuditRepo{inner: inner, guard: guard}
+}
+
+func (repo *AuditRepo) FindByStudentId(ctx context.Context, studentID uuid.UUID, offset int, limit int) ([]*entities.AuditEntry, int64, error) {
+	var total int64
+	entries, err := call(ctx, repo.guard, true, func() ([]*entities.AuditEntry, error) {
+		entries, count, err := repo.inner.FindByStudentId(ctx, studentID, offset, limit)
+		total = count
+		return entries, err
+	})
+	return entries, total, err
+}
@@ -0,0 +1,97 @@
+package resilience
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/tranvu1111/go-students-new/internal/domain/repositories"
+)
+
+// ErrCircuitOpen is returned while the breaker keeps calls off the database.
+var ErrCircuitOpen = fmt.Errorf("%w: circuit breaker is open", repositories.ErrUnavailable)
+
+type breakerState int
+
+const (
+	breakerClosed breakerState = iota
+	breakerOpen
+	breakerHalfOpen
+)
+
+// Breaker opens after Threshold consecutive connection errors and then
+// rejects calls for Cooldown. After that it lets a single probe through: its
+// success closes the breaker, its failure opens it again. Other errors, such
+// as a missing row, show the database is up and reset the count.
+type Breaker struct {
+	threshold int
+	cooldown  time.Duration
+
+	mu       sync.Mutex
+	state    breakerState
+	failures int
+	openedAt time.Time
+}
+
+func NewBreaker(threshold int, cooldown time.Duration) *Breaker {
+	if threshold <= 0 {
+		threshold = 5
+	}
+	if cooldown <= 0 {
+		cooldown = 10 * time.Second
+	}
+	return &Breaker{threshold: threshold, cooldown: cooldown}
+}
+
+// Allow returns ErrCircuitOpen when the call must not reach the database.
+// A caller that is allowed must report the outcome to Record.
+func (b *Breaker) Allow() error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	switch b.state {
+	case breakerOpen:
+		if time.Since(b.openedAt) < b.cooldown {
+			return ErrCircuitOpen
+		}
+		b.state = breakerHalfOpen
+		return nil
+	case breakerHalfOpen:
+		return ErrCircuitOpen
+	default:
+		return nil
+	}
+}
+
+func (b *Breaker) Record(err error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	// A cancelled call tells nothing about the database; a probe that was
+	// cancelled leaves the next call to probe.
+	if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		if b.state == breakerHalfOpen {
+			b.state = breakerOpen
+		}
+		return
+	}
+
+	if !IsConnectionError(err) {
+		if b.state != breakerClosed {
+			log.Printf("database is reachable again, closing the circuit breaker")
+		}
+		b.state, b.failures = breakerClosed, 0
+		return
+	}
+
+	b.failures++
+	if b.state == breakerHalfOpen || b.failures >= b.threshold {
+		if b.state != breakerOpen {
+			log.Printf("database is unreachable, opening the circuit breaker for %s: %v", b.cooldown, err)
+		}
+		b.state, b.openedAt = breakerOpen, time.Now()
+	}
+}
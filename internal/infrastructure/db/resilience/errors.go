@@ -0,0 +1,74 @@
+// Package resilience keeps the repositories usable through database
+// hiccups: transient failures are retried where that is safe, and a circuit
+// breaker fails fast with repositories.ErrUnavailable while the database is
+// down.
+package resilience
+
+import (
+	"context"
+	"database/sql/driver"
+	"errors"
+	"io"
+	"net"
+	"strings"
+	"syscall"
+
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+// SQLite result codes, without the extended bits.
+const (
+	sqliteBusy   = 5
+	sqliteLocked = 6
+)
+
+// IsRolledBack reports whether err guarantees that the operation had no
+// effect and lost only to concurrent work, so that any operation, writes
+// included, may be retried: Postgres serialization failures and deadlocks,
+// SQLite lock contention, and errors raised before anything was sent.
+func IsRolledBack(err error) bool {
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) {
+		return pgErr.Code == "40001" || pgErr.Code == "40P01"
+	}
+	var sqliteErr interface{ Code() int }
+	if errors.As(err, &sqliteErr) {
+		code := sqliteErr.Code() & 0xff
+		return code == sqliteBusy || code == sqliteLocked
+	}
+	return pgconn.SafeToRetry(err)
+}
+
+// IsConnectionError reports whether err means the database could not be
+// reached or dropped the connection. Whether the operation took effect is
+// unknown, so only idempotent operations may be retried.
+func IsConnectionError(err error) bool {
+	if err == nil || errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return false
+	}
+
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) {
+		// Class 08 is connection exceptions; 57P01-57P03 a server that is
+		// shutting down or starting up.
+		return strings.HasPrefix(pgErr.Code, "08") || pgErr.Code == "57P01" || pgErr.Code == "57P02" || pgErr.Code == "57P03"
+	}
+
+	var connectErr *pgconn.ConnectError
+	var netErr net.Error
+	return errors.As(err, &connectErr) ||
+		errors.As(err, &netErr) ||
+		errors.Is(err, driver.ErrBadConn) ||
+		errors.Is(err, io.EOF) ||
+		errors.Is(err, io.ErrUnexpectedEOF) ||
+		errors.Is(err, syscall.ECONNRESET) ||
+		errors.Is(err, syscall.ECONNREFUSED) ||
+		errors.Is(err, syscall.EPIPE) ||
+		pgconn.Timeout(err)
+}
+
+// Retryable reports whether an operation that failed with err may be tried
+// again.
+func Retryable(err error, idempotent bool) bool {
+	return IsRolledBack(err) || (idempotent && IsConnectionError(err))
+}
@@ -0,0 +1,96 @@
+package resilience
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/tranvu1111/go-students-new/internal/domain/repositories"
+)
+
+// RetryPolicy doubles the delay after every failed attempt, up to MaxDelay.
+type RetryPolicy struct {
+	MaxAttempts int
+	BaseDelay   time.Duration
+	MaxDelay    time.Duration
+}
+
+var DefaultRetryPolicy = RetryPolicy{
+	MaxAttempts: 3,
+	BaseDelay:   50 * time.Millisecond,
+	MaxDelay:    time.Second,
+}
+
+func (p RetryPolicy) NextDelay(attempts int) time.Duration {
+	delay := p.BaseDelay
+	for i := 1; i < attempts; i++ {
+		delay *= 2
+		if delay >= p.MaxDelay {
+			return p.MaxDelay
+		}
+	}
+	return delay
+}
+
+// Guard runs repository calls behind a breaker, retrying those that failed
+// transiently. One Guard is shared by every repository on the same database.
+type Guard struct {
+	policy  RetryPolicy
+	breaker *Breaker
+}
+
+func NewGuard(policy RetryPolicy, breaker *Breaker) *Guard {
+	if policy.MaxAttempts <= 0 {
+		policy = DefaultRetryPolicy
+	}
+	return &Guard{policy: policy, breaker: breaker}
+}
+
+// Do calls op until it succeeds, fails for good or runs out of attempts.
+// Connection errors are only retried when idempotent is set, and come back
+// wrapping repositories.ErrUnavailable.
+func (g *Guard) Do(ctx context.Context, idempotent bool, op func() error) error {
+	return g.do(ctx, func(err error) bool { return Retryable(err, idempotent) }, op)
+}
+
+func (g *Guard) do(ctx context.Context, retryable func(error) bool, op func() error) error {
+	for attempt := 1; ; attempt++ {
+		if err := g.breaker.Allow(); err != nil {
+			return err
+		}
+		err := op()
+		g.breaker.Record(err)
+		if err == nil {
+			return nil
+		}
+		if attempt >= g.policy.MaxAttempts || !retryable(err) {
+			return unavailable(err)
+		}
+
+		timer := time.NewTimer(g.policy.NextDelay(attempt))
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return unavailable(err)
+		case <-timer.C:
+		}
+	}
+}
+
+func unavailable(err error) error {
+	if IsConnectionError(err) {
+		return fmt.Errorf("%w: %w", repositories.ErrUnavailable, err)
+	}
+	return err
+}
+
+// call is Do for operations with a result.
+func call[T any](ctx context.Context, g *Guard, idempotent bool, op func() (T, error)) (T, error) {
+	var result T
+	err := g.Do(ctx, idempotent, func() error {
+		var err error
+		result, err = op()
+		return err
+	})
+	return result, err
+}
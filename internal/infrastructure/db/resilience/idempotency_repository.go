@@ -0,0 +1,45 @@
+package resilience
+
+import (
+	"context"
+	"time"
+
+	"github.com/tranvu1111/go-students-new/internal/domain/entities"
+	"github.com/tranvu1111/go-students-new/internal/domain/repositories"
+)
+
+// IdempotencyRepo guards an idempotency repository. Update, an upsert by
+// ID, and DeleteOlderThan may run twice; Create may not, as a second run
+// would report the key taken by the first.
+type IdempotencyRepo struct {
+	inner repositories.IdempotencyRepository
+	guard *Guard
+}
+
+func NewIdempotencyRepo(inner repositories.IdempotencyRepository, guard *Guard) repositories.IdempotencyRepository {
+	return &IdempotencyRepo{inner: inner, guard: guard}
+}
+
+func (repo *IdempotencyRepo) FindByKey(ctx context.Context, key string) (*entities.IdempotencyRecord, error) {
+	return call(ctx, repo.guard, true, func() (*entities.IdempotencyRecord, error) {
+		return repo.inner.FindByKey(ctx, key)
+	})
+}
+
+func (repo *IdempotencyRepo) Create(ctx context.Context, record *entities.IdempotencyRecord) (*entities.IdempotencyRecord, error) {
+	return call(ctx, repo.guard, false, func() (*entities.IdempotencyRecord, error) {
+		return repo.inner.Create(ctx, record)
+	})
+}
+
+func (repo *IdempotencyRepo) Update(ctx context.Context, record *entities.IdempotencyRecord) (*entities.IdempotencyRecord, error) {
+	return call(ctx, repo.guard, true, func() (*entities.IdempotencyRecord, error) {
+		return repo.inner.Update(ctx, record)
+	})
+}
+
+func (repo *IdempotencyRepo) DeleteOlderThan(ctx context.Context, cutoff time.Time) (int64, error) {
+	return call(ctx, repo.guard, true, func() (int64, error) {
+		return repo.inner.DeleteOlderThan(ctx, cutoff)
+	})
+}
@@ -0,0 +1,50 @@
+package resilience
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/tranvu1111/go-students-new/internal/domain/entities"
+	"github.com/tranvu1111/go-students-new/internal/domain/repositories"
+)
+
+type ProgramRepo struct {
+	inner repositories.ProgramRepository
+	guard *Guard
+}
+
+func NewProgramRepo(inner repositories.ProgramRepository, guard *Guard) repositories.ProgramRepository {
+	return &ProgramRepo{inner: inner, guard: guard}
+}
+
+func (repo *ProgramRepo) Create(ctx context.Context, program *entities.Program) (*entities.Program, error) {
+	return call(ctx, repo.guard, false, func() (*entities.Program, error) {
+		return repo.inner.Create(ctx, program)
+	})
+}
+
+func (repo *ProgramRepo) FindById(ctx context.Context, id uuid.UUID) (*entities.Program, error) {
+	return call(ctx, repo.guard, true, func() (*entities.Program, error) {
+		return repo.inner.FindById(ctx, id)
+	})
+}
+
+func (repo *ProgramRepo) FindByIds(ctx context.Context, ids []uuid.UUID) ([]*entities.Program, error) {
+	return call(ctx, repo.guard, true, func() ([]*entities.Program, error) {
+		return repo.inner.FindByIds(ctx, ids)
+	})
+}
+
+func (repo *ProgramRepo) FindAll(ctx context.Context, filter repositories.ProgramFilter) ([]*entities.Program, error) {
+	return call(ctx, repo.guard, true, func() ([]*entities.Program, error) {
+		return repo.inner.FindAll(ctx, filter)
+	})
+}
+
+// Update writes the whole program, so running it twice has the effect of
+// running it once.
+func (repo *ProgramRepo) Update(ctx context.Context, program *entities.Program) (*entities.Program, error) {
+	return call(ctx, repo.guard, true, func() (*entities.Program, error) {
+		return repo.inner.Update(ctx, program)
+	})
+}
@@ -0,0 +1,103 @@
+package resilience
+
+import (
+	"context"
+	"errors"
+
+	"github.com/google/uuid"
+	"github.com/tranvu1111/go-students-new/internal/domain/entities"
+	"github.com/tranvu1111/go-students-new/internal/domain/repositories"
+)
+
+// errVisitFailed stands in for an error of the visitor of Stream, which must
+// not count against the database.
+var errVisitFailed = errors.New("visit failed")
+
+// StudentRepo guards a student repository: reads are retried on connection
+// errors too, writes only when they surely had no effect.
+type StudentRepo struct {
+	inner repositories.StudentRepository
+	guard *Guard
+}
+
+func NewStudentRepo(inner repositories.StudentRepository, guard *Guard) repositories.StudentRepository {
+	return &StudentRepo{inner: inner, guard: guard}
+}
+
+func (repo *StudentRepo) Create(ctx context.Context, student *entities.ValidatedStudent) (*entities.Student, error) {
+	return call(ctx, repo.guard, false, func() (*entities.Student, error) {
+		return repo.inner.Create(ctx, student)
+	})
+}
+
+func (repo *StudentRepo) CreateBatch(ctx context.Context, students []*entities.ValidatedStudent, batchSize int) error {
+	return repo.guard.Do(ctx, false, func() error {
+		return repo.inner.CreateBatch(ctx, students, batchSize)
+	})
+}
+
+func (repo *StudentRepo) FindById(ctx context.Context, id uuid.UUID) (*entities.Student, error) {
+	return call(ctx, repo.guard, true, func() (*entities.Student, error) {
+		return repo.inner.FindById(ctx, id)
+	})
+}
+
+func (repo *StudentRepo) FindByIds(ctx context.Context, ids []uuid.UUID) ([]*entities.Student, error) {
+	return call(ctx, repo.guard, true, func() ([]*entities.Student, error) {
+		return repo.inner.FindByIds(ctx, ids)
+	})
+}
+
+func (repo *StudentRepo) FindAll(ctx context.Context, filter repositories.StudentFilter) ([]*entities.Student, error) {
+	return call(ctx, repo.guard, true, func() ([]*entities.Student, error) {
+		return repo.inner.FindAll(ctx, filter)
+	})
+}
+
+// Stream is retried only until the first student was visited, and errors
+// from visit are passed through untouched: they say nothing about the
+// database.
+func (repo *StudentRepo) Stream(ctx context.Context, filter repositories.StudentFilter, visit func(*entities.Student) error) error {
+	visited := false
+	var visitErr error
+	retryable := func(err error) bool {
+		return !visited && Retryable(err, true)
+	}
+	err := repo.guard.do(ctx, retryable, func() error {
+		return repo.inner.Stream(ctx, filter, func(student *entities.Student) error {
+			visited = true
+			if visitErr = visit(student); visitErr != nil {
+				return errVisitFailed
+			}
+			return nil
+		})
+	})
+	if visitErr != nil {
+		return visitErr
+	}
+	return err
+}
+
+func (repo *StudentRepo) Update(ctx context.Context, student *entities.ValidatedStudent) (*entities.Student, error) {
+	return call(ctx, repo.guard, false, func() (*entities.Student, error) {
+		return repo.inner.Update(ctx, student)
+	})
+}
+
+func (repo *StudentRepo) Delete(ctx context.Context, id uuid.UUID, expectedVersion int) error {
+	return repo.guard.Do(ctx, false, func() error {
+		return repo.inner.Delete(ctx, id, expectedVersion)
+	})
+}
+
+func (repo *StudentRepo) Restore(ctx context.Context, id uuid.UUID) (*entities.Student, error) {
+	return call(ctx, repo.guard, false, func() (*entities.Student, error) {
+		return repo.inner.Restore(ctx, id)
+	})
+}
+
+func (repo *StudentRepo) Search(ctx context.Context, query string, limit int) ([]repositories.StudentSearchHit, error) {
+	return call(ctx, repo.guard, true, func() ([]repositories.StudentSearchHit, error) {
+		return repo.inner.Search(ctx, query, limit)
+	})
+}
@@ -0,0 +1,28 @@
+package resilience
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/tranvu1111/go-students-new/internal/domain/entities"
+	"github.com/tranvu1111/go-students-new/internal/domain/repositories"
+)
+
+type StudentStatusRepo struct {
+	inner repositories.StudentStatusRepository
+	guard *Guard
+}
+
+func NewStudentStatusRepo(inner repositories.StudentStatusRepository, guard *Guard) repositories.StudentStatusRepository {
+	return &StudentStatusRepo{inner: inner, guard: guard}
+}
+
+func (repo *StudentStatusRepo) FindByStudentId(ctx context.Context, studentID uuid.UUID, offset int, limit int) ([]*entities.StudentStatusChange, int64, error) {
+	var total int64
+	changes, err := call(ctx, repo.guard, true, func() ([]*entities.StudentStatusChange, error) {
+		changes, count, err := repo.inner.FindByStudentId(ctx, studentID, offset, limit)
+		total = count
+		return changes, err
+	})
+	return changes, total, err
+}
@@ -272,4 +272,21 @@ func TestGormIdempotencyRepo_Update_non_exist_student(t *testing.T) {
 	})
 
 	
-}
\ No newline at end of file
+}
+func TestGormIdempotencyRepo_DeleteOlderThan(t *testing.T) {
+	db, mock := setupTestItempotencyDB(t)
+	repo := postgres2.NewGormIdempotencyRepository(db)
+	ctx := context.Background()
+	cutoff := time.Now().UTC().Add(-24 * time.Hour)
+
+	mock.ExpectBegin()
+	mock.ExpectExec(`DELETE FROM "db_idempotency_records" WHERE created_at < \$1`).
+		WithArgs(cutoff).
+		WillReturnResult(sqlmock.NewResult(0, 3))
+	mock.ExpectCommit()
+
+	deleted, err := repo.DeleteOlderThan(ctx, cutoff)
+	require.NoError(t, err)
+	assert.Equal(t, int64(3), deleted)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
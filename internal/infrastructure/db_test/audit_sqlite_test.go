@@ -0,0 +1,66 @@
+package db_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tranvu1111/go-students-new/internal/domain/entities"
+	"github.com/tranvu1111/go-students-new/internal/infrastructure/db/postgres"
+)
+
+func TestGormStudentRepo_AuditTrail(t *testing.T) {
+	repo, db := setupTestDB(t)
+	auditRepo := postgres.NewGormAuditRepo(db)
+	ctx := entities.ContextWithAuditActor(context.Background(), "advisor", "req-42")
+
+	now := time.Now()
+	phone := "+84932323232"
+	student := entities.NewStudent("John", "Doe", nil, "john.doe@aloalo.com", &phone, nil, now)
+	validStudent, err := entities.NewValidatedStudent(student)
+	require.NoError(t, err)
+
+	_, err = repo.Create(ctx, validStudent)
+	require.NoError(t, err)
+
+	major := "CNTT"
+	require.NoError(t, student.UpdateNewFields(nil, &phone, &major))
+	validStudent, err = entities.NewValidatedStudent(student)
+	require.NoError(t, err)
+	_, err = repo.Update(ctx, validStudent)
+	require.NoError(t, err)
+
+	require.NoError(t, repo.Delete(ctx, student.StudentID, 2))
+	_, err = repo.FindById(ctx, student.StudentID)
+	assert.Error(t, err, "deleted student should not be found")
+
+	restored, err := repo.Restore(ctx, student.StudentID)
+	require.NoError(t, err)
+	assert.Equal(t, student.StudentID, restored.StudentID)
+
+	entries, total, err := auditRepo.FindByStudentId(ctx, student.StudentID, 0, 10)
+	require.NoError(t, err)
+	require.Equal(t, int64(4), total)
+	require.Len(t, entries, 4)
+
+	operations := map[entities.AuditOperation]*entities.AuditEntry{}
+	for _, entry := range entries {
+		assert.Equal(t, "advisor", entry.Actor)
+		assert.Equal(t, "req-42", entry.RequestID)
+		operations[entry.Operation] = entry
+	}
+
+	update := operations[entities.AuditOperationUpdate]
+	require.NotNil(t, update)
+	require.Len(t, update.Changes, 1)
+	assert.Equal(t, "Major", update.Changes[0].Field)
+	assert.Nil(t, update.Changes[0].Before)
+	assert.Equal(t, "CNTT", *update.Changes[0].After)
+
+	page, total, err := auditRepo.FindByStudentId(ctx, student.StudentID, 2, 2)
+	require.NoError(t, err)
+	assert.Equal(t, int64(4), total)
+	assert.Len(t, page, 2)
+}
@@ -0,0 +1,30 @@
+package db_test
+
+import (
+	"testing"
+
+	"github.com/tranvu1111/go-students-new/internal/domain/repositories"
+	"github.com/tranvu1111/go-students-new/internal/infrastructure/db/postgres"
+	"github.com/tranvu1111/go-students-new/internal/infrastructure/repotest"
+)
+
+func TestGormStudentRepo_Conformance(t *testing.T) {
+	repotest.StudentRepository(t, func(t *testing.T) repotest.StudentRepos {
+		repo, db := setupTestDB(t)
+		return repotest.StudentRepos{Students: repo, Audit: postgres.NewGormAuditRepo(db), Statuses: postgres.NewGormStudentStatusRepo(db), Outbox: postgres.NewGormOutboxRepo(db)}
+	})
+}
+
+func TestGormIdempotencyRepo_Conformance(t *testing.T) {
+	repotest.IdempotencyRepository(t, func(t *testing.T) repositories.IdempotencyRepository {
+		_, db := setupTestDB(t)
+		return postgres.NewGormIdempotencyRepository(db)
+	})
+}
+
+func TestGormProgramRepo_Conformance(t *testing.T) {
+	repotest.ProgramRepository(t, func(t *testing.T) repositories.ProgramRepository {
+		_, db := setupTestDB(t)
+		return postgres.NewGormProgramRepo(db)
+	})
+}
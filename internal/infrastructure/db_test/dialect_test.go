@@ -0,0 +1,178 @@
+package db_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+
+	"github.com/tranvu1111/go-students-new/internal/domain/entities"
+	"github.com/tranvu1111/go-students-new/internal/domain/repositories"
+	"github.com/tranvu1111/go-students-new/internal/infrastructure/db/driver"
+	"github.com/tranvu1111/go-students-new/internal/infrastructure/db/postgres"
+)
+
+// openTestDB opens an empty database for one test. The suite runs on an
+// in-memory SQLite database unless TEST_DATABASE_DRIVER=postgres, in which
+// case every test gets its own schema in the database of TEST_DATABASE_DSN:
+//
+//	TEST_DATABASE_DRIVER=postgres TEST_DATABASE_DSN="host=localhost user=postgres dbname=test" go test ./internal/infrastructure/db_test/
+func openTestDB(t *testing.T) *gorm.DB {
+	config := &gorm.Config{Logger: logger.Default.LogMode(logger.Silent)}
+
+	switch name := os.Getenv("TEST_DATABASE_DRIVER"); name {
+	case "", driver.SQLite:
+		db, err := driver.Open(driver.SQLite, fmt.Sprintf("file:%s?mode=memory&cache=shared", uuid.NewString()), config)
+		require.NoError(t, err)
+		closeOnCleanup(t, db)
+		return db
+
+	case driver.Postgres:
+		dsn := os.Getenv("TEST_DATABASE_DSN")
+		if dsn == "" {
+			t.Fatal("TEST_DATABASE_DSN must name the Postgres test database")
+		}
+		admin, err := driver.Open(driver.Postgres, dsn, config)
+		require.NoError(t, err)
+		closeOnCleanup(t, admin)
+
+		schema := "test_" + strings.ReplaceAll(uuid.NewString(), "-", "")
+		require.NoError(t, admin.Exec("CREATE SCHEMA "+schema).Error)
+		t.Cleanup(func() {
+			admin.Exec("DROP SCHEMA " + schema + " CASCADE")
+		})
+
+		// public stays on the path for pg_trgm, when it is installed there.
+		db, err := driver.Open(driver.Postgres, withSearchPath(dsn, schema+",public"), config)
+		require.NoError(t, err)
+		closeOnCleanup(t, db)
+		return db
+
+	default:
+		t.Fatalf("unsupported TEST_DATABASE_DRIVER %q", name)
+		return nil
+	}
+}
+
+func closeOnCleanup(t *testing.T, db *gorm.DB) {
+	sqlDB, err := db.DB()
+	require.NoError(t, err)
+	t.Cleanup(func() { sqlDB.Close() })
+}
+
+// withSearchPath sets search_path in a URL or a key=value DSN.
+func withSearchPath(dsn string, searchPath string) string {
+	if parsed, err := url.Parse(dsn); err == nil && parsed.Scheme != "" {
+		query := parsed.Query()
+		query.Set("search_path", searchPath)
+		parsed.RawQuery = query.Encode()
+		return parsed.String()
+	}
+	return dsn + " search_path=" + searchPath
+}
+
+func newDialectTestStudent(t *testing.T, email string) *entities.ValidatedStudent {
+	dateOfBirth := time.Date(2003, time.March, 4, 0, 0, 0, 0, time.UTC)
+	student := entities.NewStudent("tran", "vu", &dateOfBirth, email, nil, nil, time.Date(2023, time.September, 1, 0, 0, 0, 0, time.UTC))
+	validated, err := entities.NewValidatedStudent(student)
+	require.NoError(t, err)
+	return validated
+}
+
+func TestGormStudentRepo_EmailIsUniqueIgnoringCase(t *testing.T) {
+	repo, db := setupTestDB(t)
+	ctx := context.Background()
+
+	first, err := repo.Create(ctx, newDialectTestStudent(t, "tran.vu@example.com"))
+	require.NoError(t, err)
+
+	// Validation only lets lower case addresses through; the index must hold
+	// for rows written by other means too.
+	require.NoError(t, db.Model(&postgres.DBStudent{}).Where("student_id = ?", first.StudentID).Update("email", "Tran.Vu@Example.com").Error)
+
+	_, err = repo.Create(ctx, newDialectTestStudent(t, "tran.vu@example.com"))
+	assert.True(t, errors.Is(err, repositories.ErrDuplicateEmail), "got %v", err)
+
+	batch := []*entities.ValidatedStudent{newDialectTestStudent(t, "an@example.com"), newDialectTestStudent(t, "tran.vu@example.com")}
+	err = repo.CreateBatch(ctx, batch, 10)
+	assert.True(t, errors.Is(err, repositories.ErrDuplicateEmail), "got %v", err)
+	_, err = repo.FindById(ctx, batch[0].StudentID)
+	assert.True(t, errors.Is(err, repositories.ErrStudentNotFound), "the batch must roll back")
+
+	// Deleted students free their address, until they are restored.
+	require.NoError(t, repo.Delete(ctx, first.StudentID, first.Version))
+	_, err = repo.Create(ctx, newDialectTestStudent(t, "tran.vu@example.com"))
+	require.NoError(t, err)
+	_, err = repo.Restore(ctx, first.StudentID)
+	assert.True(t, errors.Is(err, repositories.ErrDuplicateEmail), "got %v", err)
+}
+
+func TestGormStudentRepo_UUIDsAndTimesRoundTrip(t *testing.T) {
+	repo, _ := setupTestDB(t)
+	ctx := context.Background()
+
+	hanoi := time.FixedZone("ICT", 7*60*60)
+	dateOfBirth := time.Date(2003, time.March, 4, 6, 30, 0, 0, hanoi)
+	enrollmentDate := time.Date(2023, time.September, 1, 8, 0, 0, 0, hanoi)
+	student := entities.NewStudent("tran", "vu", &dateOfBirth, "tran.vu@example.com", nil, nil, enrollmentDate)
+	validated, err := entities.NewValidatedStudent(student)
+	require.NoError(t, err)
+
+	created, err := repo.Create(ctx, validated)
+	require.NoError(t, err)
+	assert.Equal(t, student.StudentID, created.StudentID)
+	assert.Equal(t, time.UTC, created.EnrollmentDate.Location())
+	assert.True(t, enrollmentDate.Equal(created.EnrollmentDate))
+	require.NotNil(t, created.DateOfBirth)
+	assert.True(t, dateOfBirth.Equal(*created.DateOfBirth))
+	assert.Equal(t, time.UTC, created.CreatedAt.Location())
+
+	// 2023-09-01 08:00 in Hanoi is still 2023-09-01 01:00 UTC, so a bound
+	// given in another zone must compare as the same instant.
+	from := time.Date(2023, time.September, 1, 1, 0, 0, 0, time.UTC).In(hanoi)
+	found, err := repo.FindAll(ctx, repositories.StudentFilter{EnrolledFrom: &from})
+	require.NoError(t, err)
+	assert.Len(t, found, 1)
+
+	to := time.Date(2023, time.September, 1, 0, 59, 0, 0, time.UTC).In(hanoi)
+	found, err = repo.FindAll(ctx, repositories.StudentFilter{EnrolledTo: &to})
+	require.NoError(t, err)
+	assert.Empty(t, found)
+
+	byIds, err := repo.FindByIds(ctx, []uuid.UUID{student.StudentID, uuid.New()})
+	require.NoError(t, err)
+	require.Len(t, byIds, 1)
+	assert.Equal(t, student.StudentID, byIds[0].StudentID)
+}
+
+func TestDriverOpen_TunesSQLite(t *testing.T) {
+	db, err := driver.Open(driver.SQLite, t.TempDir()+"/students.db", &gorm.Config{Logger: logger.Default.LogMode(logger.Silent)})
+	require.NoError(t, err)
+	closeOnCleanup(t, db)
+
+	var journalMode string
+	require.NoError(t, db.Raw("PRAGMA journal_mode").Scan(&journalMode).Error)
+	assert.Equal(t, "wal", journalMode)
+
+	var busyTimeout int
+	require.NoError(t, db.Raw("PRAGMA busy_timeout").Scan(&busyTimeout).Error)
+	assert.Equal(t, 5000, busyTimeout)
+
+	dsn, err := driver.SQLiteDSN("students.db?_pragma=busy_timeout(100)")
+	require.NoError(t, err)
+	assert.Contains(t, dsn, "busy_timeout%28100%29")
+	assert.NotContains(t, dsn, "busy_timeout%285000%29")
+
+	_, err = driver.Open("mysql", "", nil)
+	assert.Error(t, err)
+}
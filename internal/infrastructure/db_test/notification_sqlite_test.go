@@ -0,0 +1,210 @@
+package db_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tranvu1111/go-students-new/internal/application/command"
+	"github.com/tranvu1111/go-students-new/internal/application/services"
+	"github.com/tranvu1111/go-students-new/internal/domain/entities"
+	"github.com/tranvu1111/go-students-new/internal/domain/repositories"
+	"github.com/tranvu1111/go-students-new/internal/infrastructure/db/postgres"
+	"github.com/tranvu1111/go-students-new/internal/infrastructure/notification"
+	"github.com/tranvu1111/go-students-new/internal/infrastructure/outbox"
+)
+
+type failingChannel struct {
+	failures int
+}
+
+func (fc *failingChannel) Send(ctx context.Context, n *entities.Notification) error {
+	if fc.failures > 0 {
+		fc.failures--
+		return errors.New("gateway unavailable")
+	}
+	return nil
+}
+
+func createNotificationTemplate(t *testing.T, repo repositories.NotificationTemplateRepository, eventType entities.StudentEventType,
+	channel entities.NotificationChannel, locale string, subject string, body string) {
+	t.Helper()
+	template, err := entities.NewNotificationTemplate(string(eventType), channel, locale, subject, body, "")
+	require.NoError(t, err)
+	_, err = repo.Create(context.Background(), template)
+	require.NoError(t, err)
+}
+
+func writtenNotifications(t *testing.T, buf *bytes.Buffer) []notification.WrittenNotification {
+	t.Helper()
+	var written []notification.WrittenNotification
+	for _, line := range strings.Split(strings.TrimSpace(buf.String()), "\n") {
+		if line == "" {
+			continue
+		}
+		var n notification.WrittenNotification
+		require.NoError(t, json.Unmarshal([]byte(line), &n))
+		written = append(written, n)
+	}
+	return written
+}
+
+func TestNotifications_LifecycleInStudentLocale(t *testing.T) {
+	repo, db := setupTestDB(t)
+	ctx := context.Background()
+	templateRepo := postgres.NewGormNotificationTemplateRepo(db)
+	notificationRepo := postgres.NewGormNotificationRepo(db)
+	service := services.NewStudentService(repo, postgres.NewGormIdempotencyRepository(db), postgres.NewGormAuditRepo(db),
+		postgres.NewGormStudentStatusRepo(db), postgres.NewGormProgramRepo(db))
+
+	createNotificationTemplate(t, templateRepo, entities.StudentCreated, entities.NotificationChannelEmail, "en",
+		"Welcome, {{.FirstName}}", "Hello {{.FirstName}}, you are enrolled.")
+	createNotificationTemplate(t, templateRepo, entities.StudentCreated, entities.NotificationChannelEmail, "vi",
+		"Chào mừng {{.FirstName}}", "Chào {{.FirstName}}, bạn đã nhập học.")
+	createNotificationTemplate(t, templateRepo, entities.StudentStatusChanged, entities.NotificationChannelSMS, "en",
+		"", "{{.FirstName}}, your status is now {{.Status}}.")
+
+	_, err := templateRepo.Create(ctx, mustTemplate(t, entities.StudentCreated, entities.NotificationChannelEmail, "vi"))
+	assert.ErrorIs(t, err, repositories.ErrDuplicateNotificationTemplate)
+
+	locale, phone := "vi-VN", "+84901234567"
+	vietnamese, err := service.CreateStudent(ctx, &command.CreateStudentCommand{
+		FirstName: "Vu", LastName: "Tran", Email: "vu@example.com", Phone: &phone, Locale: &locale, EnrollmentDate: time.Now(),
+	})
+	require.NoError(t, err)
+	_, err = service.CreateStudent(ctx, &command.CreateStudentCommand{
+		FirstName: "Anna", LastName: "Smith", Email: "anna@example.com", EnrollmentDate: time.Now(),
+	})
+	require.NoError(t, err)
+	_, err = service.TransitionStudentStatus(ctx, &command.TransitionStudentStatusCommand{
+		StudentId: vietnamese.Result.StudentID, Status: entities.StudentStatusOnLeave, Reason: "medical", EffectiveDate: time.Now(),
+	})
+	require.NoError(t, err)
+
+	outboxRepo := postgres.NewGormOutboxRepo(db)
+	messages, err := outboxRepo.FindUnpublished(ctx, time.Now(), 10)
+	require.NoError(t, err)
+	sink := notification.NewSink(templateRepo, notificationRepo, repo, "en")
+	relay := outbox.NewRelay(outboxRepo, time.Second, 10, entities.DefaultOutboxRetryPolicy, sink)
+	_, err = relay.RelayOnce(ctx)
+	require.NoError(t, err)
+
+	// Publishing the events again, as the relay may after a crash, renders
+	// nothing twice.
+	for _, message := range messages {
+		require.NoError(t, sink.Publish(ctx, message))
+	}
+	_, total, err := notificationRepo.FindAll(ctx, repositories.NotificationFilter{}, 0, 10)
+	require.NoError(t, err)
+	assert.Equal(t, int64(3), total)
+
+	var buf bytes.Buffer
+	channel := notification.NewWriterChannel(&buf)
+	dispatcher := notification.NewDispatcher(notificationRepo, map[entities.NotificationChannel]notification.Channel{
+		entities.NotificationChannelEmail: channel,
+		entities.NotificationChannelSMS:   channel,
+	}, entities.DefaultNotificationRetryPolicy)
+	attempted, err := dispatcher.DispatchDue(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 3, attempted)
+
+	byRecipient := map[string]notification.WrittenNotification{}
+	for _, n := range writtenNotifications(t, &buf) {
+		byRecipient[string(n.Channel)+" "+n.To] = n
+	}
+	assert.Equal(t, "Chào mừng Vu", byRecipient["email vu@example.com"].Subject, "the Vietnamese variant is picked for vi-VN")
+	assert.Equal(t, "Welcome, Anna", byRecipient["email anna@example.com"].Subject, "the default locale is used without a locale of the student")
+	assert.Equal(t, "Vu, your status is now on_leave.", byRecipient["sms +84901234567"].Text, "without a vi variant the default locale is used")
+
+	studentID := vietnamese.Result.StudentID
+	sent := entities.NotificationSent
+	sendLog, total, err := notificationRepo.FindAll(ctx, repositories.NotificationFilter{StudentID: &studentID, Status: &sent}, 0, 10)
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), total)
+	for _, n := range sendLog {
+		assert.NotNil(t, n.SentAt)
+		assert.Equal(t, 1, n.Attempts)
+	}
+}
+
+func TestNotificationDispatcher_RetriesThenDeadLetters(t *testing.T) {
+	repo, db := setupTestDB(t)
+	ctx := context.Background()
+	templateRepo := postgres.NewGormNotificationTemplateRepo(db)
+	notificationRepo := postgres.NewGormNotificationRepo(db)
+	notificationService := services.NewNotificationService(templateRepo, notificationRepo)
+
+	createNotificationTemplate(t, templateRepo, entities.StudentCreated, entities.NotificationChannelEmail, "en",
+		"Welcome", "Hello {{.FirstName}}")
+	createNotificationTemplate(t, templateRepo, entities.StudentCreated, entities.NotificationChannelSMS, "en",
+		"", "Hello {{.FirstName}}")
+
+	phone := "+84901234567"
+	student := entities.NewStudent("John", "Doe", nil, "john.doe@aloalo.com", &phone, nil, time.Now())
+	validStudent, err := entities.NewValidatedStudent(student)
+	require.NoError(t, err)
+	_, err = repo.Create(ctx, validStudent)
+	require.NoError(t, err)
+
+	relay := outbox.NewRelay(postgres.NewGormOutboxRepo(db), time.Second, 10, entities.DefaultOutboxRetryPolicy, notification.NewSink(templateRepo, notificationRepo, repo, "en"))
+	_, err = relay.RelayOnce(ctx)
+	require.NoError(t, err)
+
+	email := &failingChannel{failures: 5}
+	policy := entities.RetryPolicy{MaxAttempts: 2, BaseDelay: 0, MaxDelay: 0}
+	dispatcher := notification.NewDispatcher(notificationRepo, map[entities.NotificationChannel]notification.Channel{
+		entities.NotificationChannelEmail: email,
+	}, policy)
+
+	attempted, err := dispatcher.DispatchDue(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 2, attempted)
+	attempted, err = dispatcher.DispatchDue(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 1, attempted, "the SMS without a channel is dead-lettered at once")
+
+	dead := entities.NotificationDead
+	deadLetters, total, err := notificationRepo.FindAll(ctx, repositories.NotificationFilter{Status: &dead}, 0, 10)
+	require.NoError(t, err)
+	require.Equal(t, int64(2), total)
+
+	var emailNotification *entities.Notification
+	for _, n := range deadLetters {
+		switch n.Channel {
+		case entities.NotificationChannelEmail:
+			emailNotification = n
+			assert.Equal(t, 2, n.Attempts)
+			assert.Equal(t, "gateway unavailable", n.LastError)
+		case entities.NotificationChannelSMS:
+			assert.Equal(t, "no sms channel is configured", n.LastError)
+		}
+	}
+	require.NotNil(t, emailNotification)
+
+	email.failures = 0
+	retried, err := notificationService.RetryNotification(ctx, emailNotification.ID)
+	require.NoError(t, err)
+	assert.Equal(t, string(entities.NotificationPending), retried.Result.Status)
+	_, err = notificationService.RetryNotification(ctx, emailNotification.ID)
+	assert.ErrorIs(t, err, entities.ErrNotificationNotDead)
+
+	attempted, err = dispatcher.DispatchDue(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 1, attempted)
+	sent, err := notificationRepo.FindById(ctx, emailNotification.ID)
+	require.NoError(t, err)
+	assert.Equal(t, entities.NotificationSent, sent.Status)
+}
+
+func mustTemplate(t *testing.T, eventType entities.StudentEventType, channel entities.NotificationChannel, locale string) *entities.NotificationTemplate {
+	t.Helper()
+	template, err := entities.NewNotificationTemplate(string(eventType), channel, locale, "Subject", "Body", "")
+	require.NoError(t, err)
+	return template
+}
@@ -0,0 +1,190 @@
+package db_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tranvu1111/go-students-new/internal/domain/entities"
+	"github.com/tranvu1111/go-students-new/internal/domain/repositories"
+	"github.com/tranvu1111/go-students-new/internal/infrastructure/db/postgres"
+	"github.com/tranvu1111/go-students-new/internal/infrastructure/outbox"
+)
+
+func TestGormStudentRepo_WritesOutboxEvents(t *testing.T) {
+	repo, db := setupTestDB(t)
+	outboxRepo := postgres.NewGormOutboxRepo(db)
+	ctx := context.Background()
+
+	student := entities.NewStudent("John", "Doe", nil, "john.doe@aloalo.com", nil, nil, time.Now())
+	validStudent, err := entities.NewValidatedStudent(student)
+	require.NoError(t, err)
+	_, err = repo.Create(ctx, validStudent)
+	require.NoError(t, err)
+
+	stored, err := repo.FindById(ctx, student.StudentID)
+	require.NoError(t, err)
+	major := "CNTT"
+	require.NoError(t, stored.UpdateNewFields(nil, nil, &major))
+	validStudent, err = entities.NewValidatedStudent(stored)
+	require.NoError(t, err)
+	_, err = repo.Update(ctx, validStudent)
+	require.NoError(t, err)
+
+	require.NoError(t, repo.Delete(ctx, student.StudentID, 2))
+
+	messages, err := outboxRepo.FindUnpublished(ctx, time.Now(), 10)
+	require.NoError(t, err)
+	require.Len(t, messages, 3)
+
+	assert.Equal(t, string(entities.StudentCreated), messages[0].EventType)
+	assert.Equal(t, string(entities.StudentUpdated), messages[1].EventType)
+	assert.Equal(t, string(entities.StudentDeleted), messages[2].EventType)
+
+	var updated entities.StudentEvent
+	require.NoError(t, json.Unmarshal([]byte(messages[1].Payload), &updated))
+	assert.Equal(t, student.StudentID, updated.StudentID)
+	require.Len(t, updated.Changes, 1)
+	assert.Equal(t, "Major", updated.Changes[0].Field)
+}
+
+func TestOutboxRelay_PublishesToSinks(t *testing.T) {
+	repo, db := setupTestDB(t)
+	outboxRepo := postgres.NewGormOutboxRepo(db)
+	ctx := context.Background()
+
+	var mu sync.Mutex
+	var received []string
+	failing := true
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		if failing {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		received = append(received, r.Header.Get(outbox.EventTypeHeader))
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	student := entities.NewStudent("John", "Doe", nil, "john.doe@aloalo.com", nil, nil, time.Now())
+	validStudent, err := entities.NewValidatedStudent(student)
+	require.NoError(t, err)
+	_, err = repo.Create(ctx, validStudent)
+	require.NoError(t, err)
+
+	var buffer bytes.Buffer
+	// Without a delay the failed message is due again on the next poll.
+	policy := entities.RetryPolicy{MaxAttempts: 3}
+	relay := outbox.NewRelay(outboxRepo, time.Second, 10, policy, outbox.NewWriterSink(&buffer), outbox.NewWebhookSink(server.URL, nil))
+
+	published, err := relay.RelayOnce(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 0, published, "a failing sink keeps the message in the outbox")
+
+	var pending postgres.DBOutboxMessage
+	require.NoError(t, db.First(&pending).Error)
+	assert.Equal(t, 1, pending.Attempts)
+	assert.Contains(t, pending.LastError, "503")
+
+	mu.Lock()
+	failing = false
+	mu.Unlock()
+
+	published, err = relay.RelayOnce(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 1, published)
+	assert.Equal(t, []string{string(entities.StudentCreated)}, received)
+	assert.Contains(t, strings.TrimSpace(buffer.String()), student.StudentID.String())
+
+	messages, err := outboxRepo.FindUnpublished(ctx, time.Now(), 10)
+	require.NoError(t, err)
+	assert.Empty(t, messages)
+}
+
+// poisonSink rejects every event of one student.
+type poisonSink struct {
+	poisoned  uuid.UUID
+	published []uuid.UUID
+}
+
+func (s *poisonSink) Publish(ctx context.Context, message *entities.OutboxMessage) error {
+	if message.AggregateID == s.poisoned {
+		return errors.New("cannot publish")
+	}
+	s.published = append(s.published, message.AggregateID)
+	return nil
+}
+
+func createOutboxStudent(t *testing.T, repo repositories.StudentRepository, email string) uuid.UUID {
+	t.Helper()
+	student := entities.NewStudent("John", "Doe", nil, email, nil, nil, time.Now())
+	validStudent, err := entities.NewValidatedStudent(student)
+	require.NoError(t, err)
+	_, err = repo.Create(context.Background(), validStudent)
+	require.NoError(t, err)
+	return student.StudentID
+}
+
+func TestOutboxRelay_PoisonedMessageDoesNotBlockLaterOnes(t *testing.T) {
+	repo, db := setupTestDB(t)
+	outboxRepo := postgres.NewGormOutboxRepo(db)
+	ctx := context.Background()
+
+	poisoned := createOutboxStudent(t, repo, "poison@aloalo.com")
+	healthy := createOutboxStudent(t, repo, "healthy@aloalo.com")
+	sink := &poisonSink{poisoned: poisoned}
+
+	// With a batch of one the poisoned message is first in line. It waits
+	// for its retry while the next one is published.
+	relay := outbox.NewRelay(outboxRepo, time.Second, 1, entities.RetryPolicy{MaxAttempts: 5, BaseDelay: time.Hour, MaxDelay: time.Hour}, sink)
+	published, err := relay.RelayOnce(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 0, published)
+	published, err = relay.RelayOnce(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 1, published)
+	assert.Equal(t, []uuid.UUID{healthy}, sink.published)
+
+	var waiting postgres.DBOutboxMessage
+	require.NoError(t, db.Where("aggregate_id = ?", poisoned).First(&waiting).Error)
+	assert.Equal(t, 1, waiting.Attempts)
+	require.NotNil(t, waiting.NextAttemptAt)
+	assert.True(t, waiting.NextAttemptAt.After(time.Now().Add(59*time.Minute)))
+	assert.Nil(t, waiting.DeadAt)
+}
+
+func TestOutboxRelay_GivesUpAfterMaxAttempts(t *testing.T) {
+	repo, db := setupTestDB(t)
+	outboxRepo := postgres.NewGormOutboxRepo(db)
+	ctx := context.Background()
+
+	poisoned := createOutboxStudent(t, repo, "poison@aloalo.com")
+	relay := outbox.NewRelay(outboxRepo, time.Second, 10, entities.RetryPolicy{MaxAttempts: 2}, &poisonSink{poisoned: poisoned})
+
+	for i := 0; i < 2; i++ {
+		_, err := relay.RelayOnce(ctx)
+		require.NoError(t, err)
+	}
+	messages, err := outboxRepo.FindUnpublished(ctx, time.Now(), 10)
+	require.NoError(t, err)
+	assert.Empty(t, messages, "a message given up on is no longer relayed")
+
+	var dead postgres.DBOutboxMessage
+	require.NoError(t, db.First(&dead).Error)
+	assert.Equal(t, 2, dead.Attempts)
+	assert.NotNil(t, dead.DeadAt)
+	assert.Nil(t, dead.PublishedAt)
+	assert.Equal(t, "cannot publish", dead.LastError)
+}
@@ -0,0 +1,113 @@
+package db_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+
+	"github.com/tranvu1111/go-students-new/internal/domain/repositories"
+	"github.com/tranvu1111/go-students-new/internal/infrastructure/db/postgres"
+	"github.com/tranvu1111/go-students-new/internal/infrastructure/db/replica"
+)
+
+// setupReplicaTestDBs opens a primary and a replica that does not replicate,
+// so that a read shows which of the two served it.
+func setupReplicaTestDBs(t *testing.T) (primary *gorm.DB, replicaDB *gorm.DB) {
+	primary, replicaDB = openTestDB(t), openTestDB(t)
+	require.NoError(t, postgres.AutoMigrate(primary))
+	require.NoError(t, postgres.AutoMigrate(replicaDB))
+	return primary, replicaDB
+}
+
+func TestRoutedGormStudentRepo_ReadsFromReplica(t *testing.T) {
+	primary, replicaDB := setupReplicaTestDBs(t)
+	repo := postgres.NewRoutedGormStudentRepo(replica.NewRouter(primary, replicaDB))
+	ctx := context.Background()
+
+	// The write returns the student even outside a request: it reads it back
+	// from the primary.
+	created, err := repo.Create(ctx, newDialectTestStudent(t, "tran.vu@example.com"))
+	require.NoError(t, err)
+
+	_, err = repo.FindById(ctx, created.StudentID)
+	assert.True(t, errors.Is(err, repositories.ErrStudentNotFound), "the replica has not seen the write, got %v", err)
+	all, err := repo.FindAll(ctx, repositories.StudentFilter{})
+	require.NoError(t, err)
+	assert.Empty(t, all)
+
+	// A consistent read goes to the primary.
+	found, err := repo.FindById(repositories.ContextWithReadSession(ctx, true), created.StudentID)
+	require.NoError(t, err)
+	assert.Equal(t, created.StudentID, found.StudentID)
+
+	// So does the read a write depends on, inside a request or not.
+	found, err = repo.FindById(repositories.ContextForWrite(ctx), created.StudentID)
+	require.NoError(t, err)
+	assert.Equal(t, created.Version, found.Version)
+}
+
+func TestRoutedGormStudentRepo_ReadsOwnWrites(t *testing.T) {
+	primary, replicaDB := setupReplicaTestDBs(t)
+	repo := postgres.NewRoutedGormStudentRepo(replica.NewRouter(primary, replicaDB))
+	ctx := repositories.ContextWithReadSession(context.Background(), false)
+
+	all, err := repo.FindAll(ctx, repositories.StudentFilter{})
+	require.NoError(t, err)
+	assert.Empty(t, all)
+	assert.False(t, repositories.NeedsConsistentRead(ctx))
+
+	created, err := repo.Create(ctx, newDialectTestStudent(t, "tran.vu@example.com"))
+	require.NoError(t, err)
+	assert.True(t, repositories.NeedsConsistentRead(ctx))
+
+	found, err := repo.FindById(ctx, created.StudentID)
+	require.NoError(t, err)
+	assert.Equal(t, created.StudentID, found.StudentID)
+	all, err = repo.FindAll(ctx, repositories.StudentFilter{})
+	require.NoError(t, err)
+	assert.Len(t, all, 1)
+}
+
+func TestRouter_FailsOverToPrimary(t *testing.T) {
+	primary, replicaDB := setupReplicaTestDBs(t)
+	router := replica.NewRouter(primary, replicaDB)
+	ctx := context.Background()
+
+	assert.Same(t, replicaDB, router.Reader(ctx))
+	assert.Equal(t, 1, router.CheckHealth(ctx))
+
+	sqlDB, err := replicaDB.DB()
+	require.NoError(t, err)
+	require.NoError(t, sqlDB.Close())
+
+	assert.Equal(t, 0, router.CheckHealth(ctx))
+	assert.Same(t, primary, router.Reader(ctx))
+
+	// Without replicas every read goes to the primary.
+	assert.Same(t, primary, replica.NewRouter(primary).Reader(ctx))
+}
+
+func TestRouter_SpreadsReadsOverHealthyReplicas(t *testing.T) {
+	primary, first := setupReplicaTestDBs(t)
+	second := openTestDB(t)
+	router := replica.NewRouter(primary, first, second)
+	ctx := context.Background()
+
+	seen := map[*gorm.DB]int{}
+	for i := 0; i < 4; i++ {
+		seen[router.Reader(ctx)]++
+	}
+	assert.Equal(t, map[*gorm.DB]int{first: 2, second: 2}, seen)
+
+	sqlDB, err := second.DB()
+	require.NoError(t, err)
+	require.NoError(t, sqlDB.Close())
+	assert.Equal(t, 1, router.CheckHealth(ctx))
+	for i := 0; i < 3; i++ {
+		assert.Same(t, first, router.Reader(ctx))
+	}
+}
@@ -0,0 +1,187 @@
+package db_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+
+	"github.com/tranvu1111/go-students-new/internal/domain/entities"
+	"github.com/tranvu1111/go-students-new/internal/domain/repositories"
+	"github.com/tranvu1111/go-students-new/internal/infrastructure/db/driver"
+	"github.com/tranvu1111/go-students-new/internal/infrastructure/db/resilience"
+)
+
+var errConnReset = fmt.Errorf("read tcp: %w", syscall.ECONNRESET)
+
+// flakyStudentRepo fails every call with the next of errs, then succeeds.
+type flakyStudentRepo struct {
+	repositories.StudentRepository
+	errs  []error
+	calls int
+}
+
+func (repo *flakyStudentRepo) fail() error {
+	repo.calls++
+	if len(repo.errs) == 0 {
+		return nil
+	}
+	err := repo.errs[0]
+	repo.errs = repo.errs[1:]
+	return err
+}
+
+func (repo *flakyStudentRepo) FindById(ctx context.Context, id uuid.UUID) (*entities.Student, error) {
+	if err := repo.fail(); err != nil {
+		return nil, err
+	}
+	return &entities.Student{StudentID: id}, nil
+}
+
+func (repo *flakyStudentRepo) Delete(ctx context.Context, id uuid.UUID, expectedVersion int) error {
+	return repo.fail()
+}
+
+func (repo *flakyStudentRepo) Stream(ctx context.Context, filter repositories.StudentFilter, visit func(*entities.Student) error) error {
+	if err := visit(&entities.Student{StudentID: uuid.New()}); err != nil {
+		return err
+	}
+	return repo.fail()
+}
+
+func newTestGuard(threshold int, cooldown time.Duration) *resilience.Guard {
+	policy := resilience.RetryPolicy{MaxAttempts: 3, BaseDelay: time.Millisecond, MaxDelay: time.Millisecond}
+	return resilience.NewGuard(policy, resilience.NewBreaker(threshold, cooldown))
+}
+
+func TestResilientStudentRepo_RetriesReadsOnConnectionErrors(t *testing.T) {
+	inner := &flakyStudentRepo{errs: []error{errConnReset, errConnReset}}
+	repo := resilience.NewStudentRepo(inner, newTestGuard(10, time.Minute))
+
+	id := uuid.New()
+	student, err := repo.FindById(context.Background(), id)
+	require.NoError(t, err)
+	assert.Equal(t, id, student.StudentID)
+	assert.Equal(t, 3, inner.calls)
+
+	// Out of attempts, the error says the database is unavailable.
+	inner.errs, inner.calls = []error{errConnReset, errConnReset, errConnReset}, 0
+	_, err = repo.FindById(context.Background(), id)
+	assert.True(t, errors.Is(err, repositories.ErrUnavailable), "got %v", err)
+	assert.True(t, errors.Is(err, syscall.ECONNRESET))
+	assert.Equal(t, 3, inner.calls)
+}
+
+func TestResilientStudentRepo_RetriesWritesOnlyWhenRolledBack(t *testing.T) {
+	inner := &flakyStudentRepo{errs: []error{errConnReset}}
+	repo := resilience.NewStudentRepo(inner, newTestGuard(10, time.Minute))
+
+	// The write may have committed before the connection dropped.
+	err := repo.Delete(context.Background(), uuid.New(), 1)
+	assert.True(t, errors.Is(err, repositories.ErrUnavailable), "got %v", err)
+	assert.Equal(t, 1, inner.calls)
+
+	inner.errs, inner.calls = []error{&pgconn.PgError{Code: "40001"}, &pgconn.PgError{Code: "40P01"}}, 0
+	require.NoError(t, repo.Delete(context.Background(), uuid.New(), 1))
+	assert.Equal(t, 3, inner.calls)
+
+	// Domain errors are not retried and come back as they are.
+	inner.errs, inner.calls = []error{repositories.ErrStudentNotFound}, 0
+	err = repo.Delete(context.Background(), uuid.New(), 1)
+	assert.Equal(t, repositories.ErrStudentNotFound, err)
+	assert.Equal(t, 1, inner.calls)
+}
+
+func TestResilientStudentRepo_StreamIsNotRetriedOnceVisited(t *testing.T) {
+	inner := &flakyStudentRepo{errs: []error{errConnReset}}
+	repo := resilience.NewStudentRepo(inner, newTestGuard(10, time.Minute))
+
+	visits := 0
+	err := repo.Stream(context.Background(), repositories.StudentFilter{}, func(*entities.Student) error {
+		visits++
+		return nil
+	})
+	assert.True(t, errors.Is(err, repositories.ErrUnavailable), "got %v", err)
+	assert.Equal(t, 1, visits)
+
+	errWrite := errors.New("client went away")
+	err = repo.Stream(context.Background(), repositories.StudentFilter{}, func(*entities.Student) error {
+		return errWrite
+	})
+	assert.Equal(t, errWrite, err)
+}
+
+func TestBreaker_FailsFastWhileDatabaseIsDown(t *testing.T) {
+	inner := &flakyStudentRepo{errs: []error{errConnReset, errConnReset, errConnReset}}
+	repo := resilience.NewStudentRepo(inner, newTestGuard(3, 50*time.Millisecond))
+	ctx := context.Background()
+
+	_, err := repo.FindById(ctx, uuid.New())
+	assert.True(t, errors.Is(err, repositories.ErrUnavailable), "got %v", err)
+	assert.Equal(t, 3, inner.calls)
+
+	// Open: calls are refused without reaching the database.
+	_, err = repo.FindById(ctx, uuid.New())
+	assert.True(t, errors.Is(err, resilience.ErrCircuitOpen), "got %v", err)
+	assert.True(t, errors.Is(err, repositories.ErrUnavailable))
+	assert.Equal(t, 3, inner.calls)
+
+	// After the cooldown a probe goes through and, succeeding, closes it.
+	time.Sleep(60 * time.Millisecond)
+	_, err = repo.FindById(ctx, uuid.New())
+	require.NoError(t, err)
+	_, err = repo.FindById(ctx, uuid.New())
+	require.NoError(t, err)
+	assert.Equal(t, 5, inner.calls)
+}
+
+func TestBreaker_ReopensWhenProbeFails(t *testing.T) {
+	breaker := resilience.NewBreaker(1, 20*time.Millisecond)
+
+	require.NoError(t, breaker.Allow())
+	breaker.Record(errConnReset)
+	assert.Equal(t, resilience.ErrCircuitOpen, breaker.Allow())
+
+	time.Sleep(30 * time.Millisecond)
+	require.NoError(t, breaker.Allow())
+	assert.Equal(t, resilience.ErrCircuitOpen, breaker.Allow(), "only one probe at a time")
+	breaker.Record(errConnReset)
+	assert.Equal(t, resilience.ErrCircuitOpen, breaker.Allow())
+
+	// Errors of a database that answered do not count.
+	breaker = resilience.NewBreaker(1, time.Minute)
+	breaker.Record(repositories.ErrStudentNotFound)
+	breaker.Record(context.Canceled)
+	assert.NoError(t, breaker.Allow())
+}
+
+func TestDriver_ConfiguresPool(t *testing.T) {
+	db := openTestDB(t)
+	require.NoError(t, driver.ConfigurePool(db, driver.Pool{MaxOpenConns: 7, ConnMaxLifetime: time.Minute}))
+
+	sqlDB, err := db.DB()
+	require.NoError(t, err)
+	assert.Equal(t, 7, sqlDB.Stats().MaxOpenConnections)
+}
+
+func TestDriver_OpenWithRetry(t *testing.T) {
+	policy := resilience.RetryPolicy{MaxAttempts: 5, BaseDelay: time.Hour, MaxDelay: time.Hour}
+	config := &gorm.Config{Logger: logger.Default.LogMode(logger.Silent)}
+	_, err := driver.OpenWithRetry(context.Background(), "mysql", "", config, policy)
+	assert.Error(t, err)
+
+	// Nothing listens on port 1; the context cuts the backoff short.
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	_, err = driver.OpenWithRetry(ctx, driver.Postgres, "host=127.0.0.1 port=1 user=postgres dbname=test sslmode=disable connect_timeout=1", config, policy)
+	assert.True(t, resilience.IsConnectionError(err), "got %v", err)
+}
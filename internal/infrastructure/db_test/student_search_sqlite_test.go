@@ -0,0 +1,65 @@
+package db_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/tranvu1111/go-students-new/internal/domain/entities"
+)
+
+// SQLite has no full-text search, so this covers the portable fallback.
+func TestGormStudentRepo_Search(t *testing.T) {
+	repo, _ := setupTestDB(t)
+	ctx := context.Background()
+
+	physics, history := "Physics", "History"
+	seed := []*entities.Student{
+		entities.NewStudent("Jonathan", "Nguyen", nil, "jnguyen@example.com", nil, &history, time.Now()),
+		entities.NewStudent("Mary", "Johnson", nil, "mary@example.com", nil, &physics, time.Now()),
+		entities.NewStudent("Linh", "Tran", nil, "linh@example.com", nil, &physics, time.Now()),
+		entities.NewStudent("Jonathon", "Le", nil, "jle@example.com", nil, nil, time.Now()),
+	}
+	for _, student := range seed {
+		validStudent, err := entities.NewValidatedStudent(student)
+		if err != nil {
+			t.Fatalf("Invalid student test case: %v", err)
+		}
+		if _, err := repo.Create(ctx, validStudent); err != nil {
+			t.Fatalf("Cannot create new student: %v", err)
+		}
+	}
+
+	hits, err := repo.Search(ctx, "jonathan", 10)
+	if err != nil {
+		t.Fatalf("Search returned an unexpected error: %v", err)
+	}
+	if len(hits) != 2 {
+		t.Fatalf("Expected the exact and the misspelled Jonathan, got %d hits", len(hits))
+	}
+	if hits[0].Student.FirstName != "Jonathan" || hits[1].Student.FirstName != "Jonathon" {
+		t.Errorf("Expected the exact match to rank first, got %s then %s", hits[0].Student.FirstName, hits[1].Student.FirstName)
+	}
+	if hits[0].Score <= hits[1].Score {
+		t.Errorf("Expected a higher score for the exact match, got %v and %v", hits[0].Score, hits[1].Score)
+	}
+
+	hits, err = repo.Search(ctx, "phisics", 1)
+	if err != nil {
+		t.Fatalf("Search returned an unexpected error: %v", err)
+	}
+	if len(hits) != 1 {
+		t.Errorf("Expected the limit to cap the results at 1, got %d", len(hits))
+	}
+
+	if err := repo.Delete(ctx, seed[2].StudentID, 1); err != nil {
+		t.Fatalf("Cannot delete student: %v", err)
+	}
+	hits, err = repo.Search(ctx, "linh", 10)
+	if err != nil {
+		t.Fatalf("Search returned an unexpected error: %v", err)
+	}
+	if len(hits) != 0 {
+		t.Errorf("Expected deleted students to be excluded, got %d hits", len(hits))
+	}
+}
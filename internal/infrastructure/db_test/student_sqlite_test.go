@@ -3,29 +3,26 @@
 package db_test
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"testing"
 	"time"
 
-	"github.com/glebarez/sqlite"
 	"github.com/google/uuid"
 	"github.com/tranvu1111/go-students-new/internal/domain/entities"
+	"github.com/tranvu1111/go-students-new/internal/domain/repositories"
 	"github.com/tranvu1111/go-students-new/internal/infrastructure/db/postgres"
 	"gorm.io/gorm"
 )
 
-// setupTestDB initializes an in-memory SQLite database for testing.
-// It auto-migrates the DBStudent model.
+// setupTestDB opens a database private to this test, see openTestDB, and
+// auto-migrates the schema of the repositories.
 func setupTestDB(t *testing.T) (*postgres.GormStudentRepo, *gorm.DB) {
-	// Open a connection to an in-memory SQLite database.
-	db, err := gorm.Open(sqlite.Open("file::memory:?cache=shared"), &gorm.Config{})
-	if err != nil {
-		t.Fatalf("Failed to connect to in-memory database: %v", err)
-	}
+	db := openTestDB(t)
 
-	// Auto-migrate the database schema for the DBStudent model.
-	if err := db.AutoMigrate(&postgres.DBStudent{}); err != nil {
+	// Auto-migrate the database schema for the repository models.
+	if err := postgres.AutoMigrate(db); err != nil {
 		t.Fatalf("Failed to auto-migrate schema: %v", err)
 	}
 
@@ -60,7 +57,7 @@ func TestGormStudentRepo_Create(t *testing.T) {
 	}
 
 	// Call the Create function and check for an error.
-	createdStudent, err := repo.Create(testStudent)
+	createdStudent, err := repo.Create(context.Background(), testStudent)
 	if err != nil {
 		t.Errorf("Create returned an unexpected error: %v", err)
 	}
@@ -117,7 +114,7 @@ func TestGormStudentRepo_FindById(t *testing.T) {
 		}
 
 		// Now, use the repository to find the student by their ID.
-		foundStudent, err := repo.FindById(testUUID)
+		foundStudent, err := repo.FindById(context.Background(), testUUID)
 		if err != nil {
 			t.Errorf("FindById returned an unexpected error: %v", err)
 		}
@@ -149,7 +146,7 @@ func TestGormStudentRepo_FindById(t *testing.T) {
 		nonExistentUUID := uuid.New()
 		
 		// Call FindById with the non-existent UUID.
-		foundStudent, err := repo.FindById(nonExistentUUID)
+		foundStudent, err := repo.FindById(context.Background(), nonExistentUUID)
 
 		// Assert that the function returned a gorm.ErrRecordNotFound error.
 		if !errors.Is(err, gorm.ErrRecordNotFound) {
@@ -195,7 +192,7 @@ func TestGormStudentRepo_FindAll(t *testing.T) {
 			t.Fatalf("Failed to seed database for test (create student 2): %v", err)
 		}
 
-		foundStudents, err := repo.FindAll()
+		foundStudents, err := repo.FindAll(context.Background(), repositories.StudentFilter{})
 		if err != nil {
 			t.Errorf("FindAll returned an unexpected error: %v", err)
 		}
@@ -239,18 +236,18 @@ func TestGormProductRepository_Update(t *testing.T){
 		t.Fatalf("Invalid student test case")
 	}
 
-	_, err = repo.Create(validStudent)
+	_, err = repo.Create(context.Background(), validStudent)
 
 	if err != nil {
 		t.Fatal("Failed to create a new student " + err.Error())
 	}
 
 	new_dob :=time.Date(2003,time.March,11,0,0,0,0,time.Local)
-	phone := "0932323232"
+	phone := "+84932323232"
 	major := "CNTT"
 	validStudent.UpdateNewFields(&new_dob,&phone,&major)
 
-	_, err = repo.Update(validStudent)
+	_, err = repo.Update(context.Background(), validStudent)
 	if err != nil {
 		t.Fatalf("UpdateName failed or fetched wrong product")
 	}
@@ -274,14 +271,190 @@ func TestGormProductRepository_Delete(t *testing.T){
 	}
 
 
-	_,err = repo.Create(validStudent)
+	_,err = repo.Create(context.Background(), validStudent)
 	if err != nil {
 		t.Fatalf("Cannot create new student: %v",err)
 	}
 
-	err = repo.Delete(validStudent.StudentID)
+	err = repo.Delete(context.Background(), validStudent.StudentID, validStudent.Version)
 	if err != nil {
 		t.Fatalf("Failed to delete a student: %v" ,err)
 	}
 
-}
\ No newline at end of file
+}
+func TestGormStudentRepo_StreamWithFilter(t *testing.T) {
+	repo, _ := setupTestDB(t)
+	ctx := context.Background()
+
+	cs, math := "CS", "Math"
+	for i, major := range []*string{&cs, &math, &cs, nil} {
+		enrollment := time.Date(2020+i, time.September, 1, 0, 0, 0, 0, time.UTC)
+		student := entities.NewStudent("John", fmt.Sprintf("Doe%d", i), nil, fmt.Sprintf("john%d@example.com", i), nil, major, enrollment)
+		validStudent, err := entities.NewValidatedStudent(student)
+		if err != nil {
+			t.Fatalf("Invalid student test case: %v", err)
+		}
+		if _, err := repo.Create(ctx, validStudent); err != nil {
+			t.Fatalf("Cannot create new student: %v", err)
+		}
+	}
+
+	var streamed []string
+	err := repo.Stream(ctx, repositories.StudentFilter{Major: &cs}, func(s *entities.Student) error {
+		streamed = append(streamed, s.LastName)
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("Stream returned an unexpected error: %v", err)
+	}
+	if len(streamed) != 2 || streamed[0] != "Doe0" || streamed[1] != "Doe2" {
+		t.Errorf("Expected Doe0 and Doe2 in creation order, got %v", streamed)
+	}
+
+	from := time.Date(2021, time.January, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2022, time.December, 31, 0, 0, 0, 0, time.UTC)
+	found, err := repo.FindAll(ctx, repositories.StudentFilter{EnrolledFrom: &from, EnrolledTo: &to})
+	if err != nil {
+		t.Fatalf("FindAll returned an unexpected error: %v", err)
+	}
+	if len(found) != 2 {
+		t.Errorf("Expected 2 students enrolled in 2021-2022, got %d", len(found))
+	}
+
+	stop := errors.New("stop")
+	visited := 0
+	err = repo.Stream(ctx, repositories.StudentFilter{}, func(s *entities.Student) error {
+		visited++
+		return stop
+	})
+	if !errors.Is(err, stop) || visited != 1 {
+		t.Errorf("Expected Stream to stop at the first visit error, got %v after %d", err, visited)
+	}
+}
+
+func TestGormStudentRepo_FindByIdsAndPaging(t *testing.T) {
+	repo, _ := setupTestDB(t)
+	ctx := context.Background()
+
+	var ids []uuid.UUID
+	for i := 0; i < 3; i++ {
+		student := entities.NewStudent("John", fmt.Sprintf("Doe%d", i), nil, fmt.Sprintf("john%d@example.com", i), nil, nil, time.Now())
+		validStudent, err := entities.NewValidatedStudent(student)
+		if err != nil {
+			t.Fatalf("Invalid student test case: %v", err)
+		}
+		if _, err := repo.Create(ctx, validStudent); err != nil {
+			t.Fatalf("Cannot create new student: %v", err)
+		}
+		ids = append(ids, student.StudentID)
+		time.Sleep(time.Millisecond)
+	}
+
+	found, err := repo.FindByIds(ctx, []uuid.UUID{ids[2], uuid.New(), ids[0]})
+	if err != nil {
+		t.Fatalf("FindByIds returned an unexpected error: %v", err)
+	}
+	if len(found) != 2 {
+		t.Errorf("Expected the 2 existing students, got %d", len(found))
+	}
+
+	page, err := repo.FindAll(ctx, repositories.StudentFilter{Offset: 1, Limit: 1})
+	if err != nil {
+		t.Fatalf("FindAll returned an unexpected error: %v", err)
+	}
+	if len(page) != 1 || page[0].StudentID != ids[1] {
+		t.Errorf("Expected the second student on the second page, got %v", page)
+	}
+}
+
+func TestGormStudentRepo_UpdateClearsFields(t *testing.T) {
+	repo, _ := setupTestDB(t)
+	ctx := context.Background()
+
+	phone, major := "+84932323232", "CNTT"
+	student := entities.NewStudent("John", "Doe", nil, "john.doe@example.com", &phone, &major, time.Now())
+	validStudent, err := entities.NewValidatedStudent(student)
+	if err != nil {
+		t.Fatalf("Invalid student test case: %v", err)
+	}
+	if _, err := repo.Create(ctx, validStudent); err != nil {
+		t.Fatalf("Cannot create new student: %v", err)
+	}
+
+	if err := validStudent.ApplyPatch(entities.StudentPatch{Phone: entities.Clear[string](), FirstName: entities.Replace("Johnny")}); err != nil {
+		t.Fatalf("Cannot patch student: %v", err)
+	}
+	updated, err := repo.Update(ctx, validStudent)
+	if err != nil {
+		t.Fatalf("Update returned an unexpected error: %v", err)
+	}
+
+	if updated.Phone != nil {
+		t.Errorf("Expected the phone to be cleared, got %v", *updated.Phone)
+	}
+	if updated.FirstName != "Johnny" || updated.Major == nil || *updated.Major != "CNTT" {
+		t.Errorf("Expected the other fields to be stored as patched, got %+v", updated)
+	}
+
+	_, err = repo.FindById(ctx, uuid.New())
+	if !errors.Is(err, repositories.ErrStudentNotFound) {
+		t.Errorf("Expected ErrStudentNotFound, got %v", err)
+	}
+}
+
+func TestGormStudentRepo_VersionConflicts(t *testing.T) {
+	repo, _ := setupTestDB(t)
+	ctx := context.Background()
+
+	student := entities.NewStudent("John", "Doe", nil, "john.doe@example.com", nil, nil, time.Now())
+	validStudent, err := entities.NewValidatedStudent(student)
+	if err != nil {
+		t.Fatalf("Invalid student test case: %v", err)
+	}
+	if _, err := repo.Create(ctx, validStudent); err != nil {
+		t.Fatalf("Cannot create new student: %v", err)
+	}
+
+	// Two advisors read version 1 and both try to save.
+	first, _ := repo.FindById(ctx, student.StudentID)
+	second, _ := repo.FindById(ctx, student.StudentID)
+
+	major := "CNTT"
+	first.UpdateNewFields(nil, nil, &major)
+	validFirst, _ := entities.NewValidatedStudent(first)
+	updated, err := repo.Update(ctx, validFirst)
+	if err != nil {
+		t.Fatalf("First update failed: %v", err)
+	}
+	if updated.Version != 2 {
+		t.Errorf("Expected version 2 after the first update, got %d", updated.Version)
+	}
+
+	phone := "+84932323232"
+	second.UpdateNewFields(nil, &phone, nil)
+	validSecond, _ := entities.NewValidatedStudent(second)
+	_, err = repo.Update(ctx, validSecond)
+
+	var conflict *repositories.VersionConflictError
+	if !errors.As(err, &conflict) {
+		t.Fatalf("Expected a VersionConflictError, got %v", err)
+	}
+	if conflict.ExpectedVersion != 1 || conflict.CurrentVersion != 2 {
+		t.Errorf("Expected conflict between versions 1 and 2, got %+v", conflict)
+	}
+
+	if err := repo.Delete(ctx, student.StudentID, 1); !errors.As(err, &conflict) {
+		t.Errorf("Expected Delete with a stale version to conflict, got %v", err)
+	}
+	if err := repo.Delete(ctx, student.StudentID, 2); err != nil {
+		t.Fatalf("Delete with the current version failed: %v", err)
+	}
+
+	restored, err := repo.Restore(ctx, student.StudentID)
+	if err != nil {
+		t.Fatalf("Restore failed: %v", err)
+	}
+	if restored.Version != 3 || restored.Phone != nil {
+		t.Errorf("Expected the restored student at version 3 without the rejected phone, got %+v", restored)
+	}
+}
@@ -0,0 +1,147 @@
+package db_test
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tranvu1111/go-students-new/internal/domain/entities"
+	"github.com/tranvu1111/go-students-new/internal/domain/repositories"
+	"github.com/tranvu1111/go-students-new/internal/infrastructure/db/postgres"
+	"github.com/tranvu1111/go-students-new/internal/infrastructure/outbox"
+	"github.com/tranvu1111/go-students-new/internal/infrastructure/webhook"
+)
+
+const testWebhookSecret = "0123456789abcdef"
+
+type webhookReceiver struct {
+	mu         sync.Mutex
+	statusCode int
+	verified   []string
+	errors     []error
+}
+
+func (wr *webhookReceiver) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	wr.mu.Lock()
+	defer wr.mu.Unlock()
+
+	body, _ := io.ReadAll(r.Body)
+	if err := webhook.Verify(testWebhookSecret, r.Header.Get(webhook.SignatureHeader), body, time.Now(), time.Minute); err != nil {
+		wr.errors = append(wr.errors, err)
+	} else {
+		wr.verified = append(wr.verified, r.Header.Get(webhook.EventTypeHeader))
+	}
+	w.WriteHeader(wr.statusCode)
+}
+
+func TestWebhookDispatcher_DeliversSignedEvents(t *testing.T) {
+	repo, db := setupTestDB(t)
+	ctx := context.Background()
+	subscriptionRepo := postgres.NewGormWebhookSubscriptionRepo(db)
+	deliveryRepo := postgres.NewGormWebhookDeliveryRepo(db)
+
+	receiver := &webhookReceiver{statusCode: http.StatusInternalServerError}
+	server := httptest.NewServer(receiver)
+	defer server.Close()
+
+	subscription, err := entities.NewWebhookSubscription(server.URL, []string{string(entities.StudentCreated)}, testWebhookSecret)
+	require.NoError(t, err)
+	_, err = subscriptionRepo.Create(ctx, subscription)
+	require.NoError(t, err)
+
+	student := entities.NewStudent("John", "Doe", nil, "john.doe@aloalo.com", nil, nil, time.Now())
+	validStudent, err := entities.NewValidatedStudent(student)
+	require.NoError(t, err)
+	_, err = repo.Create(ctx, validStudent)
+	require.NoError(t, err)
+	require.NoError(t, repo.Delete(ctx, student.StudentID, 1))
+
+	relay := outbox.NewRelay(postgres.NewGormOutboxRepo(db), time.Second, 10, entities.DefaultOutboxRetryPolicy, webhook.NewSubscriptionSink(subscriptionRepo, deliveryRepo))
+	published, err := relay.RelayOnce(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 2, published)
+
+	policy := entities.WebhookRetryPolicy{MaxAttempts: 3, BaseDelay: 0, MaxDelay: 0}
+	dispatcher := webhook.NewDispatcher(subscriptionRepo, deliveryRepo, server.Client(), policy)
+
+	attempted, err := dispatcher.DispatchDue(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 1, attempted, "only the subscribed event type is delivered")
+
+	receiver.mu.Lock()
+	receiver.statusCode = http.StatusOK
+	receiver.mu.Unlock()
+
+	_, err = dispatcher.DispatchDue(ctx)
+	require.NoError(t, err)
+
+	deliveries, total, err := deliveryRepo.FindBySubscriptionId(ctx, subscription.ID, 0, 10)
+	require.NoError(t, err)
+	require.Equal(t, int64(1), total)
+	assert.Equal(t, entities.WebhookDeliverySucceeded, deliveries[0].Status)
+	assert.Equal(t, 2, deliveries[0].Attempts)
+	assert.Equal(t, http.StatusOK, deliveries[0].LastStatusCode)
+
+	assert.Empty(t, receiver.errors)
+	assert.Equal(t, []string{string(entities.StudentCreated), string(entities.StudentCreated)}, receiver.verified)
+
+	attempted, err = dispatcher.DispatchDue(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 0, attempted)
+}
+
+func TestWebhookDispatcher_DeadLettersAfterMaxAttempts(t *testing.T) {
+	repo, db := setupTestDB(t)
+	ctx := context.Background()
+	subscriptionRepo := postgres.NewGormWebhookSubscriptionRepo(db)
+	deliveryRepo := postgres.NewGormWebhookDeliveryRepo(db)
+
+	server := httptest.NewServer(&webhookReceiver{statusCode: http.StatusBadGateway})
+	defer server.Close()
+
+	subscription, err := entities.NewWebhookSubscription(server.URL, []string{entities.WildcardEventType}, testWebhookSecret)
+	require.NoError(t, err)
+	_, err = subscriptionRepo.Create(ctx, subscription)
+	require.NoError(t, err)
+
+	student := entities.NewStudent("John", "Doe", nil, "john.doe@aloalo.com", nil, nil, time.Now())
+	validStudent, err := entities.NewValidatedStudent(student)
+	require.NoError(t, err)
+	_, err = repo.Create(ctx, validStudent)
+	require.NoError(t, err)
+
+	relay := outbox.NewRelay(postgres.NewGormOutboxRepo(db), time.Second, 10, entities.DefaultOutboxRetryPolicy, webhook.NewSubscriptionSink(subscriptionRepo, deliveryRepo))
+	_, err = relay.RelayOnce(ctx)
+	require.NoError(t, err)
+
+	dispatcher := webhook.NewDispatcher(subscriptionRepo, deliveryRepo, server.Client(), entities.WebhookRetryPolicy{MaxAttempts: 2})
+	for i := 0; i < 3; i++ {
+		_, err = dispatcher.DispatchDue(ctx)
+		require.NoError(t, err)
+	}
+
+	deliveries, _, err := deliveryRepo.FindBySubscriptionId(ctx, subscription.ID, 0, 10)
+	require.NoError(t, err)
+	require.Len(t, deliveries, 1)
+	assert.Equal(t, entities.WebhookDeliveryDead, deliveries[0].Status)
+	assert.Equal(t, 2, deliveries[0].Attempts)
+	assert.Equal(t, http.StatusBadGateway, deliveries[0].LastStatusCode)
+}
+
+func TestWebhookSubscriptionRepo_NotFound(t *testing.T) {
+	_, db := setupTestDB(t)
+	ctx := context.Background()
+	subscriptionRepo := postgres.NewGormWebhookSubscriptionRepo(db)
+
+	_, err := subscriptionRepo.FindById(ctx, uuid.New())
+	assert.ErrorIs(t, err, repositories.ErrWebhookSubscriptionNotFound)
+	err = subscriptionRepo.Delete(ctx, uuid.New())
+	assert.ErrorIs(t, err, repositories.ErrWebhookSubscriptionNotFound)
+}
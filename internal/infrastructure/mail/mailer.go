@@ -0,0 +1,149 @@
+// Package mail sends the emails the system writes to students.
+package mail
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"mime"
+	"mime/multipart"
+	"net"
+	"net/smtp"
+	"net/textproto"
+	"os"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// Message is one email. HTML is optional; when set the email carries both
+// bodies and the reader's client picks one.
+type Message struct {
+	To      string
+	Subject string
+	Text    string
+	HTML    string `json:",omitempty"`
+}
+
+// Mailer sends a message or returns why it could not. Callers retry, so a
+// message may be sent more than once.
+type Mailer interface {
+	Send(ctx context.Context, message Message) error
+}
+
+// SMTPMailer sends through an SMTP server, with STARTTLS when the server
+// offers it. Username may be empty for servers that need no login.
+type SMTPMailer struct {
+	addr string
+	host string
+	from string
+	auth smtp.Auth
+}
+
+func NewSMTPMailer(host string, port int, username string, password string, from string) *SMTPMailer {
+	mailer := &SMTPMailer{addr: net.JoinHostPort(host, strconv.Itoa(port)), host: host, from: from}
+	if username != "" {
+		mailer.auth = smtp.PlainAuth("", username, password, host)
+	}
+	return mailer
+}
+
+func (m *SMTPMailer) Send(ctx context.Context, message Message) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	body, err := encode(m.from, message, time.Now())
+	if err != nil {
+		return err
+	}
+	if err := smtp.SendMail(m.addr, m.auth, m.from, []string{message.To}, body); err != nil {
+		return fmt.Errorf("smtp %s: %w", m.addr, err)
+	}
+	return nil
+}
+
+// encode renders message as an RFC 5322 email.
+func encode(from string, message Message, date time.Time) ([]byte, error) {
+	var buf bytes.Buffer
+	header := textproto.MIMEHeader{}
+	header.Set("From", from)
+	header.Set("To", message.To)
+	header.Set("Subject", mime.QEncoding.Encode("utf-8", message.Subject))
+	header.Set("Date", date.Format(time.RFC1123Z))
+	header.Set("MIME-Version", "1.0")
+
+	if message.HTML == "" {
+		header.Set("Content-Type", "text/plain; charset=utf-8")
+		writeHeader(&buf, header)
+		buf.WriteString(message.Text)
+		return buf.Bytes(), nil
+	}
+
+	var body bytes.Buffer
+	parts := multipart.NewWriter(&body)
+	for _, part := range []struct{ contentType, content string }{
+		{"text/plain; charset=utf-8", message.Text},
+		{"text/html; charset=utf-8", message.HTML},
+	} {
+		w, err := parts.CreatePart(textproto.MIMEHeader{"Content-Type": {part.contentType}})
+		if err != nil {
+			return nil, err
+		}
+		if _, err := io.WriteString(w, part.content); err != nil {
+			return nil, err
+		}
+	}
+	if err := parts.Close(); err != nil {
+		return nil, err
+	}
+	header.Set("Content-Type", "multipart/alternative; boundary="+parts.Boundary())
+	writeHeader(&buf, header)
+	buf.Write(body.Bytes())
+	return buf.Bytes(), nil
+}
+
+func writeHeader(buf *bytes.Buffer, header textproto.MIMEHeader) {
+	for _, name := range []string{"From", "To", "Subject", "Date", "MIME-Version", "Content-Type"} {
+		fmt.Fprintf(buf, "%s: %s\r\n", name, header.Get(name))
+	}
+	buf.WriteString("\r\n")
+}
+
+// WriterMailer writes each message as one JSON line instead of sending it,
+// for local runs and tests.
+type WriterMailer struct {
+	mu sync.Mutex
+	w  io.Writer
+}
+
+func NewWriterMailer(w io.Writer) *WriterMailer {
+	return &WriterMailer{w: w}
+}
+
+// NewLogMailer writes the messages to stdout.
+func NewLogMailer() *WriterMailer {
+	return NewWriterMailer(os.Stdout)
+}
+
+// NewFileMailer appends to the file at path, creating it when needed.
+func NewFileMailer(path string) (*WriterMailer, error) {
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o644)
+	if err != nil {
+		return nil, err
+	}
+	return NewWriterMailer(f), nil
+}
+
+func (m *WriterMailer) Send(ctx context.Context, message Message) error {
+	line, err := json.Marshal(message)
+	if err != nil {
+		return err
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	_, err = fmt.Fprintln(m.w, string(line))
+	return err
+}
@@ -0,0 +1,49 @@
+package memory
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/tranvu1111/go-students-new/internal/domain/entities"
+	"github.com/tranvu1111/go-students-new/internal/domain/repositories"
+)
+
+type AuditRepo struct {
+	store *Store
+}
+
+func NewAuditRepo(store *Store) repositories.AuditRepository {
+	return &AuditRepo{store: store}
+}
+
+// FindByStudentId returns one page of the student's history, newest first,
+// together with the total number of entries.
+func (repo *AuditRepo) FindByStudentId(ctx context.Context, studentID uuid.UUID, offset int, limit int) ([]*entities.AuditEntry, int64, error) {
+	repo.store.mu.RLock()
+	defer repo.store.mu.RUnlock()
+
+	// Entries are appended in the order they occurred.
+	var history []*entities.AuditEntry
+	for i := len(repo.store.audit) - 1; i >= 0; i-- {
+		if entry := repo.store.audit[i]; entry.StudentID == studentID {
+			history = append(history, entry)
+		}
+	}
+
+	total := int64(len(history))
+	if offset >= len(history) {
+		return []*entities.AuditEntry{}, total, nil
+	}
+	history = history[offset:]
+	if limit >= 0 && len(history) > limit {
+		history = history[:limit]
+	}
+
+	entries := make([]*entities.AuditEntry, len(history))
+	for i, entry := range history {
+		copied := *entry
+		copied.Changes = append([]entities.FieldChange(nil), entry.Changes...)
+		entries[i] = &copied
+	}
+	return entries, total, nil
+}
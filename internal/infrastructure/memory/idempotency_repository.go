@@ -0,0 +1,86 @@
+package memory
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/tranvu1111/go-students-new/internal/domain/entities"
+	"github.com/tranvu1111/go-students-new/internal/domain/repositories"
+)
+
+type IdempotencyRepo struct {
+	mu      sync.RWMutex
+	records map[uuid.UUID]entities.IdempotencyRecord
+}
+
+func NewIdempotencyRepository() repositories.IdempotencyRepository {
+	return &IdempotencyRepo{records: make(map[uuid.UUID]entities.IdempotencyRecord)}
+}
+
+func (repo *IdempotencyRepo) FindByKey(ctx context.Context, key string) (*entities.IdempotencyRecord, error) {
+	repo.mu.RLock()
+	defer repo.mu.RUnlock()
+
+	for _, record := range repo.records {
+		if record.Key == key {
+			return &record, nil
+		}
+	}
+	return nil, nil
+}
+
+func (repo *IdempotencyRepo) Create(ctx context.Context, record *entities.IdempotencyRecord) (*entities.IdempotencyRecord, error) {
+	repo.mu.Lock()
+	defer repo.mu.Unlock()
+
+	if _, exists := repo.records[record.ID]; exists {
+		return nil, fmt.Errorf("idempotency record %s already exists", record.ID)
+	}
+	if err := repo.checkKey(record); err != nil {
+		return nil, err
+	}
+	return repo.save(record), nil
+}
+
+func (repo *IdempotencyRepo) Update(ctx context.Context, record *entities.IdempotencyRecord) (*entities.IdempotencyRecord, error) {
+	repo.mu.Lock()
+	defer repo.mu.Unlock()
+
+	if err := repo.checkKey(record); err != nil {
+		return nil, err
+	}
+	return repo.save(record), nil
+}
+
+func (repo *IdempotencyRepo) DeleteOlderThan(ctx context.Context, cutoff time.Time) (int64, error) {
+	repo.mu.Lock()
+	defer repo.mu.Unlock()
+
+	var deleted int64
+	for id, record := range repo.records {
+		if record.CreatedAt.Before(cutoff) {
+			delete(repo.records, id)
+			deleted++
+		}
+	}
+	return deleted, nil
+}
+
+// checkKey enforces the unique index on the key. The caller holds the lock.
+func (repo *IdempotencyRepo) checkKey(record *entities.IdempotencyRecord) error {
+	for id, other := range repo.records {
+		if id != record.ID && other.Key == record.Key {
+			return fmt.Errorf("%w: %s", repositories.ErrDuplicateIdempotencyKey, record.Key)
+		}
+	}
+	return nil
+}
+
+func (repo *IdempotencyRepo) save(record *entities.IdempotencyRecord) *entities.IdempotencyRecord {
+	stored := *record
+	repo.records[record.ID] = stored
+	return &stored
+}
@@ -0,0 +1,87 @@
+package memory
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/tranvu1111/go-students-new/internal/domain/entities"
+	"github.com/tranvu1111/go-students-new/internal/domain/repositories"
+)
+
+type OutboxRepo struct {
+	store *Store
+}
+
+// NewOutboxRepo relays the events the student repository of store wrote.
+func NewOutboxRepo(store *Store) repositories.OutboxRepository {
+	return &OutboxRepo{store: store}
+}
+
+func (repo *OutboxRepo) FindUnpublished(ctx context.Context, now time.Time, limit int) ([]*entities.OutboxMessage, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	repo.store.mu.RLock()
+	defer repo.store.mu.RUnlock()
+
+	var due []*entities.OutboxMessage
+	for _, message := range repo.store.outbox {
+		if message.PublishedAt == nil && message.DeadAt == nil && !message.NextAttemptAt.After(now) {
+			copied := *message
+			due = append(due, &copied)
+		}
+	}
+	sort.SliceStable(due, func(i, j int) bool {
+		return due[i].OccurredAt.Before(due[j].OccurredAt)
+	})
+	if len(due) > limit {
+		due = due[:limit]
+	}
+	return due, nil
+}
+
+func (repo *OutboxRepo) MarkPublished(ctx context.Context, id uuid.UUID, publishedAt time.Time) error {
+	repo.store.mu.Lock()
+	defer repo.store.mu.Unlock()
+
+	for _, message := range repo.store.outbox {
+		if message.ID == id {
+			message.PublishedAt = &publishedAt
+			message.Attempts++
+			message.LastError = ""
+		}
+	}
+	return nil
+}
+
+func (repo *OutboxRepo) MarkFailed(ctx context.Context, failed *entities.OutboxMessage) error {
+	repo.store.mu.Lock()
+	defer repo.store.mu.Unlock()
+
+	for _, message := range repo.store.outbox {
+		if message.ID == failed.ID {
+			message.Attempts = failed.Attempts
+			message.LastError = failed.LastError
+			message.NextAttemptAt = failed.NextAttemptAt
+			message.DeadAt = failed.DeadAt
+		}
+	}
+	return nil
+}
+
+// outboxMessages builds the messages of events before a write changes the
+// store, so that a write with an event that cannot be stored changes nothing.
+func outboxMessages(events []entities.StudentEvent) ([]*entities.OutboxMessage, error) {
+	messages := make([]*entities.OutboxMessage, 0, len(events))
+	for _, event := range events {
+		message, err := entities.NewOutboxMessage(event)
+		if err != nil {
+			return nil, err
+		}
+		messages = append(messages, message)
+	}
+	return messages, nil
+}
@@ -0,0 +1,108 @@
+package memory
+
+import (
+	"context"
+	"sort"
+
+	"github.com/google/uuid"
+	"github.com/tranvu1111/go-students-new/internal/domain/entities"
+	"github.com/tranvu1111/go-students-new/internal/domain/repositories"
+)
+
+type ProgramRepo struct {
+	store *Store
+}
+
+func NewProgramRepo(store *Store) repositories.ProgramRepository {
+	return &ProgramRepo{store: store}
+}
+
+func (repo *ProgramRepo) Create(ctx context.Context, program *entities.Program) (*entities.Program, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	repo.store.mu.Lock()
+	defer repo.store.mu.Unlock()
+
+	for _, stored := range repo.store.programs {
+		if stored.Code == program.Code {
+			return nil, repositories.ErrDuplicateProgramCode
+		}
+	}
+	stored := copyProgram(program)
+	repo.store.programs[stored.ID] = stored
+	return copyProgram(stored), nil
+}
+
+func (repo *ProgramRepo) FindById(ctx context.Context, id uuid.UUID) (*entities.Program, error) {
+	repo.store.mu.RLock()
+	defer repo.store.mu.RUnlock()
+
+	program, ok := repo.store.programs[id]
+	if !ok {
+		return nil, repositories.ErrProgramNotFound
+	}
+	return copyProgram(program), nil
+}
+
+func (repo *ProgramRepo) FindByIds(ctx context.Context, ids []uuid.UUID) ([]*entities.Program, error) {
+	repo.store.mu.RLock()
+	defer repo.store.mu.RUnlock()
+
+	programs := []*entities.Program{}
+	seen := make(map[uuid.UUID]bool, len(ids))
+	for _, id := range ids {
+		if program, ok := repo.store.programs[id]; ok && !seen[id] {
+			seen[id] = true
+			programs = append(programs, copyProgram(program))
+		}
+	}
+	return programs, nil
+}
+
+func (repo *ProgramRepo) FindAll(ctx context.Context, filter repositories.ProgramFilter) ([]*entities.Program, error) {
+	repo.store.mu.RLock()
+	defer repo.store.mu.RUnlock()
+
+	programs := []*entities.Program{}
+	for _, program := range repo.store.programs {
+		switch {
+		case filter.Active != nil && program.Active != *filter.Active:
+		case filter.Department != nil && program.Department != *filter.Department:
+		case filter.DegreeLevel != nil && program.DegreeLevel != *filter.DegreeLevel:
+		default:
+			programs = append(programs, copyProgram(program))
+		}
+	}
+
+	sort.Slice(programs, func(i, j int) bool { return programs[i].Code < programs[j].Code })
+	return programs, nil
+}
+
+// Update keeps the stored code and creation time, like the Gorm repository.
+func (repo *ProgramRepo) Update(ctx context.Context, program *entities.Program) (*entities.Program, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	repo.store.mu.Lock()
+	defer repo.store.mu.Unlock()
+
+	stored, ok := repo.store.programs[program.ID]
+	if !ok {
+		return nil, repositories.ErrProgramNotFound
+	}
+	updated := copyProgram(program)
+	updated.Code, updated.CreatedAt = stored.Code, stored.CreatedAt
+	repo.store.programs[updated.ID] = updated
+	return copyProgram(updated), nil
+}
+
+// copyProgram returns a copy in UTC, like the Gorm repositories return it.
+func copyProgram(program *entities.Program) *entities.Program {
+	copied := *program
+	copied.CreatedAt = program.CreatedAt.UTC()
+	copied.UpdatedAt = program.UpdatedAt.UTC()
+	return &copied
+}
@@ -0,0 +1,105 @@
+// Package memory keeps students, their audit trail, status history, outbox,
+// the program catalog and idempotency records in process memory. It has the
+// semantics of the Gorm repositories, checked by the shared suite in
+// repotest, and serves tests and embedded use that need no database.
+package memory
+
+import (
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/tranvu1111/go-students-new/internal/domain/entities"
+)
+
+// Store is the in-memory counterpart of the database: the student and audit
+// repositories built on the same Store see each other's writes, and every
+// write takes the lock once, so that it is atomic like a transaction.
+type Store struct {
+	mu       sync.RWMutex
+	students map[uuid.UUID]*storedStudent
+	audit    []*entities.AuditEntry
+	statuses []*entities.StudentStatusChange
+	outbox   []*entities.OutboxMessage
+	programs map[uuid.UUID]*entities.Program
+}
+
+type storedStudent struct {
+	student   entities.Student
+	deletedAt *time.Time
+}
+
+func NewStore() *Store {
+	return &Store{
+		students: make(map[uuid.UUID]*storedStudent),
+		programs: make(map[uuid.UUID]*entities.Program),
+	}
+}
+
+// copyStudent returns a copy that shares no pointers with student and carries
+// no events, in UTC like the Gorm repositories return them.
+func copyStudent(student *entities.Student) *entities.Student {
+	return &entities.Student{
+		StudentID:           student.StudentID,
+		FirstName:           student.FirstName,
+		LastName:            student.LastName,
+		DateOfBirth:         copyTime(student.DateOfBirth),
+		Email:               student.Email,
+		EmailVerifiedAt:     copyTime(student.EmailVerifiedAt),
+		Phone:               copyString(student.Phone),
+		Major:               copyString(student.Major),
+		Locale:              copyString(student.Locale),
+		Programs:            copyPrograms(student.Programs),
+		Contacts:            copyContacts(student.Contacts),
+		EnrollmentDate:      student.EnrollmentDate.UTC(),
+		Status:              student.Status,
+		StatusReason:        student.StatusReason,
+		StatusEffectiveDate: student.StatusEffectiveDate.UTC(),
+		CreatedAt:           student.CreatedAt.UTC(),
+		UpdatedAt:           student.UpdatedAt.UTC(),
+		Version:             student.Version,
+	}
+}
+
+func copyTime(t *time.Time) *time.Time {
+	if t == nil {
+		return nil
+	}
+	utc := t.UTC()
+	return &utc
+}
+
+func copyString(s *string) *string {
+	if s == nil {
+		return nil
+	}
+	value := *s
+	return &value
+}
+
+func copyPrograms(programs []entities.StudentProgram) []entities.StudentProgram {
+	if len(programs) == 0 {
+		return nil
+	}
+	copied := make([]entities.StudentProgram, len(programs))
+	for i, program := range programs {
+		program.DeclaredAt = program.DeclaredAt.UTC()
+		copied[i] = program
+	}
+	return copied
+}
+
+func copyContacts(contacts entities.StudentContacts) entities.StudentContacts {
+	copied := contacts.Clone()
+	for i := range copied.Addresses {
+		copied.Addresses[i].ValidFrom = copied.Addresses[i].ValidFrom.UTC()
+		copied.Addresses[i].ValidTo = copyTime(copied.Addresses[i].ValidTo)
+	}
+	for i := range copied.Emails {
+		copied.Emails[i].VerifiedAt = copyTime(copied.Emails[i].VerifiedAt)
+	}
+	for i := range copied.Phones {
+		copied.Phones[i].VerifiedAt = copyTime(copied.Phones[i].VerifiedAt)
+	}
+	return copied
+}
@@ -0,0 +1,315 @@
+package memory
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/tranvu1111/go-students-new/internal/domain/entities"
+	"github.com/tranvu1111/go-students-new/internal/domain/repositories"
+	"github.com/tranvu1111/go-students-new/internal/domain/search"
+)
+
+type StudentRepo struct {
+	store *Store
+}
+
+func NewStudentRepo(store *Store) repositories.StudentRepository {
+	return &StudentRepo{store: store}
+}
+
+func (repo *StudentRepo) Create(ctx context.Context, student *entities.ValidatedStudent) (*entities.Student, error) {
+	if err := repo.CreateBatch(ctx, []*entities.ValidatedStudent{student}, 1); err != nil {
+		return nil, err
+	}
+	return repo.FindById(ctx, student.StudentID)
+}
+
+// CreateBatch stores every student or, when one of them cannot be stored,
+// none of them.
+func (repo *StudentRepo) CreateBatch(ctx context.Context, students []*entities.ValidatedStudent, batchSize int) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	if len(students) == 0 {
+		return nil
+	}
+
+	repo.store.mu.Lock()
+	defer repo.store.mu.Unlock()
+
+	emails := make(map[string]uuid.UUID, len(students))
+	for _, student := range students {
+		if _, exists := repo.store.students[student.StudentID]; exists {
+			return fmt.Errorf("student %s already exists", student.StudentID)
+		}
+		email := strings.ToLower(student.Email)
+		if _, taken := emails[email]; taken {
+			return fmt.Errorf("%w: %s", repositories.ErrDuplicateEmail, student.Email)
+		}
+		if err := repo.checkEmail(student.StudentID, student.Email); err != nil {
+			return err
+		}
+		emails[email] = student.StudentID
+	}
+
+	var events []entities.StudentEvent
+	for _, student := range students {
+		events = append(events, student.Events()...)
+	}
+	messages, err := outboxMessages(events)
+	if err != nil {
+		return err
+	}
+
+	repo.store.outbox = append(repo.store.outbox, messages...)
+	for _, student := range students {
+		repo.store.students[student.StudentID] = &storedStudent{student: *copyStudent(&student.Student)}
+		repo.store.audit = append(repo.store.audit, entities.NewAuditEntry(ctx, student.StudentID, entities.AuditOperationCreate, entities.DiffStudents(nil, &student.Student)))
+	}
+	for _, student := range students {
+		student.ClearEvents()
+	}
+	return nil
+}
+
+func (repo *StudentRepo) FindById(ctx context.Context, id uuid.UUID) (*entities.Student, error) {
+	repo.store.mu.RLock()
+	defer repo.store.mu.RUnlock()
+
+	stored, ok := repo.store.students[id]
+	if !ok || stored.deletedAt != nil {
+		return nil, fmt.Errorf("%w: %s", repositories.ErrStudentNotFound, id)
+	}
+	return copyStudent(&stored.student), nil
+}
+
+func (repo *StudentRepo) FindByIds(ctx context.Context, ids []uuid.UUID) ([]*entities.Student, error) {
+	if len(ids) == 0 {
+		return nil, nil
+	}
+
+	repo.store.mu.RLock()
+	defer repo.store.mu.RUnlock()
+
+	seen := make(map[uuid.UUID]bool, len(ids))
+	var students []*entities.Student
+	for _, id := range ids {
+		stored, ok := repo.store.students[id]
+		if !ok || stored.deletedAt != nil || seen[id] {
+			continue
+		}
+		seen[id] = true
+		students = append(students, copyStudent(&stored.student))
+	}
+	return students, nil
+}
+
+// FindAll returns the matches oldest first, which the Gorm repository only
+// guarantees when paging.
+func (repo *StudentRepo) FindAll(ctx context.Context, filter repositories.StudentFilter) ([]*entities.Student, error) {
+	students := repo.matching(filter)
+	if filter.Limit > 0 {
+		if filter.Offset >= len(students) {
+			return []*entities.Student{}, nil
+		}
+		students = students[filter.Offset:]
+		if len(students) > filter.Limit {
+			students = students[:filter.Limit]
+		}
+	}
+	return students, nil
+}
+
+// Stream visits a snapshot of the matches, so that visit may call back into
+// the repository.
+func (repo *StudentRepo) Stream(ctx context.Context, filter repositories.StudentFilter, visit func(*entities.Student) error) error {
+	for _, student := range repo.matching(filter) {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		if err := visit(student); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// matching returns copies of the live students that pass filter, oldest first.
+func (repo *StudentRepo) matching(filter repositories.StudentFilter) []*entities.Student {
+	repo.store.mu.RLock()
+	defer repo.store.mu.RUnlock()
+
+	students := make([]*entities.Student, 0, len(repo.store.students))
+	for _, stored := range repo.store.students {
+		if stored.deletedAt != nil || !matchesFilter(&stored.student, filter) {
+			continue
+		}
+		students = append(students, copyStudent(&stored.student))
+	}
+
+	sort.Slice(students, func(i, j int) bool {
+		if !students[i].CreatedAt.Equal(students[j].CreatedAt) {
+			return students[i].CreatedAt.Before(students[j].CreatedAt)
+		}
+		return students[i].StudentID.String() < students[j].StudentID.String()
+	})
+	return students
+}
+
+func matchesFilter(student *entities.Student, filter repositories.StudentFilter) bool {
+	if filter.Major != nil && (student.Major == nil || *student.Major != *filter.Major) {
+		return false
+	}
+	if filter.Status != nil && student.Status != *filter.Status {
+		return false
+	}
+	if filter.EnrolledFrom != nil && student.EnrollmentDate.Before(*filter.EnrolledFrom) {
+		return false
+	}
+	if filter.EnrolledTo != nil && student.EnrollmentDate.After(*filter.EnrolledTo) {
+		return false
+	}
+	return true
+}
+
+func (repo *StudentRepo) Update(ctx context.Context, student *entities.ValidatedStudent) (*entities.Student, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	repo.store.mu.Lock()
+	defer repo.store.mu.Unlock()
+
+	stored, err := repo.findForWrite(student.StudentID, student.Version)
+	if err != nil {
+		return nil, err
+	}
+	if err := repo.checkEmail(student.StudentID, student.Email); err != nil {
+		return nil, err
+	}
+	messages, err := outboxMessages(student.Events())
+	if err != nil {
+		return nil, err
+	}
+
+	repo.store.outbox = append(repo.store.outbox, messages...)
+	before := copyStudent(&stored.student)
+	after := copyStudent(&student.Student)
+	after.CreatedAt = before.CreatedAt
+	after.Version = before.Version + 1
+	stored.student = *after
+
+	repo.store.audit = append(repo.store.audit, entities.NewAuditEntry(ctx, student.StudentID, entities.AuditOperationUpdate, entities.DiffStudents(before, after)))
+	actor, requestID := entities.AuditActorFromContext(ctx)
+	for _, change := range student.StatusChanges() {
+		change.Actor, change.RequestID = actor, requestID
+		change.EffectiveDate, change.RecordedAt = change.EffectiveDate.UTC(), change.RecordedAt.UTC()
+		repo.store.statuses = append(repo.store.statuses, &change)
+	}
+	student.ClearEvents()
+	return copyStudent(after), nil
+}
+
+// Delete soft deletes the student so that it can be restored later.
+func (repo *StudentRepo) Delete(ctx context.Context, id uuid.UUID, expectedVersion int) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	repo.store.mu.Lock()
+	defer repo.store.mu.Unlock()
+
+	stored, err := repo.findForWrite(id, expectedVersion)
+	if err != nil {
+		return err
+	}
+
+	deleted := copyStudent(&stored.student)
+	deleted.MarkDeleted()
+	messages, err := outboxMessages(deleted.Events())
+	if err != nil {
+		return err
+	}
+
+	deletedAt := time.Now().UTC()
+	stored.deletedAt = &deletedAt
+	repo.store.outbox = append(repo.store.outbox, messages...)
+	repo.store.audit = append(repo.store.audit, entities.NewAuditEntry(ctx, id, entities.AuditOperationDelete, entities.DiffStudents(&stored.student, nil)))
+	return nil
+}
+
+func (repo *StudentRepo) Restore(ctx context.Context, id uuid.UUID) (*entities.Student, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	repo.store.mu.Lock()
+	defer repo.store.mu.Unlock()
+
+	stored, ok := repo.store.students[id]
+	if !ok || stored.deletedAt == nil {
+		return nil, fmt.Errorf("%w: no deleted student %s", repositories.ErrStudentNotFound, id)
+	}
+	if err := repo.checkEmail(id, stored.student.Email); err != nil {
+		return nil, err
+	}
+	restored := copyStudent(&stored.student)
+	restored.MarkRestored()
+	messages, err := outboxMessages(restored.Events())
+	if err != nil {
+		return nil, err
+	}
+
+	stored.deletedAt = nil
+	stored.student.Version++
+	repo.store.outbox = append(repo.store.outbox, messages...)
+	repo.store.audit = append(repo.store.audit, entities.NewAuditEntry(ctx, id, entities.AuditOperationRestore, entities.DiffStudents(nil, &stored.student)))
+	return copyStudent(&stored.student), nil
+}
+
+// Search scores every live student in Go, as the Gorm repository does on
+// databases without full-text search.
+func (repo *StudentRepo) Search(ctx context.Context, query string, limit int) ([]repositories.StudentSearchHit, error) {
+	var hits []repositories.StudentSearchHit
+	for _, student := range repo.matching(repositories.StudentFilter{}) {
+		if score := search.Score(query, student); score > 0 {
+			hits = append(hits, repositories.StudentSearchHit{Student: student, Score: score})
+		}
+	}
+
+	sort.SliceStable(hits, func(i, j int) bool {
+		return hits[i].Score > hits[j].Score
+	})
+	if len(hits) > limit {
+		hits = hits[:limit]
+	}
+	return hits, nil
+}
+
+// findForWrite returns the live student if the caller saw its current
+// version. The caller holds the write lock.
+func (repo *StudentRepo) findForWrite(id uuid.UUID, expectedVersion int) (*storedStudent, error) {
+	stored, ok := repo.store.students[id]
+	if !ok || stored.deletedAt != nil {
+		return nil, fmt.Errorf("%w: %s", repositories.ErrStudentNotFound, id)
+	}
+	if stored.student.Version != expectedVersion {
+		return nil, &repositories.VersionConflictError{StudentID: id, ExpectedVersion: expectedVersion, CurrentVersion: stored.student.Version}
+	}
+	return stored, nil
+}
+
+// checkEmail enforces the unique index of the Gorm repositories: no two live
+// students share an email, ignoring case. The caller holds the lock.
+func (repo *StudentRepo) checkEmail(id uuid.UUID, email string) error {
+	for otherID, other := range repo.store.students {
+		if otherID != id && other.deletedAt == nil && strings.EqualFold(other.student.Email, email) {
+			return fmt.Errorf("%w: %s", repositories.ErrDuplicateEmail, email)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,47 @@
+package memory
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/tranvu1111/go-students-new/internal/domain/entities"
+	"github.com/tranvu1111/go-students-new/internal/domain/repositories"
+)
+
+type StudentStatusRepo struct {
+	store *Store
+}
+
+func NewStudentStatusRepo(store *Store) repositories.StudentStatusRepository {
+	return &StudentStatusRepo{store: store}
+}
+
+// FindByStudentId returns one page of the student's status history, latest
+// first, together with the total number of transitions.
+func (repo *StudentStatusRepo) FindByStudentId(ctx context.Context, studentID uuid.UUID, offset int, limit int) ([]*entities.StudentStatusChange, int64, error) {
+	repo.store.mu.RLock()
+	defer repo.store.mu.RUnlock()
+
+	var history []*entities.StudentStatusChange
+	for i := len(repo.store.statuses) - 1; i >= 0; i-- {
+		if change := repo.store.statuses[i]; change.StudentID == studentID {
+			history = append(history, change)
+		}
+	}
+
+	total := int64(len(history))
+	if offset >= len(history) {
+		return []*entities.StudentStatusChange{}, total, nil
+	}
+	history = history[offset:]
+	if limit >= 0 && len(history) > limit {
+		history = history[:limit]
+	}
+
+	changes := make([]*entities.StudentStatusChange, len(history))
+	for i, change := range history {
+		copied := *change
+		changes[i] = &copied
+	}
+	return changes, total, nil
+}
@@ -0,0 +1,37 @@
+package memory
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/tranvu1111/go-students-new/internal/domain/repositories"
+)
+
+type VerificationEmailRepo struct {
+	mu   sync.RWMutex
+	sent map[uuid.UUID]time.Time
+}
+
+func NewVerificationEmailRepo() repositories.VerificationEmailRepository {
+	return &VerificationEmailRepo{sent: make(map[uuid.UUID]time.Time)}
+}
+
+func (repo *VerificationEmailRepo) WasSent(ctx context.Context, messageID uuid.UUID) (bool, error) {
+	repo.mu.RLock()
+	defer repo.mu.RUnlock()
+
+	_, sent := repo.sent[messageID]
+	return sent, nil
+}
+
+func (repo *VerificationEmailRepo) RecordSent(ctx context.Context, messageID uuid.UUID, studentID uuid.UUID, sentAt time.Time) error {
+	repo.mu.Lock()
+	defer repo.mu.Unlock()
+
+	if _, sent := repo.sent[messageID]; !sent {
+		repo.sent[messageID] = sentAt
+	}
+	return nil
+}
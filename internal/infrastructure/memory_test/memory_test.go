@@ -0,0 +1,130 @@
+package memory_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/tranvu1111/go-students-new/internal/domain/entities"
+	"github.com/tranvu1111/go-students-new/internal/domain/repositories"
+	"github.com/tranvu1111/go-students-new/internal/infrastructure/memory"
+	"github.com/tranvu1111/go-students-new/internal/infrastructure/repotest"
+)
+
+func TestStudentRepo_Conformance(t *testing.T) {
+	repotest.StudentRepository(t, func(t *testing.T) repotest.StudentRepos {
+		store := memory.NewStore()
+		return repotest.StudentRepos{Students: memory.NewStudentRepo(store), Audit: memory.NewAuditRepo(store), Statuses: memory.NewStudentStatusRepo(store), Outbox: memory.NewOutboxRepo(store)}
+	})
+}
+
+func TestIdempotencyRepo_Conformance(t *testing.T) {
+	repotest.IdempotencyRepository(t, func(t *testing.T) repositories.IdempotencyRepository {
+		return memory.NewIdempotencyRepository()
+	})
+}
+
+func TestProgramRepo_Conformance(t *testing.T) {
+	repotest.ProgramRepository(t, func(t *testing.T) repositories.ProgramRepository {
+		return memory.NewProgramRepo(memory.NewStore())
+	})
+}
+
+func newStudent(t *testing.T, email string) *entities.ValidatedStudent {
+	student, err := entities.NewValidatedStudent(entities.NewStudent("tran", "vu", nil, email, nil, nil, time.Date(2023, time.September, 1, 0, 0, 0, 0, time.UTC)))
+	require.NoError(t, err)
+	return student
+}
+
+func TestStudentRepo_ConcurrentUpdatesOfOneVersion(t *testing.T) {
+	repo := memory.NewStudentRepo(memory.NewStore())
+	ctx := context.Background()
+	created, err := repo.Create(ctx, newStudent(t, "tran.vu@example.com"))
+	require.NoError(t, err)
+
+	const writers = 20
+	var wg sync.WaitGroup
+	results := make(chan error, writers)
+	for i := 0; i < writers; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			student := *created
+			major := fmt.Sprintf("Major %d", i)
+			student.Major = &major
+			validated, err := entities.NewValidatedStudent(&student)
+			if err != nil {
+				results <- err
+				return
+			}
+			_, err = repo.Update(ctx, validated)
+			results <- err
+		}(i)
+	}
+	wg.Wait()
+	close(results)
+
+	succeeded := 0
+	for err := range results {
+		var conflict *repositories.VersionConflictError
+		switch {
+		case err == nil:
+			succeeded++
+		case errors.As(err, &conflict):
+		default:
+			t.Errorf("unexpected error: %v", err)
+		}
+	}
+	assert.Equal(t, 1, succeeded)
+
+	stored, err := repo.FindById(ctx, created.StudentID)
+	require.NoError(t, err)
+	assert.Equal(t, 2, stored.Version)
+}
+
+func TestStudentRepo_ConcurrentCreatesOfOneEmail(t *testing.T) {
+	repo := memory.NewStudentRepo(memory.NewStore())
+	ctx := context.Background()
+
+	const writers = 20
+	var wg sync.WaitGroup
+	var mu sync.Mutex
+	succeeded := 0
+	for i := 0; i < writers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, err := repo.Create(ctx, newStudent(t, "tran.vu@example.com")); err == nil {
+				mu.Lock()
+				succeeded++
+				mu.Unlock()
+			} else {
+				assert.ErrorIs(t, err, repositories.ErrDuplicateEmail)
+			}
+		}()
+	}
+	wg.Wait()
+	assert.Equal(t, 1, succeeded)
+}
+
+func TestStudentRepo_ReturnsCopies(t *testing.T) {
+	repo := memory.NewStudentRepo(memory.NewStore())
+	ctx := context.Background()
+	created, err := repo.Create(ctx, newStudent(t, "tran.vu@example.com"))
+	require.NoError(t, err)
+
+	major := "Physics"
+	created.Major = &major
+	created.FirstName = "changed"
+
+	stored, err := repo.FindById(ctx, created.StudentID)
+	require.NoError(t, err)
+	assert.Nil(t, stored.Major)
+	assert.Equal(t, "tran", stored.FirstName)
+}
@@ -0,0 +1,147 @@
+// Package notification renders the notification templates for student events
+// and sends them through pluggable channels.
+package notification
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/tranvu1111/go-students-new/internal/domain/entities"
+	"github.com/tranvu1111/go-students-new/internal/infrastructure/mail"
+)
+
+// Channel delivers a rendered notification or returns why it could not. The
+// Dispatcher retries failures, so a notification may be sent more than once.
+type Channel interface {
+	Send(ctx context.Context, notification *entities.Notification) error
+}
+
+// EmailChannel sends email notifications through a mailer.
+type EmailChannel struct {
+	mailer mail.Mailer
+}
+
+func NewEmailChannel(mailer mail.Mailer) *EmailChannel {
+	return &EmailChannel{mailer: mailer}
+}
+
+func (c *EmailChannel) Send(ctx context.Context, notification *entities.Notification) error {
+	return c.mailer.Send(ctx, mail.Message{
+		To:      notification.Recipient,
+		Subject: notification.Subject,
+		Text:    notification.Text,
+		HTML:    notification.HTML,
+	})
+}
+
+// SMSGateway posts text messages to an HTTP SMS gateway as
+// {"From": ..., "To": ..., "Text": ...}, authenticated with a bearer token.
+// Any 2xx response counts as accepted.
+type SMSGateway struct {
+	url    string
+	token  string
+	from   string
+	client *http.Client
+}
+
+func NewSMSGateway(url string, token string, from string, client *http.Client) *SMSGateway {
+	if client == nil {
+		client = &http.Client{Timeout: 10 * time.Second}
+	}
+	return &SMSGateway{url: url, token: token, from: from, client: client}
+}
+
+type smsGatewayRequest struct {
+	From string `json:",omitempty"`
+	To   string
+	Text string
+}
+
+func (g *SMSGateway) Send(ctx context.Context, notification *entities.Notification) error {
+	body, err := json.Marshal(smsGatewayRequest{From: g.from, To: notification.Recipient, Text: notification.Text})
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, g.url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if g.token != "" {
+		req.Header.Set("Authorization", "Bearer "+g.token)
+	}
+
+	resp, err := g.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 64<<10))
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("SMS gateway responded with status %d", resp.StatusCode)
+	}
+	return nil
+}
+
+// WriterChannel writes each notification as one JSON line instead of sending
+// it, for development and tests. It serves any channel.
+type WriterChannel struct {
+	mu sync.Mutex
+	w  io.Writer
+}
+
+func NewWriterChannel(w io.Writer) *WriterChannel {
+	return &WriterChannel{w: w}
+}
+
+// NewFileChannel appends to the file at path, creating it if needed.
+func NewFileChannel(path string) (*WriterChannel, error) {
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o644)
+	if err != nil {
+		return nil, err
+	}
+	return NewWriterChannel(f), nil
+}
+
+// WrittenNotification is the line WriterChannel writes.
+type WrittenNotification struct {
+	ID      string
+	Channel entities.NotificationChannel
+	Locale  string
+	To      string
+	Subject string `json:",omitempty"`
+	Text    string
+	HTML    string `json:",omitempty"`
+}
+
+func (c *WriterChannel) Send(ctx context.Context, notification *entities.Notification) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	line, err := json.Marshal(WrittenNotification{
+		ID:      notification.ID.String(),
+		Channel: notification.Channel,
+		Locale:  notification.Locale,
+		To:      notification.Recipient,
+		Subject: notification.Subject,
+		Text:    notification.Text,
+		HTML:    notification.HTML,
+	})
+	if err != nil {
+		return err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	_, err = c.w.Write(append(line, '\n'))
+	return err
+}
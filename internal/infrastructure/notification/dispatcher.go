@@ -0,0 +1,87 @@
+package notification
+
+import (
+	"context"
+	"errors"
+	"log"
+	"time"
+
+	"github.com/tranvu1111/go-students-new/internal/domain/entities"
+	"github.com/tranvu1111/go-students-new/internal/domain/repositories"
+)
+
+const (
+	defaultDispatchInterval  = time.Second
+	defaultDispatchBatchSize = 50
+)
+
+// Dispatcher sends due notifications through the channel configured for
+// each, rescheduling failures according to the retry policy.
+type Dispatcher struct {
+	notifications repositories.NotificationRepository
+	channels      map[entities.NotificationChannel]Channel
+	policy        entities.RetryPolicy
+	interval      time.Duration
+	batchSize     int
+}
+
+// NewDispatcher dead-letters notifications for a channel missing from
+// channels, so that they show up in the send log instead of piling up.
+func NewDispatcher(notifications repositories.NotificationRepository, channels map[entities.NotificationChannel]Channel, policy entities.RetryPolicy) *Dispatcher {
+	return &Dispatcher{
+		notifications: notifications,
+		channels:      channels,
+		policy:        policy,
+		interval:      defaultDispatchInterval,
+		batchSize:     defaultDispatchBatchSize,
+	}
+}
+
+// Run dispatches until ctx is cancelled.
+func (d *Dispatcher) Run(ctx context.Context) {
+	ticker := time.NewTicker(d.interval)
+	defer ticker.Stop()
+
+	for {
+		if _, err := d.DispatchDue(ctx); err != nil && !errors.Is(err, context.Canceled) {
+			log.Printf("notification dispatcher: %v", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// DispatchDue attempts every notification whose next attempt is due and
+// returns how many were attempted.
+func (d *Dispatcher) DispatchDue(ctx context.Context) (int, error) {
+	due, err := d.notifications.FindDue(ctx, time.Now(), d.batchSize)
+	if err != nil {
+		return 0, err
+	}
+
+	for _, notification := range due {
+		d.attempt(ctx, notification)
+		if err := d.notifications.Update(ctx, notification); err != nil {
+			return 0, err
+		}
+	}
+	return len(due), nil
+}
+
+func (d *Dispatcher) attempt(ctx context.Context, notification *entities.Notification) {
+	channel, ok := d.channels[notification.Channel]
+	if !ok {
+		notification.DeadLetter("no "+string(notification.Channel)+" channel is configured", time.Now())
+		return
+	}
+
+	if err := channel.Send(ctx, notification); err != nil {
+		notification.RecordFailure(err.Error(), time.Now(), d.policy)
+		return
+	}
+	notification.RecordSuccess(time.Now())
+}
@@ -0,0 +1,111 @@
+package notification
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/tranvu1111/go-students-new/internal/domain/entities"
+	"github.com/tranvu1111/go-students-new/internal/domain/repositories"
+)
+
+// Sink is an outbox sink that renders the active templates for a student
+// event and enqueues one notification per channel. Sending is left to the
+// Dispatcher, so a slow mail server does not hold up the outbox relay.
+type Sink struct {
+	templates     repositories.NotificationTemplateRepository
+	notifications repositories.NotificationRepository
+	students      repositories.StudentRepository
+	defaultLocale string
+}
+
+// NewSink writes to students in their own locale when a template has it, and
+// otherwise in defaultLocale, e.g. "vi".
+func NewSink(templates repositories.NotificationTemplateRepository, notifications repositories.NotificationRepository,
+	students repositories.StudentRepository, defaultLocale string) *Sink {
+	return &Sink{
+		templates:     templates,
+		notifications: notifications,
+		students:      students,
+		defaultLocale: entities.NormalizeLocale(defaultLocale),
+	}
+}
+
+func (s *Sink) Publish(ctx context.Context, message *entities.OutboxMessage) error {
+	templates, err := s.templates.FindAll(ctx, repositories.NotificationTemplateFilter{EventType: message.EventType})
+	if err != nil {
+		return err
+	}
+	if len(templates) == 0 {
+		return nil
+	}
+
+	var event entities.StudentEvent
+	if err := json.Unmarshal([]byte(message.Payload), &event); err != nil {
+		return fmt.Errorf("decode event %s: %w", message.ID, err)
+	}
+
+	// Templates render the student as they are now, which may already be past
+	// the event; a student who has left is not written to.
+	student, err := s.students.FindById(ctx, event.StudentID)
+	if errors.Is(err, repositories.ErrStudentNotFound) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	data := entities.NewNotificationData(student, event)
+	var notifications []*entities.Notification
+	for _, channel := range []entities.NotificationChannel{entities.NotificationChannelEmail, entities.NotificationChannelSMS} {
+		template := s.variant(templates, channel, student)
+		recipient := recipientOf(student, channel)
+		if template == nil || recipient == "" {
+			continue
+		}
+
+		rendered, err := template.Render(data)
+		notification := entities.NewNotification(template, message.ID, student.StudentID, recipient, rendered)
+		if err != nil {
+			// Kept in the send log, so that the template can be fixed and the
+			// notification retried.
+			notification.DeadLetter("render template: "+err.Error(), time.Now())
+		}
+		notifications = append(notifications, notification)
+	}
+	return s.notifications.Create(ctx, notifications)
+}
+
+// variant picks the active template of the channel closest to the student's
+// locale, then to the default locale. With neither, nothing is sent rather
+// than writing in a language the student may not read.
+func (s *Sink) variant(templates []*entities.NotificationTemplate, channel entities.NotificationChannel, student *entities.Student) *entities.NotificationTemplate {
+	var locales []string
+	if student.Locale != nil {
+		locales = entities.LocaleFallbacks(*student.Locale)
+	}
+	locales = append(locales, entities.LocaleFallbacks(s.defaultLocale)...)
+
+	for _, locale := range locales {
+		for _, template := range templates {
+			if template.Active && template.Channel == channel && template.Locale == locale {
+				return template
+			}
+		}
+	}
+	return nil
+}
+
+func recipientOf(student *entities.Student, channel entities.NotificationChannel) string {
+	switch channel {
+	case entities.NotificationChannelEmail:
+		return student.Email
+	case entities.NotificationChannelSMS:
+		if student.Phone != nil {
+			return *student.Phone
+		}
+	}
+	return ""
+}
@@ -0,0 +1,91 @@
+package outbox
+
+import (
+	"context"
+	"errors"
+	"log"
+	"time"
+
+	"github.com/tranvu1111/go-students-new/internal/domain/entities"
+	"github.com/tranvu1111/go-students-new/internal/domain/repositories"
+)
+
+const (
+	defaultRelayInterval  = 2 * time.Second
+	defaultRelayBatchSize = 100
+)
+
+// Relay polls the outbox and publishes unpublished messages to every sink.
+// A message is only marked published once all sinks accepted it; otherwise it
+// is retried according to the retry policy, and given up on once its attempts
+// are used up.
+type Relay struct {
+	repo      repositories.OutboxRepository
+	sinks     []Sink
+	policy    entities.RetryPolicy
+	interval  time.Duration
+	batchSize int
+}
+
+func NewRelay(repo repositories.OutboxRepository, interval time.Duration, batchSize int, policy entities.RetryPolicy, sinks ...Sink) *Relay {
+	if interval <= 0 {
+		interval = defaultRelayInterval
+	}
+	if batchSize <= 0 {
+		batchSize = defaultRelayBatchSize
+	}
+	return &Relay{repo: repo, sinks: sinks, policy: policy, interval: interval, batchSize: batchSize}
+}
+
+// Run relays until ctx is cancelled.
+func (r *Relay) Run(ctx context.Context) {
+	ticker := time.NewTicker(r.interval)
+	defer ticker.Stop()
+
+	for {
+		if _, err := r.RelayOnce(ctx); err != nil && !errors.Is(err, context.Canceled) {
+			log.Printf("outbox relay: %v", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// RelayOnce publishes one batch and returns how many messages were published.
+func (r *Relay) RelayOnce(ctx context.Context) (int, error) {
+	messages, err := r.repo.FindUnpublished(ctx, time.Now(), r.batchSize)
+	if err != nil {
+		return 0, err
+	}
+
+	published := 0
+	for _, message := range messages {
+		var publishErr error
+		for _, sink := range r.sinks {
+			if publishErr = sink.Publish(ctx, message); publishErr != nil {
+				break
+			}
+		}
+
+		if publishErr != nil {
+			message.RecordFailure(publishErr.Error(), time.Now(), r.policy)
+			if message.DeadAt != nil {
+				log.Printf("outbox relay: giving up on message %s after %d attempts: %v", message.ID, message.Attempts, publishErr)
+			}
+			if err := r.repo.MarkFailed(ctx, message); err != nil {
+				return published, err
+			}
+			continue
+		}
+
+		if err := r.repo.MarkPublished(ctx, message.ID, time.Now()); err != nil {
+			return published, err
+		}
+		published++
+	}
+	return published, nil
+}
@@ -0,0 +1,120 @@
+package repotest
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/tranvu1111/go-students-new/internal/domain/entities"
+	"github.com/tranvu1111/go-students-new/internal/domain/repositories"
+)
+
+// IdempotencyRepository runs the suite, calling open for an empty store in
+// every subtest.
+func IdempotencyRepository(t *testing.T, open func(t *testing.T) repositories.IdempotencyRepository) {
+	tests := []struct {
+		name string
+		run  func(t *testing.T, repo repositories.IdempotencyRepository)
+	}{
+		{"FindByKeyMissing", testFindByKeyMissing},
+		{"CreateAndFindByKey", testCreateAndFindByKey},
+		{"KeyIsUnique", testKeyIsUnique},
+		{"UpdateSavesResponse", testUpdateSavesResponse},
+		{"DeleteOlderThan", testDeleteOlderThan},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			test.run(t, open(t))
+		})
+	}
+}
+
+func newRecord(key string, createdAt time.Time) *entities.IdempotencyRecord {
+	record := entities.NewIdempotencyRecord(key, `{"FirstName":"tran"}`)
+	record.CreatedAt = createdAt
+	return record
+}
+
+func testFindByKeyMissing(t *testing.T, repo repositories.IdempotencyRepository) {
+	record, err := repo.FindByKey(context.Background(), "missing")
+	require.NoError(t, err)
+	assert.Nil(t, record)
+}
+
+func testCreateAndFindByKey(t *testing.T, repo repositories.IdempotencyRepository) {
+	ctx := context.Background()
+	record := newRecord("key-1", createdAt)
+	record.SetResponse(`{"Result":{}}`, 200)
+
+	created, err := repo.Create(ctx, record)
+	require.NoError(t, err)
+	assert.Equal(t, record.ID, created.ID)
+
+	found, err := repo.FindByKey(ctx, "key-1")
+	require.NoError(t, err)
+	require.NotNil(t, found)
+	assert.Equal(t, record.ID, found.ID)
+	assert.Equal(t, record.Request, found.Request)
+	assert.Equal(t, `{"Result":{}}`, found.Response)
+	assert.Equal(t, 200, found.StatusCode)
+	assert.True(t, createdAt.Equal(found.CreatedAt))
+}
+
+func testKeyIsUnique(t *testing.T, repo repositories.IdempotencyRepository) {
+	ctx := context.Background()
+	_, err := repo.Create(ctx, newRecord("key-1", createdAt))
+	require.NoError(t, err)
+
+	_, err = repo.Create(ctx, newRecord("key-1", createdAt))
+	assert.True(t, errors.Is(err, repositories.ErrDuplicateIdempotencyKey), "got %v", err)
+}
+
+func testUpdateSavesResponse(t *testing.T, repo repositories.IdempotencyRepository) {
+	ctx := context.Background()
+	record := newRecord("key-1", createdAt)
+	_, err := repo.Create(ctx, record)
+	require.NoError(t, err)
+
+	record.SetResponse(`{"done":true}`, 201)
+	updated, err := repo.Update(ctx, record)
+	require.NoError(t, err)
+	assert.Equal(t, 201, updated.StatusCode)
+
+	found, err := repo.FindByKey(ctx, "key-1")
+	require.NoError(t, err)
+	require.NotNil(t, found)
+	assert.Equal(t, `{"done":true}`, found.Response)
+	assert.Equal(t, 201, found.StatusCode)
+
+	// Update inserts a record it does not know.
+	inserted := newRecord("key-2", createdAt)
+	_, err = repo.Update(ctx, inserted)
+	require.NoError(t, err)
+	found, err = repo.FindByKey(ctx, "key-2")
+	require.NoError(t, err)
+	require.NotNil(t, found)
+	assert.Equal(t, inserted.ID, found.ID)
+}
+
+func testDeleteOlderThan(t *testing.T, repo repositories.IdempotencyRepository) {
+	ctx := context.Background()
+	for i, key := range []string{"old-1", "old-2", "new"} {
+		_, err := repo.Create(ctx, newRecord(key, createdAt.Add(time.Duration(i)*time.Hour)))
+		require.NoError(t, err)
+	}
+
+	deleted, err := repo.DeleteOlderThan(ctx, createdAt.Add(90*time.Minute))
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), deleted)
+
+	found, err := repo.FindByKey(ctx, "old-1")
+	require.NoError(t, err)
+	assert.Nil(t, found)
+	found, err = repo.FindByKey(ctx, "new")
+	require.NoError(t, err)
+	assert.NotNil(t, found)
+}
@@ -0,0 +1,148 @@
+package repotest
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/tranvu1111/go-students-new/internal/domain/entities"
+	"github.com/tranvu1111/go-students-new/internal/domain/repositories"
+)
+
+// ProgramRepository runs the suite, calling open for an empty store in every
+// subtest.
+func ProgramRepository(t *testing.T, open func(t *testing.T) repositories.ProgramRepository) {
+	tests := []struct {
+		name string
+		run  func(t *testing.T, repo repositories.ProgramRepository)
+	}{
+		{"CreateAndFindById", testCreateAndFindProgram},
+		{"FindByIdNotFound", testFindProgramNotFound},
+		{"CodeIsUnique", testProgramCodeIsUnique},
+		{"FindByIdsSkipsMissing", testFindProgramsByIdsSkipsMissing},
+		{"FindAllFiltersByCode", testFindAllProgramsFiltersByCode},
+		{"UpdateKeepsCode", testUpdateProgramKeepsCode},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			test.run(t, open(t))
+		})
+	}
+}
+
+func newProgram(t *testing.T, code string, department string, level entities.DegreeLevel) *entities.Program {
+	program, err := entities.NewProgram(code, code+" program", department, level)
+	require.NoError(t, err)
+	program.CreatedAt = createdAt
+	program.UpdatedAt = createdAt
+	return program
+}
+
+func createProgram(t *testing.T, repo repositories.ProgramRepository, program *entities.Program) *entities.Program {
+	created, err := repo.Create(context.Background(), program)
+	require.NoError(t, err)
+	return created
+}
+
+func testCreateAndFindProgram(t *testing.T, repo repositories.ProgramRepository) {
+	program := createProgram(t, repo, newProgram(t, "CS", "Computing", entities.DegreeLevelBachelor))
+
+	found, err := repo.FindById(context.Background(), program.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "CS", found.Code)
+	assert.Equal(t, "CS program", found.Name)
+	assert.Equal(t, "Computing", found.Department)
+	assert.Equal(t, entities.DegreeLevelBachelor, found.DegreeLevel)
+	assert.True(t, found.Active)
+	assert.True(t, createdAt.Equal(found.CreatedAt))
+}
+
+func testFindProgramNotFound(t *testing.T, repo repositories.ProgramRepository) {
+	_, err := repo.FindById(context.Background(), uuid.New())
+	assert.True(t, errors.Is(err, repositories.ErrProgramNotFound), "got %v", err)
+}
+
+func testProgramCodeIsUnique(t *testing.T, repo repositories.ProgramRepository) {
+	createProgram(t, repo, newProgram(t, "CS", "Computing", entities.DegreeLevelBachelor))
+
+	_, err := repo.Create(context.Background(), newProgram(t, "cs", "Computing", entities.DegreeLevelMaster))
+	assert.True(t, errors.Is(err, repositories.ErrDuplicateProgramCode), "got %v", err)
+}
+
+func testFindProgramsByIdsSkipsMissing(t *testing.T, repo repositories.ProgramRepository) {
+	ctx := context.Background()
+	cs := createProgram(t, repo, newProgram(t, "CS", "Computing", entities.DegreeLevelBachelor))
+	math := createProgram(t, repo, newProgram(t, "MATH", "Mathematics", entities.DegreeLevelBachelor))
+
+	found, err := repo.FindByIds(ctx, []uuid.UUID{cs.ID, uuid.New(), math.ID})
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []uuid.UUID{cs.ID, math.ID}, programIDs(found))
+
+	found, err = repo.FindByIds(ctx, nil)
+	require.NoError(t, err)
+	assert.Empty(t, found)
+}
+
+func testFindAllProgramsFiltersByCode(t *testing.T, repo repositories.ProgramRepository) {
+	ctx := context.Background()
+	math := createProgram(t, repo, newProgram(t, "MATH", "Mathematics", entities.DegreeLevelBachelor))
+	cs := createProgram(t, repo, newProgram(t, "CS", "Computing", entities.DegreeLevelBachelor))
+	csMaster := createProgram(t, repo, newProgram(t, "CS-MS", "Computing", entities.DegreeLevelMaster))
+	require.NoError(t, csMaster.Update(csMaster.Name, csMaster.Department, csMaster.DegreeLevel, false))
+	_, err := repo.Update(ctx, csMaster)
+	require.NoError(t, err)
+
+	found, err := repo.FindAll(ctx, repositories.ProgramFilter{})
+	require.NoError(t, err)
+	assert.Equal(t, []uuid.UUID{cs.ID, csMaster.ID, math.ID}, programIDs(found), "programs go by code")
+
+	active := true
+	found, err = repo.FindAll(ctx, repositories.ProgramFilter{Active: &active})
+	require.NoError(t, err)
+	assert.Equal(t, []uuid.UUID{cs.ID, math.ID}, programIDs(found))
+
+	department := "Computing"
+	found, err = repo.FindAll(ctx, repositories.ProgramFilter{Department: &department})
+	require.NoError(t, err)
+	assert.Equal(t, []uuid.UUID{cs.ID, csMaster.ID}, programIDs(found))
+
+	level := entities.DegreeLevelMaster
+	found, err = repo.FindAll(ctx, repositories.ProgramFilter{DegreeLevel: &level})
+	require.NoError(t, err)
+	assert.Equal(t, []uuid.UUID{csMaster.ID}, programIDs(found))
+}
+
+func testUpdateProgramKeepsCode(t *testing.T, repo repositories.ProgramRepository) {
+	ctx := context.Background()
+	program := createProgram(t, repo, newProgram(t, "CS", "Computing", entities.DegreeLevelBachelor))
+
+	program.Code = "IT"
+	require.NoError(t, program.Update("Computer Science", "Engineering", entities.DegreeLevelMaster, false))
+	_, err := repo.Update(ctx, program)
+	require.NoError(t, err)
+
+	found, err := repo.FindById(ctx, program.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "CS", found.Code, "the code cannot change")
+	assert.Equal(t, "Computer Science", found.Name)
+	assert.Equal(t, "Engineering", found.Department)
+	assert.Equal(t, entities.DegreeLevelMaster, found.DegreeLevel)
+	assert.False(t, found.Active)
+	assert.True(t, createdAt.Equal(found.CreatedAt))
+
+	missing := newProgram(t, "MATH", "Mathematics", entities.DegreeLevelBachelor)
+	_, err = repo.Update(ctx, missing)
+	assert.True(t, errors.Is(err, repositories.ErrProgramNotFound), "got %v", err)
+}
+
+func programIDs(programs []*entities.Program) []uuid.UUID {
+	ids := make([]uuid.UUID, 0, len(programs))
+	for _, program := range programs {
+		ids = append(ids, program.ID)
+	}
+	return ids
+}
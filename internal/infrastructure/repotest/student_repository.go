@@ -0,0 +1,513 @@
+// Package repotest is the conformance suite of the repository interfaces.
+// Every implementation runs it from its own tests, so that the Gorm and the
+// in-memory repositories cannot drift apart.
+package repotest
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/tranvu1111/go-students-new/internal/domain/entities"
+	"github.com/tranvu1111/go-students-new/internal/domain/repositories"
+)
+
+// StudentRepos are the repositories of one empty store; Audit, Statuses and
+// Outbox must see the entries written by Students.
+type StudentRepos struct {
+	Students repositories.StudentRepository
+	Audit    repositories.AuditRepository
+	Statuses repositories.StudentStatusRepository
+	Outbox   repositories.OutboxRepository
+}
+
+// StudentRepository runs the suite, calling open for an empty store in every
+// subtest.
+func StudentRepository(t *testing.T, open func(t *testing.T) StudentRepos) {
+	tests := []struct {
+		name string
+		run  func(t *testing.T, repos StudentRepos)
+	}{
+		{"CreateAndFindById", testCreateAndFindById},
+		{"FindByIdNotFound", testFindByIdNotFound},
+		{"FindByIdsSkipsMissingAndDeleted", testFindByIdsSkipsMissingAndDeleted},
+		{"FindAllFiltersAndPages", testFindAllFiltersAndPages},
+		{"StreamStopsAtVisitError", testStreamStopsAtVisitError},
+		{"UpdateChecksVersion", testUpdateChecksVersion},
+		{"ProgramsRoundTrip", testProgramsRoundTrip},
+		{"ContactsRoundTrip", testContactsRoundTrip},
+		{"EmailVerificationRoundTrip", testEmailVerificationRoundTrip},
+		{"DeleteAndRestore", testDeleteAndRestore},
+		{"EmailIsUniqueAmongLiveStudents", testEmailIsUniqueAmongLiveStudents},
+		{"CreateBatchIsAllOrNothing", testCreateBatchIsAllOrNothing},
+		{"AuditTrailNewestFirst", testAuditTrailNewestFirst},
+		{"StatusTransitionsAreRecorded", testStatusTransitionsAreRecorded},
+		{"SearchRanksMatches", testSearchRanksMatches},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			test.run(t, open(t))
+		})
+	}
+}
+
+// newStudent returns a valid student created at createdAt, to control the
+// oldest first order.
+func newStudent(t *testing.T, email string, major string, enrolled time.Time, createdAt time.Time) *entities.ValidatedStudent {
+	dateOfBirth := time.Date(2003, time.March, 4, 0, 0, 0, 0, time.UTC)
+	phone := "+84901234567"
+	student := entities.NewStudent("tran", "vu", &dateOfBirth, email, &phone, &major, enrolled)
+	student.CreatedAt = createdAt
+	student.UpdatedAt = createdAt
+
+	validated, err := entities.NewValidatedStudent(student)
+	require.NoError(t, err)
+	return validated
+}
+
+var (
+	enrolled2022 = time.Date(2022, time.September, 1, 0, 0, 0, 0, time.UTC)
+	enrolled2023 = time.Date(2023, time.September, 1, 0, 0, 0, 0, time.UTC)
+	enrolled2024 = time.Date(2024, time.September, 1, 0, 0, 0, 0, time.UTC)
+	createdAt    = time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC)
+)
+
+func create(t *testing.T, repos StudentRepos, student *entities.ValidatedStudent) *entities.Student {
+	created, err := repos.Students.Create(context.Background(), student)
+	require.NoError(t, err)
+	return created
+}
+
+func testCreateAndFindById(t *testing.T, repos StudentRepos) {
+	student := newStudent(t, "tran.vu@example.com", "CNTT", enrolled2023, createdAt)
+	created := create(t, repos, student)
+
+	assert.Equal(t, student.StudentID, created.StudentID)
+	assert.Equal(t, 1, created.Version)
+	assert.Empty(t, student.Events(), "stored events are cleared")
+
+	found, err := repos.Students.FindById(context.Background(), student.StudentID)
+	require.NoError(t, err)
+	assert.Equal(t, "tran", found.FirstName)
+	assert.Equal(t, "vu", found.LastName)
+	assert.Equal(t, "tran.vu@example.com", found.Email)
+	require.NotNil(t, found.Major)
+	assert.Equal(t, "CNTT", *found.Major)
+	require.NotNil(t, found.Phone)
+	assert.Equal(t, "+84901234567", *found.Phone)
+	require.NotNil(t, found.DateOfBirth)
+	assert.True(t, student.DateOfBirth.Equal(*found.DateOfBirth))
+	assert.True(t, enrolled2023.Equal(found.EnrollmentDate))
+	assert.True(t, createdAt.Equal(found.CreatedAt))
+	assert.Equal(t, time.UTC, found.EnrollmentDate.Location())
+}
+
+func testFindByIdNotFound(t *testing.T, repos StudentRepos) {
+	_, err := repos.Students.FindById(context.Background(), uuid.New())
+	assert.True(t, errors.Is(err, repositories.ErrStudentNotFound), "got %v", err)
+}
+
+func testFindByIdsSkipsMissingAndDeleted(t *testing.T, repos StudentRepos) {
+	ctx := context.Background()
+	first := create(t, repos, newStudent(t, "first@example.com", "CNTT", enrolled2023, createdAt))
+	second := create(t, repos, newStudent(t, "second@example.com", "CNTT", enrolled2023, createdAt.Add(time.Second)))
+	require.NoError(t, repos.Students.Delete(ctx, second.StudentID, second.Version))
+
+	found, err := repos.Students.FindByIds(ctx, []uuid.UUID{first.StudentID, second.StudentID, uuid.New()})
+	require.NoError(t, err)
+	require.Len(t, found, 1)
+	assert.Equal(t, first.StudentID, found[0].StudentID)
+
+	found, err = repos.Students.FindByIds(ctx, nil)
+	require.NoError(t, err)
+	assert.Empty(t, found)
+}
+
+func testFindAllFiltersAndPages(t *testing.T, repos StudentRepos) {
+	ctx := context.Background()
+	var ids []uuid.UUID
+	for i, enrolled := range []time.Time{enrolled2022, enrolled2023, enrolled2024, enrolled2023} {
+		major := "CNTT"
+		if i == 3 {
+			major = "Physics"
+		}
+		student := create(t, repos, newStudent(t, fmt.Sprintf("student%d@example.com", i), major, enrolled, createdAt.Add(time.Duration(i)*time.Minute)))
+		ids = append(ids, student.StudentID)
+	}
+
+	major := "CNTT"
+	found, err := repos.Students.FindAll(ctx, repositories.StudentFilter{Major: &major})
+	require.NoError(t, err)
+	assert.ElementsMatch(t, ids[:3], studentIDs(found))
+
+	found, err = repos.Students.FindAll(ctx, repositories.StudentFilter{EnrolledFrom: &enrolled2023, EnrolledTo: &enrolled2023})
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []uuid.UUID{ids[1], ids[3]}, studentIDs(found))
+
+	found, err = repos.Students.FindAll(ctx, repositories.StudentFilter{Offset: 1, Limit: 2})
+	require.NoError(t, err)
+	assert.Equal(t, ids[1:3], studentIDs(found), "pages go oldest first")
+
+	found, err = repos.Students.FindAll(ctx, repositories.StudentFilter{Offset: 10, Limit: 2})
+	require.NoError(t, err)
+	assert.Empty(t, found)
+}
+
+func testStreamStopsAtVisitError(t *testing.T, repos StudentRepos) {
+	ctx := context.Background()
+	var ids []uuid.UUID
+	for i := 0; i < 3; i++ {
+		student := create(t, repos, newStudent(t, fmt.Sprintf("student%d@example.com", i), "CNTT", enrolled2023, createdAt.Add(time.Duration(i)*time.Minute)))
+		ids = append(ids, student.StudentID)
+	}
+
+	var visited []uuid.UUID
+	err := repos.Students.Stream(ctx, repositories.StudentFilter{}, func(student *entities.Student) error {
+		visited = append(visited, student.StudentID)
+		return nil
+	})
+	require.NoError(t, err)
+	assert.Equal(t, ids, visited, "stream goes oldest first")
+
+	stop := errors.New("stop")
+	visited = nil
+	err = repos.Students.Stream(ctx, repositories.StudentFilter{}, func(student *entities.Student) error {
+		visited = append(visited, student.StudentID)
+		return stop
+	})
+	assert.ErrorIs(t, err, stop)
+	assert.Len(t, visited, 1)
+}
+
+func testUpdateChecksVersion(t *testing.T, repos StudentRepos) {
+	ctx := context.Background()
+	created := create(t, repos, newStudent(t, "tran.vu@example.com", "CNTT", enrolled2023, createdAt))
+
+	physics := "Physics"
+	created.Major = &physics
+	created.Phone = nil
+	created.UpdatedAt = createdAt.Add(time.Hour)
+	validated, err := entities.NewValidatedStudent(created)
+	require.NoError(t, err)
+
+	updated, err := repos.Students.Update(ctx, validated)
+	require.NoError(t, err)
+	assert.Equal(t, 2, updated.Version)
+	require.NotNil(t, updated.Major)
+	assert.Equal(t, "Physics", *updated.Major)
+	assert.Nil(t, updated.Phone, "nil values are written too")
+	assert.True(t, createdAt.Equal(updated.CreatedAt))
+
+	// validated still carries version 1.
+	_, err = repos.Students.Update(ctx, validated)
+	var conflict *repositories.VersionConflictError
+	require.True(t, errors.As(err, &conflict), "got %v", err)
+	assert.Equal(t, 1, conflict.ExpectedVersion)
+	assert.Equal(t, 2, conflict.CurrentVersion)
+
+	missing := newStudent(t, "missing@example.com", "CNTT", enrolled2023, createdAt)
+	_, err = repos.Students.Update(ctx, missing)
+	assert.True(t, errors.Is(err, repositories.ErrStudentNotFound), "got %v", err)
+}
+
+func testProgramsRoundTrip(t *testing.T, repos StudentRepos) {
+	ctx := context.Background()
+	student := newStudent(t, "tran.vu@example.com", "CNTT", enrolled2023, createdAt)
+	major := entities.StudentProgram{ProgramID: uuid.New(), Kind: entities.ProgramKindMajor, DeclaredAt: enrolled2023}
+	student.Programs = []entities.StudentProgram{major}
+	created := create(t, repos, student)
+
+	found, err := repos.Students.FindById(ctx, created.StudentID)
+	require.NoError(t, err)
+	require.Len(t, found.Programs, 1)
+	assert.Equal(t, major.ProgramID, found.Programs[0].ProgramID)
+	assert.Equal(t, entities.ProgramKindMajor, found.Programs[0].Kind)
+	assert.True(t, enrolled2023.Equal(found.Programs[0].DeclaredAt))
+
+	minor := entities.StudentProgram{ProgramID: uuid.New(), Kind: entities.ProgramKindMinor, DeclaredAt: enrolled2024}
+	found.Programs = append(found.Programs, minor)
+	validated, err := entities.NewValidatedStudent(found)
+	require.NoError(t, err)
+	updated, err := repos.Students.Update(ctx, validated)
+	require.NoError(t, err)
+	require.Len(t, updated.Programs, 2)
+	assert.Equal(t, minor.ProgramID, updated.Programs[1].ProgramID)
+
+	updated.Programs = nil
+	validated, err = entities.NewValidatedStudent(updated)
+	require.NoError(t, err)
+	cleared, err := repos.Students.Update(ctx, validated)
+	require.NoError(t, err)
+	assert.Empty(t, cleared.Programs)
+}
+
+func testContactsRoundTrip(t *testing.T, repos StudentRepos) {
+	ctx := context.Background()
+	created := create(t, repos, newStudent(t, "tran.vu@example.com", "CNTT", enrolled2023, createdAt))
+
+	movedOut := enrolled2024
+	require.NoError(t, created.SaveAddress(entities.Address{Type: entities.AddressTypeHome, Line1: "1 Le Loi", City: "Hue", Country: "vn", ValidFrom: enrolled2022, ValidTo: &movedOut}))
+	require.NoError(t, created.SaveEmail(entities.ContactEmail{Address: "tran.vu@school.edu"}))
+	require.NoError(t, created.SavePhone(entities.ContactPhone{Number: "+84 90 123 4567"}))
+	guardianEmail := "mai.vu@example.com"
+	require.NoError(t, created.SaveEmergencyContact(entities.EmergencyContact{Name: "Mai Vu", Relationship: "mother", Phone: "+84912345678", Email: &guardianEmail}))
+	validated, err := entities.NewValidatedStudent(created)
+	require.NoError(t, err)
+	_, err = repos.Students.Update(ctx, validated)
+	require.NoError(t, err)
+
+	found, err := repos.Students.FindById(ctx, created.StudentID)
+	require.NoError(t, err)
+	require.Len(t, found.Contacts.Addresses, 1)
+	address := found.Contacts.Addresses[0]
+	assert.Equal(t, entities.AddressTypeHome, address.Type)
+	assert.Equal(t, "VN", address.Country)
+	assert.True(t, enrolled2022.Equal(address.ValidFrom))
+	require.NotNil(t, address.ValidTo)
+	assert.True(t, enrolled2024.Equal(*address.ValidTo))
+	require.Len(t, found.Contacts.Emails, 1)
+	assert.Equal(t, "tran.vu@school.edu", found.Contacts.Emails[0].Address)
+	assert.True(t, found.Contacts.Emails[0].Primary)
+	assert.Equal(t, entities.VerificationUnverified, found.Contacts.Emails[0].Verification)
+	require.Len(t, found.Contacts.Phones, 1)
+	assert.Equal(t, "+84901234567", found.Contacts.Phones[0].Number)
+	require.Len(t, found.Contacts.EmergencyContacts, 1)
+	require.NotNil(t, found.Contacts.EmergencyContacts[0].Email)
+	assert.Equal(t, guardianEmail, *found.Contacts.EmergencyContacts[0].Email)
+
+	require.NoError(t, found.RemoveContact(entities.ContactKindAddress, address.ID))
+	require.NoError(t, found.RemoveContact(entities.ContactKindEmail, found.Contacts.Emails[0].ID))
+	require.NoError(t, found.RemoveContact(entities.ContactKindPhone, found.Contacts.Phones[0].ID))
+	require.NoError(t, found.RemoveContact(entities.ContactKindEmergencyContact, found.Contacts.EmergencyContacts[0].ID))
+	validated, err = entities.NewValidatedStudent(found)
+	require.NoError(t, err)
+	cleared, err := repos.Students.Update(ctx, validated)
+	require.NoError(t, err)
+	assert.True(t, cleared.Contacts.IsEmpty())
+}
+
+func testEmailVerificationRoundTrip(t *testing.T, repos StudentRepos) {
+	ctx := context.Background()
+	created := create(t, repos, newStudent(t, "tran.vu@example.com", "CNTT", enrolled2023, createdAt))
+	assert.Nil(t, created.EmailVerifiedAt)
+
+	verifiedAt := time.Date(2024, time.March, 1, 8, 30, 0, 0, time.UTC)
+	require.NoError(t, created.VerifyEmail(entities.NewEmailVerificationToken(created, time.Hour), verifiedAt))
+	validated, err := entities.NewValidatedStudent(created)
+	require.NoError(t, err)
+	_, err = repos.Students.Update(ctx, validated)
+	require.NoError(t, err)
+
+	found, err := repos.Students.FindById(ctx, created.StudentID)
+	require.NoError(t, err)
+	require.NotNil(t, found.EmailVerifiedAt)
+	assert.True(t, verifiedAt.Equal(*found.EmailVerifiedAt))
+
+	require.NoError(t, found.ApplyPatch(entities.StudentPatch{Email: entities.Replace("vu.tran@example.com")}))
+	validated, err = entities.NewValidatedStudent(found)
+	require.NoError(t, err)
+	changed, err := repos.Students.Update(ctx, validated)
+	require.NoError(t, err)
+	assert.Nil(t, changed.EmailVerifiedAt)
+}
+
+func testDeleteAndRestore(t *testing.T, repos StudentRepos) {
+	ctx := context.Background()
+	created := create(t, repos, newStudent(t, "tran.vu@example.com", "CNTT", enrolled2023, createdAt))
+
+	var conflict *repositories.VersionConflictError
+	err := repos.Students.Delete(ctx, created.StudentID, created.Version+1)
+	require.True(t, errors.As(err, &conflict), "got %v", err)
+
+	require.NoError(t, repos.Students.Delete(ctx, created.StudentID, created.Version))
+	_, err = repos.Students.FindById(ctx, created.StudentID)
+	assert.True(t, errors.Is(err, repositories.ErrStudentNotFound), "got %v", err)
+	err = repos.Students.Delete(ctx, created.StudentID, created.Version)
+	assert.True(t, errors.Is(err, repositories.ErrStudentNotFound), "got %v", err)
+
+	found, err := repos.Students.FindAll(ctx, repositories.StudentFilter{})
+	require.NoError(t, err)
+	assert.Empty(t, found)
+
+	restored, err := repos.Students.Restore(ctx, created.StudentID)
+	require.NoError(t, err)
+	assert.Equal(t, created.Version+1, restored.Version)
+	assert.Equal(t, "tran.vu@example.com", restored.Email)
+
+	_, err = repos.Students.Restore(ctx, created.StudentID)
+	assert.True(t, errors.Is(err, repositories.ErrStudentNotFound), "only deleted students can be restored, got %v", err)
+	_, err = repos.Students.Restore(ctx, uuid.New())
+	assert.True(t, errors.Is(err, repositories.ErrStudentNotFound), "got %v", err)
+
+	messages, err := repos.Outbox.FindUnpublished(ctx, time.Now().Add(time.Minute), 10)
+	require.NoError(t, err)
+	var eventTypes []string
+	for _, message := range messages {
+		if message.AggregateID == created.StudentID {
+			eventTypes = append(eventTypes, message.EventType)
+		}
+	}
+	assert.Contains(t, eventTypes, string(entities.StudentDeleted))
+	assert.Contains(t, eventTypes, string(entities.StudentRestored), "consumers must hear the student is back")
+}
+
+func testEmailIsUniqueAmongLiveStudents(t *testing.T, repos StudentRepos) {
+	ctx := context.Background()
+	first := create(t, repos, newStudent(t, "tran.vu@example.com", "CNTT", enrolled2023, createdAt))
+
+	_, err := repos.Students.Create(ctx, newStudent(t, "tran.vu@example.com", "CNTT", enrolled2023, createdAt))
+	assert.True(t, errors.Is(err, repositories.ErrDuplicateEmail), "got %v", err)
+
+	other := create(t, repos, newStudent(t, "other@example.com", "CNTT", enrolled2023, createdAt))
+	other.Email = first.Email
+	validated, err := entities.NewValidatedStudent(other)
+	require.NoError(t, err)
+	_, err = repos.Students.Update(ctx, validated)
+	assert.True(t, errors.Is(err, repositories.ErrDuplicateEmail), "got %v", err)
+
+	require.NoError(t, repos.Students.Delete(ctx, first.StudentID, first.Version))
+	create(t, repos, newStudent(t, "tran.vu@example.com", "CNTT", enrolled2023, createdAt))
+	_, err = repos.Students.Restore(ctx, first.StudentID)
+	assert.True(t, errors.Is(err, repositories.ErrDuplicateEmail), "got %v", err)
+}
+
+func testCreateBatchIsAllOrNothing(t *testing.T, repos StudentRepos) {
+	ctx := context.Background()
+	require.NoError(t, repos.Students.CreateBatch(ctx, nil, 10))
+
+	batch := []*entities.ValidatedStudent{
+		newStudent(t, "first@example.com", "CNTT", enrolled2023, createdAt),
+		newStudent(t, "second@example.com", "CNTT", enrolled2023, createdAt),
+		newStudent(t, "first@example.com", "CNTT", enrolled2023, createdAt),
+	}
+	err := repos.Students.CreateBatch(ctx, batch, 2)
+	assert.True(t, errors.Is(err, repositories.ErrDuplicateEmail), "got %v", err)
+	found, err := repos.Students.FindAll(ctx, repositories.StudentFilter{})
+	require.NoError(t, err)
+	assert.Empty(t, found)
+
+	require.NoError(t, repos.Students.CreateBatch(ctx, batch[:2], 1))
+	found, err = repos.Students.FindAll(ctx, repositories.StudentFilter{})
+	require.NoError(t, err)
+	assert.Len(t, found, 2)
+}
+
+func testAuditTrailNewestFirst(t *testing.T, repos StudentRepos) {
+	ctx := entities.ContextWithAuditActor(context.Background(), "tester", "request-1")
+	created, err := repos.Students.Create(ctx, newStudent(t, "tran.vu@example.com", "CNTT", enrolled2023, createdAt))
+	require.NoError(t, err)
+
+	physics := "Physics"
+	created.Major = &physics
+	validated, err := entities.NewValidatedStudent(created)
+	require.NoError(t, err)
+	updated, err := repos.Students.Update(ctx, validated)
+	require.NoError(t, err)
+	require.NoError(t, repos.Students.Delete(ctx, updated.StudentID, updated.Version))
+
+	entries, total, err := repos.Audit.FindByStudentId(ctx, created.StudentID, 0, 2)
+	require.NoError(t, err)
+	assert.Equal(t, int64(3), total)
+	require.Len(t, entries, 2)
+	assert.Equal(t, entities.AuditOperationDelete, entries[0].Operation)
+	assert.Equal(t, entities.AuditOperationUpdate, entries[1].Operation)
+	assert.Equal(t, "tester", entries[1].Actor)
+	assert.Equal(t, "request-1", entries[1].RequestID)
+	require.Len(t, entries[1].Changes, 1)
+	assert.Equal(t, "Major", entries[1].Changes[0].Field)
+
+	entries, _, err = repos.Audit.FindByStudentId(ctx, created.StudentID, 2, 2)
+	require.NoError(t, err)
+	require.Len(t, entries, 1)
+	assert.Equal(t, entities.AuditOperationCreate, entries[0].Operation)
+
+	entries, total, err = repos.Audit.FindByStudentId(ctx, uuid.New(), 0, 10)
+	require.NoError(t, err)
+	assert.Zero(t, total)
+	assert.Empty(t, entries)
+}
+
+func testStatusTransitionsAreRecorded(t *testing.T, repos StudentRepos) {
+	ctx := entities.ContextWithAuditActor(context.Background(), "registrar", "request-2")
+	student := create(t, repos, newStudent(t, "tran.vu@example.com", "CNTT", enrolled2023, createdAt))
+	other := create(t, repos, newStudent(t, "le.minh@example.com", "CNTT", enrolled2023, createdAt.Add(time.Hour)))
+	assert.Equal(t, entities.StudentStatusActive, student.Status)
+	assert.True(t, enrolled2023.Equal(student.StatusEffectiveDate))
+
+	onLeave := time.Date(2024, time.January, 10, 0, 0, 0, 0, time.UTC)
+	require.NoError(t, student.TransitionTo(entities.StudentStatusOnLeave, "medical leave", onLeave))
+	validated, err := entities.NewValidatedStudent(student)
+	require.NoError(t, err)
+	updated, err := repos.Students.Update(ctx, validated)
+	require.NoError(t, err)
+	assert.Equal(t, entities.StudentStatusOnLeave, updated.Status)
+	assert.Equal(t, "medical leave", updated.StatusReason)
+	assert.True(t, onLeave.Equal(updated.StatusEffectiveDate))
+
+	back := time.Date(2024, time.February, 1, 0, 0, 0, 0, time.UTC)
+	require.NoError(t, updated.TransitionTo(entities.StudentStatusActive, "returned", back))
+	validated, err = entities.NewValidatedStudent(updated)
+	require.NoError(t, err)
+	_, err = repos.Students.Update(ctx, validated)
+	require.NoError(t, err)
+
+	changes, total, err := repos.Statuses.FindByStudentId(ctx, student.StudentID, 0, 10)
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), total)
+	require.Len(t, changes, 2)
+	assert.Equal(t, entities.StudentStatusOnLeave, changes[0].From)
+	assert.Equal(t, entities.StudentStatusActive, changes[0].To)
+	assert.True(t, back.Equal(changes[0].EffectiveDate))
+	assert.Equal(t, entities.StudentStatusActive, changes[1].From)
+	assert.Equal(t, entities.StudentStatusOnLeave, changes[1].To)
+	assert.Equal(t, "medical leave", changes[1].Reason)
+	assert.Equal(t, "registrar", changes[1].Actor)
+	assert.Equal(t, "request-2", changes[1].RequestID)
+
+	changes, total, err = repos.Statuses.FindByStudentId(ctx, student.StudentID, 1, 10)
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), total)
+	require.Len(t, changes, 1)
+	assert.Equal(t, entities.StudentStatusOnLeave, changes[0].To)
+
+	require.NoError(t, other.TransitionTo(entities.StudentStatusSuspended, "unpaid fees", back))
+	validated, err = entities.NewValidatedStudent(other)
+	require.NoError(t, err)
+	_, err = repos.Students.Update(ctx, validated)
+	require.NoError(t, err)
+
+	suspended := entities.StudentStatusSuspended
+	found, err := repos.Students.FindAll(ctx, repositories.StudentFilter{Status: &suspended, Limit: 10})
+	require.NoError(t, err)
+	require.Len(t, found, 1)
+	assert.Equal(t, other.StudentID, found[0].StudentID)
+}
+
+func testSearchRanksMatches(t *testing.T, repos StudentRepos) {
+	ctx := context.Background()
+	student := newStudent(t, "nguyen.lan@example.com", "CNTT", enrolled2023, createdAt)
+	student.FirstName = "Lan"
+	student.LastName = "Nguyen"
+	create(t, repos, student)
+	create(t, repos, newStudent(t, "tran.vu@example.com", "Physics", enrolled2023, createdAt))
+
+	hits, err := repos.Students.Search(ctx, "nguyen", 10)
+	require.NoError(t, err)
+	require.NotEmpty(t, hits)
+	assert.Equal(t, student.StudentID, hits[0].Student.StudentID)
+	assert.Greater(t, hits[0].Score, 0.0)
+}
+
+func studentIDs(students []*entities.Student) []uuid.UUID {
+	ids := make([]uuid.UUID, len(students))
+	for i, student := range students {
+		ids[i] = student.StudentID
+	}
+	return ids
+}
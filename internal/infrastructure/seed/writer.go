@@ -0,0 +1,67 @@
+package seed
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"io"
+
+	"github.com/tranvu1111/go-students-new/internal/domain/entities"
+	"github.com/tranvu1111/go-students-new/internal/domain/repositories"
+)
+
+// DefaultBatchSize matches the batch size of the import.
+const DefaultBatchSize = 500
+
+// importRow has the fields and date format of the NDJSON import.
+type importRow struct {
+	FirstName      string  `json:"FirstName"`
+	LastName       string  `json:"LastName"`
+	DateOfBirth    *string `json:"DateOfBirth,omitempty"`
+	Email          string  `json:"Email"`
+	Phone          *string `json:"Phone,omitempty"`
+	Major          *string `json:"Major,omitempty"`
+	EnrollmentDate string  `json:"EnrollmentDate"`
+}
+
+// WriteNDJSON writes one line per student that POST
+// /api/v2/students/import and studentctl import accept.
+func WriteNDJSON(w io.Writer, students []*entities.Student) error {
+	buffered := bufio.NewWriter(w)
+	encoder := json.NewEncoder(buffered)
+	for _, student := range students {
+		row := importRow{
+			FirstName:      student.FirstName,
+			LastName:       student.LastName,
+			Email:          student.Email,
+			Phone:          student.Phone,
+			Major:          student.Major,
+			EnrollmentDate: student.EnrollmentDate.Format("2006-01-02"),
+		}
+		if student.DateOfBirth != nil {
+			dateOfBirth := student.DateOfBirth.Format("2006-01-02")
+			row.DateOfBirth = &dateOfBirth
+		}
+		if err := encoder.Encode(row); err != nil {
+			return err
+		}
+	}
+	return buffered.Flush()
+}
+
+// Insert validates the students and stores them with CreateBatch, so that
+// they get the same audit entries and outbox events as any other create.
+func Insert(ctx context.Context, repo repositories.StudentRepository, students []*entities.Student, batchSize int) error {
+	validated := make([]*entities.ValidatedStudent, len(students))
+	for i, student := range students {
+		validStudent, err := entities.NewValidatedStudent(student)
+		if err != nil {
+			return err
+		}
+		validated[i] = validStudent
+	}
+	if batchSize <= 0 {
+		batchSize = DefaultBatchSize
+	}
+	return repo.CreateBatch(ctx, validated, batchSize)
+}
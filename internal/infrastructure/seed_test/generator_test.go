@@ -0,0 +1,84 @@
+package seed_test
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"testing"
+
+	"github.com/glebarez/sqlite"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+
+	"github.com/tranvu1111/go-students-new/internal/domain/entities"
+	"github.com/tranvu1111/go-students-new/internal/domain/repositories"
+	"github.com/tranvu1111/go-students-new/internal/infrastructure/db/postgres"
+	"github.com/tranvu1111/go-students-new/internal/infrastructure/seed"
+	"github.com/tranvu1111/go-students-new/internal/interface/api/rest/dto/request"
+)
+
+func TestGenerator_StudentsAreValid(t *testing.T) {
+	students := seed.NewGenerator(seed.Options{Seed: 7, Majors: []string{"Physics", "CNTT"}}).Generate(500)
+
+	emails := make(map[string]bool)
+	for _, student := range students {
+		_, err := entities.NewValidatedStudent(student)
+		require.NoError(t, err, student.Email)
+
+		assert.False(t, emails[student.Email], "duplicate email %s", student.Email)
+		emails[student.Email] = true
+		if student.Major != nil {
+			assert.Contains(t, []string{"Physics", "CNTT"}, *student.Major)
+		}
+		require.NotNil(t, student.DateOfBirth)
+		assert.True(t, student.DateOfBirth.Before(student.EnrollmentDate))
+	}
+}
+
+func TestGenerator_SameSeedSameStudents(t *testing.T) {
+	var first, second, other bytes.Buffer
+	require.NoError(t, seed.WriteNDJSON(&first, seed.NewGenerator(seed.Options{Seed: 42}).Generate(50)))
+	require.NoError(t, seed.WriteNDJSON(&second, seed.NewGenerator(seed.Options{Seed: 42}).Generate(50)))
+	require.NoError(t, seed.WriteNDJSON(&other, seed.NewGenerator(seed.Options{Seed: 43}).Generate(50)))
+
+	assert.Equal(t, first.String(), second.String())
+	assert.NotEqual(t, first.String(), other.String())
+}
+
+func TestWriteNDJSON_IsImportable(t *testing.T) {
+	var buffer bytes.Buffer
+	require.NoError(t, seed.WriteNDJSON(&buffer, seed.NewGenerator(seed.Options{Seed: 1}).Generate(20)))
+
+	rows := request.NewNDJSONStudentReader(&buffer)
+	count := 0
+	for {
+		createCommand, err := rows.Next()
+		if err == io.EOF {
+			break
+		}
+		require.NoError(t, err)
+		student := entities.NewStudent(createCommand.FirstName, createCommand.LastName, createCommand.DateOfBirth,
+			createCommand.Email, createCommand.Phone, createCommand.Major, createCommand.EnrollmentDate)
+		_, err = entities.NewValidatedStudent(student)
+		require.NoError(t, err)
+		count++
+	}
+	assert.Equal(t, 20, count)
+}
+
+func TestInsert_StoresStudents(t *testing.T) {
+	db, err := gorm.Open(sqlite.Open(fmt.Sprintf("file:%s?mode=memory&cache=shared", uuid.NewString())), &gorm.Config{})
+	require.NoError(t, err)
+	require.NoError(t, postgres.AutoMigrate(db))
+	repo := postgres.NewGormStudentRepo(db)
+
+	ctx := context.Background()
+	require.NoError(t, seed.Insert(ctx, repo, seed.NewGenerator(seed.Options{Seed: 3}).Generate(25), 10))
+
+	stored, err := repo.FindAll(ctx, repositories.StudentFilter{})
+	require.NoError(t, err)
+	assert.Len(t, stored, 25)
+}
@@ -0,0 +1,104 @@
+// Package verification mails email verification tokens to students.
+package verification
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"time"
+
+	"github.com/tranvu1111/go-students-new/internal/domain/entities"
+	"github.com/tranvu1111/go-students-new/internal/domain/repositories"
+	"github.com/tranvu1111/go-students-new/internal/infrastructure/mail"
+)
+
+// DefaultTokenTTL is how long a student has to follow the link.
+const DefaultTokenTTL = 48 * time.Hour
+
+// EmailSink is an outbox sink that mails a verification token whenever a
+// student is created or changes their email. It records each email it sends,
+// so that the relay retrying a message for another sink does not mail the
+// student again.
+type EmailSink struct {
+	students repositories.StudentRepository
+	sent     repositories.VerificationEmailRepository
+	mailer   mail.Mailer
+	secret   []byte
+	ttl      time.Duration
+	link     string
+}
+
+// NewEmailSink signs tokens with secret. link is the page that verifies them,
+// e.g. "https://students.example.edu/verify-email"; the token is added as the
+// "token" query parameter. Without a link the email only holds the token.
+func NewEmailSink(students repositories.StudentRepository, sent repositories.VerificationEmailRepository, mailer mail.Mailer,
+	secret []byte, ttl time.Duration, link string) *EmailSink {
+	if ttl <= 0 {
+		ttl = DefaultTokenTTL
+	}
+	return &EmailSink{students: students, sent: sent, mailer: mailer, secret: secret, ttl: ttl, link: link}
+}
+
+func (s *EmailSink) Publish(ctx context.Context, message *entities.OutboxMessage) error {
+	var event entities.StudentEvent
+	if err := json.Unmarshal([]byte(message.Payload), &event); err != nil {
+		return fmt.Errorf("decode event %s: %w", message.ID, err)
+	}
+	email, ok := event.VerificationEmail()
+	if !ok {
+		return nil
+	}
+	sent, err := s.sent.WasSent(ctx, message.ID)
+	if err != nil || sent {
+		return err
+	}
+
+	// The student may have changed their email again, verified it or left
+	// since the event; only the current, unverified email gets a token.
+	student, err := s.students.FindById(ctx, event.StudentID)
+	if errors.Is(err, repositories.ErrStudentNotFound) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if student.Email != email || student.EmailVerifiedAt != nil {
+		return nil
+	}
+
+	token := entities.NewEmailVerificationToken(student, s.ttl)
+	verificationMessage, err := s.verificationMessage(student, token)
+	if err != nil {
+		return err
+	}
+	if err := s.mailer.Send(ctx, verificationMessage); err != nil {
+		return err
+	}
+	// Should recording fail, the relay retries and the student gets a second
+	// email, whose token is as good as the first.
+	return s.sent.RecordSent(ctx, message.ID, student.StudentID, time.Now())
+}
+
+func (s *EmailSink) verificationMessage(student *entities.Student, token entities.EmailVerificationToken) (mail.Message, error) {
+	signed := token.Sign(s.secret)
+	action := "Your verification code is:\n\n" + signed
+	if s.link != "" {
+		link, err := url.Parse(s.link)
+		if err != nil {
+			return mail.Message{}, fmt.Errorf("verification link: %w", err)
+		}
+		query := link.Query()
+		query.Set("token", signed)
+		link.RawQuery = query.Encode()
+		action = "Open this link to verify it:\n\n" + link.String()
+	}
+
+	return mail.Message{
+		To:      student.Email,
+		Subject: "Verify your email address",
+		Text: fmt.Sprintf("Hello %s %s,\n\nPlease confirm that %s is your email address. %s\n\nThe code expires at %s.\n",
+			student.FirstName, student.LastName, student.Email, action, token.ExpiresAt.Format(time.RFC1123)),
+	}, nil
+}
@@ -0,0 +1,135 @@
+package verification_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tranvu1111/go-students-new/internal/domain/entities"
+	"github.com/tranvu1111/go-students-new/internal/infrastructure/mail"
+	"github.com/tranvu1111/go-students-new/internal/infrastructure/memory"
+	"github.com/tranvu1111/go-students-new/internal/infrastructure/outbox"
+	"github.com/tranvu1111/go-students-new/internal/infrastructure/verification"
+)
+
+var secret = []byte("secret")
+
+func outboxMessages(t *testing.T, student *entities.Student) []*entities.OutboxMessage {
+	t.Helper()
+	var messages []*entities.OutboxMessage
+	for _, event := range student.Events() {
+		message, err := entities.NewOutboxMessage(event)
+		require.NoError(t, err)
+		messages = append(messages, message)
+	}
+	return messages
+}
+
+func sentMessages(t *testing.T, buf *bytes.Buffer) []mail.Message {
+	t.Helper()
+	var messages []mail.Message
+	for _, line := range strings.Split(strings.TrimSpace(buf.String()), "\n") {
+		if line == "" {
+			continue
+		}
+		var message mail.Message
+		require.NoError(t, json.Unmarshal([]byte(line), &message))
+		messages = append(messages, message)
+	}
+	return messages
+}
+
+func TestEmailSink_MailsTokenForNewEmail(t *testing.T) {
+	ctx := context.Background()
+	repo := memory.NewStudentRepo(memory.NewStore())
+	var buf bytes.Buffer
+	sink := verification.NewEmailSink(repo, memory.NewVerificationEmailRepo(), mail.NewWriterMailer(&buf), secret, time.Hour, "https://students.example.edu/verify-email?lang=vi")
+
+	student := entities.NewStudent("tran", "vu", nil, "tranvu@example.com", nil, nil, time.Date(2023, time.September, 1, 0, 0, 0, 0, time.UTC))
+	created := outboxMessages(t, student)
+	validated, err := entities.NewValidatedStudent(student)
+	require.NoError(t, err)
+	_, err = repo.Create(ctx, validated)
+	require.NoError(t, err)
+
+	for _, message := range created {
+		require.NoError(t, sink.Publish(ctx, message))
+	}
+	sent := sentMessages(t, &buf)
+	require.Len(t, sent, 1)
+	assert.Equal(t, "tranvu@example.com", sent[0].To)
+
+	start := strings.Index(sent[0].Text, "https://")
+	require.GreaterOrEqual(t, start, 0, sent[0].Text)
+	link, err := url.Parse(strings.Fields(sent[0].Text[start:])[0])
+	require.NoError(t, err)
+	assert.Equal(t, "vi", link.Query().Get("lang"))
+	token, err := entities.ParseEmailVerificationToken(link.Query().Get("token"), secret, time.Now())
+	require.NoError(t, err)
+	assert.Equal(t, student.StudentID, token.StudentID)
+	assert.Equal(t, "tranvu@example.com", token.Email)
+}
+
+func TestEmailSink_SkipsStaleEvents(t *testing.T) {
+	ctx := context.Background()
+	repo := memory.NewStudentRepo(memory.NewStore())
+	var buf bytes.Buffer
+	sink := verification.NewEmailSink(repo, memory.NewVerificationEmailRepo(), mail.NewWriterMailer(&buf), secret, time.Hour, "")
+
+	student := entities.NewStudent("tran", "vu", nil, "tranvu@example.com", nil, nil, time.Date(2023, time.September, 1, 0, 0, 0, 0, time.UTC))
+	created := outboxMessages(t, student)
+	validated, err := entities.NewValidatedStudent(student)
+	require.NoError(t, err)
+	stored, err := repo.Create(ctx, validated)
+	require.NoError(t, err)
+
+	require.NoError(t, stored.ApplyPatch(entities.StudentPatch{Email: entities.Replace("vu.tran@example.com")}))
+	changed := outboxMessages(t, stored)
+	validated, err = entities.NewValidatedStudent(stored)
+	require.NoError(t, err)
+	_, err = repo.Update(ctx, validated)
+	require.NoError(t, err)
+
+	for _, message := range append(created, changed...) {
+		require.NoError(t, sink.Publish(ctx, message))
+	}
+	sent := sentMessages(t, &buf)
+	require.Len(t, sent, 1, "the student no longer has the email they enrolled with")
+	assert.Equal(t, "vu.tran@example.com", sent[0].To)
+	assert.Contains(t, sent[0].Text, "Your verification code is")
+}
+
+type failingSink struct{}
+
+func (failingSink) Publish(ctx context.Context, message *entities.OutboxMessage) error {
+	return errors.New("webhook store is down")
+}
+
+func TestEmailSink_MailsOnceWhenRelayRetries(t *testing.T) {
+	ctx := context.Background()
+	store := memory.NewStore()
+	repo := memory.NewStudentRepo(store)
+	var buf bytes.Buffer
+	sink := verification.NewEmailSink(repo, memory.NewVerificationEmailRepo(), mail.NewWriterMailer(&buf), secret, time.Hour, "")
+	relay := outbox.NewRelay(memory.NewOutboxRepo(store), time.Second, 10, entities.RetryPolicy{MaxAttempts: 3}, sink, failingSink{})
+
+	student := entities.NewStudent("tran", "vu", nil, "tranvu@example.com", nil, nil, time.Date(2023, time.September, 1, 0, 0, 0, 0, time.UTC))
+	validated, err := entities.NewValidatedStudent(student)
+	require.NoError(t, err)
+	_, err = repo.Create(ctx, validated)
+	require.NoError(t, err)
+
+	for i := 0; i < 3; i++ {
+		published, err := relay.RelayOnce(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, 0, published, "the failing sink keeps the message unpublished")
+	}
+	assert.Len(t, sentMessages(t, &buf), 1, "a retry for another sink must not mail the student again")
+}
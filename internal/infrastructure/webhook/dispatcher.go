@@ -0,0 +1,124 @@
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/tranvu1111/go-students-new/internal/domain/entities"
+	"github.com/tranvu1111/go-students-new/internal/domain/repositories"
+)
+
+const (
+	defaultDispatchInterval  = time.Second
+	defaultDispatchBatchSize = 50
+)
+
+// Dispatcher sends due webhook deliveries, signing each request with the
+// subscription secret and rescheduling failures according to the retry policy.
+type Dispatcher struct {
+	subscriptions repositories.WebhookSubscriptionRepository
+	deliveries    repositories.WebhookDeliveryRepository
+	client        *http.Client
+	policy        entities.WebhookRetryPolicy
+	interval      time.Duration
+	batchSize     int
+}
+
+func NewDispatcher(subscriptions repositories.WebhookSubscriptionRepository, deliveries repositories.WebhookDeliveryRepository,
+	client *http.Client, policy entities.WebhookRetryPolicy) *Dispatcher {
+	if client == nil {
+		client = &http.Client{Timeout: 10 * time.Second}
+	}
+	return &Dispatcher{
+		subscriptions: subscriptions,
+		deliveries:    deliveries,
+		client:        client,
+		policy:        policy,
+		interval:      defaultDispatchInterval,
+		batchSize:     defaultDispatchBatchSize,
+	}
+}
+
+// Run dispatches until ctx is cancelled.
+func (d *Dispatcher) Run(ctx context.Context) {
+	ticker := time.NewTicker(d.interval)
+	defer ticker.Stop()
+
+	for {
+		if _, err := d.DispatchDue(ctx); err != nil && !errors.Is(err, context.Canceled) {
+			log.Printf("webhook dispatcher: %v", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// DispatchDue attempts every delivery whose next attempt is due and returns
+// how many were attempted.
+func (d *Dispatcher) DispatchDue(ctx context.Context) (int, error) {
+	due, err := d.deliveries.FindDue(ctx, time.Now(), d.batchSize)
+	if err != nil {
+		return 0, err
+	}
+
+	for _, delivery := range due {
+		d.attempt(ctx, delivery)
+		if err := d.deliveries.Update(ctx, delivery); err != nil {
+			return 0, err
+		}
+	}
+	return len(due), nil
+}
+
+func (d *Dispatcher) attempt(ctx context.Context, delivery *entities.WebhookDelivery) {
+	subscription, err := d.subscriptions.FindById(ctx, delivery.SubscriptionID)
+	if errors.Is(err, repositories.ErrWebhookSubscriptionNotFound) || (err == nil && !subscription.Active) {
+		delivery.DeadLetter("subscription removed or inactive", time.Now())
+		return
+	}
+	if err != nil {
+		delivery.RecordFailure(0, err.Error(), time.Now(), d.policy)
+		return
+	}
+
+	statusCode, err := d.send(ctx, subscription, delivery)
+	if err != nil {
+		delivery.RecordFailure(statusCode, err.Error(), time.Now(), d.policy)
+		return
+	}
+	delivery.RecordSuccess(statusCode, time.Now())
+}
+
+func (d *Dispatcher) send(ctx context.Context, subscription *entities.WebhookSubscription, delivery *entities.WebhookDelivery) (int, error) {
+	body := []byte(delivery.Payload)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, subscription.URL, bytes.NewReader(body))
+	if err != nil {
+		return 0, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(EventTypeHeader, delivery.EventType)
+	req.Header.Set(DeliveryIDHeader, delivery.ID.String())
+	req.Header.Set(SignatureHeader, Sign(subscription.Secret, time.Now(), body))
+
+	resp, err := d.client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 64<<10))
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return resp.StatusCode, fmt.Errorf("subscriber responded with status %d", resp.StatusCode)
+	}
+	return resp.StatusCode, nil
+}
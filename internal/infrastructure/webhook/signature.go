@@ -0,0 +1,65 @@
+package webhook
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	SignatureHeader  = "X-Webhook-Signature"
+	EventTypeHeader  = "X-Webhook-Event"
+	DeliveryIDHeader = "X-Webhook-Delivery"
+)
+
+// Sign returns the signature header value "t=<unix>,v1=<hex>", where v1 is the
+// HMAC-SHA256 of "<unix>.<body>" keyed with the subscription secret. Binding
+// the timestamp lets receivers reject replays.
+func Sign(secret string, timestamp time.Time, body []byte) string {
+	ts := strconv.FormatInt(timestamp.Unix(), 10)
+	return fmt.Sprintf("t=%s,v1=%s", ts, computeSignature(secret, ts, body))
+}
+
+// Verify checks a signature header produced by Sign and that it is not older
+// than tolerance. Receivers can use it as a reference implementation.
+func Verify(secret string, header string, body []byte, now time.Time, tolerance time.Duration) error {
+	var ts, signature string
+	for _, part := range strings.Split(header, ",") {
+		key, value, found := strings.Cut(part, "=")
+		if !found {
+			continue
+		}
+		switch key {
+		case "t":
+			ts = value
+		case "v1":
+			signature = value
+		}
+	}
+
+	unix, err := strconv.ParseInt(ts, 10, 64)
+	if err != nil || signature == "" {
+		return fmt.Errorf("malformed signature header")
+	}
+
+	if now.Sub(time.Unix(unix, 0)) > tolerance {
+		return fmt.Errorf("signature timestamp is too old")
+	}
+
+	if !hmac.Equal([]byte(signature), []byte(computeSignature(secret, ts, body))) {
+		return fmt.Errorf("signature mismatch")
+	}
+	return nil
+}
+
+func computeSignature(secret string, ts string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(ts))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return hex.EncodeToString(mac.Sum(nil))
+}
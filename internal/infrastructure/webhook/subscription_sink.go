@@ -0,0 +1,35 @@
+package webhook
+
+import (
+	"context"
+
+	"github.com/tranvu1111/go-students-new/internal/domain/entities"
+	"github.com/tranvu1111/go-students-new/internal/domain/repositories"
+)
+
+// SubscriptionSink is an outbox sink that enqueues one delivery per matching
+// subscription. Actual sending is left to the Dispatcher, so a slow partner
+// does not hold up the outbox relay.
+type SubscriptionSink struct {
+	subscriptions repositories.WebhookSubscriptionRepository
+	deliveries    repositories.WebhookDeliveryRepository
+}
+
+func NewSubscriptionSink(subscriptions repositories.WebhookSubscriptionRepository, deliveries repositories.WebhookDeliveryRepository) *SubscriptionSink {
+	return &SubscriptionSink{subscriptions: subscriptions, deliveries: deliveries}
+}
+
+func (s *SubscriptionSink) Publish(ctx context.Context, message *entities.OutboxMessage) error {
+	subscriptions, err := s.subscriptions.FindAll(ctx)
+	if err != nil {
+		return err
+	}
+
+	var deliveries []*entities.WebhookDelivery
+	for _, subscription := range subscriptions {
+		if subscription.Matches(message.EventType) {
+			deliveries = append(deliveries, entities.NewWebhookDelivery(subscription.ID, message))
+		}
+	}
+	return s.deliveries.Create(ctx, deliveries)
+}
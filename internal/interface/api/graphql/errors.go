@@ -0,0 +1,63 @@
+package graphql
+
+import (
+	"errors"
+
+	"github.com/google/uuid"
+	graphqlgo "github.com/graph-gophers/graphql-go"
+
+	"github.com/tranvu1111/go-students-new/internal/domain/entities"
+	"github.com/tranvu1111/go-students-new/internal/domain/repositories"
+)
+
+// resolverError carries a machine readable code in the "extensions" of the
+// GraphQL error, the counterpart of an HTTP status.
+type resolverError struct {
+	err  error
+	code string
+}
+
+func (e *resolverError) Error() string {
+	return e.err.Error()
+}
+
+func (e *resolverError) Unwrap() error {
+	return e.err
+}
+
+func (e *resolverError) Extensions() map[string]interface{} {
+	return map[string]interface{}{"code": e.code}
+}
+
+func invalidArgument(message string) error {
+	return &resolverError{err: errors.New(message), code: "INVALID_ARGUMENT"}
+}
+
+func toResolverError(err error) error {
+	var conflict *repositories.VersionConflictError
+	switch {
+	case errors.As(err, &conflict):
+		return &resolverError{err: err, code: "VERSION_CONFLICT"}
+	case errors.Is(err, repositories.ErrStudentNotFound):
+		return &resolverError{err: err, code: "NOT_FOUND"}
+	case errors.Is(err, repositories.ErrDuplicateEmail):
+		return &resolverError{err: err, code: "ALREADY_EXISTS"}
+	case errors.Is(err, entities.ErrStatusTransitionNotAllowed):
+		return &resolverError{err: err, code: "FAILED_PRECONDITION"}
+	case errors.Is(err, entities.ErrInvalidStudent), errors.Is(err, entities.ErrInvalidPatch), errors.Is(err, entities.ErrInvalidStatusChange),
+		errors.Is(err, entities.ErrInvalidProgramDeclaration):
+		return &resolverError{err: err, code: "INVALID_ARGUMENT"}
+	case errors.Is(err, repositories.ErrUnavailable):
+		return &resolverError{err: err, code: "UNAVAILABLE"}
+	default:
+		return &resolverError{err: err, code: "INTERNAL"}
+	}
+}
+
+func parseID(id graphqlgo.ID) (uuid.UUID, error) {
+	parsed, err := uuid.Parse(string(id))
+	if err != nil {
+		return uuid.Nil, invalidArgument("invalid student ID: " + err.Error())
+	}
+	return parsed, nil
+}
@@ -0,0 +1,34 @@
+package graphql
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/tranvu1111/go-students-new/internal/application/interfaces"
+)
+
+const Path = "/graphql"
+
+type graphQLRequest struct {
+	Query         string                 `json:"query"`
+	OperationName string                 `json:"operationName"`
+	Variables     map[string]interface{} `json:"variables"`
+}
+
+// Register serves the student schema at POST /graphql. Every request gets
+// its own StudentLoader.
+func Register(r *gin.Engine, service interfaces.StudentService) {
+	schema := NewSchema(service)
+
+	r.POST(Path, func(c *gin.Context) {
+		var req graphQLRequest
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"message": "Invalid request", "error1": err.Error()})
+			return
+		}
+
+		ctx := ContextWithStudentLoader(c.Request.Context(), NewStudentLoader(service))
+		c.JSON(http.StatusOK, schema.Exec(ctx, req.Query, req.OperationName, req.Variables))
+	})
+}
@@ -0,0 +1,150 @@
+package graphql
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/tranvu1111/go-students-new/internal/application/common"
+	"github.com/tranvu1111/go-students-new/internal/application/interfaces"
+)
+
+const (
+	defaultBatchWait = 2 * time.Millisecond
+	defaultMaxBatch  = 100
+)
+
+// StudentLoader batches the student lookups made while resolving one request:
+// IDs asked for within the wait window, which the executor's concurrent field
+// resolution fills, are fetched with a single FindStudentsByIds call instead
+// of one query each. Results are memoized for the life of the loader, so a
+// loader must not outlive its request.
+type StudentLoader struct {
+	service  interfaces.StudentService
+	wait     time.Duration
+	maxBatch int
+
+	mu      sync.Mutex
+	pending *studentBatch
+	loaded  map[uuid.UUID]*studentBatch
+}
+
+type studentBatch struct {
+	ids      []uuid.UUID
+	done     chan struct{}
+	students map[uuid.UUID]*common.StudentResult
+	err      error
+}
+
+func NewStudentLoader(service interfaces.StudentService) *StudentLoader {
+	return &StudentLoader{
+		service:  service,
+		wait:     defaultBatchWait,
+		maxBatch: defaultMaxBatch,
+		loaded:   map[uuid.UUID]*studentBatch{},
+	}
+}
+
+// Load returns the student with id, or nil when there is no live student.
+func (l *StudentLoader) Load(ctx context.Context, id uuid.UUID) (*common.StudentResult, error) {
+	students, err := l.LoadMany(ctx, []uuid.UUID{id})
+	if err != nil {
+		return nil, err
+	}
+	return students[0], nil
+}
+
+// LoadMany returns one entry per id, nil where there is no live student. All
+// of ids join the same batch unless it fills up.
+func (l *StudentLoader) LoadMany(ctx context.Context, ids []uuid.UUID) ([]*common.StudentResult, error) {
+	batches := make([]*studentBatch, len(ids))
+
+	l.mu.Lock()
+	for i, id := range ids {
+		batch, ok := l.loaded[id]
+		if !ok {
+			batch = l.enqueue(ctx, id)
+		}
+		batches[i] = batch
+	}
+	l.mu.Unlock()
+
+	students := make([]*common.StudentResult, len(ids))
+	for i, batch := range batches {
+		select {
+		case <-batch.done:
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+		if batch.err != nil {
+			return nil, batch.err
+		}
+		students[i] = batch.students[ids[i]]
+	}
+	return students, nil
+}
+
+// enqueue adds id to the pending batch; l.mu must be held.
+func (l *StudentLoader) enqueue(ctx context.Context, id uuid.UUID) *studentBatch {
+	if l.pending == nil {
+		l.pending = &studentBatch{done: make(chan struct{})}
+		go l.dispatchAfterWait(ctx, l.pending)
+	}
+	batch := l.pending
+	batch.ids = append(batch.ids, id)
+	l.loaded[id] = batch
+
+	if len(batch.ids) >= l.maxBatch {
+		l.pending = nil
+		go l.dispatch(ctx, batch)
+	}
+	return batch
+}
+
+func (l *StudentLoader) dispatchAfterWait(ctx context.Context, batch *studentBatch) {
+	time.Sleep(l.wait)
+
+	l.mu.Lock()
+	if l.pending != batch {
+		// The batch filled up and was dispatched already.
+		l.mu.Unlock()
+		return
+	}
+	l.pending = nil
+	l.mu.Unlock()
+
+	l.dispatch(ctx, batch)
+}
+
+func (l *StudentLoader) dispatch(ctx context.Context, batch *studentBatch) {
+	defer close(batch.done)
+
+	result, err := l.service.FindStudentsByIds(ctx, batch.ids)
+	if err != nil {
+		batch.err = toResolverError(err)
+		return
+	}
+
+	batch.students = make(map[uuid.UUID]*common.StudentResult, len(result.Result))
+	for _, student := range result.Result {
+		batch.students[student.StudentID] = student
+	}
+}
+
+type studentLoaderKey struct{}
+
+// ContextWithStudentLoader attaches a loader for the lifetime of one request.
+func ContextWithStudentLoader(ctx context.Context, loader *StudentLoader) context.Context {
+	return context.WithValue(ctx, studentLoaderKey{}, loader)
+}
+
+// studentLoaderFor returns the request's loader, or an unshared one when the
+// schema is executed without one, which still works but does not batch.
+func studentLoaderFor(ctx context.Context, service interfaces.StudentService) *StudentLoader {
+	if loader, ok := ctx.Value(studentLoaderKey{}).(*StudentLoader); ok {
+		return loader
+	}
+	return NewStudentLoader(service)
+}
@@ -0,0 +1,187 @@
+package graphql
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	graphqlgo "github.com/graph-gophers/graphql-go"
+
+	"github.com/tranvu1111/go-students-new/internal/application/command"
+	"github.com/tranvu1111/go-students-new/internal/application/interfaces"
+	"github.com/tranvu1111/go-students-new/internal/application/query"
+	"github.com/tranvu1111/go-students-new/internal/domain/entities"
+)
+
+// maxPageSize caps students(limit:); the schema defaults the limit to 20.
+const maxPageSize = 100
+
+// Resolver is the root of the schema: its methods resolve the fields of
+// Query and Mutation.
+type Resolver struct {
+	service interfaces.StudentService
+}
+
+func (r *Resolver) Student(ctx context.Context, args struct{ ID graphqlgo.ID }) (*studentResolver, error) {
+	id, err := parseID(args.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	student, err := studentLoaderFor(ctx, r.service).Load(ctx, id)
+	if err != nil || student == nil {
+		return nil, err
+	}
+	return &studentResolver{student: student}, nil
+}
+
+func (r *Resolver) StudentsByIds(ctx context.Context, args struct{ IDs []graphqlgo.ID }) ([]*studentResolver, error) {
+	ids := make([]uuid.UUID, len(args.IDs))
+	for i, rawID := range args.IDs {
+		id, err := parseID(rawID)
+		if err != nil {
+			return nil, err
+		}
+		ids[i] = id
+	}
+
+	students, err := studentLoaderFor(ctx, r.service).LoadMany(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+
+	resolvers := make([]*studentResolver, len(students))
+	for i, student := range students {
+		if student != nil {
+			resolvers[i] = &studentResolver{student: student}
+		}
+	}
+	return resolvers, nil
+}
+
+type studentFilterInput struct {
+	Major        *string
+	Status       *string
+	EnrolledFrom *graphqlgo.Time
+	EnrolledTo   *graphqlgo.Time
+}
+
+type studentsArgs struct {
+	Filter *studentFilterInput
+	Offset int32
+	Limit  int32
+}
+
+func (r *Resolver) Students(ctx context.Context, args studentsArgs) (*studentPageResolver, error) {
+	offset, limit := int(args.Offset), int(args.Limit)
+	if offset < 0 || limit <= 0 || limit > maxPageSize {
+		return nil, invalidArgument("offset cannot be negative and limit must be between 1 and 100")
+	}
+
+	// One extra row tells whether there is a next page.
+	listQuery := &query.StudentListQuery{Offset: offset, Limit: limit + 1}
+	if args.Filter != nil {
+		listQuery.Major = args.Filter.Major
+		if args.Filter.Status != nil {
+			status, err := entities.ParseStudentStatus(*args.Filter.Status)
+			if err != nil {
+				return nil, toResolverError(err)
+			}
+			listQuery.Status = &status
+		}
+		listQuery.EnrolledFrom = optionalTime(args.Filter.EnrolledFrom)
+		listQuery.EnrolledTo = optionalTime(args.Filter.EnrolledTo)
+	}
+
+	result, err := r.service.FindAllStudent(ctx, listQuery)
+	if err != nil {
+		return nil, toResolverError(err)
+	}
+
+	page := &studentPageResolver{offset: int32(offset), limit: int32(limit)}
+	for i, student := range result.Result {
+		if i == limit {
+			page.hasNextPage = true
+			break
+		}
+		page.students = append(page.students, &studentResolver{student: student})
+	}
+	return page, nil
+}
+
+type createStudentInput struct {
+	IdempotencyKey *string
+	FirstName      string
+	LastName       string
+	DateOfBirth    *graphqlgo.Time
+	Email          string
+	Phone          *string
+	Major          *string
+	EnrollmentDate graphqlgo.Time
+}
+
+func (r *Resolver) CreateStudent(ctx context.Context, args struct{ Input createStudentInput }) (*studentResolver, error) {
+	input := args.Input
+	result, err := r.service.CreateStudent(ctx, &command.CreateStudentCommand{
+		IdempotencyKey: stringOrEmpty(input.IdempotencyKey),
+		FirstName:      input.FirstName,
+		LastName:       input.LastName,
+		DateOfBirth:    optionalTime(input.DateOfBirth),
+		Email:          input.Email,
+		Phone:          input.Phone,
+		Major:          input.Major,
+		EnrollmentDate: input.EnrollmentDate.Time,
+	})
+	if err != nil {
+		return nil, toResolverError(err)
+	}
+	return &studentResolver{student: result.Result}, nil
+}
+
+type updateStudentInput struct {
+	StudentId       graphqlgo.ID
+	ExpectedVersion int32
+	IdempotencyKey  *string
+	DateOfBirth     *graphqlgo.Time
+	Phone           *string
+	Major           *string
+}
+
+func (r *Resolver) UpdateStudent(ctx context.Context, args struct{ Input updateStudentInput }) (*studentResolver, error) {
+	input := args.Input
+	id, err := parseID(input.StudentId)
+	if err != nil {
+		return nil, err
+	}
+	if input.ExpectedVersion <= 0 {
+		return nil, invalidArgument("expectedVersion must be positive")
+	}
+
+	result, err := r.service.UpdateStudent(ctx, &command.UpdateStudentCommand{
+		IdempotencyKey:  stringOrEmpty(input.IdempotencyKey),
+		StudentId:       id,
+		DateOfBirth:     optionalTime(input.DateOfBirth),
+		Phone:           input.Phone,
+		Major:           input.Major,
+		ExpectedVersion: int(input.ExpectedVersion),
+	})
+	if err != nil {
+		return nil, toResolverError(err)
+	}
+	return &studentResolver{student: result.Result}, nil
+}
+
+func optionalTime(value *graphqlgo.Time) *time.Time {
+	if value == nil {
+		return nil
+	}
+	t := value.Time
+	return &t
+}
+
+func stringOrEmpty(value *string) string {
+	if value == nil {
+		return ""
+	}
+	return *value
+}
@@ -0,0 +1,23 @@
+// Package graphql serves the student API at /graphql, so that clients can
+// fetch exactly the fields they need in one round trip. Like the REST
+// controllers it only adapts requests to interfaces.StudentService.
+package graphql
+
+import (
+	_ "embed"
+
+	graphqlgo "github.com/graph-gophers/graphql-go"
+
+	"github.com/tranvu1111/go-students-new/internal/application/interfaces"
+)
+
+//go:embed schema.graphql
+var schemaSource string
+
+// NewSchema parses the student schema and binds it to service.
+func NewSchema(service interfaces.StudentService) *graphqlgo.Schema {
+	return graphqlgo.MustParseSchema(schemaSource, &Resolver{service: service},
+		graphqlgo.UseStringDescriptions(),
+		graphqlgo.MaxDepth(8),
+	)
+}
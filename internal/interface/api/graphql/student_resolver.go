@@ -0,0 +1,93 @@
+package graphql
+
+import (
+	graphqlgo "github.com/graph-gophers/graphql-go"
+
+	"github.com/tranvu1111/go-students-new/internal/application/common"
+)
+
+type studentResolver struct {
+	student *common.StudentResult
+}
+
+func (r *studentResolver) StudentId() graphqlgo.ID {
+	return graphqlgo.ID(r.student.StudentID.String())
+}
+
+func (r *studentResolver) FirstName() string {
+	return r.student.FirstName
+}
+
+func (r *studentResolver) LastName() string {
+	return r.student.LastName
+}
+
+func (r *studentResolver) DateOfBirth() *graphqlgo.Time {
+	if r.student.DateOfBirth == nil {
+		return nil
+	}
+	return &graphqlgo.Time{Time: *r.student.DateOfBirth}
+}
+
+func (r *studentResolver) Email() string {
+	return r.student.Email
+}
+
+func (r *studentResolver) Phone() *string {
+	return r.student.Phone
+}
+
+func (r *studentResolver) Major() *string {
+	return r.student.Major
+}
+
+func (r *studentResolver) EnrollmentDate() graphqlgo.Time {
+	return graphqlgo.Time{Time: r.student.EnrollmentDate}
+}
+
+func (r *studentResolver) Status() string {
+	return r.student.Status
+}
+
+func (r *studentResolver) StatusReason() string {
+	return r.student.StatusReason
+}
+
+func (r *studentResolver) StatusEffectiveDate() graphqlgo.Time {
+	return graphqlgo.Time{Time: r.student.StatusEffectiveDate}
+}
+
+func (r *studentResolver) CreatedAt() graphqlgo.Time {
+	return graphqlgo.Time{Time: r.student.CreatedAt}
+}
+
+func (r *studentResolver) UpdatedAt() graphqlgo.Time {
+	return graphqlgo.Time{Time: r.student.UpdatedAt}
+}
+
+func (r *studentResolver) Version() int32 {
+	return int32(r.student.Version)
+}
+
+type studentPageResolver struct {
+	students    []*studentResolver
+	offset      int32
+	limit       int32
+	hasNextPage bool
+}
+
+func (r *studentPageResolver) Students() []*studentResolver {
+	return r.students
+}
+
+func (r *studentPageResolver) Offset() int32 {
+	return r.offset
+}
+
+func (r *studentPageResolver) Limit() int32 {
+	return r.limit
+}
+
+func (r *studentPageResolver) HasNextPage() bool {
+	return r.hasNextPage
+}
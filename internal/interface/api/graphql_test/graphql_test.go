@@ -0,0 +1,193 @@
+package graphql_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/glebarez/sqlite"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+
+	"github.com/tranvu1111/go-students-new/internal/application/services"
+	"github.com/tranvu1111/go-students-new/internal/domain/entities"
+	"github.com/tranvu1111/go-students-new/internal/domain/repositories"
+	"github.com/tranvu1111/go-students-new/internal/infrastructure/db/postgres"
+	"github.com/tranvu1111/go-students-new/internal/interface/api/graphql"
+)
+
+// countingRepo counts the lookups that reach the database.
+type countingRepo struct {
+	repositories.StudentRepository
+	findById  atomic.Int32
+	findByIds atomic.Int32
+}
+
+func (r *countingRepo) FindById(ctx context.Context, id uuid.UUID) (*entities.Student, error) {
+	r.findById.Add(1)
+	return r.StudentRepository.FindById(ctx, id)
+}
+
+func (r *countingRepo) FindByIds(ctx context.Context, ids []uuid.UUID) ([]*entities.Student, error) {
+	r.findByIds.Add(1)
+	return r.StudentRepository.FindByIds(ctx, ids)
+}
+
+func setupGraphQL(t *testing.T) (*gin.Engine, *countingRepo) {
+	gin.SetMode(gin.ReleaseMode)
+	db, err := gorm.Open(sqlite.Open(fmt.Sprintf("file:%s?mode=memory&cache=shared", uuid.NewString())), &gorm.Config{})
+	require.NoError(t, err)
+	require.NoError(t, postgres.AutoMigrate(db))
+
+	repo := &countingRepo{StudentRepository: postgres.NewGormStudentRepo(db)}
+	service := services.NewStudentService(repo, postgres.NewGormIdempotencyRepository(db), postgres.NewGormAuditRepo(db), postgres.NewGormStudentStatusRepo(db), postgres.NewGormProgramRepo(db))
+
+	r := gin.New()
+	graphql.Register(r, service)
+	return r, repo
+}
+
+type graphQLResponse struct {
+	Data   map[string]json.RawMessage `json:"data"`
+	Errors []struct {
+		Message    string                 `json:"message"`
+		Extensions map[string]interface{} `json:"extensions"`
+	} `json:"errors"`
+}
+
+func execute(t *testing.T, r *gin.Engine, query string, variables map[string]interface{}) graphQLResponse {
+	body, err := json.Marshal(map[string]interface{}{"query": query, "variables": variables})
+	require.NoError(t, err)
+
+	req := httptest.NewRequest(http.MethodPost, "/graphql", strings.NewReader(string(body)))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+	var resp graphQLResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	return resp
+}
+
+type studentFields struct {
+	StudentId string
+	FirstName string
+	Email     string
+	Major     *string
+	Version   int
+}
+
+func createStudent(t *testing.T, r *gin.Engine, email string, major string) studentFields {
+	resp := execute(t, r, `mutation($input: CreateStudentInput!) {
+		createStudent(input: $input) { studentId firstName email major version }
+	}`, map[string]interface{}{"input": map[string]interface{}{
+		"firstName":      "tran",
+		"lastName":       "vu",
+		"email":          email,
+		"major":          major,
+		"enrollmentDate": "2023-09-01T00:00:00Z",
+	}})
+	require.Empty(t, resp.Errors)
+
+	var created studentFields
+	require.NoError(t, json.Unmarshal(resp.Data["createStudent"], &created))
+	return created
+}
+
+func TestGraphQL_CreateAndFetchSelectedFields(t *testing.T) {
+	r, _ := setupGraphQL(t)
+	created := createStudent(t, r, "tranvu@example.com", "CNTT")
+	assert.Equal(t, 1, created.Version)
+
+	resp := execute(t, r, `query($id: ID!) { student(id: $id) { firstName email } }`, map[string]interface{}{"id": created.StudentId})
+	require.Empty(t, resp.Errors)
+	assert.JSONEq(t, `{"firstName":"tran","email":"tranvu@example.com"}`, string(resp.Data["student"]))
+
+	resp = execute(t, r, `query($id: ID!) { student(id: $id) { firstName } }`, map[string]interface{}{"id": uuid.NewString()})
+	require.Empty(t, resp.Errors)
+	assert.JSONEq(t, `null`, string(resp.Data["student"]))
+}
+
+func TestGraphQL_BatchesStudentLookups(t *testing.T) {
+	r, repo := setupGraphQL(t)
+	a := createStudent(t, r, "a@example.com", "CNTT")
+	b := createStudent(t, r, "b@example.com", "CNTT")
+	c := createStudent(t, r, "c@example.com", "Physics")
+	repo.findById.Store(0)
+
+	resp := execute(t, r, `query($a: ID!, $b: ID!, $c: ID!) {
+		a: student(id: $a) { email }
+		b: student(id: $b) { email }
+		again: student(id: $a) { email }
+		many: studentsByIds(ids: [$c, $b]) { email }
+	}`, map[string]interface{}{"a": a.StudentId, "b": b.StudentId, "c": c.StudentId})
+	require.Empty(t, resp.Errors)
+
+	assert.JSONEq(t, `{"email":"a@example.com"}`, string(resp.Data["a"]))
+	assert.JSONEq(t, `{"email":"a@example.com"}`, string(resp.Data["again"]))
+	assert.JSONEq(t, `[{"email":"c@example.com"},{"email":"b@example.com"}]`, string(resp.Data["many"]))
+	assert.Equal(t, int32(1), repo.findByIds.Load())
+	assert.Equal(t, int32(0), repo.findById.Load())
+}
+
+func TestGraphQL_ListFiltersAndPages(t *testing.T) {
+	r, _ := setupGraphQL(t)
+	for i := 0; i < 3; i++ {
+		createStudent(t, r, fmt.Sprintf("cntt%d@example.com", i), "CNTT")
+		time.Sleep(time.Millisecond)
+	}
+	createStudent(t, r, "physics@example.com", "Physics")
+
+	query := `query($offset: Int) {
+		students(filter: {major: "CNTT"}, offset: $offset, limit: 2) { students { email } hasNextPage }
+	}`
+
+	resp := execute(t, r, query, map[string]interface{}{"offset": 0})
+	require.Empty(t, resp.Errors)
+	assert.JSONEq(t, `{"students":[{"email":"cntt0@example.com"},{"email":"cntt1@example.com"}],"hasNextPage":true}`, string(resp.Data["students"]))
+
+	resp = execute(t, r, query, map[string]interface{}{"offset": 2})
+	require.Empty(t, resp.Errors)
+	assert.JSONEq(t, `{"students":[{"email":"cntt2@example.com"}],"hasNextPage":false}`, string(resp.Data["students"]))
+
+	resp = execute(t, r, `{ students(limit: 1000) { hasNextPage } }`, nil)
+	require.Len(t, resp.Errors, 1)
+	assert.Equal(t, "INVALID_ARGUMENT", resp.Errors[0].Extensions["code"])
+}
+
+func TestGraphQL_UpdateChecksVersion(t *testing.T) {
+	r, _ := setupGraphQL(t)
+	created := createStudent(t, r, "tranvu@example.com", "CNTT")
+
+	mutation := `mutation($input: UpdateStudentInput!) { updateStudent(input: $input) { major version } }`
+	input := map[string]interface{}{"studentId": created.StudentId, "expectedVersion": created.Version, "major": "Physics"}
+
+	resp := execute(t, r, mutation, map[string]interface{}{"input": input})
+	require.Empty(t, resp.Errors)
+	assert.JSONEq(t, `{"major":"Physics","version":2}`, string(resp.Data["updateStudent"]))
+
+	resp = execute(t, r, mutation, map[string]interface{}{"input": input})
+	require.Len(t, resp.Errors, 1)
+	assert.Equal(t, "VERSION_CONFLICT", resp.Errors[0].Extensions["code"])
+}
+
+func TestGraphQL_CreateRejectsInvalidStudent(t *testing.T) {
+	r, _ := setupGraphQL(t)
+
+	resp := execute(t, r, `mutation {
+		createStudent(input: {firstName: "tran", lastName: "vu", email: "not-an-email", enrollmentDate: "2023-09-01T00:00:00Z"}) { studentId }
+	}`, nil)
+	require.Len(t, resp.Errors, 1)
+	assert.Equal(t, "Invalid email", resp.Errors[0].Message)
+	assert.Equal(t, "INVALID_ARGUMENT", resp.Errors[0].Extensions["code"])
+}
@@ -0,0 +1,40 @@
+package grpc
+
+import (
+	"context"
+	"errors"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/tranvu1111/go-students-new/internal/domain/entities"
+	"github.com/tranvu1111/go-students-new/internal/domain/repositories"
+)
+
+// toStatus maps domain errors to gRPC codes, as writeStudentError does to
+// HTTP statuses. A failed version check is Aborted: the caller should read the
+// student again and retry.
+func toStatus(err error) error {
+	var conflict *repositories.VersionConflictError
+	switch {
+	case errors.As(err, &conflict):
+		return status.Error(codes.Aborted, err.Error())
+	case errors.Is(err, repositories.ErrStudentNotFound):
+		return status.Error(codes.NotFound, err.Error())
+	case errors.Is(err, repositories.ErrDuplicateEmail):
+		return status.Error(codes.AlreadyExists, err.Error())
+	case errors.Is(err, entities.ErrStatusTransitionNotAllowed):
+		return status.Error(codes.FailedPrecondition, err.Error())
+	case errors.Is(err, entities.ErrInvalidStudent), errors.Is(err, entities.ErrInvalidPatch), errors.Is(err, entities.ErrInvalidStatusChange),
+		errors.Is(err, entities.ErrInvalidProgramDeclaration):
+		return status.Error(codes.InvalidArgument, err.Error())
+	case errors.Is(err, repositories.ErrUnavailable):
+		return status.Error(codes.Unavailable, err.Error())
+	case errors.Is(err, context.Canceled):
+		return status.Error(codes.Canceled, err.Error())
+	case errors.Is(err, context.DeadlineExceeded):
+		return status.Error(codes.DeadlineExceeded, err.Error())
+	default:
+		return status.Error(codes.Internal, err.Error())
+	}
+}
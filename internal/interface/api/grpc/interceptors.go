@@ -0,0 +1,79 @@
+package grpc
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/google/uuid"
+	"google.golang.org/grpc/metadata"
+
+	grpclib "google.golang.org/grpc"
+
+	"github.com/tranvu1111/go-students-new/internal/domain/entities"
+	"github.com/tranvu1111/go-students-new/internal/domain/repositories"
+)
+
+// Metadata keys matching the X-Actor, X-Request-ID and X-Consistent-Read
+// headers of the REST API.
+const (
+	ActorMetadataKey          = "x-actor"
+	RequestIDMetadataKey      = "x-request-id"
+	ConsistentReadMetadataKey = "x-consistent-read"
+)
+
+// RequestContextUnaryInterceptor is the gRPC counterpart of
+// rest.RequestContextMiddleware: it attributes the call for the audit trail,
+// echoes the request ID back in the response header and starts the read
+// session of the call.
+func RequestContextUnaryInterceptor() grpclib.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpclib.UnaryServerInfo, handler grpclib.UnaryHandler) (interface{}, error) {
+		ctx, err := withRequestContext(ctx)
+		if err != nil {
+			return nil, err
+		}
+		return handler(ctx, req)
+	}
+}
+
+func RequestContextStreamInterceptor() grpclib.StreamServerInterceptor {
+	return func(srv interface{}, stream grpclib.ServerStream, info *grpclib.StreamServerInfo, handler grpclib.StreamHandler) error {
+		ctx, err := withRequestContext(stream.Context())
+		if err != nil {
+			return err
+		}
+		return handler(srv, &contextStream{ServerStream: stream, ctx: ctx})
+	}
+}
+
+func withRequestContext(ctx context.Context) (context.Context, error) {
+	md, _ := metadata.FromIncomingContext(ctx)
+	actor := firstMetadataValue(md, ActorMetadataKey)
+	requestID := firstMetadataValue(md, RequestIDMetadataKey)
+	if requestID == "" {
+		requestID = uuid.NewString()
+	}
+
+	if err := grpclib.SetHeader(ctx, metadata.Pairs(RequestIDMetadataKey, requestID)); err != nil {
+		return nil, err
+	}
+	consistent, _ := strconv.ParseBool(firstMetadataValue(md, ConsistentReadMetadataKey))
+	ctx = entities.ContextWithAuditActor(ctx, actor, requestID)
+	return repositories.ContextWithReadSession(ctx, consistent), nil
+}
+
+func firstMetadataValue(md metadata.MD, key string) string {
+	if values := md.Get(key); len(values) > 0 {
+		return values[0]
+	}
+	return ""
+}
+
+// contextStream replaces the context of a server stream.
+type contextStream struct {
+	grpclib.ServerStream
+	ctx context.Context
+}
+
+func (s *contextStream) Context() context.Context {
+	return s.ctx
+}
@@ -0,0 +1,79 @@
+package grpc
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/tranvu1111/go-students-new/internal/application/command"
+	"github.com/tranvu1111/go-students-new/internal/application/common"
+	"github.com/tranvu1111/go-students-new/internal/application/query"
+	"github.com/tranvu1111/go-students-new/internal/interface/api/grpc/studentpb"
+)
+
+func toStudentMessage(student *common.StudentResult) *studentpb.Student {
+	message := &studentpb.Student{
+		StudentId:      student.StudentID.String(),
+		FirstName:      student.FirstName,
+		LastName:       student.LastName,
+		Email:          student.Email,
+		Phone:          student.Phone,
+		Major:          student.Major,
+		EnrollmentDate: timestamppb.New(student.EnrollmentDate),
+		CreatedAt:      timestamppb.New(student.CreatedAt),
+		UpdatedAt:      timestamppb.New(student.UpdatedAt),
+		Version:        int32(student.Version),
+	}
+	if student.DateOfBirth != nil {
+		message.DateOfBirth = timestamppb.New(*student.DateOfBirth)
+	}
+	return message
+}
+
+func toCreateStudentCommand(req *studentpb.CreateStudentRequest) *command.CreateStudentCommand {
+	return &command.CreateStudentCommand{
+		IdempotencyKey: req.GetIdempotencyKey(),
+		FirstName:      req.GetFirstName(),
+		LastName:       req.GetLastName(),
+		DateOfBirth:    optionalTime(req.GetDateOfBirth()),
+		Email:          req.GetEmail(),
+		Phone:          req.Phone,
+		Major:          req.Major,
+		EnrollmentDate: timeOrZero(req.GetEnrollmentDate()),
+	}
+}
+
+func toUpdateStudentCommand(id uuid.UUID, req *studentpb.UpdateStudentRequest) *command.UpdateStudentCommand {
+	return &command.UpdateStudentCommand{
+		IdempotencyKey:  req.GetIdempotencyKey(),
+		StudentId:       id,
+		DateOfBirth:     optionalTime(req.GetDateOfBirth()),
+		Phone:           req.Phone,
+		Major:           req.Major,
+		ExpectedVersion: int(req.GetExpectedVersion()),
+	}
+}
+
+func toStudentListQuery(req *studentpb.ListStudentsRequest) *query.StudentListQuery {
+	return &query.StudentListQuery{
+		Major:        req.Major,
+		EnrolledFrom: optionalTime(req.GetEnrolledFrom()),
+		EnrolledTo:   optionalTime(req.GetEnrolledTo()),
+	}
+}
+
+func optionalTime(timestamp *timestamppb.Timestamp) *time.Time {
+	if timestamp == nil {
+		return nil
+	}
+	t := timestamp.AsTime()
+	return &t
+}
+
+func timeOrZero(timestamp *timestamppb.Timestamp) time.Time {
+	if timestamp == nil {
+		return time.Time{}
+	}
+	return timestamp.AsTime()
+}
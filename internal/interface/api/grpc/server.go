@@ -0,0 +1,115 @@
+// Package grpc serves the student API over gRPC. Like the REST controllers it
+// only adapts requests to interfaces.StudentService; the messages are defined
+// in proto/student/v1/student.proto.
+package grpc
+
+//go:generate buf generate
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
+
+	grpclib "google.golang.org/grpc"
+
+	"github.com/tranvu1111/go-students-new/internal/application/common"
+	"github.com/tranvu1111/go-students-new/internal/application/interfaces"
+	"github.com/tranvu1111/go-students-new/internal/interface/api/grpc/studentpb"
+)
+
+type StudentServer struct {
+	studentpb.UnimplementedStudentServiceServer
+	service interfaces.StudentService
+}
+
+func NewStudentServer(service interfaces.StudentService) *StudentServer {
+	return &StudentServer{service: service}
+}
+
+// NewServer returns a gRPC server with the student service registered behind
+// the request context interceptors.
+func NewServer(service interfaces.StudentService, options ...grpclib.ServerOption) *grpclib.Server {
+	options = append(options,
+		grpclib.ChainUnaryInterceptor(RequestContextUnaryInterceptor()),
+		grpclib.ChainStreamInterceptor(RequestContextStreamInterceptor()),
+	)
+	server := grpclib.NewServer(options...)
+	studentpb.RegisterStudentServiceServer(server, NewStudentServer(service))
+	return server
+}
+
+func (s *StudentServer) CreateStudent(ctx context.Context, req *studentpb.CreateStudentRequest) (*studentpb.Student, error) {
+	result, err := s.service.CreateStudent(ctx, toCreateStudentCommand(req))
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	return toStudentMessage(result.Result), nil
+}
+
+func (s *StudentServer) GetStudent(ctx context.Context, req *studentpb.GetStudentRequest) (*studentpb.Student, error) {
+	id, err := parseStudentID(req.GetStudentId())
+	if err != nil {
+		return nil, err
+	}
+
+	student, err := s.service.FindStudentById(ctx, id)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	if student == nil {
+		return nil, status.Error(codes.NotFound, "student not found")
+	}
+	return toStudentMessage(student.Result), nil
+}
+
+func (s *StudentServer) ListStudents(req *studentpb.ListStudentsRequest, stream studentpb.StudentService_ListStudentsServer) error {
+	err := s.service.ExportStudents(stream.Context(), toStudentListQuery(req), func(student *common.StudentResult) error {
+		return stream.Send(toStudentMessage(student))
+	})
+	if err != nil {
+		return toStatus(err)
+	}
+	return nil
+}
+
+func (s *StudentServer) UpdateStudent(ctx context.Context, req *studentpb.UpdateStudentRequest) (*studentpb.Student, error) {
+	id, err := parseStudentID(req.GetStudentId())
+	if err != nil {
+		return nil, err
+	}
+	if req.GetExpectedVersion() <= 0 {
+		return nil, status.Error(codes.InvalidArgument, "expected_version is required")
+	}
+
+	result, err := s.service.UpdateStudent(ctx, toUpdateStudentCommand(id, req))
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	return toStudentMessage(result.Result), nil
+}
+
+func (s *StudentServer) DeleteStudent(ctx context.Context, req *studentpb.DeleteStudentRequest) (*emptypb.Empty, error) {
+	id, err := parseStudentID(req.GetStudentId())
+	if err != nil {
+		return nil, err
+	}
+	if req.GetExpectedVersion() <= 0 {
+		return nil, status.Error(codes.InvalidArgument, "expected_version is required")
+	}
+
+	if err := s.service.DeleteStudent(ctx, id, int(req.GetExpectedVersion())); err != nil {
+		return nil, toStatus(err)
+	}
+	return &emptypb.Empty{}, nil
+}
+
+func parseStudentID(value string) (uuid.UUID, error) {
+	id, err := uuid.Parse(value)
+	if err != nil {
+		return uuid.Nil, status.Errorf(codes.InvalidArgument, "invalid student_id: %v", err)
+	}
+	return id, nil
+}
@@ -0,0 +1,638 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.11
+// 	protoc        (unknown)
+// source: student/v1/student.proto
+
+package studentpb
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	emptypb "google.golang.org/protobuf/types/known/emptypb"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type Student struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	StudentId      string                 `protobuf:"bytes,1,opt,name=student_id,json=studentId,proto3" json:"student_id,omitempty"`
+	FirstName      string                 `protobuf:"bytes,2,opt,name=first_name,json=firstName,proto3" json:"first_name,omitempty"`
+	LastName       string                 `protobuf:"bytes,3,opt,name=last_name,json=lastName,proto3" json:"last_name,omitempty"`
+	DateOfBirth    *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=date_of_birth,json=dateOfBirth,proto3" json:"date_of_birth,omitempty"`
+	Email          string                 `protobuf:"bytes,5,opt,name=email,proto3" json:"email,omitempty"`
+	Phone          *string                `protobuf:"bytes,6,opt,name=phone,proto3,oneof" json:"phone,omitempty"`
+	Major          *string                `protobuf:"bytes,7,opt,name=major,proto3,oneof" json:"major,omitempty"`
+	EnrollmentDate *timestamppb.Timestamp `protobuf:"bytes,8,opt,name=enrollment_date,json=enrollmentDate,proto3" json:"enrollment_date,omitempty"`
+	CreatedAt      *timestamppb.Timestamp `protobuf:"bytes,9,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	UpdatedAt      *timestamppb.Timestamp `protobuf:"bytes,10,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	// version changes on every write; send it back as expected_version.
+	Version       int32 `protobuf:"varint,11,opt,name=version,proto3" json:"version,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Student) Reset() {
+	*x = Student{}
+	mi := &file_student_v1_student_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Student) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Student) ProtoMessage() {}
+
+func (x *Student) ProtoReflect() protoreflect.Message {
+	mi := &file_student_v1_student_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Student.ProtoReflect.Descriptor instead.
+func (*Student) Descriptor() ([]byte, []int) {
+	return file_student_v1_student_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Student) GetStudentId() string {
+	if x != nil {
+		return x.StudentId
+	}
+	return ""
+}
+
+func (x *Student) GetFirstName() string {
+	if x != nil {
+		return x.FirstName
+	}
+	return ""
+}
+
+func (x *Student) GetLastName() string {
+	if x != nil {
+		return x.LastName
+	}
+	return ""
+}
+
+func (x *Student) GetDateOfBirth() *timestamppb.Timestamp {
+	if x != nil {
+		return x.DateOfBirth
+	}
+	return nil
+}
+
+func (x *Student) GetEmail() string {
+	if x != nil {
+		return x.Email
+	}
+	return ""
+}
+
+func (x *Student) GetPhone() string {
+	if x != nil && x.Phone != nil {
+		return *x.Phone
+	}
+	return ""
+}
+
+func (x *Student) GetMajor() string {
+	if x != nil && x.Major != nil {
+		return *x.Major
+	}
+	return ""
+}
+
+func (x *Student) GetEnrollmentDate() *timestamppb.Timestamp {
+	if x != nil {
+		return x.EnrollmentDate
+	}
+	return nil
+}
+
+func (x *Student) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *Student) GetUpdatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return nil
+}
+
+func (x *Student) GetVersion() int32 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+type CreateStudentRequest struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	IdempotencyKey string                 `protobuf:"bytes,1,opt,name=idempotency_key,json=idempotencyKey,proto3" json:"idempotency_key,omitempty"`
+	FirstName      string                 `protobuf:"bytes,2,opt,name=first_name,json=firstName,proto3" json:"first_name,omitempty"`
+	LastName       string                 `protobuf:"bytes,3,opt,name=last_name,json=lastName,proto3" json:"last_name,omitempty"`
+	DateOfBirth    *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=date_of_birth,json=dateOfBirth,proto3" json:"date_of_birth,omitempty"`
+	Email          string                 `protobuf:"bytes,5,opt,name=email,proto3" json:"email,omitempty"`
+	Phone          *string                `protobuf:"bytes,6,opt,name=phone,proto3,oneof" json:"phone,omitempty"`
+	Major          *string                `protobuf:"bytes,7,opt,name=major,proto3,oneof" json:"major,omitempty"`
+	EnrollmentDate *timestamppb.Timestamp `protobuf:"bytes,8,opt,name=enrollment_date,json=enrollmentDate,proto3" json:"enrollment_date,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *CreateStudentRequest) Reset() {
+	*x = CreateStudentRequest{}
+	mi := &file_student_v1_student_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateStudentRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateStudentRequest) ProtoMessage() {}
+
+func (x *CreateStudentRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_student_v1_student_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateStudentRequest.ProtoReflect.Descriptor instead.
+func (*CreateStudentRequest) Descriptor() ([]byte, []int) {
+	return file_student_v1_student_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *CreateStudentRequest) GetIdempotencyKey() string {
+	if x != nil {
+		return x.IdempotencyKey
+	}
+	return ""
+}
+
+func (x *CreateStudentRequest) GetFirstName() string {
+	if x != nil {
+		return x.FirstName
+	}
+	return ""
+}
+
+func (x *CreateStudentRequest) GetLastName() string {
+	if x != nil {
+		return x.LastName
+	}
+	return ""
+}
+
+func (x *CreateStudentRequest) GetDateOfBirth() *timestamppb.Timestamp {
+	if x != nil {
+		return x.DateOfBirth
+	}
+	return nil
+}
+
+func (x *CreateStudentRequest) GetEmail() string {
+	if x != nil {
+		return x.Email
+	}
+	return ""
+}
+
+func (x *CreateStudentRequest) GetPhone() string {
+	if x != nil && x.Phone != nil {
+		return *x.Phone
+	}
+	return ""
+}
+
+func (x *CreateStudentRequest) GetMajor() string {
+	if x != nil && x.Major != nil {
+		return *x.Major
+	}
+	return ""
+}
+
+func (x *CreateStudentRequest) GetEnrollmentDate() *timestamppb.Timestamp {
+	if x != nil {
+		return x.EnrollmentDate
+	}
+	return nil
+}
+
+type GetStudentRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	StudentId     string                 `protobuf:"bytes,1,opt,name=student_id,json=studentId,proto3" json:"student_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetStudentRequest) Reset() {
+	*x = GetStudentRequest{}
+	mi := &file_student_v1_student_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetStudentRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetStudentRequest) ProtoMessage() {}
+
+func (x *GetStudentRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_student_v1_student_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetStudentRequest.ProtoReflect.Descriptor instead.
+func (*GetStudentRequest) Descriptor() ([]byte, []int) {
+	return file_student_v1_student_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *GetStudentRequest) GetStudentId() string {
+	if x != nil {
+		return x.StudentId
+	}
+	return ""
+}
+
+type ListStudentsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Major         *string                `protobuf:"bytes,1,opt,name=major,proto3,oneof" json:"major,omitempty"`
+	EnrolledFrom  *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=enrolled_from,json=enrolledFrom,proto3" json:"enrolled_from,omitempty"`
+	EnrolledTo    *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=enrolled_to,json=enrolledTo,proto3" json:"enrolled_to,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListStudentsRequest) Reset() {
+	*x = ListStudentsRequest{}
+	mi := &file_student_v1_student_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListStudentsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListStudentsRequest) ProtoMessage() {}
+
+func (x *ListStudentsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_student_v1_student_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListStudentsRequest.ProtoReflect.Descriptor instead.
+func (*ListStudentsRequest) Descriptor() ([]byte, []int) {
+	return file_student_v1_student_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *ListStudentsRequest) GetMajor() string {
+	if x != nil && x.Major != nil {
+		return *x.Major
+	}
+	return ""
+}
+
+func (x *ListStudentsRequest) GetEnrolledFrom() *timestamppb.Timestamp {
+	if x != nil {
+		return x.EnrolledFrom
+	}
+	return nil
+}
+
+func (x *ListStudentsRequest) GetEnrolledTo() *timestamppb.Timestamp {
+	if x != nil {
+		return x.EnrolledTo
+	}
+	return nil
+}
+
+// UpdateStudentRequest changes the optional fields of a student, like
+// PUT /api/v1/students. Unset fields keep their stored value.
+type UpdateStudentRequest struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	IdempotencyKey string                 `protobuf:"bytes,1,opt,name=idempotency_key,json=idempotencyKey,proto3" json:"idempotency_key,omitempty"`
+	StudentId      string                 `protobuf:"bytes,2,opt,name=student_id,json=studentId,proto3" json:"student_id,omitempty"`
+	DateOfBirth    *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=date_of_birth,json=dateOfBirth,proto3" json:"date_of_birth,omitempty"`
+	Phone          *string                `protobuf:"bytes,4,opt,name=phone,proto3,oneof" json:"phone,omitempty"`
+	Major          *string                `protobuf:"bytes,5,opt,name=major,proto3,oneof" json:"major,omitempty"`
+	// expected_version is required and must match the stored version.
+	ExpectedVersion int32 `protobuf:"varint,6,opt,name=expected_version,json=expectedVersion,proto3" json:"expected_version,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *UpdateStudentRequest) Reset() {
+	*x = UpdateStudentRequest{}
+	mi := &file_student_v1_student_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateStudentRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateStudentRequest) ProtoMessage() {}
+
+func (x *UpdateStudentRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_student_v1_student_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateStudentRequest.ProtoReflect.Descriptor instead.
+func (*UpdateStudentRequest) Descriptor() ([]byte, []int) {
+	return file_student_v1_student_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *UpdateStudentRequest) GetIdempotencyKey() string {
+	if x != nil {
+		return x.IdempotencyKey
+	}
+	return ""
+}
+
+func (x *UpdateStudentRequest) GetStudentId() string {
+	if x != nil {
+		return x.StudentId
+	}
+	return ""
+}
+
+func (x *UpdateStudentRequest) GetDateOfBirth() *timestamppb.Timestamp {
+	if x != nil {
+		return x.DateOfBirth
+	}
+	return nil
+}
+
+func (x *UpdateStudentRequest) GetPhone() string {
+	if x != nil && x.Phone != nil {
+		return *x.Phone
+	}
+	return ""
+}
+
+func (x *UpdateStudentRequest) GetMajor() string {
+	if x != nil && x.Major != nil {
+		return *x.Major
+	}
+	return ""
+}
+
+func (x *UpdateStudentRequest) GetExpectedVersion() int32 {
+	if x != nil {
+		return x.ExpectedVersion
+	}
+	return 0
+}
+
+type DeleteStudentRequest struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	StudentId       string                 `protobuf:"bytes,1,opt,name=student_id,json=studentId,proto3" json:"student_id,omitempty"`
+	ExpectedVersion int32                  `protobuf:"varint,2,opt,name=expected_version,json=expectedVersion,proto3" json:"expected_version,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *DeleteStudentRequest) Reset() {
+	*x = DeleteStudentRequest{}
+	mi := &file_student_v1_student_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteStudentRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteStudentRequest) ProtoMessage() {}
+
+func (x *DeleteStudentRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_student_v1_student_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteStudentRequest.ProtoReflect.Descriptor instead.
+func (*DeleteStudentRequest) Descriptor() ([]byte, []int) {
+	return file_student_v1_student_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *DeleteStudentRequest) GetStudentId() string {
+	if x != nil {
+		return x.StudentId
+	}
+	return ""
+}
+
+func (x *DeleteStudentRequest) GetExpectedVersion() int32 {
+	if x != nil {
+		return x.ExpectedVersion
+	}
+	return 0
+}
+
+var File_student_v1_student_proto protoreflect.FileDescriptor
+
+const file_student_v1_student_proto_rawDesc = "" +
+	"\n" +
+	"\x18student/v1/student.proto\x12\n" +
+	"student.v1\x1a\x1bgoogle/protobuf/empty.proto\x1a\x1fgoogle/protobuf/timestamp.proto\"\xd9\x03\n" +
+	"\aStudent\x12\x1d\n" +
+	"\n" +
+	"student_id\x18\x01 \x01(\tR\tstudentId\x12\x1d\n" +
+	"\n" +
+	"first_name\x18\x02 \x01(\tR\tfirstName\x12\x1b\n" +
+	"\tlast_name\x18\x03 \x01(\tR\blastName\x12>\n" +
+	"\rdate_of_birth\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\vdateOfBirth\x12\x14\n" +
+	"\x05email\x18\x05 \x01(\tR\x05email\x12\x19\n" +
+	"\x05phone\x18\x06 \x01(\tH\x00R\x05phone\x88\x01\x01\x12\x19\n" +
+	"\x05major\x18\a \x01(\tH\x01R\x05major\x88\x01\x01\x12C\n" +
+	"\x0fenrollment_date\x18\b \x01(\v2\x1a.google.protobuf.TimestampR\x0eenrollmentDate\x129\n" +
+	"\n" +
+	"created_at\x18\t \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x129\n" +
+	"\n" +
+	"updated_at\x18\n" +
+	" \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\x12\x18\n" +
+	"\aversion\x18\v \x01(\x05R\aversionB\b\n" +
+	"\x06_phoneB\b\n" +
+	"\x06_major\"\xe0\x02\n" +
+	"\x14CreateStudentRequest\x12'\n" +
+	"\x0fidempotency_key\x18\x01 \x01(\tR\x0eidempotencyKey\x12\x1d\n" +
+	"\n" +
+	"first_name\x18\x02 \x01(\tR\tfirstName\x12\x1b\n" +
+	"\tlast_name\x18\x03 \x01(\tR\blastName\x12>\n" +
+	"\rdate_of_birth\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\vdateOfBirth\x12\x14\n" +
+	"\x05email\x18\x05 \x01(\tR\x05email\x12\x19\n" +
+	"\x05phone\x18\x06 \x01(\tH\x00R\x05phone\x88\x01\x01\x12\x19\n" +
+	"\x05major\x18\a \x01(\tH\x01R\x05major\x88\x01\x01\x12C\n" +
+	"\x0fenrollment_date\x18\b \x01(\v2\x1a.google.protobuf.TimestampR\x0eenrollmentDateB\b\n" +
+	"\x06_phoneB\b\n" +
+	"\x06_major\"2\n" +
+	"\x11GetStudentRequest\x12\x1d\n" +
+	"\n" +
+	"student_id\x18\x01 \x01(\tR\tstudentId\"\xb8\x01\n" +
+	"\x13ListStudentsRequest\x12\x19\n" +
+	"\x05major\x18\x01 \x01(\tH\x00R\x05major\x88\x01\x01\x12?\n" +
+	"\renrolled_from\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\fenrolledFrom\x12;\n" +
+	"\venrolled_to\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"enrolledToB\b\n" +
+	"\x06_major\"\x93\x02\n" +
+	"\x14UpdateStudentRequest\x12'\n" +
+	"\x0fidempotency_key\x18\x01 \x01(\tR\x0eidempotencyKey\x12\x1d\n" +
+	"\n" +
+	"student_id\x18\x02 \x01(\tR\tstudentId\x12>\n" +
+	"\rdate_of_birth\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\vdateOfBirth\x12\x19\n" +
+	"\x05phone\x18\x04 \x01(\tH\x00R\x05phone\x88\x01\x01\x12\x19\n" +
+	"\x05major\x18\x05 \x01(\tH\x01R\x05major\x88\x01\x01\x12)\n" +
+	"\x10expected_version\x18\x06 \x01(\x05R\x0fexpectedVersionB\b\n" +
+	"\x06_phoneB\b\n" +
+	"\x06_major\"`\n" +
+	"\x14DeleteStudentRequest\x12\x1d\n" +
+	"\n" +
+	"student_id\x18\x01 \x01(\tR\tstudentId\x12)\n" +
+	"\x10expected_version\x18\x02 \x01(\x05R\x0fexpectedVersion2\xf5\x02\n" +
+	"\x0eStudentService\x12F\n" +
+	"\rCreateStudent\x12 .student.v1.CreateStudentRequest\x1a\x13.student.v1.Student\x12@\n" +
+	"\n" +
+	"GetStudent\x12\x1d.student.v1.GetStudentRequest\x1a\x13.student.v1.Student\x12F\n" +
+	"\fListStudents\x12\x1f.student.v1.ListStudentsRequest\x1a\x13.student.v1.Student0\x01\x12F\n" +
+	"\rUpdateStudent\x12 .student.v1.UpdateStudentRequest\x1a\x13.student.v1.Student\x12I\n" +
+	"\rDeleteStudent\x12 .student.v1.DeleteStudentRequest\x1a\x16.google.protobuf.EmptyBWZUgithub.com/tranvu1111/go-students-new/internal/interface/api/grpc/studentpb;studentpbb\x06proto3"
+
+var (
+	file_student_v1_student_proto_rawDescOnce sync.Once
+	file_student_v1_student_proto_rawDescData []byte
+)
+
+func file_student_v1_student_proto_rawDescGZIP() []byte {
+	file_student_v1_student_proto_rawDescOnce.Do(func() {
+		file_student_v1_student_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_student_v1_student_proto_rawDesc), len(file_student_v1_student_proto_rawDesc)))
+	})
+	return file_student_v1_student_proto_rawDescData
+}
+
+var file_student_v1_student_proto_msgTypes = make([]protoimpl.MessageInfo, 6)
+var file_student_v1_student_proto_goTypes = []any{
+	(*Student)(nil),               // 0: student.v1.Student
+	(*CreateStudentRequest)(nil),  // 1: student.v1.CreateStudentRequest
+	(*GetStudentRequest)(nil),     // 2: student.v1.GetStudentRequest
+	(*ListStudentsRequest)(nil),   // 3: student.v1.ListStudentsRequest
+	(*UpdateStudentRequest)(nil),  // 4: student.v1.UpdateStudentRequest
+	(*DeleteStudentRequest)(nil),  // 5: student.v1.DeleteStudentRequest
+	(*timestamppb.Timestamp)(nil), // 6: google.protobuf.Timestamp
+	(*emptypb.Empty)(nil),         // 7: google.protobuf.Empty
+}
+var file_student_v1_student_proto_depIdxs = []int32{
+	6,  // 0: student.v1.Student.date_of_birth:type_name -> google.protobuf.Timestamp
+	6,  // 1: student.v1.Student.enrollment_date:type_name -> google.protobuf.Timestamp
+	6,  // 2: student.v1.Student.created_at:type_name -> google.protobuf.Timestamp
+	6,  // 3: student.v1.Student.updated_at:type_name -> google.protobuf.Timestamp
+	6,  // 4: student.v1.CreateStudentRequest.date_of_birth:type_name -> google.protobuf.Timestamp
+	6,  // 5: student.v1.CreateStudentRequest.enrollment_date:type_name -> google.protobuf.Timestamp
+	6,  // 6: student.v1.ListStudentsRequest.enrolled_from:type_name -> google.protobuf.Timestamp
+	6,  // 7: student.v1.ListStudentsRequest.enrolled_to:type_name -> google.protobuf.Timestamp
+	6,  // 8: student.v1.UpdateStudentRequest.date_of_birth:type_name -> google.protobuf.Timestamp
+	1,  // 9: student.v1.StudentService.CreateStudent:input_type -> student.v1.CreateStudentRequest
+	2,  // 10: student.v1.StudentService.GetStudent:input_type -> student.v1.GetStudentRequest
+	3,  // 11: student.v1.StudentService.ListStudents:input_type -> student.v1.ListStudentsRequest
+	4,  // 12: student.v1.StudentService.UpdateStudent:input_type -> student.v1.UpdateStudentRequest
+	5,  // 13: student.v1.StudentService.DeleteStudent:input_type -> student.v1.DeleteStudentRequest
+	0,  // 14: student.v1.StudentService.CreateStudent:output_type -> student.v1.Student
+	0,  // 15: student.v1.StudentService.GetStudent:output_type -> student.v1.Student
+	0,  // 16: student.v1.StudentService.ListStudents:output_type -> student.v1.Student
+	0,  // 17: student.v1.StudentService.UpdateStudent:output_type -> student.v1.Student
+	7,  // 18: student.v1.StudentService.DeleteStudent:output_type -> google.protobuf.Empty
+	14, // [14:19] is the sub-list for method output_type
+	9,  // [9:14] is the sub-list for method input_type
+	9,  // [9:9] is the sub-list for extension type_name
+	9,  // [9:9] is the sub-list for extension extendee
+	0,  // [0:9] is the sub-list for field type_name
+}
+
+func init() { file_student_v1_student_proto_init() }
+func file_student_v1_student_proto_init() {
+	if File_student_v1_student_proto != nil {
+		return
+	}
+	file_student_v1_student_proto_msgTypes[0].OneofWrappers = []any{}
+	file_student_v1_student_proto_msgTypes[1].OneofWrappers = []any{}
+	file_student_v1_student_proto_msgTypes[3].OneofWrappers = []any{}
+	file_student_v1_student_proto_msgTypes[4].OneofWrappers = []any{}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_student_v1_student_proto_rawDesc), len(file_student_v1_student_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   6,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_student_v1_student_proto_goTypes,
+		DependencyIndexes: file_student_v1_student_proto_depIdxs,
+		MessageInfos:      file_student_v1_student_proto_msgTypes,
+	}.Build()
+	File_student_v1_student_proto = out.File
+	file_student_v1_student_proto_goTypes = nil
+	file_student_v1_student_proto_depIdxs = nil
+}
@@ -0,0 +1,178 @@
+package grpc_test
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/glebarez/sqlite"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	"gorm.io/gorm"
+
+	"github.com/tranvu1111/go-students-new/internal/application/services"
+	"github.com/tranvu1111/go-students-new/internal/infrastructure/db/postgres"
+	studentgrpc "github.com/tranvu1111/go-students-new/internal/interface/api/grpc"
+	"github.com/tranvu1111/go-students-new/internal/interface/api/grpc/studentpb"
+)
+
+// setupServer serves a StudentService backed by an in-memory SQLite database
+// over bufconn and returns a client connected to it.
+func setupServer(t *testing.T) (studentpb.StudentServiceClient, *gorm.DB) {
+	db, err := gorm.Open(sqlite.Open(fmt.Sprintf("file:%s?mode=memory&cache=shared", uuid.NewString())), &gorm.Config{})
+	require.NoError(t, err)
+	require.NoError(t, postgres.AutoMigrate(db))
+
+	service := services.NewStudentService(postgres.NewGormStudentRepo(db), postgres.NewGormIdempotencyRepository(db), postgres.NewGormAuditRepo(db), postgres.NewGormStudentStatusRepo(db), postgres.NewGormProgramRepo(db))
+	server := studentgrpc.NewServer(service)
+
+	listener := bufconn.Listen(1 << 20)
+	go server.Serve(listener)
+	t.Cleanup(server.Stop)
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return listener.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	require.NoError(t, err)
+	t.Cleanup(func() { conn.Close() })
+
+	return studentpb.NewStudentServiceClient(conn), db
+}
+
+func createStudent(t *testing.T, client studentpb.StudentServiceClient, email string, major string) *studentpb.Student {
+	student, err := client.CreateStudent(context.Background(), &studentpb.CreateStudentRequest{
+		FirstName:      "tran",
+		LastName:       "vu",
+		Email:          email,
+		Major:          &major,
+		EnrollmentDate: timestamppb.New(time.Date(2023, 9, 1, 0, 0, 0, 0, time.UTC)),
+	})
+	require.NoError(t, err)
+	return student
+}
+
+func TestStudentServer_CreateAndGet(t *testing.T) {
+	client, db := setupServer(t)
+
+	ctx := metadata.AppendToOutgoingContext(context.Background(), "x-actor", "ops", "x-request-id", "req-1")
+	var header metadata.MD
+	major := "CNTT"
+	created, err := client.CreateStudent(ctx, &studentpb.CreateStudentRequest{
+		FirstName:      "tran",
+		LastName:       "vu",
+		Email:          "tranvu@example.com",
+		Major:          &major,
+		EnrollmentDate: timestamppb.New(time.Date(2023, 9, 1, 0, 0, 0, 0, time.UTC)),
+	}, grpc.Header(&header))
+	require.NoError(t, err)
+	assert.Equal(t, int32(1), created.Version)
+	assert.Equal(t, []string{"req-1"}, header.Get("x-request-id"))
+
+	found, err := client.GetStudent(context.Background(), &studentpb.GetStudentRequest{StudentId: created.StudentId})
+	require.NoError(t, err)
+	assert.Equal(t, "tranvu@example.com", found.Email)
+	assert.Equal(t, "CNTT", found.GetMajor())
+	assert.Nil(t, found.Phone)
+	assert.Nil(t, found.DateOfBirth)
+
+	entries, _, err := postgres.NewGormAuditRepo(db).FindByStudentId(context.Background(), uuid.MustParse(created.StudentId), 0, 10)
+	require.NoError(t, err)
+	require.Len(t, entries, 1)
+	assert.Equal(t, "ops", entries[0].Actor)
+	assert.Equal(t, "req-1", entries[0].RequestID)
+}
+
+func TestStudentServer_StatusCodes(t *testing.T) {
+	client, _ := setupServer(t)
+	student := createStudent(t, client, "tranvu@example.com", "CNTT")
+
+	_, err := client.GetStudent(context.Background(), &studentpb.GetStudentRequest{StudentId: uuid.NewString()})
+	assert.Equal(t, codes.NotFound, status.Code(err))
+
+	_, err = client.GetStudent(context.Background(), &studentpb.GetStudentRequest{StudentId: "not-a-uuid"})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	_, err = client.CreateStudent(context.Background(), &studentpb.CreateStudentRequest{
+		FirstName:      "tran",
+		LastName:       "vu",
+		Email:          "not-an-email",
+		EnrollmentDate: timestamppb.Now(),
+	})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	assert.Equal(t, "Invalid email", status.Convert(err).Message())
+
+	_, err = client.UpdateStudent(context.Background(), &studentpb.UpdateStudentRequest{StudentId: student.StudentId})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	_, err = client.DeleteStudent(context.Background(), &studentpb.DeleteStudentRequest{StudentId: student.StudentId, ExpectedVersion: 7})
+	assert.Equal(t, codes.Aborted, status.Code(err))
+}
+
+func TestStudentServer_UpdateChecksVersion(t *testing.T) {
+	client, _ := setupServer(t)
+	student := createStudent(t, client, "tranvu@example.com", "CNTT")
+
+	major := "Physics"
+	updated, err := client.UpdateStudent(context.Background(), &studentpb.UpdateStudentRequest{
+		StudentId:       student.StudentId,
+		Major:           &major,
+		ExpectedVersion: student.Version,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "Physics", updated.GetMajor())
+	assert.Equal(t, student.Version+1, updated.Version)
+
+	_, err = client.UpdateStudent(context.Background(), &studentpb.UpdateStudentRequest{
+		StudentId:       student.StudentId,
+		Major:           &major,
+		ExpectedVersion: student.Version,
+	})
+	assert.Equal(t, codes.Aborted, status.Code(err))
+}
+
+func TestStudentServer_ListStreamsMatchingStudents(t *testing.T) {
+	client, _ := setupServer(t)
+	createStudent(t, client, "tran@example.com", "CNTT")
+	createStudent(t, client, "vu@example.com", "Physics")
+	createStudent(t, client, "an@example.com", "CNTT")
+
+	major := "CNTT"
+	stream, err := client.ListStudents(context.Background(), &studentpb.ListStudentsRequest{Major: &major})
+	require.NoError(t, err)
+
+	var emails []string
+	for {
+		student, err := stream.Recv()
+		if err == io.EOF {
+			break
+		}
+		require.NoError(t, err)
+		emails = append(emails, student.Email)
+	}
+	assert.Equal(t, []string{"tran@example.com", "an@example.com"}, emails)
+}
+
+func TestStudentServer_Delete(t *testing.T) {
+	client, _ := setupServer(t)
+	student := createStudent(t, client, "tranvu@example.com", "CNTT")
+
+	_, err := client.DeleteStudent(context.Background(), &studentpb.DeleteStudentRequest{StudentId: student.StudentId, ExpectedVersion: student.Version})
+	require.NoError(t, err)
+
+	_, err = client.GetStudent(context.Background(), &studentpb.GetStudentRequest{StudentId: student.StudentId})
+	assert.Equal(t, codes.NotFound, status.Code(err))
+}
@@ -0,0 +1,116 @@
+// Package i18n holds the message catalogs the APIs answer in, keyed by error
+// code, and picks the catalog a client asked for.
+package i18n
+
+import (
+	"embed"
+	"encoding/json"
+	"fmt"
+	"path"
+	"strings"
+
+	"github.com/tranvu1111/go-students-new/internal/domain/entities"
+	"golang.org/x/text/language"
+)
+
+// DefaultLocale answers clients that accept none of the catalogs, and fills
+// in messages a catalog is missing.
+const DefaultLocale = "en"
+
+//go:embed locales/*.json
+var files embed.FS
+
+var (
+	catalogs map[string]map[string]string
+	locales  []string
+	matcher  language.Matcher
+)
+
+func init() {
+	var err error
+	if catalogs, err = loadCatalogs(); err != nil {
+		panic(err)
+	}
+
+	locales = []string{DefaultLocale}
+	for locale := range catalogs {
+		if locale != DefaultLocale {
+			locales = append(locales, locale)
+		}
+	}
+	tags := make([]language.Tag, len(locales))
+	for i, locale := range locales {
+		tags[i] = language.MustParse(locale)
+	}
+	matcher = language.NewMatcher(tags)
+}
+
+func loadCatalogs() (map[string]map[string]string, error) {
+	entries, err := files.ReadDir("locales")
+	if err != nil {
+		return nil, err
+	}
+
+	loaded := make(map[string]map[string]string, len(entries))
+	for _, entry := range entries {
+		data, err := files.ReadFile(path.Join("locales", entry.Name()))
+		if err != nil {
+			return nil, err
+		}
+		var messages map[string]string
+		if err := json.Unmarshal(data, &messages); err != nil {
+			return nil, fmt.Errorf("catalog %s: %w", entry.Name(), err)
+		}
+		loaded[strings.TrimSuffix(entry.Name(), ".json")] = messages
+	}
+	if _, ok := loaded[DefaultLocale]; !ok {
+		return nil, fmt.Errorf("no catalog for the default locale %s", DefaultLocale)
+	}
+	return loaded, nil
+}
+
+// Locales lists the locales there is a catalog for, the default first.
+func Locales() []string {
+	return append([]string(nil), locales...)
+}
+
+// Negotiate picks the catalog closest to an Accept-Language header, e.g.
+// "vi" for "vi-VN,vi;q=0.9,en;q=0.8". A missing or unmatched header gets
+// DefaultLocale.
+func Negotiate(acceptLanguage string) string {
+	if strings.TrimSpace(acceptLanguage) == "" {
+		return DefaultLocale
+	}
+	tags, _, err := language.ParseAcceptLanguage(acceptLanguage)
+	if err != nil || len(tags) == 0 {
+		return DefaultLocale
+	}
+	_, index, confidence := matcher.Match(tags...)
+	if confidence == language.No {
+		return DefaultLocale
+	}
+	return locales[index]
+}
+
+// Translate renders the message for code in locale, falling back to
+// DefaultLocale, with its {name} placeholders filled from params. It reports
+// false when neither catalog knows code.
+func Translate(locale string, code string, params map[string]string) (string, bool) {
+	message, ok := catalogs[locale][code]
+	if !ok {
+		message, ok = catalogs[DefaultLocale][code]
+	}
+	if !ok {
+		return "", false
+	}
+	return entities.FormatMessage(message, params), true
+}
+
+// Message is Translate for codes the caller knows to be in the catalogs; an
+// unknown code is returned as is.
+func Message(locale string, code string) string {
+	if message, ok := Translate(locale, code, nil); ok {
+		return message
+	}
+	return code
+}
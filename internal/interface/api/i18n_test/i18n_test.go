@@ -0,0 +1,105 @@
+package i18n_test
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tranvu1111/go-students-new/internal/interface/api/i18n"
+)
+
+func readCatalog(t *testing.T, locale string) map[string]string {
+	t.Helper()
+	data, err := os.ReadFile(filepath.Join("..", "i18n", "locales", locale+".json"))
+	require.NoError(t, err)
+	var messages map[string]string
+	require.NoError(t, json.Unmarshal(data, &messages))
+	return messages
+}
+
+var placeholderRegex = regexp.MustCompile(`\{[a-z_]+\}`)
+
+// TestCatalogsHaveTheSameMessages keeps a translation from missing a code, or
+// a placeholder of the English message.
+func TestCatalogsHaveTheSameMessages(t *testing.T) {
+	english := readCatalog(t, i18n.DefaultLocale)
+	for _, locale := range i18n.Locales() {
+		catalog := readCatalog(t, locale)
+		assert.Len(t, catalog, len(english), locale)
+		for code, message := range english {
+			translated, ok := catalog[code]
+			if !assert.True(t, ok, "%s has no %s", locale, code) {
+				continue
+			}
+			assert.ElementsMatch(t, placeholderRegex.FindAllString(message, -1), placeholderRegex.FindAllString(translated, -1),
+				"placeholders of %s in %s", code, locale)
+		}
+	}
+}
+
+// TestCatalogsCoverDomainCodes fails when a rule is added to the domain
+// without a message in the catalogs.
+func TestCatalogsCoverDomainCodes(t *testing.T) {
+	english := readCatalog(t, i18n.DefaultLocale)
+	codeRegex := regexp.MustCompile(`newValidationError\([A-Za-z.]+, "([a-z_.]+)", (?:"|` + "`" + `)`)
+
+	sources, err := filepath.Glob(filepath.Join("..", "..", "..", "domain", "entities", "*.go"))
+	require.NoError(t, err)
+	found := 0
+	for _, source := range sources {
+		if strings.HasSuffix(source, "_test.go") {
+			continue
+		}
+		data, err := os.ReadFile(source)
+		require.NoError(t, err)
+		for _, match := range codeRegex.FindAllStringSubmatch(string(data), -1) {
+			found++
+			assert.Contains(t, english, match[1], filepath.Base(source))
+		}
+	}
+	assert.Greater(t, found, 50)
+}
+
+func TestNegotiate(t *testing.T) {
+	tests := []struct {
+		acceptLanguage string
+		want           string
+	}{
+		{"", "en"},
+		{"vi", "vi"},
+		{"vi-VN,vi;q=0.9,en-US;q=0.8,en;q=0.7", "vi"},
+		{"en-GB", "en"},
+		{"fr-FR, vi;q=0.5", "vi"},
+		{"fr-FR", "en"},
+		{"not a language tag;;", "en"},
+	}
+
+	for _, tc := range tests {
+		assert.Equal(t, tc.want, i18n.Negotiate(tc.acceptLanguage), tc.acceptLanguage)
+	}
+}
+
+func TestTranslate(t *testing.T) {
+	params := map[string]string{"from": "graduated", "to": "active"}
+
+	message, ok := i18n.Translate("en", "status.transition_not_allowed", params)
+	assert.True(t, ok)
+	assert.Equal(t, "a student who is graduated cannot become active", message)
+
+	message, ok = i18n.Translate("vi", "status.transition_not_allowed", params)
+	assert.True(t, ok)
+	assert.Equal(t, "sinh viên đang ở trạng thái graduated không thể chuyển sang active", message)
+
+	message, ok = i18n.Translate("fr", "student.first_name_required", nil)
+	assert.True(t, ok, "a locale without a catalog falls back to the default")
+	assert.Equal(t, "Must have first name.", message)
+
+	_, ok = i18n.Translate("vi", "no.such_code", nil)
+	assert.False(t, ok)
+	assert.Equal(t, "no.such_code", i18n.Message("vi", "no.such_code"))
+}
@@ -0,0 +1,15 @@
+package rest
+
+import (
+	"github.com/gin-gonic/gin"
+)
+
+// DeprecatedRoutes marks every response of a route group as deprecated and
+// links to the routes that replace it.
+func DeprecatedRoutes(successor string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.Header("Deprecation", "true")
+		c.Header("Link", "<"+successor+`>; rel="successor-version"`)
+		c.Next()
+	}
+}
@@ -0,0 +1,70 @@
+package mapper
+
+import (
+	"github.com/tranvu1111/go-students-new/internal/application/common"
+	"github.com/tranvu1111/go-students-new/internal/application/query"
+	"github.com/tranvu1111/go-students-new/internal/interface/api/rest/dto/response"
+)
+
+func ToAuditEntryResponse(entry *common.AuditEntryResult) *response.AuditEntryResponse {
+	changes := make([]response.FieldChangeResponse, len(entry.Changes))
+	for i, change := range entry.Changes {
+		changes[i] = response.FieldChangeResponse{
+			Field: change.Field,
+			Before: change.Before,
+			After: change.After,
+		}
+	}
+
+	return &response.AuditEntryResponse{
+		ID: entry.ID.String(),
+		StudentID: entry.StudentID.String(),
+		Actor: entry.Actor,
+		RequestID: entry.RequestID,
+		Operation: entry.Operation,
+		Changes: changes,
+		OccurredAt: entry.OccurredAt,
+	}
+}
+
+func ToStudentHistoryResponse(history *query.StudentHistoryQueryResult) *response.StudentHistoryResponse {
+	entries := make([]*response.AuditEntryResponse, 0, len(history.Result))
+	for _, entry := range history.Result {
+		entries = append(entries, ToAuditEntryResponse(entry))
+	}
+
+	return &response.StudentHistoryResponse{
+		Entries: entries,
+		Page: history.Page,
+		PageSize: history.PageSize,
+		Total: history.Total,
+	}
+}
+
+func ToStudentStatusChangeResponse(change *common.StudentStatusChangeResult) *response.StudentStatusChangeResponse {
+	return &response.StudentStatusChangeResponse{
+		ID: change.ID.String(),
+		StudentID: change.StudentID.String(),
+		From: change.From,
+		To: change.To,
+		Reason: change.Reason,
+		EffectiveDate: change.EffectiveDate,
+		Actor: change.Actor,
+		RequestID: change.RequestID,
+		RecordedAt: change.RecordedAt,
+	}
+}
+
+func ToStudentStatusHistoryResponse(history *query.StudentStatusHistoryQueryResult) *response.StudentStatusHistoryResponse {
+	transitions := make([]*response.StudentStatusChangeResponse, 0, len(history.Result))
+	for _, change := range history.Result {
+		transitions = append(transitions, ToStudentStatusChangeResponse(change))
+	}
+
+	return &response.StudentStatusHistoryResponse{
+		Transitions: transitions,
+		Page: history.Page,
+		PageSize: history.PageSize,
+		Total: history.Total,
+	}
+}
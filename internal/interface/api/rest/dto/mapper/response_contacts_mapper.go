@@ -0,0 +1,59 @@
+package mapper
+
+import (
+	"github.com/tranvu1111/go-students-new/internal/application/common"
+	"github.com/tranvu1111/go-students-new/internal/interface/api/rest/dto/response"
+)
+
+func ToStudentContactsResponse(student *common.StudentResult) *response.StudentContactsResponse {
+	contacts := student.Contacts
+	contactsResponse := &response.StudentContactsResponse{
+		StudentID: student.StudentID.String(),
+		Addresses: make([]*response.AddressResponse, 0, len(contacts.Addresses)),
+		Emails: make([]*response.ContactEmailResponse, 0, len(contacts.Emails)),
+		Phones: make([]*response.ContactPhoneResponse, 0, len(contacts.Phones)),
+		EmergencyContacts: make([]*response.EmergencyContactResponse, 0, len(contacts.EmergencyContacts)),
+	}
+	for _, address := range contacts.Addresses {
+		contactsResponse.Addresses = append(contactsResponse.Addresses, &response.AddressResponse{
+			ID: address.ID.String(),
+			Type: address.Type,
+			Line1: address.Line1,
+			Line2: address.Line2,
+			City: address.City,
+			Region: address.Region,
+			PostalCode: address.PostalCode,
+			Country: address.Country,
+			ValidFrom: address.ValidFrom,
+			ValidTo: address.ValidTo,
+		})
+	}
+	for _, email := range contacts.Emails {
+		contactsResponse.Emails = append(contactsResponse.Emails, &response.ContactEmailResponse{
+			ID: email.ID.String(),
+			Address: email.Address,
+			Primary: email.Primary,
+			Verification: email.Verification,
+			VerifiedAt: email.VerifiedAt,
+		})
+	}
+	for _, phone := range contacts.Phones {
+		contactsResponse.Phones = append(contactsResponse.Phones, &response.ContactPhoneResponse{
+			ID: phone.ID.String(),
+			Number: phone.Number,
+			Primary: phone.Primary,
+			Verification: phone.Verification,
+			VerifiedAt: phone.VerifiedAt,
+		})
+	}
+	for _, contact := range contacts.EmergencyContacts {
+		contactsResponse.EmergencyContacts = append(contactsResponse.EmergencyContacts, &response.EmergencyContactResponse{
+			ID: contact.ID.String(),
+			Name: contact.Name,
+			Relationship: contact.Relationship,
+			Phone: contact.Phone,
+			Email: contact.Email,
+		})
+	}
+	return contactsResponse
+}
@@ -0,0 +1,26 @@
+package mapper
+
+import (
+	"github.com/tranvu1111/go-students-new/internal/application/command"
+	"github.com/tranvu1111/go-students-new/internal/interface/api/rest/dto/response"
+)
+
+func ToImportStudentsResponse(result *command.ImportStudentsCommandResult) *response.ImportStudentsResponse {
+	rows := make([]response.ImportRowResponse, len(result.Rows))
+	for i, row := range result.Rows {
+		rows[i] = response.ImportRowResponse{Row: row.Row, Error: row.Error}
+		if row.StudentID != nil {
+			rows[i].StudentID = row.StudentID.String()
+		}
+	}
+
+	return &response.ImportStudentsResponse{
+		Mode: string(result.Mode),
+		DryRun: result.DryRun,
+		Committed: result.Committed,
+		Total: result.Total,
+		Created: result.Created,
+		Failed: result.Failed,
+		Rows: rows,
+	}
+}
@@ -0,0 +1,67 @@
+package mapper
+
+import (
+	"github.com/tranvu1111/go-students-new/internal/application/common"
+	"github.com/tranvu1111/go-students-new/internal/application/query"
+	"github.com/tranvu1111/go-students-new/internal/interface/api/rest/dto/response"
+)
+
+func ToNotificationTemplateResponse(template *common.NotificationTemplateResult) *response.NotificationTemplateResponse {
+	return &response.NotificationTemplateResponse{
+		ID: template.ID.String(),
+		EventType: template.EventType,
+		Channel: template.Channel,
+		Locale: template.Locale,
+		Subject: template.Subject,
+		Body: template.Body,
+		HTMLBody: template.HTMLBody,
+		Active: template.Active,
+		CreatedAt: template.CreatedAt,
+		UpdatedAt: template.UpdatedAt,
+	}
+}
+
+func ToNotificationTemplateListResponse(templates []*common.NotificationTemplateResult) *response.NotificationTemplateResponseList {
+	list := make([]*response.NotificationTemplateResponse, 0, len(templates))
+	for _, template := range templates {
+		list = append(list, ToNotificationTemplateResponse(template))
+	}
+	return &response.NotificationTemplateResponseList{Templates: list}
+}
+
+func ToNotificationResponse(notification *common.NotificationResult) *response.NotificationResponse {
+	return &response.NotificationResponse{
+		ID: notification.ID.String(),
+		TemplateID: notification.TemplateID.String(),
+		EventID: notification.EventID.String(),
+		EventType: notification.EventType,
+		StudentID: notification.StudentID.String(),
+		Channel: notification.Channel,
+		Locale: notification.Locale,
+		Recipient: notification.Recipient,
+		Subject: notification.Subject,
+		Text: notification.Text,
+		HTML: notification.HTML,
+		Status: notification.Status,
+		Attempts: notification.Attempts,
+		NextAttemptAt: notification.NextAttemptAt,
+		LastError: notification.LastError,
+		SentAt: notification.SentAt,
+		CreatedAt: notification.CreatedAt,
+		UpdatedAt: notification.UpdatedAt,
+	}
+}
+
+func ToNotificationListResponse(notifications *query.NotificationQueryListResult) *response.NotificationResponseList {
+	list := make([]*response.NotificationResponse, 0, len(notifications.Result))
+	for _, notification := range notifications.Result {
+		list = append(list, ToNotificationResponse(notification))
+	}
+
+	return &response.NotificationResponseList{
+		Notifications: list,
+		Page: notifications.Page,
+		PageSize: notifications.PageSize,
+		Total: notifications.Total,
+	}
+}
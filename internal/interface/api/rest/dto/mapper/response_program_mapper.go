@@ -0,0 +1,27 @@
+package mapper
+
+import (
+	"github.com/tranvu1111/go-students-new/internal/application/common"
+	"github.com/tranvu1111/go-students-new/internal/interface/api/rest/dto/response"
+)
+
+func ToProgramResponse(program *common.ProgramResult) *response.ProgramResponse {
+	return &response.ProgramResponse{
+		ID: program.ID.String(),
+		Code: program.Code,
+		Name: program.Name,
+		Department: program.Department,
+		DegreeLevel: program.DegreeLevel,
+		Active: program.Active,
+		CreatedAt: program.CreatedAt,
+		UpdatedAt: program.UpdatedAt,
+	}
+}
+
+func ToProgramListResponse(programs []*common.ProgramResult) *response.ProgramResponseList {
+	list := make([]*response.ProgramResponse, 0, len(programs))
+	for _, program := range programs {
+		list = append(list, ToProgramResponse(program))
+	}
+	return &response.ProgramResponseList{Programs: list}
+}
@@ -0,0 +1,22 @@
+package mapper
+
+import (
+	"github.com/tranvu1111/go-students-new/internal/application/query"
+	"github.com/tranvu1111/go-students-new/internal/interface/api/rest/dto/response"
+)
+
+func ToStudentSearchResponse(searchResult *query.StudentSearchQueryResult) *response.StudentSearchResponse {
+	results := make([]*response.StudentSearchHitResponse, 0, len(searchResult.Result))
+	for _, hit := range searchResult.Result {
+		results = append(results, &response.StudentSearchHitResponse{
+			Student: ToStudentResponse(hit.Student),
+			Score: hit.Score,
+			Highlights: hit.Highlights,
+		})
+	}
+
+	return &response.StudentSearchResponse{
+		Query: searchResult.Query,
+		Results: results,
+	}
+}
@@ -13,14 +13,32 @@ func ToStudentResponse(studentResult *common.StudentResult) *response.StudentRes
 		LastName:       studentResult.LastName,
 		DateOfBirth: 	studentResult.DateOfBirth,
 		Email:          studentResult.Email,
+		EmailVerifiedAt: studentResult.EmailVerifiedAt,
 		Phone: 			studentResult.Phone,
 		Major: 			studentResult.Major,			
+		Locale: 		studentResult.Locale,
+		Programs: 		ToStudentProgramResponses(studentResult.Programs),
 		CreatedAt: 		studentResult.CreatedAt,
 		UpdatedAt: 		studentResult.UpdatedAt,
 		EnrollmentDate: studentResult.EnrollmentDate,			
+		Status: 		studentResult.Status,
+		StatusReason: 	studentResult.StatusReason,
+		StatusEffectiveDate: studentResult.StatusEffectiveDate,
 	}
 }
 
+func ToStudentProgramResponses(programs []common.StudentProgramResult) []response.StudentProgramResponse {
+	responses := make([]response.StudentProgramResponse, 0, len(programs))
+	for _, program := range programs {
+		responses = append(responses, response.StudentProgramResponse{
+			ProgramID: program.ProgramID.String(),
+			Kind: program.Kind,
+			DeclaredAt: program.DeclaredAt,
+		})
+	}
+	return responses
+}
+
 func ToStudentListResponse(students []*common.StudentResult) *response.StudentResponseList{
 	var studentResponseList []*response.StudentResponse
 
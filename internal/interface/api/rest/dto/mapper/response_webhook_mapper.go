@@ -0,0 +1,52 @@
+package mapper
+
+import (
+	"github.com/tranvu1111/go-students-new/internal/application/common"
+	"github.com/tranvu1111/go-students-new/internal/application/query"
+	"github.com/tranvu1111/go-students-new/internal/interface/api/rest/dto/response"
+)
+
+func ToWebhookSubscriptionResponse(subscription *common.WebhookSubscriptionResult) *response.WebhookSubscriptionResponse {
+	return &response.WebhookSubscriptionResponse{
+		ID: subscription.ID.String(),
+		URL: subscription.URL,
+		EventTypes: subscription.EventTypes,
+		Active: subscription.Active,
+		CreatedAt: subscription.CreatedAt,
+		UpdatedAt: subscription.UpdatedAt,
+	}
+}
+
+func ToWebhookSubscriptionListResponse(subscriptions []*common.WebhookSubscriptionResult) *response.WebhookSubscriptionResponseList {
+	list := make([]*response.WebhookSubscriptionResponse, 0, len(subscriptions))
+	for _, subscription := range subscriptions {
+		list = append(list, ToWebhookSubscriptionResponse(subscription))
+	}
+	return &response.WebhookSubscriptionResponseList{Subscriptions: list}
+}
+
+func ToWebhookDeliveryListResponse(deliveries *query.WebhookDeliveryQueryListResult) *response.WebhookDeliveryResponseList {
+	list := make([]*response.WebhookDeliveryResponse, 0, len(deliveries.Result))
+	for _, delivery := range deliveries.Result {
+		list = append(list, &response.WebhookDeliveryResponse{
+			ID: delivery.ID.String(),
+			SubscriptionID: delivery.SubscriptionID.String(),
+			EventID: delivery.EventID.String(),
+			EventType: delivery.EventType,
+			Status: delivery.Status,
+			Attempts: delivery.Attempts,
+			NextAttemptAt: delivery.NextAttemptAt,
+			LastStatusCode: delivery.LastStatusCode,
+			LastError: delivery.LastError,
+			CreatedAt: delivery.CreatedAt,
+			UpdatedAt: delivery.UpdatedAt,
+		})
+	}
+
+	return &response.WebhookDeliveryResponseList{
+		Deliveries: list,
+		Page: deliveries.Page,
+		PageSize: deliveries.PageSize,
+		Total: deliveries.Total,
+	}
+}
@@ -1,6 +1,7 @@
 package request
 
 import (
+	"encoding/json"
 	"time"
 
 	"github.com/tranvu1111/go-students-new/internal/application/command"
@@ -10,14 +11,19 @@ import (
 type JsonTime time.Time
 
 func (jt *JsonTime) UnmarshalJSON(b []byte) error {
-	// The JSON value will be a string, so we need to trim the quotes.
-	s := string(b)
-	s = s[1 : len(s)-1]
+	// The JSON value must be a string.
+	var s string
+	if err := json.Unmarshal(b, &s); err != nil {
+		return err
+	}
 
-	// Use time.Parse with the expected format (e.g., "YYYY-MM-DD").
+	// Use time.Parse with the expected format (e.g., "YYYY-MM-DD"), falling
+	// back to the RFC 3339 timestamps the API itself returns.
 	t, err := time.Parse("2006-01-02", s)
 	if err != nil {
-		return err
+		if t, err = time.Parse(time.RFC3339, s); err != nil {
+			return err
+		}
 	}
 
 	// Assign the parsed time to the JsonTime pointer.
@@ -27,13 +33,15 @@ func (jt *JsonTime) UnmarshalJSON(b []byte) error {
 
 type CreateStudentRequest struct {
 	IdempotencyKey string    `json:"IdempotencyKey"`
-	FirstName      string    `json:"FirstName"`
-	LastName       string    `json:"LastName"`
+	FirstName      string    `json:"FirstName" binding:"required"`
+	LastName       string    `json:"LastName" binding:"required"`
 	DateOfBirth    *JsonTime `json:"DateOfBirth,omitempty"`
-	Email          string    `json:"Email"`
+	Email          string    `json:"Email" binding:"required"`
 	Phone          *string   `json:"Phone,omitempty"`
 	Major          *string   `json:"Major,omitempty"`
-	EnrollmentDate JsonTime  `json:"EnrollmentDate"`
+	Locale         *string   `json:"Locale,omitempty"`
+	EnrollmentDate JsonTime  `json:"EnrollmentDate" binding:"required"`
+	Programs       []StudentProgramRequest `json:"Programs,omitempty"`
 }
 
 func (req *CreateStudentRequest) ToCreateStudentCommand() (*command.CreateStudentCommand, error) {
@@ -57,6 +65,8 @@ func (req *CreateStudentRequest) ToCreateStudentCommand() (*command.CreateStuden
 		Email:          req.Email,
 		Phone:          req.Phone,
 		Major:          req.Major,
+		Locale:         req.Locale,
 		EnrollmentDate: enrollmentDate,
+		Programs:       toStudentPrograms(req.Programs),
 	}, nil
 }
@@ -0,0 +1,136 @@
+package request
+
+import (
+	"bufio"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/tranvu1111/go-students-new/internal/application/command"
+)
+
+const maxNDJSONLineSize = 1024 * 1024
+
+// NDJSONStudentReader reads one CreateStudentRequest per line. Blank lines
+// are skipped; a line over 1 MB ends the import.
+type NDJSONStudentReader struct {
+	scanner *bufio.Scanner
+}
+
+func NewNDJSONStudentReader(r io.Reader) *NDJSONStudentReader {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), maxNDJSONLineSize)
+	return &NDJSONStudentReader{scanner: scanner}
+}
+
+func (nr *NDJSONStudentReader) Next() (*command.CreateStudentCommand, error) {
+	for nr.scanner.Scan() {
+		line := strings.TrimSpace(nr.scanner.Text())
+		if line == "" {
+			continue
+		}
+
+		var createStudentRequest CreateStudentRequest
+		if err := json.Unmarshal([]byte(line), &createStudentRequest); err != nil {
+			return nil, fmt.Errorf("invalid JSON: %w", err)
+		}
+		return createStudentRequest.ToCreateStudentCommand()
+	}
+
+	if err := nr.scanner.Err(); err != nil {
+		if errors.Is(err, bufio.ErrTooLong) {
+			return nil, fmt.Errorf("%w: a line is longer than %d bytes", command.ErrImportUnreadable, maxNDJSONLineSize)
+		}
+		return nil, fmt.Errorf("%w: %w", command.ErrImportUnreadable, err)
+	}
+	return nil, io.EOF
+}
+
+// CSVStudentReader reads rows whose header names the CreateStudentRequest
+// fields, in any order and case. Dates use the "YYYY-MM-DD" format.
+type CSVStudentReader struct {
+	reader  *csv.Reader
+	columns map[string]int
+}
+
+var requiredImportColumns = []string{"firstname", "lastname", "email", "enrollmentdate"}
+
+func NewCSVStudentReader(r io.Reader) (*CSVStudentReader, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if err != nil {
+		return nil, fmt.Errorf("cannot read CSV header: %w", err)
+	}
+
+	columns := make(map[string]int, len(header))
+	for i, name := range header {
+		columns[strings.ToLower(strings.TrimSpace(name))] = i
+	}
+	for _, name := range requiredImportColumns {
+		if _, ok := columns[name]; !ok {
+			return nil, errors.New("CSV header is missing column " + name)
+		}
+	}
+
+	return &CSVStudentReader{reader: reader, columns: columns}, nil
+}
+
+// Next reports a malformed record against its row; csv.Reader resumes at
+// the next one. Any other read error ends the import.
+func (cr *CSVStudentReader) Next() (*command.CreateStudentCommand, error) {
+	record, err := cr.reader.Read()
+	if err != nil {
+		var parseErr *csv.ParseError
+		if err == io.EOF || errors.As(err, &parseErr) {
+			return nil, err
+		}
+		return nil, fmt.Errorf("%w: %w", command.ErrImportUnreadable, err)
+	}
+
+	enrollmentDate, err := time.Parse("2006-01-02", cr.value(record, "enrollmentdate"))
+	if err != nil {
+		return nil, fmt.Errorf("invalid EnrollmentDate: %w", err)
+	}
+
+	var dateOfBirth *time.Time
+	if raw := cr.value(record, "dateofbirth"); raw != "" {
+		parsed, err := time.Parse("2006-01-02", raw)
+		if err != nil {
+			return nil, fmt.Errorf("invalid DateOfBirth: %w", err)
+		}
+		dateOfBirth = &parsed
+	}
+
+	return &command.CreateStudentCommand{
+		FirstName:      cr.value(record, "firstname"),
+		LastName:       cr.value(record, "lastname"),
+		DateOfBirth:    dateOfBirth,
+		Email:          cr.value(record, "email"),
+		Phone:          optionalValue(cr.value(record, "phone")),
+		Major:          optionalValue(cr.value(record, "major")),
+		Locale:         optionalValue(cr.value(record, "locale")),
+		EnrollmentDate: enrollmentDate,
+	}, nil
+}
+
+func (cr *CSVStudentReader) value(record []string, column string) string {
+	i, ok := cr.columns[column]
+	if !ok || i >= len(record) {
+		return ""
+	}
+	return strings.TrimSpace(record[i])
+}
+
+func optionalValue(v string) *string {
+	if v == "" {
+		return nil
+	}
+	return &v
+}
@@ -0,0 +1,50 @@
+package request
+
+import (
+	"github.com/google/uuid"
+	"github.com/tranvu1111/go-students-new/internal/application/command"
+)
+
+type CreateNotificationTemplateRequest struct {
+	EventType string `json:"EventType" binding:"required"`
+	Channel   string `json:"Channel" binding:"required"`
+	Locale    string `json:"Locale" binding:"required"`
+	Subject   string `json:"Subject"`
+	Body      string `json:"Body" binding:"required"`
+	HTMLBody  string `json:"HTMLBody"`
+}
+
+func (req *CreateNotificationTemplateRequest) ToCreateNotificationTemplateCommand() *command.CreateNotificationTemplateCommand {
+	return &command.CreateNotificationTemplateCommand{
+		EventType: req.EventType,
+		Channel:   req.Channel,
+		Locale:    req.Locale,
+		Subject:   req.Subject,
+		Body:      req.Body,
+		HTMLBody:  req.HTMLBody,
+	}
+}
+
+// UpdateNotificationTemplateRequest replaces the content of a template; the
+// event, channel and locale it is for never change.
+type UpdateNotificationTemplateRequest struct {
+	Subject  string `json:"Subject"`
+	Body     string `json:"Body" binding:"required"`
+	HTMLBody string `json:"HTMLBody"`
+	Active   *bool  `json:"Active"`
+}
+
+func (req *UpdateNotificationTemplateRequest) ToUpdateNotificationTemplateCommand(id uuid.UUID) *command.UpdateNotificationTemplateCommand {
+	active := true
+	if req.Active != nil {
+		active = *req.Active
+	}
+
+	return &command.UpdateNotificationTemplateCommand{
+		TemplateID: id,
+		Subject:    req.Subject,
+		Body:       req.Body,
+		HTMLBody:   req.HTMLBody,
+		Active:     active,
+	}
+}
@@ -0,0 +1,92 @@
+package request
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/tranvu1111/go-students-new/internal/application/command"
+	"github.com/tranvu1111/go-students-new/internal/domain/entities"
+)
+
+// readOnlyStudentFields appear in StudentResponse but cannot be patched.
+var readOnlyStudentFields = map[string]bool{
+	"StudentID": 		true,
+	"EnrollmentDate": 	true,
+	"CreatedAt": 		true,
+	"UpdatedAt": 		true,
+	// The status changes only through a transition.
+	"Status": 				true,
+	"StatusReason": 		true,
+	"StatusEffectiveDate": 	true,
+	// EmailVerifiedAt changes only through the verify-email endpoint.
+	"EmailVerifiedAt": 		true,
+}
+
+// StudentMergePatch is an RFC 7396 merge patch of a student. A member that is
+// absent leaves the field alone, null clears it, and a value replaces it.
+type StudentMergePatch map[string]json.RawMessage
+
+func (mp StudentMergePatch) ToPatchStudentCommand(id uuid.UUID) (*command.PatchStudentCommand, error) {
+	var patch entities.StudentPatch
+	for field, raw := range mp {
+		var err error
+		switch field {
+		case "FirstName":
+			patch.FirstName, err = decodePatchValue[string](raw)
+		case "LastName":
+			patch.LastName, err = decodePatchValue[string](raw)
+		case "Email":
+			patch.Email, err = decodePatchValue[string](raw)
+		case "Phone":
+			patch.Phone, err = decodePatchValue[string](raw)
+		case "Major":
+			patch.Major, err = decodePatchValue[string](raw)
+		case "Locale":
+			patch.Locale, err = decodePatchValue[string](raw)
+		case "Programs":
+			var programs entities.PatchValue[[]StudentProgramRequest]
+			if programs, err = decodePatchValue[[]StudentProgramRequest](raw); err == nil && programs.Set {
+				patch.Programs = entities.Clear[[]entities.StudentProgram]()
+				if programs.Value != nil && len(*programs.Value) > 0 {
+					patch.Programs = entities.Replace(toStudentPrograms(*programs.Value))
+				}
+			}
+		case "DateOfBirth":
+			var dateOfBirth entities.PatchValue[JsonTime]
+			if dateOfBirth, err = decodePatchValue[JsonTime](raw); err == nil && dateOfBirth.Set {
+				patch.DateOfBirth = entities.Clear[time.Time]()
+				if dateOfBirth.Value != nil {
+					patch.DateOfBirth = entities.Replace(time.Time(*dateOfBirth.Value))
+				}
+			}
+		default:
+			if readOnlyStudentFields[field] {
+				return nil, fmt.Errorf("%s cannot be changed", field)
+			}
+			return nil, fmt.Errorf("unknown field %s", field)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("invalid %s: %w", field, err)
+		}
+	}
+
+	return &command.PatchStudentCommand{
+		StudentId: 	id,
+		Patch: 		patch,
+	}, nil
+}
+
+func decodePatchValue[T any](raw json.RawMessage) (entities.PatchValue[T], error) {
+	if bytes.Equal(bytes.TrimSpace(raw), []byte("null")) {
+		return entities.Clear[T](), nil
+	}
+
+	var value T
+	if err := json.Unmarshal(raw, &value); err != nil {
+		return entities.PatchValue[T]{}, err
+	}
+	return entities.Replace(value), nil
+}
@@ -0,0 +1,71 @@
+package request
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/tranvu1111/go-students-new/internal/application/command"
+	"github.com/tranvu1111/go-students-new/internal/domain/entities"
+)
+
+type CreateProgramRequest struct {
+	Code        string `json:"Code" binding:"required"`
+	Name        string `json:"Name" binding:"required"`
+	Department  string `json:"Department" binding:"required"`
+	DegreeLevel string `json:"DegreeLevel" binding:"required"`
+}
+
+func (req *CreateProgramRequest) ToCreateProgramCommand() *command.CreateProgramCommand {
+	return &command.CreateProgramCommand{
+		Code:        req.Code,
+		Name:        req.Name,
+		Department:  req.Department,
+		DegreeLevel: req.DegreeLevel,
+	}
+}
+
+// UpdateProgramRequest replaces everything but the code, which never changes.
+type UpdateProgramRequest struct {
+	Name        string `json:"Name" binding:"required"`
+	Department  string `json:"Department" binding:"required"`
+	DegreeLevel string `json:"DegreeLevel" binding:"required"`
+	Active      *bool  `json:"Active"`
+}
+
+func (req *UpdateProgramRequest) ToUpdateProgramCommand(id uuid.UUID) *command.UpdateProgramCommand {
+	active := true
+	if req.Active != nil {
+		active = *req.Active
+	}
+
+	return &command.UpdateProgramCommand{
+		ProgramID:   id,
+		Name:        req.Name,
+		Department:  req.Department,
+		DegreeLevel: req.DegreeLevel,
+		Active:      active,
+	}
+}
+
+// StudentProgramRequest declares a program of the catalog for a student.
+type StudentProgramRequest struct {
+	ProgramID  uuid.UUID `json:"ProgramID"`
+	Kind       string    `json:"Kind"`
+	DeclaredAt JsonTime  `json:"DeclaredAt"`
+}
+
+func toStudentPrograms(programs []StudentProgramRequest) []entities.StudentProgram {
+	if programs == nil {
+		return nil
+	}
+
+	declared := make([]entities.StudentProgram, 0, len(programs))
+	for _, program := range programs {
+		declared = append(declared, entities.StudentProgram{
+			ProgramID:  program.ProgramID,
+			Kind:       entities.ProgramKind(program.Kind),
+			DeclaredAt: time.Time(program.DeclaredAt),
+		})
+	}
+	return declared
+}
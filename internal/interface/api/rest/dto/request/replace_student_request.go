@@ -0,0 +1,57 @@
+package request
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/tranvu1111/go-students-new/internal/application/command"
+	"github.com/tranvu1111/go-students-new/internal/domain/entities"
+)
+
+// ReplaceStudentRequest is the body of PUT /api/v2/students/:id. It replaces
+// every writable field: optional fields left out are cleared.
+type ReplaceStudentRequest struct {
+	FirstName   string    `json:"FirstName" binding:"required"`
+	LastName    string    `json:"LastName" binding:"required"`
+	DateOfBirth *JsonTime `json:"DateOfBirth"`
+	Email       string    `json:"Email" binding:"required"`
+	Phone       *string   `json:"Phone"`
+	Major       *string   `json:"Major"`
+	Locale      *string   `json:"Locale"`
+	Programs    []StudentProgramRequest `json:"Programs"`
+}
+
+func (req *ReplaceStudentRequest) ToPatchStudentCommand(id uuid.UUID) *command.PatchStudentCommand {
+	dateOfBirth := entities.Clear[time.Time]()
+	if req.DateOfBirth != nil {
+		dateOfBirth = entities.Replace(time.Time(*req.DateOfBirth))
+	}
+
+	return &command.PatchStudentCommand{
+		StudentId: id,
+		Patch: entities.StudentPatch{
+			FirstName:   entities.Replace(req.FirstName),
+			LastName:    entities.Replace(req.LastName),
+			DateOfBirth: dateOfBirth,
+			Email:       entities.Replace(req.Email),
+			Phone:       replaceOrClear(req.Phone),
+			Major:       replaceOrClear(req.Major),
+			Locale:      replaceOrClear(req.Locale),
+			Programs:    replaceOrClearPrograms(req.Programs),
+		},
+	}
+}
+
+func replaceOrClearPrograms(programs []StudentProgramRequest) entities.PatchValue[[]entities.StudentProgram] {
+	if len(programs) == 0 {
+		return entities.Clear[[]entities.StudentProgram]()
+	}
+	return entities.Replace(toStudentPrograms(programs))
+}
+
+func replaceOrClear(value *string) entities.PatchValue[string] {
+	if value == nil {
+		return entities.Clear[string]()
+	}
+	return entities.Replace(*value)
+}
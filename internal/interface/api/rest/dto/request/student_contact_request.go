@@ -0,0 +1,93 @@
+package request
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/tranvu1111/go-students-new/internal/application/command"
+	"github.com/tranvu1111/go-students-new/internal/domain/entities"
+)
+
+// AddressRequest adds or replaces an address. ValidTo may be left out for an
+// address the student still uses.
+type AddressRequest struct {
+	Type 		string 		`json:"Type" binding:"required"`
+	Line1 		string 		`json:"Line1" binding:"required"`
+	Line2 		string 		`json:"Line2"`
+	City 		string 		`json:"City" binding:"required"`
+	Region 		string 		`json:"Region"`
+	PostalCode 	string 		`json:"PostalCode"`
+	Country 	string 		`json:"Country" binding:"required"`
+	ValidFrom 	JsonTime 	`json:"ValidFrom" binding:"required"`
+	ValidTo 	*JsonTime 	`json:"ValidTo"`
+}
+
+// ContactEmailRequest adds or replaces an email. Its verification state is
+// kept by the server and cannot be sent.
+type ContactEmailRequest struct {
+	Address 	string 	`json:"Address" binding:"required"`
+	Primary 	bool 	`json:"Primary"`
+}
+
+// ContactPhoneRequest adds or replaces a phone number in E.164 form, e.g.
+// +84901234567; spaces, dashes and a leading 00 are accepted.
+type ContactPhoneRequest struct {
+	Number 		string 	`json:"Number" binding:"required"`
+	Primary 	bool 	`json:"Primary"`
+}
+
+type EmergencyContactRequest struct {
+	Name 			string 	`json:"Name" binding:"required"`
+	Relationship 	string 	`json:"Relationship" binding:"required"`
+	Phone 			string 	`json:"Phone" binding:"required"`
+	Email 			*string `json:"Email"`
+}
+
+// The To...Command methods add the contact when contactID is uuid.Nil and
+// replace the contact with that ID otherwise.
+
+func (req *AddressRequest) ToSaveStudentContactCommand(studentID uuid.UUID, contactID uuid.UUID) *command.SaveStudentContactCommand {
+	address := entities.Address{
+		ID: 		contactID,
+		Type: 		entities.AddressType(req.Type),
+		Line1: 		req.Line1,
+		Line2: 		req.Line2,
+		City: 		req.City,
+		Region: 	req.Region,
+		PostalCode: req.PostalCode,
+		Country: 	req.Country,
+		ValidFrom: 	time.Time(req.ValidFrom),
+	}
+	if req.ValidTo != nil {
+		validTo := time.Time(*req.ValidTo)
+		address.ValidTo = &validTo
+	}
+	return &command.SaveStudentContactCommand{StudentId: studentID, Address: &address}
+}
+
+func (req *ContactEmailRequest) ToSaveStudentContactCommand(studentID uuid.UUID, contactID uuid.UUID) *command.SaveStudentContactCommand {
+	return &command.SaveStudentContactCommand{
+		StudentId: 	studentID,
+		Email: 		&entities.ContactEmail{ID: contactID, Address: req.Address, Primary: req.Primary},
+	}
+}
+
+func (req *ContactPhoneRequest) ToSaveStudentContactCommand(studentID uuid.UUID, contactID uuid.UUID) *command.SaveStudentContactCommand {
+	return &command.SaveStudentContactCommand{
+		StudentId: 	studentID,
+		Phone: 		&entities.ContactPhone{ID: contactID, Number: req.Number, Primary: req.Primary},
+	}
+}
+
+func (req *EmergencyContactRequest) ToSaveStudentContactCommand(studentID uuid.UUID, contactID uuid.UUID) *command.SaveStudentContactCommand {
+	return &command.SaveStudentContactCommand{
+		StudentId: 			studentID,
+		EmergencyContact: 	&entities.EmergencyContact{
+			ID: 			contactID,
+			Name: 			req.Name,
+			Relationship: 	req.Relationship,
+			Phone: 			req.Phone,
+			Email: 			req.Email,
+		},
+	}
+}
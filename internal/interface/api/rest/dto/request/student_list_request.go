@@ -0,0 +1,53 @@
+package request
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/tranvu1111/go-students-new/internal/application/query"
+	"github.com/tranvu1111/go-students-new/internal/domain/entities"
+)
+
+// StudentListRequest holds the query string filters of the listing and export.
+type StudentListRequest struct {
+	Major        string `form:"major"`
+	Status       string `form:"status"`
+	EnrolledFrom string `form:"enrolled_from"`
+	EnrolledTo   string `form:"enrolled_to"`
+}
+
+func (req *StudentListRequest) ToStudentListQuery() (*query.StudentListQuery, error) {
+	listQuery := &query.StudentListQuery{}
+	if req.Major != "" {
+		major := req.Major
+		listQuery.Major = &major
+	}
+
+	if req.Status != "" {
+		status, err := entities.ParseStudentStatus(req.Status)
+		if err != nil {
+			return nil, fmt.Errorf("invalid status: %w", err)
+		}
+		listQuery.Status = &status
+	}
+
+	var err error
+	if listQuery.EnrolledFrom, err = parseOptionalDate("enrolled_from", req.EnrolledFrom); err != nil {
+		return nil, err
+	}
+	if listQuery.EnrolledTo, err = parseOptionalDate("enrolled_to", req.EnrolledTo); err != nil {
+		return nil, err
+	}
+	return listQuery, nil
+}
+
+func parseOptionalDate(name string, value string) (*time.Time, error) {
+	if value == "" {
+		return nil, nil
+	}
+	parsed, err := time.Parse("2006-01-02", value)
+	if err != nil {
+		return nil, fmt.Errorf("invalid %s: %w", name, err)
+	}
+	return &parsed, nil
+}
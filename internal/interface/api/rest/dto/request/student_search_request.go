@@ -0,0 +1,27 @@
+package request
+
+import (
+	"errors"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/tranvu1111/go-students-new/internal/application/query"
+)
+
+const minSearchQueryLength = 2
+
+type StudentSearchRequest struct {
+	Q     string `form:"q"`
+	Limit int    `form:"limit"`
+}
+
+func (req *StudentSearchRequest) ToStudentSearchQuery() (*query.StudentSearchQuery, error) {
+	text := strings.TrimSpace(req.Q)
+	if utf8.RuneCountInString(text) < minSearchQueryLength {
+		return nil, errors.New("q must be at least 2 characters")
+	}
+	if req.Limit < 0 {
+		return nil, errors.New("limit cannot be negative")
+	}
+	return &query.StudentSearchQuery{Query: text, Limit: req.Limit}, nil
+}
@@ -0,0 +1,26 @@
+package request
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/tranvu1111/go-students-new/internal/application/command"
+	"github.com/tranvu1111/go-students-new/internal/domain/entities"
+)
+
+// TransitionStudentStatusRequest moves a student to another status. Whether
+// the move is allowed is up to the student, not to this request.
+type TransitionStudentStatusRequest struct {
+	Status 			string 		`json:"Status" binding:"required"`
+	Reason 			string 		`json:"Reason" binding:"required"`
+	EffectiveDate 	JsonTime 	`json:"EffectiveDate" binding:"required"`
+}
+
+func (req *TransitionStudentStatusRequest) ToTransitionStudentStatusCommand(id uuid.UUID) *command.TransitionStudentStatusCommand {
+	return &command.TransitionStudentStatusCommand{
+		StudentId: 		id,
+		Status: 		entities.StudentStatus(req.Status),
+		Reason: 		req.Reason,
+		EffectiveDate: 	time.Time(req.EffectiveDate),
+	}
+}
@@ -5,6 +5,7 @@ import (
 
 	"github.com/google/uuid"
 	"github.com/tranvu1111/go-students-new/internal/application/command"
+	"github.com/tranvu1111/go-students-new/internal/domain/entities"
 )
 
 // JsonTime is a custom type to handle JSON unmarshaling of time strings.
@@ -12,10 +13,12 @@ import (
 
 type UpdateStudentResquest struct {
 	IdempotencyKey string		`json:"IdempotencyKey"`
-	StudentId      uuid.UUID	`json:"StudentId"`
+	StudentId      uuid.UUID	`json:"StudentId" binding:"required"`
 	DateOfBirth    *JsonTime	`json:"DateOfBirth"`
 	Phone          *string		`json:"Phone"`
 	Major          *string		`json:"Major"`
+	// Programs replaces the declared programs when present.
+	Programs       *[]StudentProgramRequest	`json:"Programs"`
 }
 
 func (ur *UpdateStudentResquest) ToUpdateStudentCommand() (*command.UpdateStudentCommand , error ) {
@@ -27,12 +30,19 @@ func (ur *UpdateStudentResquest) ToUpdateStudentCommand() (*command.UpdateStuden
 		dateOfBirth = &convertedDate
 	}
 
+	var programs *[]entities.StudentProgram
+	if ur.Programs != nil {
+		declared := toStudentPrograms(*ur.Programs)
+		programs = &declared
+	}
+
 	return &command.UpdateStudentCommand{
 		IdempotencyKey:	ur.IdempotencyKey,
 		StudentId:		ur.StudentId,
 		DateOfBirth: 	dateOfBirth, 	 	
 		Phone: 			ur.Phone,
 		Major: 			ur.Major,
+		Programs: 		programs,
 	},nil
 
 
@@ -0,0 +1,16 @@
+package request
+
+import (
+	"github.com/tranvu1111/go-students-new/internal/application/command"
+)
+
+// VerifyEmailRequest carries the token from the verification email.
+type VerifyEmailRequest struct {
+	Token 	string 	`json:"Token" binding:"required"`
+}
+
+func (req *VerifyEmailRequest) ToVerifyEmailCommand() *command.VerifyEmailCommand {
+	return &command.VerifyEmailCommand{
+		Token: 	req.Token,
+	}
+}
@@ -0,0 +1,42 @@
+package request
+
+import (
+	"github.com/google/uuid"
+	"github.com/tranvu1111/go-students-new/internal/application/command"
+)
+
+type CreateWebhookSubscriptionRequest struct {
+	URL        string   `json:"URL"`
+	EventTypes []string `json:"EventTypes"`
+	Secret     string   `json:"Secret"`
+}
+
+func (req *CreateWebhookSubscriptionRequest) ToCreateWebhookSubscriptionCommand() *command.CreateWebhookSubscriptionCommand {
+	return &command.CreateWebhookSubscriptionCommand{
+		URL:        req.URL,
+		EventTypes: req.EventTypes,
+		Secret:     req.Secret,
+	}
+}
+
+type UpdateWebhookSubscriptionRequest struct {
+	URL        string   `json:"URL"`
+	EventTypes []string `json:"EventTypes"`
+	Secret     string   `json:"Secret"`
+	Active     *bool    `json:"Active"`
+}
+
+func (req *UpdateWebhookSubscriptionRequest) ToUpdateWebhookSubscriptionCommand(id uuid.UUID) *command.UpdateWebhookSubscriptionCommand {
+	active := true
+	if req.Active != nil {
+		active = *req.Active
+	}
+
+	return &command.UpdateWebhookSubscriptionCommand{
+		SubscriptionID: id,
+		URL:            req.URL,
+		EventTypes:     req.EventTypes,
+		Secret:         req.Secret,
+		Active:         active,
+	}
+}
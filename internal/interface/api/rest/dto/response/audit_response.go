@@ -0,0 +1,47 @@
+package response
+
+import (
+	"time"
+)
+
+type FieldChangeResponse struct {
+	Field 	string
+	Before 	*string
+	After 	*string
+}
+
+type AuditEntryResponse struct {
+	ID 			string
+	StudentID 	string
+	Actor 		string
+	RequestID 	string
+	Operation 	string
+	Changes 	[]FieldChangeResponse
+	OccurredAt 	time.Time
+}
+
+type StudentStatusChangeResponse struct {
+	ID 				string
+	StudentID 		string
+	From 			string
+	To 				string
+	Reason 			string
+	EffectiveDate 	time.Time
+	Actor 			string
+	RequestID 		string
+	RecordedAt 		time.Time
+}
+
+type StudentStatusHistoryResponse struct {
+	Transitions 	[]*StudentStatusChangeResponse 	`json:"Transitions"`
+	Page 			int 							`json:"Page"`
+	PageSize 		int 							`json:"PageSize"`
+	Total 			int64 							`json:"Total"`
+}
+
+type StudentHistoryResponse struct {
+	Entries 	[]*AuditEntryResponse 	`json:"Entries"`
+	Page 		int 					`json:"Page"`
+	PageSize 	int 					`json:"PageSize"`
+	Total 		int64 					`json:"Total"`
+}
@@ -0,0 +1,17 @@
+package response
+
+type ImportRowResponse struct {
+	Row 		int 	`json:"Row"`
+	StudentID 	string 	`json:"StudentID,omitempty"`
+	Error 		string 	`json:"Error,omitempty"`
+}
+
+type ImportStudentsResponse struct {
+	Mode 		string 				`json:"Mode"`
+	DryRun 		bool 				`json:"DryRun"`
+	Committed 	bool 				`json:"Committed"`
+	Total 		int 				`json:"Total"`
+	Created 	int 				`json:"Created"`
+	Failed 		int 				`json:"Failed"`
+	Rows 		[]ImportRowResponse `json:"Rows"`
+}
@@ -0,0 +1,50 @@
+package response
+
+import (
+	"time"
+)
+
+type NotificationTemplateResponse struct {
+	ID 			string
+	EventType 	string
+	Channel 	string
+	Locale 		string
+	Subject 	string
+	Body 		string
+	HTMLBody 	string
+	Active 		bool
+	CreatedAt 	time.Time
+	UpdatedAt 	time.Time
+}
+
+type NotificationTemplateResponseList struct {
+	Templates []*NotificationTemplateResponse 	`json:"Templates"`
+}
+
+type NotificationResponse struct {
+	ID 				string
+	TemplateID 		string
+	EventID 		string
+	EventType 		string
+	StudentID 		string
+	Channel 		string
+	Locale 			string
+	Recipient 		string
+	Subject 		string
+	Text 			string
+	HTML 			string
+	Status 			string
+	Attempts 		int
+	NextAttemptAt 	time.Time
+	LastError 		string
+	SentAt 			*time.Time
+	CreatedAt 		time.Time
+	UpdatedAt 		time.Time
+}
+
+type NotificationResponseList struct {
+	Notifications 	[]*NotificationResponse 	`json:"Notifications"`
+	Page 			int 						`json:"Page"`
+	PageSize 		int 						`json:"PageSize"`
+	Total 			int64 						`json:"Total"`
+}
@@ -0,0 +1,20 @@
+package response
+
+import (
+	"time"
+)
+
+type ProgramResponse struct {
+	ID 			string
+	Code 		string
+	Name 		string
+	Department 	string
+	DegreeLevel string
+	Active 		bool
+	CreatedAt 	time.Time
+	UpdatedAt 	time.Time
+}
+
+type ProgramResponseList struct {
+	Programs []*ProgramResponse 	`json:"Programs"`
+}
@@ -0,0 +1,50 @@
+package response
+
+import (
+	"time"
+)
+
+type StudentContactsResponse struct {
+	StudentID 			string
+	Addresses 			[]*AddressResponse
+	Emails 				[]*ContactEmailResponse
+	Phones 				[]*ContactPhoneResponse
+	EmergencyContacts 	[]*EmergencyContactResponse
+}
+
+type AddressResponse struct {
+	ID 			string
+	Type 		string
+	Line1 		string
+	Line2 		string
+	City 		string
+	Region 		string
+	PostalCode 	string
+	Country 	string
+	ValidFrom 	time.Time
+	ValidTo 	*time.Time
+}
+
+type ContactEmailResponse struct {
+	ID 				string
+	Address 		string
+	Primary 		bool
+	Verification 	string
+	VerifiedAt 		*time.Time
+}
+
+type ContactPhoneResponse struct {
+	ID 				string
+	Number 			string
+	Primary 		bool
+	Verification 	string
+	VerifiedAt 		*time.Time
+}
+
+type EmergencyContactResponse struct {
+	ID 				string
+	Name 			string
+	Relationship 	string
+	Phone 			string
+	Email 			*string
+}
@@ -12,11 +12,25 @@ type StudentResponse struct {
 	LastName       	string
 	DateOfBirth 	*time.Time 	
 	Email          	string
+	// EmailVerifiedAt is null until the student follows the link mailed to Email.
+	EmailVerifiedAt *time.Time
 	Phone 			*string 
 	Major 			*string 
+	// Locale is the language the student is written to in, null for the default.
+	Locale 			*string
+	Programs 		[]StudentProgramResponse
 	CreatedAt 		time.Time
 	UpdatedAt 		time.Time
 	EnrollmentDate 	time.Time 	
+	Status 			string
+	StatusReason 	string
+	StatusEffectiveDate time.Time
+}
+
+type StudentProgramResponse struct {
+	ProgramID 	string
+	Kind 		string
+	DeclaredAt 	time.Time
 }
 
 type StudentResponseList struct {
@@ -0,0 +1,12 @@
+package response
+
+type StudentSearchHitResponse struct {
+	Student 	*StudentResponse 	`json:"Student"`
+	Score 		float64 			`json:"Score"`
+	Highlights 	map[string]string 	`json:"Highlights"`
+}
+
+type StudentSearchResponse struct {
+	Query 		string 						`json:"Query"`
+	Results 	[]*StudentSearchHitResponse `json:"Results"`
+}
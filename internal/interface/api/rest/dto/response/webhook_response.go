@@ -0,0 +1,39 @@
+package response
+
+import (
+	"time"
+)
+
+type WebhookSubscriptionResponse struct {
+	ID 			string
+	URL 		string
+	EventTypes 	[]string
+	Active 		bool
+	CreatedAt 	time.Time
+	UpdatedAt 	time.Time
+}
+
+type WebhookSubscriptionResponseList struct {
+	Subscriptions []*WebhookSubscriptionResponse 	`json:"Subscriptions"`
+}
+
+type WebhookDeliveryResponse struct {
+	ID 				string
+	SubscriptionID 	string
+	EventID 		string
+	EventType 		string
+	Status 			string
+	Attempts 		int
+	NextAttemptAt 	time.Time
+	LastStatusCode 	int
+	LastError 		string
+	CreatedAt 		time.Time
+	UpdatedAt 		time.Time
+}
+
+type WebhookDeliveryResponseList struct {
+	Deliveries 	[]*WebhookDeliveryResponse 	`json:"Deliveries"`
+	Page 		int 						`json:"Page"`
+	PageSize 	int 						`json:"PageSize"`
+	Total 		int64 						`json:"Total"`
+}
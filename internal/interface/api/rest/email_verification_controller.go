@@ -0,0 +1,46 @@
+package rest
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/tranvu1111/go-students-new/internal/application/interfaces"
+	"github.com/tranvu1111/go-students-new/internal/interface/api/rest/dto/mapper"
+	"github.com/tranvu1111/go-students-new/internal/interface/api/rest/dto/request"
+)
+
+// EmailVerificationController lets students prove they own their email. The
+// token names the student, so the route takes no student ID.
+type EmailVerificationController struct {
+	service interfaces.EmailVerificationService
+}
+
+func NewEmailVerificationController(r *gin.Engine, service interfaces.EmailVerificationService) *EmailVerificationController {
+	controller := &EmailVerificationController{
+		service: service,
+	}
+
+	r.POST("/api/v1/students/verify-email", DeprecatedRoutes(studentsV2Path+"/verify-email"), controller.VerifyEmailController)
+	r.POST(studentsV2Path+"/verify-email", controller.VerifyEmailController)
+
+	return controller
+}
+
+// VerifyEmailController answers 422 for a token that is forged, expired or
+// issued for an email the student has since changed.
+func (ec *EmailVerificationController) VerifyEmailController(c *gin.Context) {
+	var verifyRequest request.VerifyEmailRequest
+	if err := c.ShouldBindJSON(&verifyRequest); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"message": tr(c, "problem.invalid_request"), "error1": bindErrorDetail(c, err)})
+		return
+	}
+
+	result, err := ec.service.VerifyEmail(c.Request.Context(), verifyRequest.ToVerifyEmailCommand())
+	if err != nil {
+		writeStudentError(c, "problem.verify_email_failed", err)
+		return
+	}
+
+	setStudentETag(c, result.Result)
+	c.JSON(http.StatusOK, mapper.ToStudentResponse(result.Result))
+}
@@ -0,0 +1,90 @@
+// Package export renders student rows as CSV, NDJSON or XLSX while they are
+// streamed from the database.
+package export
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/tranvu1111/go-students-new/internal/interface/api/rest/dto/response"
+)
+
+// StudentColumns lists the exportable columns in the field order of
+// response.StudentResponse, so that exports and the JSON API stay aligned.
+var StudentColumns = studentResponseColumns()
+
+func studentResponseColumns() []string {
+	responseType := reflect.TypeOf(response.StudentResponse{})
+	columns := make([]string, 0, responseType.NumField())
+	for i := 0; i < responseType.NumField(); i++ {
+		if field := responseType.Field(i); field.IsExported() {
+			columns = append(columns, field.Name)
+		}
+	}
+	return columns
+}
+
+// SelectColumns validates a comma separated column list. The selection keeps
+// the caller's order; an empty selection means every column.
+func SelectColumns(selection string) ([]string, error) {
+	if strings.TrimSpace(selection) == "" {
+		return StudentColumns, nil
+	}
+
+	known := make(map[string]string, len(StudentColumns))
+	for _, column := range StudentColumns {
+		known[strings.ToLower(column)] = column
+	}
+
+	var columns []string
+	seen := map[string]bool{}
+	for _, name := range strings.Split(selection, ",") {
+		column, ok := known[strings.ToLower(strings.TrimSpace(name))]
+		if !ok {
+			return nil, fmt.Errorf("unknown column %q", strings.TrimSpace(name))
+		}
+		if !seen[column] {
+			seen[column] = true
+			columns = append(columns, column)
+		}
+	}
+	return columns, nil
+}
+
+// RowValues renders the selected columns of a student as text. Unset optional
+// fields become empty strings, times use RFC 3339 and programs read like
+// "major <id>@2023-09-01, minor <id>@2024-02-01".
+func RowValues(student *response.StudentResponse, columns []string) []string {
+	value := reflect.ValueOf(student).Elem()
+	values := make([]string, len(columns))
+	for i, column := range columns {
+		values[i] = formatValue(value.FieldByName(column))
+	}
+	return values
+}
+
+func formatValue(v reflect.Value) string {
+	if v.Kind() == reflect.Pointer {
+		if v.IsNil() {
+			return ""
+		}
+		v = v.Elem()
+	}
+
+	switch value := v.Interface().(type) {
+	case time.Time:
+		return value.UTC().Format(time.RFC3339)
+	case []response.StudentProgramResponse:
+		programs := make([]string, len(value))
+		for i, program := range value {
+			programs[i] = fmt.Sprintf("%s %s@%s", program.Kind, program.ProgramID, program.DeclaredAt.UTC().Format("2006-01-02"))
+		}
+		return strings.Join(programs, ", ")
+	case string:
+		return value
+	default:
+		return fmt.Sprint(value)
+	}
+}
@@ -0,0 +1,115 @@
+package export
+
+import (
+	"bufio"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+)
+
+// RowWriter writes a header followed by rows; Close flushes any buffered
+// output and writes trailers, it does not close the underlying writer.
+type RowWriter interface {
+	WriteHeader(columns []string) error
+	WriteRow(values []string) error
+	Close() error
+}
+
+type Format struct {
+	Name        string
+	ContentType string
+	Extension   string
+	NewWriter   func(w io.Writer) RowWriter
+}
+
+var formats = map[string]Format{
+	"csv": {
+		Name:        "csv",
+		ContentType: "text/csv; charset=utf-8",
+		Extension:   "csv",
+		NewWriter:   func(w io.Writer) RowWriter { return &csvWriter{w: csv.NewWriter(w)} },
+	},
+	"ndjson": {
+		Name:        "ndjson",
+		ContentType: "application/x-ndjson",
+		Extension:   "ndjson",
+		NewWriter:   func(w io.Writer) RowWriter { return &ndjsonWriter{w: bufio.NewWriter(w)} },
+	},
+	"xlsx": {
+		Name:        "xlsx",
+		ContentType: "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet",
+		Extension:   "xlsx",
+		NewWriter:   func(w io.Writer) RowWriter { return newXLSXWriter(w) },
+	},
+}
+
+func LookupFormat(name string) (Format, error) {
+	format, ok := formats[name]
+	if !ok {
+		return Format{}, fmt.Errorf("unsupported export format %q, use csv, ndjson or xlsx", name)
+	}
+	return format, nil
+}
+
+type csvWriter struct {
+	w *csv.Writer
+}
+
+func (cw *csvWriter) WriteHeader(columns []string) error {
+	return cw.w.Write(columns)
+}
+
+func (cw *csvWriter) WriteRow(values []string) error {
+	return cw.w.Write(values)
+}
+
+func (cw *csvWriter) Close() error {
+	cw.w.Flush()
+	return cw.w.Error()
+}
+
+// ndjsonWriter writes one object per row with keys in column order. Empty
+// values are written as null.
+type ndjsonWriter struct {
+	w       *bufio.Writer
+	columns []string
+}
+
+func (nw *ndjsonWriter) WriteHeader(columns []string) error {
+	nw.columns = make([]string, len(columns))
+	for i, column := range columns {
+		key, err := json.Marshal(column)
+		if err != nil {
+			return err
+		}
+		nw.columns[i] = string(key)
+	}
+	return nil
+}
+
+func (nw *ndjsonWriter) WriteRow(values []string) error {
+	nw.w.WriteByte('{')
+	for i, value := range values {
+		if i > 0 {
+			nw.w.WriteByte(',')
+		}
+		nw.w.WriteString(nw.columns[i])
+		nw.w.WriteByte(':')
+		if value == "" {
+			nw.w.WriteString("null")
+			continue
+		}
+		encoded, err := json.Marshal(value)
+		if err != nil {
+			return err
+		}
+		nw.w.Write(encoded)
+	}
+	_, err := nw.w.WriteString("}\n")
+	return err
+}
+
+func (nw *ndjsonWriter) Close() error {
+	return nw.w.Flush()
+}
@@ -0,0 +1,75 @@
+package rest
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/tranvu1111/go-students-new/internal/application/common"
+)
+
+// DefaultCacheControl lets clients keep student reads but makes them
+// revalidate every time, which is cheap thanks to 304 responses.
+const DefaultCacheControl = "private, no-cache"
+
+type StudentControllerOption func(*StudentController)
+
+// WithCacheControl sets the Cache-Control header of student reads.
+func WithCacheControl(value string) StudentControllerOption {
+	return func(sc *StudentController) {
+		sc.cacheControl = value
+	}
+}
+
+// notModified sets the validators and Cache-Control of a read, and reports
+// whether the client's copy is still fresh, in which case it answers 304.
+// If-None-Match wins over If-Modified-Since, as RFC 9110 requires; a zero
+// lastModified leaves the read with the ETag only.
+func (sc *StudentController) notModified(c *gin.Context, etag string, lastModified time.Time) bool {
+	c.Header("ETag", etag)
+	if !lastModified.IsZero() {
+		c.Header("Last-Modified", lastModified.UTC().Format(http.TimeFormat))
+	}
+	c.Header("Cache-Control", sc.cacheControl)
+
+	fresh := false
+	if ifNoneMatch := c.GetHeader("If-None-Match"); ifNoneMatch != "" {
+		fresh = etagListMatches(ifNoneMatch, etag)
+	} else if ifModifiedSince := c.GetHeader("If-Modified-Since"); ifModifiedSince != "" && !lastModified.IsZero() {
+		since, err := http.ParseTime(ifModifiedSince)
+		fresh = err == nil && !lastModified.Truncate(time.Second).After(since)
+	}
+
+	if fresh {
+		c.Status(http.StatusNotModified)
+	}
+	return fresh
+}
+
+// etagListMatches uses the weak comparison that If-None-Match calls for.
+func etagListMatches(header string, etag string) bool {
+	for _, candidate := range strings.Split(header, ",") {
+		candidate = strings.TrimSpace(candidate)
+		if candidate == "*" || strings.TrimPrefix(candidate, "W/") == strings.TrimPrefix(etag, "W/") {
+			return true
+		}
+	}
+	return false
+}
+
+// studentListETag derives a weak ETag from the IDs and versions of the
+// listed students. Lists carry no Last-Modified: deleting a student takes it
+// off the list without making any listed student newer, so If-Modified-Since
+// would answer 304 with the deleted student still in the client's copy.
+func studentListETag(students []*common.StudentResult) string {
+	hash := sha256.New()
+	for _, student := range students {
+		hash.Write([]byte(student.StudentID.String()))
+		hash.Write([]byte(":" + strconv.Itoa(student.Version) + ";"))
+	}
+	return `W/"` + hex.EncodeToString(hash.Sum(nil))[:32] + `"`
+}
@@ -0,0 +1,330 @@
+// Package jsonpatch applies RFC 6902 JSON Patch documents and turns their
+// effect into an RFC 7396 merge patch, so that both PATCH formats reach the
+// service as the same field changes.
+package jsonpatch
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+const (
+	JSONPatchContentType  = "application/json-patch+json"
+	MergePatchContentType = "application/merge-patch+json"
+)
+
+// Operation is one entry of a JSON Patch document.
+type Operation struct {
+	Op    string           `json:"op"`
+	Path  *string          `json:"path"`
+	From  *string          `json:"from"`
+	Value *json.RawMessage `json:"value"`
+}
+
+// Apply runs every operation of patch against doc, in order. The patch is
+// atomic: if any operation fails, an error is returned and doc is unchanged.
+func Apply(doc []byte, patch []byte) ([]byte, error) {
+	var operations []Operation
+	if err := json.Unmarshal(patch, &operations); err != nil {
+		return nil, fmt.Errorf("a JSON Patch must be an array of operations: %w", err)
+	}
+
+	var node interface{}
+	if err := json.Unmarshal(doc, &node); err != nil {
+		return nil, err
+	}
+
+	for i, operation := range operations {
+		var err error
+		if node, err = applyOperation(node, operation); err != nil {
+			return nil, fmt.Errorf("operation %d (%s): %w", i, operation.Op, err)
+		}
+	}
+	return json.Marshal(node)
+}
+
+func applyOperation(node interface{}, operation Operation) (interface{}, error) {
+	if operation.Path == nil {
+		return nil, errors.New("missing path")
+	}
+	path, err := parsePointer(*operation.Path)
+	if err != nil {
+		return nil, err
+	}
+
+	switch operation.Op {
+	case "add", "replace", "test":
+		value, err := operationValue(operation)
+		if err != nil {
+			return nil, err
+		}
+		switch operation.Op {
+		case "add":
+			return add(node, path, value)
+		case "replace":
+			if len(path) == 0 {
+				return value, nil
+			}
+			if node, err = remove(node, path); err != nil {
+				return nil, err
+			}
+			return add(node, path, value)
+		default:
+			current, err := get(node, path)
+			if err != nil {
+				return nil, err
+			}
+			if !reflect.DeepEqual(current, value) {
+				return nil, errors.New("test failed: value differs")
+			}
+			return node, nil
+		}
+
+	case "remove":
+		return remove(node, path)
+
+	case "move", "copy":
+		if operation.From == nil {
+			return nil, errors.New("missing from")
+		}
+		from, err := parsePointer(*operation.From)
+		if err != nil {
+			return nil, err
+		}
+		value, err := get(node, from)
+		if err != nil {
+			return nil, err
+		}
+		if operation.Op == "copy" {
+			return add(node, path, deepCopy(value))
+		}
+		if isProperPrefix(from, path) {
+			return nil, errors.New("cannot move a value into one of its children")
+		}
+		if node, err = remove(node, from); err != nil {
+			return nil, err
+		}
+		return add(node, path, value)
+
+	default:
+		return nil, fmt.Errorf("unknown op %q", operation.Op)
+	}
+}
+
+func operationValue(operation Operation) (interface{}, error) {
+	if operation.Value == nil {
+		return nil, errors.New("missing value")
+	}
+	var value interface{}
+	if err := json.Unmarshal(*operation.Value, &value); err != nil {
+		return nil, err
+	}
+	return value, nil
+}
+
+// parsePointer splits an RFC 6901 JSON Pointer into unescaped reference tokens.
+func parsePointer(pointer string) ([]string, error) {
+	if pointer == "" {
+		return nil, nil
+	}
+	if !strings.HasPrefix(pointer, "/") {
+		return nil, fmt.Errorf("invalid JSON pointer %q", pointer)
+	}
+
+	tokens := strings.Split(pointer[1:], "/")
+	for i, token := range tokens {
+		tokens[i] = strings.ReplaceAll(strings.ReplaceAll(token, "~1", "/"), "~0", "~")
+	}
+	return tokens, nil
+}
+
+func get(node interface{}, path []string) (interface{}, error) {
+	for _, token := range path {
+		switch n := node.(type) {
+		case map[string]interface{}:
+			child, ok := n[token]
+			if !ok {
+				return nil, fmt.Errorf("path member %q does not exist", token)
+			}
+			node = child
+		case []interface{}:
+			i, err := arrayIndex(token, len(n)-1)
+			if err != nil {
+				return nil, err
+			}
+			node = n[i]
+		default:
+			return nil, fmt.Errorf("cannot traverse into %q", token)
+		}
+	}
+	return node, nil
+}
+
+func add(node interface{}, path []string, value interface{}) (interface{}, error) {
+	if len(path) == 0 {
+		return value, nil
+	}
+	return modifyParent(node, path, func(parent interface{}, key string) (interface{}, error) {
+		switch p := parent.(type) {
+		case map[string]interface{}:
+			p[key] = value
+			return p, nil
+		case []interface{}:
+			if key == "-" {
+				return append(p, value), nil
+			}
+			i, err := arrayIndex(key, len(p))
+			if err != nil {
+				return nil, err
+			}
+			p = append(p, nil)
+			copy(p[i+1:], p[i:])
+			p[i] = value
+			return p, nil
+		default:
+			return nil, fmt.Errorf("cannot add %q to a scalar", key)
+		}
+	})
+}
+
+func remove(node interface{}, path []string) (interface{}, error) {
+	if len(path) == 0 {
+		return nil, errors.New("cannot remove the whole document")
+	}
+	return modifyParent(node, path, func(parent interface{}, key string) (interface{}, error) {
+		switch p := parent.(type) {
+		case map[string]interface{}:
+			if _, ok := p[key]; !ok {
+				return nil, fmt.Errorf("path member %q does not exist", key)
+			}
+			delete(p, key)
+			return p, nil
+		case []interface{}:
+			i, err := arrayIndex(key, len(p)-1)
+			if err != nil {
+				return nil, err
+			}
+			return append(p[:i], p[i+1:]...), nil
+		default:
+			return nil, fmt.Errorf("cannot remove %q from a scalar", key)
+		}
+	})
+}
+
+// modifyParent walks to the parent of path and replaces it with whatever
+// change returns; arrays may be reallocated, so every level is reassigned.
+func modifyParent(node interface{}, path []string, change func(parent interface{}, key string) (interface{}, error)) (interface{}, error) {
+	if len(path) == 1 {
+		return change(node, path[0])
+	}
+
+	switch n := node.(type) {
+	case map[string]interface{}:
+		child, ok := n[path[0]]
+		if !ok {
+			return nil, fmt.Errorf("path member %q does not exist", path[0])
+		}
+		updated, err := modifyParent(child, path[1:], change)
+		if err != nil {
+			return nil, err
+		}
+		n[path[0]] = updated
+		return n, nil
+	case []interface{}:
+		i, err := arrayIndex(path[0], len(n)-1)
+		if err != nil {
+			return nil, err
+		}
+		updated, err := modifyParent(n[i], path[1:], change)
+		if err != nil {
+			return nil, err
+		}
+		n[i] = updated
+		return n, nil
+	default:
+		return nil, fmt.Errorf("cannot traverse into %q", path[0])
+	}
+}
+
+func arrayIndex(token string, max int) (int, error) {
+	if token == "" || (len(token) > 1 && token[0] == '0') {
+		return 0, fmt.Errorf("invalid array index %q", token)
+	}
+	i, err := strconv.Atoi(token)
+	if err != nil || i < 0 || i > max {
+		return 0, fmt.Errorf("array index %q out of range", token)
+	}
+	return i, nil
+}
+
+func isProperPrefix(prefix []string, path []string) bool {
+	if len(prefix) >= len(path) {
+		return false
+	}
+	for i := range prefix {
+		if prefix[i] != path[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func deepCopy(value interface{}) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		copied := make(map[string]interface{}, len(v))
+		for key, child := range v {
+			copied[key] = deepCopy(child)
+		}
+		return copied
+	case []interface{}:
+		copied := make([]interface{}, len(v))
+		for i, child := range v {
+			copied[i] = deepCopy(child)
+		}
+		return copied
+	default:
+		return v
+	}
+}
+
+// CreateMergePatch returns the RFC 7396 merge patch that turns original into
+// modified. Removed members become null.
+func CreateMergePatch(original []byte, modified []byte) ([]byte, error) {
+	var before, after interface{}
+	if err := json.Unmarshal(original, &before); err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(modified, &after); err != nil {
+		return nil, err
+	}
+	return json.Marshal(mergeDiff(before, after))
+}
+
+func mergeDiff(before interface{}, after interface{}) interface{} {
+	beforeObject, beforeIsObject := before.(map[string]interface{})
+	afterObject, afterIsObject := after.(map[string]interface{})
+	if !beforeIsObject || !afterIsObject {
+		return after
+	}
+
+	diff := map[string]interface{}{}
+	for key, afterValue := range afterObject {
+		beforeValue, existed := beforeObject[key]
+		if !existed {
+			diff[key] = afterValue
+		} else if !reflect.DeepEqual(beforeValue, afterValue) {
+			diff[key] = mergeDiff(beforeValue, afterValue)
+		}
+	}
+	for key := range beforeObject {
+		if _, kept := afterObject[key]; !kept {
+			diff[key] = nil
+		}
+	}
+	return diff
+}
@@ -0,0 +1,125 @@
+package rest
+
+import (
+	"errors"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+	"github.com/tranvu1111/go-students-new/internal/domain/entities"
+	"github.com/tranvu1111/go-students-new/internal/domain/repositories"
+	"github.com/tranvu1111/go-students-new/internal/interface/api/i18n"
+)
+
+const localeKey = "locale"
+
+// LocaleMiddleware negotiates the language of error responses from the
+// Accept-Language header and announces it in Content-Language.
+func LocaleMiddleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		locale := i18n.Negotiate(c.GetHeader("Accept-Language"))
+		c.Set(localeKey, locale)
+		c.Header("Content-Language", locale)
+		c.Writer.Header().Add("Vary", "Accept-Language")
+		c.Next()
+	}
+}
+
+// requestLocale also serves routers set up without LocaleMiddleware.
+func requestLocale(c *gin.Context) string {
+	if locale := c.GetString(localeKey); locale != "" {
+		return locale
+	}
+	return i18n.Negotiate(c.GetHeader("Accept-Language"))
+}
+
+// tr renders the catalog message for code in the language of the request.
+func tr(c *gin.Context, code string) string {
+	return i18n.Message(requestLocale(c), code)
+}
+
+// errorCodes names the domain and storage errors whose text is in the
+// catalogs. Validation errors wrap one of them as their kind.
+var errorCodes = []struct {
+	err  error
+	code string
+}{
+	{entities.ErrInvalidContact, "error.invalid_contact"},
+	{entities.ErrInvalidProgramDeclaration, "error.invalid_program_declaration"},
+	{entities.ErrInvalidProgram, "error.invalid_program"},
+	{entities.ErrInvalidStatusChange, "error.invalid_status_change"},
+	{entities.ErrStatusTransitionNotAllowed, "error.status_transition_not_allowed"},
+	{entities.ErrInvalidVerificationToken, "error.invalid_verification_token"},
+	{entities.ErrInvalidNotificationTemplate, "error.invalid_notification_template"},
+	{entities.ErrContactNotFound, "error.contact_not_found"},
+	{entities.ErrNotificationNotDead, "error.notification_not_dead"},
+	{repositories.ErrStudentNotFound, "error.student_not_found"},
+	{repositories.ErrDuplicateEmail, "error.duplicate_email"},
+	{repositories.ErrProgramNotFound, "error.program_not_found"},
+	{repositories.ErrDuplicateProgramCode, "error.duplicate_program_code"},
+	{repositories.ErrNotificationTemplateNotFound, "error.notification_template_not_found"},
+	{repositories.ErrDuplicateNotificationTemplate, "error.duplicate_notification_template"},
+	{repositories.ErrNotificationNotFound, "error.notification_not_found"},
+	{repositories.ErrWebhookSubscriptionNotFound, "error.webhook_subscription_not_found"},
+	{repositories.ErrUnavailable, "error.unavailable"},
+}
+
+func errorCode(err error) (string, bool) {
+	for _, known := range errorCodes {
+		if errors.Is(err, known.err) {
+			return known.code, true
+		}
+	}
+	return "", false
+}
+
+// errorDetail renders err in the language of the request. A broken rule is
+// rendered from its code, prefixed with its kind like its English text; a
+// known error from its catalog entry. Anything else, e.g. a database error,
+// is passed on as is.
+func errorDetail(c *gin.Context, err error) string {
+	locale := requestLocale(c)
+
+	var invalid *entities.ValidationError
+	if errors.As(err, &invalid) {
+		message, ok := i18n.Translate(locale, invalid.Code, invalid.Params)
+		if !ok {
+			return err.Error()
+		}
+		if kind := invalid.Unwrap(); kind != nil {
+			if code, ok := errorCode(kind); ok {
+				return i18n.Message(locale, code) + ": " + message
+			}
+		}
+		return message
+	}
+
+	if code, ok := errorCode(err); ok {
+		return i18n.Message(locale, code)
+	}
+	return err.Error()
+}
+
+// bindErrorDetail renders the binding rules a request broke, e.g.
+// "FirstName is required". Other errors, e.g. malformed JSON, are rendered
+// by errorDetail.
+func bindErrorDetail(c *gin.Context, err error) string {
+	var invalid validator.ValidationErrors
+	if !errors.As(err, &invalid) {
+		return errorDetail(c, err)
+	}
+
+	locale := requestLocale(c)
+	detail := ""
+	for i, field := range invalid {
+		code := "request.field_invalid"
+		if field.Tag() == "required" {
+			code = "request.field_required"
+		}
+		message, _ := i18n.Translate(locale, code, map[string]string{"field": field.Field(), "rule": field.Tag()})
+		if i > 0 {
+			detail += "; "
+		}
+		detail += message
+	}
+	return detail
+}
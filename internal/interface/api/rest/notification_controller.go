@@ -0,0 +1,204 @@
+package rest
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/tranvu1111/go-students-new/internal/application/interfaces"
+	"github.com/tranvu1111/go-students-new/internal/application/query"
+	"github.com/tranvu1111/go-students-new/internal/domain/entities"
+	"github.com/tranvu1111/go-students-new/internal/domain/repositories"
+	"github.com/tranvu1111/go-students-new/internal/interface/api/rest/dto/mapper"
+	"github.com/tranvu1111/go-students-new/internal/interface/api/rest/dto/request"
+)
+
+// NotificationController serves the templates students are notified with,
+// and the log of what was sent to them.
+type NotificationController struct {
+	service interfaces.NotificationService
+}
+
+func NewNotificationController(r *gin.Engine, service interfaces.NotificationService) *NotificationController {
+	controller := &NotificationController{
+		service: service,
+	}
+
+	r.POST("/api/v1/notification-templates", controller.CreateTemplateController)
+	r.GET("/api/v1/notification-templates", controller.GetAllTemplateController)
+	r.GET("/api/v1/notification-templates/:id", controller.GetTemplateByIdController)
+	r.PUT("/api/v1/notification-templates/:id", controller.PutTemplateController)
+	r.DELETE("/api/v1/notification-templates/:id", controller.DeleteTemplateController)
+	r.GET("/api/v1/notifications", controller.GetNotificationsController)
+	r.POST("/api/v1/notifications/:id/retry", controller.RetryNotificationController)
+
+	return controller
+}
+
+func (nc *NotificationController) CreateTemplateController(c *gin.Context) {
+	var createRequest request.CreateNotificationTemplateRequest
+	if err := c.ShouldBindJSON(&createRequest); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"message": tr(c, "problem.invalid_request"), "error1": bindErrorDetail(c, err)})
+		return
+	}
+
+	result, err := nc.service.CreateTemplate(c.Request.Context(), createRequest.ToCreateNotificationTemplateCommand())
+	if err != nil {
+		writeNotificationError(c, "problem.create_notification_template_failed", err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, mapper.ToNotificationTemplateResponse(result.Result))
+}
+
+// GetAllTemplateController filters by the event_type, channel and locale query
+// parameters.
+func (nc *NotificationController) GetAllTemplateController(c *gin.Context) {
+	listQuery := query.NotificationTemplateListQuery{
+		EventType: 	c.Query("event_type"),
+		Channel: 	c.Query("channel"),
+		Locale: 	c.Query("locale"),
+	}
+	if listQuery.Channel != "" && !entities.NotificationChannel(listQuery.Channel).IsValid() {
+		c.JSON(http.StatusBadRequest, gin.H{"error": tr(c, "problem.invalid_channel"), "context": listQuery.Channel})
+		return
+	}
+
+	templates, err := nc.service.FindAllTemplates(c.Request.Context(), &listQuery)
+	if err != nil {
+		writeNotificationError(c, "problem.load_notification_templates_failed", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, mapper.ToNotificationTemplateListResponse(templates.Result))
+}
+
+func (nc *NotificationController) GetTemplateByIdController(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": tr(c, "problem.invalid_template_id"), "context": err.Error()})
+		return
+	}
+
+	template, err := nc.service.FindTemplateById(c.Request.Context(), id)
+	if err != nil {
+		writeNotificationError(c, "problem.load_notification_template_failed", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, mapper.ToNotificationTemplateResponse(template.Result))
+}
+
+func (nc *NotificationController) PutTemplateController(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": tr(c, "problem.invalid_template_id"), "context": err.Error()})
+		return
+	}
+
+	var updateRequest request.UpdateNotificationTemplateRequest
+	if err := c.ShouldBindJSON(&updateRequest); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"message": tr(c, "problem.invalid_request"), "error1": bindErrorDetail(c, err)})
+		return
+	}
+
+	result, err := nc.service.UpdateTemplate(c.Request.Context(), updateRequest.ToUpdateNotificationTemplateCommand(id))
+	if err != nil {
+		writeNotificationError(c, "problem.update_notification_template_failed", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, mapper.ToNotificationTemplateResponse(result.Result))
+}
+
+func (nc *NotificationController) DeleteTemplateController(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": tr(c, "problem.invalid_template_id"), "context": err.Error()})
+		return
+	}
+
+	if err := nc.service.DeleteTemplate(c.Request.Context(), id); err != nil {
+		writeNotificationError(c, "problem.delete_notification_template_failed", err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// GetNotificationsController pages through the send log, filtered by the
+// student_id and status query parameters.
+func (nc *NotificationController) GetNotificationsController(c *gin.Context) {
+	var listQuery query.NotificationListQuery
+	if value := c.Query("student_id"); value != "" {
+		studentID, err := uuid.Parse(value)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": tr(c, "problem.invalid_student_id_filter"), "context": err.Error()})
+			return
+		}
+		listQuery.StudentID = &studentID
+	}
+	if value := c.Query("status"); value != "" {
+		if !entities.NotificationStatus(value).IsValid() {
+			c.JSON(http.StatusBadRequest, gin.H{"error": tr(c, "problem.invalid_status"), "context": value})
+			return
+		}
+		listQuery.Status = value
+	}
+
+	var err error
+	if listQuery.Page, err = strconv.Atoi(c.DefaultQuery("page", "1")); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": tr(c, "problem.invalid_page"), "context": err.Error()})
+		return
+	}
+	if listQuery.PageSize, err = strconv.Atoi(c.DefaultQuery("page_size", "0")); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": tr(c, "problem.invalid_page_size"), "context": err.Error()})
+		return
+	}
+
+	notifications, err := nc.service.FindNotifications(c.Request.Context(), &listQuery)
+	if err != nil {
+		writeNotificationError(c, "problem.load_notifications_failed", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, mapper.ToNotificationListResponse(notifications))
+}
+
+func (nc *NotificationController) RetryNotificationController(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": tr(c, "problem.invalid_notification_id"), "context": err.Error()})
+		return
+	}
+
+	result, err := nc.service.RetryNotification(c.Request.Context(), id)
+	if err != nil {
+		writeNotificationError(c, "problem.retry_notification_failed", err)
+		return
+	}
+
+	c.JSON(http.StatusAccepted, mapper.ToNotificationResponse(result.Result))
+}
+
+// writeNotificationError maps the notification errors like writeProgramError
+// maps those of the catalog.
+func writeNotificationError(c *gin.Context, code string, err error) {
+	switch {
+	case errors.Is(err, repositories.ErrNotificationTemplateNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": tr(c, "problem.notification_template_not_found"), "content": errorDetail(c, err)})
+	case errors.Is(err, repositories.ErrNotificationNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": tr(c, "problem.notification_not_found"), "content": errorDetail(c, err)})
+	case errors.Is(err, repositories.ErrDuplicateNotificationTemplate), errors.Is(err, entities.ErrNotificationNotDead):
+		c.JSON(http.StatusConflict, gin.H{"error": tr(c, code), "content": errorDetail(c, err)})
+	case errors.Is(err, entities.ErrInvalidNotificationTemplate):
+		c.JSON(http.StatusUnprocessableEntity, gin.H{"error": tr(c, code), "content": errorDetail(c, err)})
+	case errors.Is(err, repositories.ErrUnavailable):
+		c.Header("Retry-After", "10")
+		c.JSON(http.StatusServiceUnavailable, gin.H{"error": tr(c, code), "content": errorDetail(c, err)})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": tr(c, code), "content": errorDetail(c, err)})
+	}
+}
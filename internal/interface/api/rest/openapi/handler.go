@@ -0,0 +1,46 @@
+package openapi
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	swaggerFiles "github.com/swaggo/files/v2"
+)
+
+const (
+	SpecPath = "/openapi.json"
+	DocsPath = "/docs"
+)
+
+// swaggerInitializer replaces the one bundled with Swagger UI, which points at
+// the petstore example.
+const swaggerInitializer = `window.onload = function() {
+  window.ui = SwaggerUIBundle({
+    url: "` + SpecPath + `",
+    dom_id: "#swagger-ui",
+    deepLinking: true,
+    presets: [SwaggerUIBundle.presets.apis, SwaggerUIStandalonePreset],
+    layout: "StandaloneLayout"
+  });
+};
+`
+
+// Register serves doc at /openapi.json and Swagger UI at /docs/.
+func Register(r *gin.Engine, doc *Document) {
+	r.GET(SpecPath, func(c *gin.Context) {
+		c.JSON(http.StatusOK, doc)
+	})
+
+	assets := http.StripPrefix(DocsPath, http.FileServer(http.FS(swaggerFiles.FS)))
+
+	r.GET(DocsPath, func(c *gin.Context) {
+		c.Redirect(http.StatusMovedPermanently, DocsPath+"/")
+	})
+	r.GET(DocsPath+"/*asset", func(c *gin.Context) {
+		if c.Param("asset") == "/swagger-initializer.js" {
+			c.Data(http.StatusOK, "application/javascript", []byte(swaggerInitializer))
+			return
+		}
+		assets.ServeHTTP(c.Writer, c.Request)
+	})
+}
@@ -0,0 +1,168 @@
+package openapi
+
+import (
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Schema is the subset of JSON Schema 2020-12, as used by OpenAPI 3.1, that
+// the student API needs.
+type Schema struct {
+	Ref                  string             `json:"$ref,omitempty"`
+	Type                 interface{}        `json:"type,omitempty"`
+	Format               string             `json:"format,omitempty"`
+	Description          string             `json:"description,omitempty"`
+	Deprecated           bool               `json:"deprecated,omitempty"`
+	Enum                 []interface{}      `json:"enum,omitempty"`
+	MinLength            *int               `json:"minLength,omitempty"`
+	Minimum              *float64           `json:"minimum,omitempty"`
+	Properties           map[string]*Schema `json:"properties,omitempty"`
+	Required             []string           `json:"required,omitempty"`
+	AdditionalProperties *Schema            `json:"additionalProperties,omitempty"`
+	Items                *Schema            `json:"items,omitempty"`
+}
+
+func refTo(name string) *Schema {
+	return &Schema{Ref: "#/components/schemas/" + name}
+}
+
+func stringSchema(format string) *Schema {
+	return &Schema{Type: "string", Format: format}
+}
+
+func integerSchema(minimum float64) *Schema {
+	return &Schema{Type: "integer", Minimum: &minimum}
+}
+
+func enumSchema(values ...string) *Schema {
+	schema := &Schema{Type: "string"}
+	for _, value := range values {
+		schema.Enum = append(schema.Enum, value)
+	}
+	return schema
+}
+
+// nullable allows null besides the schema's own type.
+func nullable(schema *Schema) *Schema {
+	if t, ok := schema.Type.(string); ok {
+		copied := *schema
+		copied.Type = []string{t, "null"}
+		return &copied
+	}
+	return schema
+}
+
+// generator derives schemas from Go types, registering every named struct as
+// a component so that it is described once and referenced everywhere.
+type generator struct {
+	components map[string]*Schema
+	// names renames components whose Go name should not leak into the spec.
+	names map[reflect.Type]string
+	// formats describes types that marshal to a JSON string.
+	formats map[reflect.Type]*Schema
+}
+
+func newGenerator() *generator {
+	return &generator{
+		components: map[string]*Schema{},
+		names:      map[reflect.Type]string{},
+		formats: map[reflect.Type]*Schema{
+			reflect.TypeOf(time.Time{}): stringSchema("date-time"),
+			reflect.TypeOf(uuid.UUID{}): stringSchema("uuid"),
+		},
+	}
+}
+
+// component registers the type of value and returns a reference to it.
+func (g *generator) component(value interface{}) *Schema {
+	return g.schemaFor(reflect.TypeOf(value))
+}
+
+func (g *generator) componentName(t reflect.Type) string {
+	if name, ok := g.names[t]; ok {
+		return name
+	}
+	return t.Name()
+}
+
+func (g *generator) schemaFor(t reflect.Type) *Schema {
+	if format, ok := g.formats[t]; ok {
+		copied := *format
+		return &copied
+	}
+
+	switch t.Kind() {
+	case reflect.Ptr:
+		return nullable(g.schemaFor(t.Elem()))
+	case reflect.String:
+		return &Schema{Type: "string"}
+	case reflect.Bool:
+		return &Schema{Type: "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return &Schema{Type: "integer"}
+	case reflect.Float32, reflect.Float64:
+		return &Schema{Type: "number"}
+	case reflect.Slice, reflect.Array:
+		return &Schema{Type: "array", Items: g.schemaFor(t.Elem())}
+	case reflect.Map:
+		return &Schema{Type: "object", AdditionalProperties: g.schemaFor(t.Elem())}
+	case reflect.Struct:
+		name := g.componentName(t)
+		if _, ok := g.components[name]; !ok {
+			g.components[name] = &Schema{}
+			g.components[name] = g.structSchema(t)
+		}
+		return refTo(name)
+	default:
+		return &Schema{}
+	}
+}
+
+// structSchema lists the JSON members of t. Request fields are required when
+// tagged binding:"required"; response fields are always present unless they
+// are omitempty.
+func (g *generator) structSchema(t reflect.Type) *Schema {
+	schema := &Schema{Type: "object", Properties: map[string]*Schema{}}
+	isResponse := strings.HasSuffix(t.PkgPath(), "/dto/response")
+
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+
+		name, omitEmpty := jsonName(field)
+		if name == "-" {
+			continue
+		}
+		schema.Properties[name] = g.schemaFor(field.Type)
+
+		required := strings.Contains(field.Tag.Get("binding"), "required")
+		if required || (isResponse && !omitEmpty) {
+			schema.Required = append(schema.Required, name)
+		}
+	}
+	return schema
+}
+
+func jsonName(field reflect.StructField) (string, bool) {
+	tag := field.Tag.Get("json")
+	if tag == "" {
+		return field.Name, false
+	}
+	parts := strings.Split(tag, ",")
+	name := parts[0]
+	if name == "" {
+		name = field.Name
+	}
+	for _, option := range parts[1:] {
+		if option == "omitempty" {
+			return name, true
+		}
+	}
+	return name, false
+}
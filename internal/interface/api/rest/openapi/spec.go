@@ -0,0 +1,418 @@
+package openapi
+
+import (
+	"net/http"
+	"reflect"
+	"strconv"
+
+	"github.com/tranvu1111/go-students-new/internal/domain/entities"
+	"github.com/tranvu1111/go-students-new/internal/interface/api/rest/dto/request"
+	"github.com/tranvu1111/go-students-new/internal/interface/api/rest/dto/response"
+	"github.com/tranvu1111/go-students-new/internal/interface/api/rest/jsonpatch"
+)
+
+const (
+	studentsV1Path = "/api/v1/students"
+	studentsV2Path = "/api/v2/students"
+)
+
+// StudentsSpec describes the v1 and v2 student routes. The schemas are
+// generated from the DTOs the handlers bind and render, so a field added to a
+// DTO shows up in the spec without further changes.
+func StudentsSpec() *Document {
+	g := newGenerator()
+	g.names[reflect.TypeOf(request.UpdateStudentResquest{})] = "UpdateStudentRequest"
+	g.formats[reflect.TypeOf(request.JsonTime{})] = stringSchema("date")
+
+	s := &studentSpec{
+		g: g,
+		doc: &Document{
+			OpenAPI: Version,
+			Info: Info{
+				Title:       "Students API",
+				Version:     "2.0.0",
+				Description: "JSON members are PascalCase, as in the DTOs.",
+			},
+			Paths: map[string]*PathItem{},
+		},
+	}
+	g.components["Error"] = &Schema{
+		Type: "object",
+		Properties: map[string]*Schema{
+			"error":   {Type: "string"},
+			"content": {Type: "string"},
+			"context": {Type: "string"},
+			"message": {Type: "string"},
+			"error1":  {Type: "string"},
+		},
+	}
+	g.components["JSONPatch"] = &Schema{
+		Type: "array",
+		Items: &Schema{
+			Type:     "object",
+			Required: []string{"op", "path"},
+			Properties: map[string]*Schema{
+				"op":    enumSchema("add", "remove", "replace", "move", "copy", "test"),
+				"path":  {Type: "string"},
+				"from":  {Type: "string"},
+				"value": {},
+			},
+		},
+	}
+	// A merge patch names only the fields it changes, and null clears one.
+	mergePatch := g.structSchema(reflect.TypeOf(request.ReplaceStudentRequest{}))
+	mergePatch.Required = nil
+	for name, property := range mergePatch.Properties {
+		mergePatch.Properties[name] = nullable(property)
+	}
+	g.components["StudentMergePatch"] = mergePatch
+
+	s.studentRoutes(studentsV1Path, true)
+	s.studentRoutes(studentsV2Path, false)
+
+	s.doc.Components.Schemas = g.components
+	return s.doc
+}
+
+type studentSpec struct {
+	g   *generator
+	doc *Document
+}
+
+func (s *studentSpec) studentRoutes(prefix string, v1 bool) {
+	version := "V2"
+	if v1 {
+		version = "V1"
+	}
+	byID := prefix + "/{id}"
+	student := s.g.component(response.StudentResponse{})
+	studentOK := s.jsonResponse("The student", student, etagHeader())
+
+	if v1 {
+		s.add(http.MethodPost, prefix, v1, &Operation{
+			OperationID: "createStudent" + version,
+			Summary:     "Create a student",
+			RequestBody: &RequestBody{Required: true, Content: jsonContent(s.g.component(request.CreateStudentRequest{}))},
+			Responses: map[string]*Response{
+				// The legacy envelope's "message " key really has a trailing space.
+				"201": s.jsonResponse("The created student", &Schema{
+					Type:     "object",
+					Required: []string{"message ", "student"},
+					Properties: map[string]*Schema{
+						"message ": {Type: "string"},
+						"student":  student,
+					},
+				}, etagHeader()),
+			},
+		}, http.StatusBadRequest, http.StatusConflict, http.StatusInternalServerError)
+	} else {
+		created := s.jsonResponse("The created student", student, etagHeader())
+		created.Headers["Location"] = &Header{Description: "URL of the created student", Schema: stringSchema("uri-reference")}
+		s.add(http.MethodPost, prefix, v1, &Operation{
+			OperationID: "createStudent" + version,
+			Summary:     "Create a student",
+			RequestBody: &RequestBody{Required: true, Content: jsonContent(s.g.component(request.CreateStudentRequest{}))},
+			Responses:   map[string]*Response{"201": created},
+		}, http.StatusBadRequest, http.StatusConflict, http.StatusInternalServerError)
+	}
+
+	s.add(http.MethodPost, prefix+"/import", v1, &Operation{
+		OperationID: "importStudents" + version,
+		Summary:     "Import students from CSV or NDJSON",
+		Parameters: []*Parameter{
+			queryParameter("format", enumSchema("csv", "ndjson"), "Defaults to the Content-Type of the body"),
+			queryParameter("mode", enumSchema("all_or_nothing", "best_effort"), ""),
+			queryParameter("dry_run", &Schema{Type: "boolean"}, ""),
+			queryParameter("batch_size", integerSchema(0), ""),
+		},
+		RequestBody: &RequestBody{Required: true, Content: map[string]*MediaType{
+			"text/csv":             {Schema: &Schema{Type: "string"}},
+			"application/x-ndjson": {Schema: &Schema{Type: "string"}},
+		}},
+		Responses: map[string]*Response{
+			"200": s.jsonResponse("The import report", s.g.component(response.ImportStudentsResponse{})),
+			"422": s.jsonResponse("The import was rolled back", s.g.component(response.ImportStudentsResponse{})),
+		},
+	}, http.StatusBadRequest, http.StatusInternalServerError)
+
+	list := s.g.component(response.StudentResponseList{})
+	if !v1 {
+		list = &Schema{Type: "array", Items: student}
+	}
+	s.add(http.MethodGet, prefix, v1, &Operation{
+		OperationID: "listStudents" + version,
+		Summary:     "List students",
+		Parameters:  listParameters(),
+		Responses: map[string]*Response{
+			"200": s.jsonResponse("The students", list, etagHeader()),
+			"304": {Description: "The client's copy is still fresh"},
+		},
+	}, http.StatusBadRequest, http.StatusInternalServerError)
+
+	s.add(http.MethodGet, prefix+"/export", v1, &Operation{
+		OperationID: "exportStudents" + version,
+		Summary:     "Export students",
+		Parameters: append([]*Parameter{
+			queryParameter("format", enumSchema("csv", "ndjson", "xlsx"), ""),
+			queryParameter("columns", &Schema{Type: "string"}, "Comma separated column names"),
+		}, listParameters()...),
+		Responses: map[string]*Response{
+			"200": {Description: "The exported students", Content: map[string]*MediaType{
+				"text/csv":             {Schema: &Schema{Type: "string"}},
+				"application/x-ndjson": {Schema: &Schema{Type: "string"}},
+				"application/vnd.openxmlformats-officedocument.spreadsheetml.sheet": {Schema: &Schema{Type: "string", Format: "binary"}},
+			}},
+		},
+	}, http.StatusBadRequest, http.StatusInternalServerError)
+
+	minQueryLength := 2
+	s.add(http.MethodGet, prefix+"/search", v1, &Operation{
+		OperationID: "searchStudents" + version,
+		Summary:     "Search students by name, email and major",
+		Parameters: []*Parameter{
+			{Name: "q", In: "query", Required: true, Schema: &Schema{Type: "string", MinLength: &minQueryLength}},
+			queryParameter("limit", integerSchema(0), ""),
+		},
+		Responses: map[string]*Response{
+			"200": s.jsonResponse("The ranked matches", s.g.component(response.StudentSearchResponse{})),
+		},
+	}, http.StatusBadRequest, http.StatusInternalServerError)
+
+	s.add(http.MethodGet, byID, v1, &Operation{
+		OperationID: "getStudent" + version,
+		Summary:     "Get a student",
+		Parameters:  []*Parameter{idParameter()},
+		Responses: map[string]*Response{
+			"200": studentOK,
+			"304": {Description: "The client's copy is still fresh"},
+		},
+	}, http.StatusBadRequest, http.StatusNotFound, http.StatusInternalServerError)
+
+	if v1 {
+		s.add(http.MethodPut, prefix, v1, &Operation{
+			OperationID: "updateStudent" + version,
+			Summary:     "Update the optional fields of a student",
+			Parameters:  []*Parameter{ifMatchParameter()},
+			RequestBody: &RequestBody{Required: true, Content: jsonContent(s.g.component(request.UpdateStudentResquest{}))},
+			Responses:   map[string]*Response{"200": studentOK},
+		}, writeErrors...)
+	} else {
+		s.add(http.MethodPut, byID, v1, &Operation{
+			OperationID: "replaceStudent" + version,
+			Summary:     "Replace every writable field of a student",
+			Parameters:  []*Parameter{idParameter(), ifMatchParameter()},
+			RequestBody: &RequestBody{Required: true, Content: jsonContent(s.g.component(request.ReplaceStudentRequest{}))},
+			Responses:   map[string]*Response{"200": studentOK},
+		}, writeErrors...)
+	}
+
+	s.add(http.MethodPatch, byID, v1, &Operation{
+		OperationID: "patchStudent" + version,
+		Summary:     "Change some fields of a student",
+		Parameters:  []*Parameter{idParameter(), ifMatchParameter()},
+		RequestBody: &RequestBody{Required: true, Content: map[string]*MediaType{
+			jsonpatch.MergePatchContentType: {Schema: refTo("StudentMergePatch")},
+			"application/json":              {Schema: refTo("StudentMergePatch")},
+			jsonpatch.JSONPatchContentType:  {Schema: refTo("JSONPatch")},
+		}},
+		Responses: map[string]*Response{"200": studentOK},
+	}, append(writeErrors, http.StatusUnsupportedMediaType, http.StatusUnprocessableEntity)...)
+
+	deleted := &Response{Description: "The student was deleted"}
+	deletedStatus := "204"
+	if v1 {
+		deleted = s.jsonResponse("The student was deleted", refTo("Error"))
+		deletedStatus = "200"
+	}
+	s.add(http.MethodDelete, byID, v1, &Operation{
+		OperationID: "deleteStudent" + version,
+		Summary:     "Soft delete a student",
+		Parameters:  []*Parameter{idParameter(), ifMatchParameter()},
+		Responses:   map[string]*Response{deletedStatus: deleted},
+	}, writeErrors...)
+
+	s.add(http.MethodPost, byID+"/restore", v1, &Operation{
+		OperationID: "restoreStudent" + version,
+		Summary:     "Restore a deleted student",
+		Parameters:  []*Parameter{idParameter()},
+		Responses:   map[string]*Response{"200": studentOK},
+	}, http.StatusBadRequest, http.StatusNotFound, http.StatusConflict, http.StatusInternalServerError)
+
+	s.add(http.MethodGet, byID+"/history", v1, &Operation{
+		OperationID: "getStudentHistory" + version,
+		Summary:     "Page through the audit trail of a student",
+		Parameters: []*Parameter{
+			idParameter(),
+			queryParameter("page", integerSchema(1), ""),
+			queryParameter("page_size", integerSchema(0), ""),
+		},
+		Responses: map[string]*Response{
+			"200": s.jsonResponse("One page of history, newest first", s.g.component(response.StudentHistoryResponse{})),
+		},
+	}, http.StatusBadRequest, http.StatusInternalServerError)
+
+	// 409 when the current status cannot change to the requested one, 422
+	// when the reason or effective date is missing or impossible.
+	s.add(http.MethodPost, byID+"/transitions", v1, &Operation{
+		OperationID: "transitionStudentStatus" + version,
+		Summary:     "Move a student to another status",
+		Parameters:  []*Parameter{idParameter(), ifMatchParameter()},
+		RequestBody: &RequestBody{Required: true, Content: jsonContent(s.g.component(request.TransitionStudentStatusRequest{}))},
+		Responses:   map[string]*Response{"200": studentOK},
+	}, append(writeErrors, http.StatusUnprocessableEntity)...)
+
+	s.add(http.MethodGet, byID+"/transitions", v1, &Operation{
+		OperationID: "getStudentStatusHistory" + version,
+		Summary:     "Page through the status transitions of a student",
+		Parameters: []*Parameter{
+			idParameter(),
+			queryParameter("page", integerSchema(1), ""),
+			queryParameter("page_size", integerSchema(0), ""),
+		},
+		Responses: map[string]*Response{
+			"200": s.jsonResponse("One page of transitions, latest first", s.g.component(response.StudentStatusHistoryResponse{})),
+		},
+	}, http.StatusBadRequest, http.StatusInternalServerError)
+
+	s.contactRoutes(byID, v1, version)
+
+	// The token names the student; 422 when it is forged, expired or for an
+	// email the student no longer has.
+	s.add(http.MethodPost, prefix+"/verify-email", v1, &Operation{
+		OperationID: "verifyStudentEmail" + version,
+		Summary:     "Verify a student's email with the token mailed to it",
+		RequestBody: &RequestBody{Required: true, Content: jsonContent(s.g.component(request.VerifyEmailRequest{}))},
+		Responses:   map[string]*Response{"200": studentOK},
+	}, http.StatusBadRequest, http.StatusUnprocessableEntity, http.StatusInternalServerError)
+}
+
+// contactRoutes documents the contacts nested under a student. Every write
+// answers with all of the student's contacts and the student's new ETag.
+func (s *studentSpec) contactRoutes(byID string, v1 bool, version string) {
+	contacts := s.g.component(response.StudentContactsResponse{})
+	contactsOK := s.jsonResponse("The student's contacts", contacts, etagHeader())
+	contactID := &Parameter{Name: "contactId", In: "path", Required: true, Schema: stringSchema("uuid")}
+
+	s.add(http.MethodGet, byID+"/contacts", v1, &Operation{
+		OperationID: "getStudentContacts" + version,
+		Summary:     "Get the addresses, emails, phones and emergency contacts of a student",
+		Parameters:  []*Parameter{idParameter()},
+		Responses: map[string]*Response{
+			"200": contactsOK,
+			"304": {Description: "The client's copy is still fresh"},
+		},
+	}, http.StatusBadRequest, http.StatusNotFound, http.StatusInternalServerError)
+
+	kinds := []struct {
+		path, name, operation string
+		body                  any
+	}{
+		{"addresses", "an address", "Address", request.AddressRequest{}},
+		{"emails", "an email", "Email", request.ContactEmailRequest{}},
+		{"phones", "a phone number", "Phone", request.ContactPhoneRequest{}},
+		{"emergency-contacts", "an emergency contact", "EmergencyContact", request.EmergencyContactRequest{}},
+	}
+	// 422 when the contact is invalid, e.g. a phone number that is not E.164
+	// or a second home address for the same period.
+	contactErrors := append(writeErrors, http.StatusUnprocessableEntity)
+	for _, kind := range kinds {
+		collection := byID + "/contacts/" + kind.path
+		body := &RequestBody{Required: true, Content: jsonContent(s.g.component(kind.body))}
+
+		s.add(http.MethodPost, collection, v1, &Operation{
+			OperationID: "addStudent" + kind.operation + version,
+			Summary:     "Add " + kind.name + " to a student",
+			Parameters:  []*Parameter{idParameter(), ifMatchParameter()},
+			RequestBody: body,
+			Responses:   map[string]*Response{"201": contactsOK},
+		}, contactErrors...)
+
+		s.add(http.MethodPut, collection+"/{contactId}", v1, &Operation{
+			OperationID: "replaceStudent" + kind.operation + version,
+			Summary:     "Replace " + kind.name + " of a student",
+			Parameters:  []*Parameter{idParameter(), contactID, ifMatchParameter()},
+			RequestBody: body,
+			Responses:   map[string]*Response{"200": contactsOK},
+		}, contactErrors...)
+
+		s.add(http.MethodDelete, collection+"/{contactId}", v1, &Operation{
+			OperationID: "removeStudent" + kind.operation + version,
+			Summary:     "Remove " + kind.name + " from a student",
+			Parameters:  []*Parameter{idParameter(), contactID, ifMatchParameter()},
+			Responses:   map[string]*Response{"200": contactsOK},
+		}, writeErrors...)
+	}
+}
+
+var writeErrors = []int{
+	http.StatusBadRequest,
+	http.StatusNotFound,
+	http.StatusConflict,
+	http.StatusPreconditionFailed,
+	http.StatusPreconditionRequired,
+	http.StatusInternalServerError,
+}
+
+// add registers operation, adding the shared error responses for statuses.
+// Every operation reaches the database, so every one may answer 503 while
+// it is down.
+func (s *studentSpec) add(method string, path string, v1 bool, operation *Operation, errorStatuses ...int) {
+	operation.Tags = []string{"students"}
+	operation.Deprecated = v1
+	for _, status := range append(errorStatuses, http.StatusServiceUnavailable) {
+		operation.Responses[strconv.Itoa(status)] = s.jsonResponse(http.StatusText(status), refTo("Error"))
+	}
+	s.doc.addOperation(method, path, operation)
+}
+
+func (s *studentSpec) jsonResponse(description string, schema *Schema, headers ...*namedHeader) *Response {
+	resp := &Response{Description: description, Content: jsonContent(schema), Headers: map[string]*Header{}}
+	for _, header := range headers {
+		resp.Headers[header.name] = header.header
+	}
+	return resp
+}
+
+type namedHeader struct {
+	name   string
+	header *Header
+}
+
+func etagHeader() *namedHeader {
+	return &namedHeader{name: "ETag", header: &Header{Description: "Version of the representation", Schema: &Schema{Type: "string"}}}
+}
+
+func idParameter() *Parameter {
+	return &Parameter{Name: "id", In: "path", Required: true, Schema: stringSchema("uuid")}
+}
+
+func ifMatchParameter() *Parameter {
+	return &Parameter{
+		Name:        "If-Match",
+		In:          "header",
+		Required:    true,
+		Description: "The ETag of the version being changed",
+		Schema:      &Schema{Type: "string"},
+	}
+}
+
+func queryParameter(name string, schema *Schema, description string) *Parameter {
+	return &Parameter{Name: name, In: "query", Description: description, Schema: schema}
+}
+
+func listParameters() []*Parameter {
+	return []*Parameter{
+		queryParameter("major", &Schema{Type: "string"}, ""),
+		queryParameter("status", statusSchema(), ""),
+		queryParameter("enrolled_from", stringSchema("date"), ""),
+		queryParameter("enrolled_to", stringSchema("date"), ""),
+	}
+}
+
+func statusSchema() *Schema {
+	statuses := make([]string, len(entities.StudentStatuses))
+	for i, status := range entities.StudentStatuses {
+		statuses[i] = string(status)
+	}
+	return enumSchema(statuses...)
+}
@@ -0,0 +1,139 @@
+package openapi
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+)
+
+// Validate checks a decoded JSON value against schema and returns one message
+// per violation, each prefixed with the JSON path of the offending value.
+func (d *Document) Validate(schema *Schema, value interface{}) []string {
+	return d.validate(schema, value, "$")
+}
+
+func (d *Document) validate(schema *Schema, value interface{}, path string) []string {
+	if schema.Ref != "" {
+		resolved, ok := d.Components.Schemas[strings.TrimPrefix(schema.Ref, "#/components/schemas/")]
+		if !ok {
+			return []string{fmt.Sprintf("%s: unknown schema %s", path, schema.Ref)}
+		}
+		return d.validate(resolved, value, path)
+	}
+
+	if types := schemaTypes(schema); len(types) > 0 && !matchesAnyType(types, value) {
+		return []string{fmt.Sprintf("%s: expected %s, got %s", path, strings.Join(types, " or "), jsonType(value))}
+	}
+
+	var errs []string
+	switch v := value.(type) {
+	case string:
+		errs = append(errs, validateString(schema, v, path)...)
+	case float64:
+		if schema.Minimum != nil && v < *schema.Minimum {
+			errs = append(errs, fmt.Sprintf("%s: must be at least %v", path, *schema.Minimum))
+		}
+	case []interface{}:
+		if schema.Items != nil {
+			for i, item := range v {
+				errs = append(errs, d.validate(schema.Items, item, fmt.Sprintf("%s[%d]", path, i))...)
+			}
+		}
+	case map[string]interface{}:
+		for _, name := range schema.Required {
+			if _, ok := v[name]; !ok {
+				errs = append(errs, fmt.Sprintf("%s.%s: is required", path, name))
+			}
+		}
+
+		names := make([]string, 0, len(v))
+		for name := range v {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			if property, ok := schema.Properties[name]; ok {
+				errs = append(errs, d.validate(property, v[name], path+"."+name)...)
+			} else if schema.AdditionalProperties != nil {
+				errs = append(errs, d.validate(schema.AdditionalProperties, v[name], path+"."+name)...)
+			}
+		}
+	}
+	return errs
+}
+
+func validateString(schema *Schema, value string, path string) []string {
+	if schema.MinLength != nil && utf8.RuneCountInString(value) < *schema.MinLength {
+		return []string{fmt.Sprintf("%s: must be at least %d characters", path, *schema.MinLength)}
+	}
+	if len(schema.Enum) > 0 {
+		for _, allowed := range schema.Enum {
+			if allowed == value {
+				return nil
+			}
+		}
+		return []string{fmt.Sprintf("%s: must be one of %v", path, schema.Enum)}
+	}
+
+	var err error
+	switch schema.Format {
+	case "date":
+		_, err = time.Parse("2006-01-02", value)
+	case "date-time":
+		_, err = time.Parse(time.RFC3339, value)
+	case "uuid":
+		_, err = uuid.Parse(value)
+	}
+	if err != nil {
+		return []string{fmt.Sprintf("%s: is not a valid %s", path, schema.Format)}
+	}
+	return nil
+}
+
+func schemaTypes(schema *Schema) []string {
+	switch t := schema.Type.(type) {
+	case string:
+		return []string{t}
+	case []string:
+		return t
+	default:
+		return nil
+	}
+}
+
+func matchesAnyType(types []string, value interface{}) bool {
+	actual := jsonType(value)
+	for _, t := range types {
+		if t == actual || (t == "number" && actual == "integer") {
+			return true
+		}
+	}
+	return false
+}
+
+func jsonType(value interface{}) string {
+	switch v := value.(type) {
+	case nil:
+		return "null"
+	case bool:
+		return "boolean"
+	case string:
+		return "string"
+	case float64:
+		if v == math.Trunc(v) {
+			return "integer"
+		}
+		return "number"
+	case []interface{}:
+		return "array"
+	case map[string]interface{}:
+		return "object"
+	default:
+		return fmt.Sprintf("%T", value)
+	}
+}
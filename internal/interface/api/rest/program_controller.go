@@ -0,0 +1,137 @@
+package rest
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/tranvu1111/go-students-new/internal/application/interfaces"
+	"github.com/tranvu1111/go-students-new/internal/application/query"
+	"github.com/tranvu1111/go-students-new/internal/domain/entities"
+	"github.com/tranvu1111/go-students-new/internal/domain/repositories"
+	"github.com/tranvu1111/go-students-new/internal/interface/api/rest/dto/mapper"
+	"github.com/tranvu1111/go-students-new/internal/interface/api/rest/dto/request"
+)
+
+// ProgramController serves the program catalog. Programs are deactivated
+// rather than deleted, since students keep referring to them.
+type ProgramController struct {
+	service interfaces.ProgramService
+}
+
+func NewProgramController(r *gin.Engine, service interfaces.ProgramService) *ProgramController {
+	controller := &ProgramController{
+		service: service,
+	}
+
+	r.POST("/api/v1/programs", controller.CreateProgramController)
+	r.GET("/api/v1/programs", controller.GetAllProgramController)
+	r.GET("/api/v1/programs/:id", controller.GetProgramByIdController)
+	r.PUT("/api/v1/programs/:id", controller.PutProgramController)
+
+	return controller
+}
+
+func (pc *ProgramController) CreateProgramController(c *gin.Context) {
+	var createRequest request.CreateProgramRequest
+	if err := c.ShouldBindJSON(&createRequest); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"message": tr(c, "problem.invalid_request"), "error1": bindErrorDetail(c, err)})
+		return
+	}
+
+	result, err := pc.service.CreateProgram(c.Request.Context(), createRequest.ToCreateProgramCommand())
+	if err != nil {
+		writeProgramError(c, "problem.create_program_failed", err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, mapper.ToProgramResponse(result.Result))
+}
+
+// GetAllProgramController filters by the active, department and degree_level
+// query parameters.
+func (pc *ProgramController) GetAllProgramController(c *gin.Context) {
+	var listQuery query.ProgramListQuery
+	if value := c.Query("active"); value != "" {
+		active, err := strconv.ParseBool(value)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": tr(c, "problem.invalid_active"), "context": err.Error()})
+			return
+		}
+		listQuery.Active = &active
+	}
+	listQuery.Department = c.Query("department")
+	if value := c.Query("degree_level"); value != "" {
+		if !entities.DegreeLevel(value).IsValid() {
+			c.JSON(http.StatusBadRequest, gin.H{"error": tr(c, "problem.invalid_degree_level"), "context": value})
+			return
+		}
+		listQuery.DegreeLevel = value
+	}
+
+	programs, err := pc.service.FindAllPrograms(c.Request.Context(), &listQuery)
+	if err != nil {
+		writeProgramError(c, "problem.load_programs_failed", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, mapper.ToProgramListResponse(programs.Result))
+}
+
+func (pc *ProgramController) GetProgramByIdController(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": tr(c, "problem.invalid_program_id"), "context": err.Error()})
+		return
+	}
+
+	program, err := pc.service.FindProgramById(c.Request.Context(), id)
+	if err != nil {
+		writeProgramError(c, "problem.load_program_failed", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, mapper.ToProgramResponse(program.Result))
+}
+
+func (pc *ProgramController) PutProgramController(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": tr(c, "problem.invalid_program_id"), "context": err.Error()})
+		return
+	}
+
+	var updateRequest request.UpdateProgramRequest
+	if err := c.ShouldBindJSON(&updateRequest); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"message": tr(c, "problem.invalid_request"), "error1": bindErrorDetail(c, err)})
+		return
+	}
+
+	result, err := pc.service.UpdateProgram(c.Request.Context(), updateRequest.ToUpdateProgramCommand(id))
+	if err != nil {
+		writeProgramError(c, "problem.update_program_failed", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, mapper.ToProgramResponse(result.Result))
+}
+
+// writeProgramError maps the catalog errors like writeStudentError maps those
+// of students.
+func writeProgramError(c *gin.Context, code string, err error) {
+	switch {
+	case errors.Is(err, repositories.ErrProgramNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": tr(c, "problem.program_not_found"), "content": errorDetail(c, err)})
+	case errors.Is(err, repositories.ErrDuplicateProgramCode):
+		c.JSON(http.StatusConflict, gin.H{"error": tr(c, code), "content": errorDetail(c, err)})
+	case errors.Is(err, entities.ErrInvalidProgram):
+		c.JSON(http.StatusUnprocessableEntity, gin.H{"error": tr(c, code), "content": errorDetail(c, err)})
+	case errors.Is(err, repositories.ErrUnavailable):
+		c.Header("Retry-After", "10")
+		c.JSON(http.StatusServiceUnavailable, gin.H{"error": tr(c, code), "content": errorDetail(c, err)})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": tr(c, code), "content": errorDetail(c, err)})
+	}
+}
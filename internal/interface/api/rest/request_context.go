@@ -0,0 +1,38 @@
+package rest
+
+import (
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/tranvu1111/go-students-new/internal/domain/entities"
+	"github.com/tranvu1111/go-students-new/internal/domain/repositories"
+)
+
+const (
+	ActorHeader = "X-Actor"
+	RequestIDHeader = "X-Request-ID"
+	ConsistentReadHeader = "X-Consistent-Read"
+)
+
+// RequestContextMiddleware records who made the request and under which
+// request ID, so that audit entries written further down can be attributed.
+// A request ID is generated when the caller does not send one.
+//
+// It also starts the read session of the request: reads go to the primary
+// database once the request wrote, or throughout with X-Consistent-Read: true.
+func RequestContextMiddleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		requestID := c.GetHeader(RequestIDHeader)
+		if requestID == "" {
+			requestID = uuid.NewString()
+		}
+		c.Header(RequestIDHeader, requestID)
+
+		consistent, _ := strconv.ParseBool(c.GetHeader(ConsistentReadHeader))
+		ctx := entities.ContextWithAuditActor(c.Request.Context(), c.GetHeader(ActorHeader), requestID)
+		ctx = repositories.ContextWithReadSession(ctx, consistent)
+		c.Request = c.Request.WithContext(ctx)
+		c.Next()
+	}
+}
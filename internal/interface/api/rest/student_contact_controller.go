@@ -0,0 +1,154 @@
+package rest
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/tranvu1111/go-students-new/internal/application/command"
+	"github.com/tranvu1111/go-students-new/internal/domain/entities"
+	"github.com/tranvu1111/go-students-new/internal/interface/api/rest/dto/mapper"
+	"github.com/tranvu1111/go-students-new/internal/interface/api/rest/dto/request"
+)
+
+// contactPaths are the nested collections under /students/:id/contacts.
+var contactPaths = []struct {
+	segment string
+	kind    entities.ContactKind
+}{
+	{"addresses", entities.ContactKindAddress},
+	{"emails", entities.ContactKindEmail},
+	{"phones", entities.ContactKindPhone},
+	{"emergency-contacts", entities.ContactKindEmergencyContact},
+}
+
+type contactRequest interface {
+	ToSaveStudentContactCommand(studentID uuid.UUID, contactID uuid.UUID) *command.SaveStudentContactCommand
+}
+
+func registerContactRoutes(group *gin.RouterGroup, controller *StudentController) {
+	group.GET("/:id/contacts", controller.GetStudentContactsController)
+	for _, path := range contactPaths {
+		group.POST("/:id/contacts/"+path.segment, controller.SaveStudentContactController(path.kind))
+		group.PUT("/:id/contacts/"+path.segment+"/:contactId", controller.SaveStudentContactController(path.kind))
+		group.DELETE("/:id/contacts/"+path.segment+"/:contactId", controller.RemoveStudentContactController(path.kind))
+	}
+}
+
+// GetStudentContactsController returns the addresses, emails, phones and
+// emergency contacts of a student with the student's ETag, which the writes
+// below expect in If-Match.
+func (sc *StudentController) GetStudentContactsController(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": tr(c, "problem.invalid_student_id"), "context": err.Error()})
+		return
+	}
+
+	student, err := sc.service.FindStudentById(c.Request.Context(), id)
+	if err != nil {
+		writeStudentError(c, "problem.load_contacts_failed", err)
+		return
+	}
+
+	if sc.notModified(c, studentETag(student.Result), student.Result.UpdatedAt) {
+		return
+	}
+
+	c.JSON(http.StatusOK, mapper.ToStudentContactsResponse(student.Result))
+}
+
+// SaveStudentContactController adds a contact of the kind on POST and
+// replaces the one named by :contactId on PUT.
+func (sc *StudentController) SaveStudentContactController(kind entities.ContactKind) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id, contactID, ok := parseContactPath(c)
+		if !ok {
+			return
+		}
+
+		expectedVersion, ok := requireIfMatch(c)
+		if !ok {
+			return
+		}
+
+		var contactRequest contactRequest
+		switch kind {
+		case entities.ContactKindAddress:
+			contactRequest = &request.AddressRequest{}
+		case entities.ContactKindEmail:
+			contactRequest = &request.ContactEmailRequest{}
+		case entities.ContactKindPhone:
+			contactRequest = &request.ContactPhoneRequest{}
+		default:
+			contactRequest = &request.EmergencyContactRequest{}
+		}
+		if err := c.ShouldBindJSON(contactRequest); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"message": tr(c, "problem.invalid_request"), "error1": bindErrorDetail(c, err)})
+			return
+		}
+
+		contactCommand := contactRequest.ToSaveStudentContactCommand(id, contactID)
+		contactCommand.ExpectedVersion = expectedVersion
+
+		result, err := sc.service.SaveStudentContact(c.Request.Context(), contactCommand)
+		if err != nil {
+			writeStudentError(c, "problem.save_contact_failed", err)
+			return
+		}
+
+		status := http.StatusOK
+		if contactID == uuid.Nil {
+			status = http.StatusCreated
+		}
+		setStudentETag(c, result.Result)
+		c.JSON(status, mapper.ToStudentContactsResponse(result.Result))
+	}
+}
+
+func (sc *StudentController) RemoveStudentContactController(kind entities.ContactKind) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id, contactID, ok := parseContactPath(c)
+		if !ok {
+			return
+		}
+
+		expectedVersion, ok := requireIfMatch(c)
+		if !ok {
+			return
+		}
+
+		result, err := sc.service.RemoveStudentContact(c.Request.Context(), &command.RemoveStudentContactCommand{
+			StudentId:       id,
+			Kind:            kind,
+			ContactId:       contactID,
+			ExpectedVersion: expectedVersion,
+		})
+		if err != nil {
+			writeStudentError(c, "problem.remove_contact_failed", err)
+			return
+		}
+
+		setStudentETag(c, result.Result)
+		c.JSON(http.StatusOK, mapper.ToStudentContactsResponse(result.Result))
+	}
+}
+
+// parseContactPath reads :id and, on the routes that have one, :contactId.
+func parseContactPath(c *gin.Context) (uuid.UUID, uuid.UUID, bool) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": tr(c, "problem.invalid_student_id"), "context": err.Error()})
+		return uuid.Nil, uuid.Nil, false
+	}
+
+	if c.Param("contactId") == "" {
+		return id, uuid.Nil, true
+	}
+	contactID, err := uuid.Parse(c.Param("contactId"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": tr(c, "problem.invalid_contact_id"), "context": err.Error()})
+		return uuid.Nil, uuid.Nil, false
+	}
+	return id, contactID, true
+}
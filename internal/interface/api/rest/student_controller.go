@@ -3,6 +3,8 @@ package rest
 import (
 	"fmt"
 	"net/http"
+	"strconv"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
@@ -17,19 +19,50 @@ import (
 )
 
 type StudentController struct {
-	service interfaces.StudentService
+	service 		interfaces.StudentService
+	cacheControl 	string
 }
 
-func NewStudentController(r *gin.Engine, service interfaces.StudentService) *StudentController {
+func NewStudentController(r *gin.Engine, service interfaces.StudentService, options ...StudentControllerOption) *StudentController {
 	controller := &StudentController{
 		service: service,
+		cacheControl: DefaultCacheControl,
 	}
-	// API group with JWT authentication
-	r.POST("/api/v1/students", controller.CreateStudentController)
-	r.GET("/api/v1/students", controller.GetAllStudentController)
-	r.GET("/api/v1/students/:id", controller.GetStudentByIdController)
-	r.PUT("/api/v1/students", controller.PutStudentController)
-	// r.DELETE("/api/v1/students/:id", controller.DeleteStudentController)
+	for _, option := range options {
+		option(controller)
+	}
+	// v1 is kept for existing clients; new clients should use v2.
+	v1 := r.Group("/api/v1/students", DeprecatedRoutes(studentsV2Path))
+	v1.POST("", controller.CreateStudentController)
+	v1.POST("/import", controller.ImportStudentsController)
+	v1.GET("", controller.GetAllStudentController)
+	v1.GET("/export", controller.ExportStudentsController)
+	v1.GET("/search", controller.SearchStudentsController)
+	v1.GET("/:id", controller.GetStudentByIdController)
+	v1.PUT("", controller.PutStudentController)
+	v1.PATCH("/:id", controller.PatchStudentController)
+	v1.DELETE("/:id", controller.DeleteStudentController)
+	v1.POST("/:id/restore", controller.RestoreStudentController)
+	v1.GET("/:id/history", controller.GetStudentHistoryController)
+	v1.POST("/:id/transitions", controller.TransitionStudentStatusController)
+	v1.GET("/:id/transitions", controller.GetStudentStatusHistoryController)
+	registerContactRoutes(v1, controller)
+
+	v2 := r.Group(studentsV2Path)
+	v2.POST("", controller.CreateStudentV2Controller)
+	v2.POST("/import", controller.ImportStudentsController)
+	v2.GET("", controller.GetAllStudentV2Controller)
+	v2.GET("/export", controller.ExportStudentsController)
+	v2.GET("/search", controller.SearchStudentsController)
+	v2.GET("/:id", controller.GetStudentByIdController)
+	v2.PUT("/:id", controller.ReplaceStudentController)
+	v2.PATCH("/:id", controller.PatchStudentController)
+	v2.DELETE("/:id", controller.DeleteStudentV2Controller)
+	v2.POST("/:id/restore", controller.RestoreStudentController)
+	v2.GET("/:id/history", controller.GetStudentHistoryController)
+	v2.POST("/:id/transitions", controller.TransitionStudentStatusController)
+	v2.GET("/:id/transitions", controller.GetStudentStatusHistoryController)
+	registerContactRoutes(v2, controller)
 
 	return controller
 }
@@ -40,31 +73,48 @@ func (sc *StudentController) CreateStudentController( c *gin.Context )  {
 	var createStudentRequest request.CreateStudentRequest
 
 	if err := c.ShouldBindJSON(&createStudentRequest); err != nil {
-		c.JSON(http.StatusBadRequest,gin.H{"message": "Invalid request","error1":err.Error()} )
+		c.JSON(http.StatusBadRequest,gin.H{"message": tr(c, "problem.invalid_request"),"error1": bindErrorDetail(c, err)} )
 		return
 	}
 
 	createStudentCommand ,err := createStudentRequest.ToCreateStudentCommand()
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid seller Id format"})
+		c.JSON(http.StatusBadRequest, gin.H{"error": tr(c, "problem.invalid_request")})
 		return
 	}
 
-	commandStudentResult, err := sc.service.CreateStudent(createStudentCommand)
+	commandStudentResult, err := sc.service.CreateStudent(c.Request.Context(), createStudentCommand)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to create student", "content":err.Error()})
+		writeStudentError(c, "problem.create_student_failed", err)
 		return 
 	}
 	fmt.Printf("result : %v", commandStudentResult.Result.StudentID)
 
 	response := mapper.ToStudentResponse(commandStudentResult.Result)
+	setStudentETag(c, commandStudentResult.Result)
 	c.JSON(http.StatusCreated, gin.H{"message ": "Create a student successfully", "student" : response } )
 }
 
 func (sc *StudentController) GetAllStudentController(c *gin.Context) {
-	sellers , err := sc.service.FindAllStudent()
+	var listRequest request.StudentListRequest
+	if err := c.ShouldBindQuery(&listRequest); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"message": tr(c, "problem.invalid_request"), "error1": bindErrorDetail(c, err)})
+		return
+	}
+
+	listQuery, err := listRequest.ToStudentListQuery()
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"message": tr(c, "problem.invalid_request"), "error1": bindErrorDetail(c, err)})
+		return
+	}
+
+	sellers , err := sc.service.FindAllStudent(c.Request.Context(), listQuery)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error":"Failed to load all students", "content":err.Error()})
+		writeStudentError(c, "problem.load_students_failed", err)
+		return
+	}
+
+	if sc.notModified(c, studentListETag(sellers.Result), time.Time{}) {
 		return
 	}
 
@@ -75,27 +125,28 @@ func (sc *StudentController) GetAllStudentController(c *gin.Context) {
 }
 
 func (sc *StudentController) GetStudentByIdController(c *gin.Context) {
-	idRaw := c.Request.URL.Path[len("/api/v1/students/"):]
-
-	id, err := uuid.Parse(idRaw)
+	id, err := uuid.Parse(c.Param("id"))
 	if err != nil{
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid student Id format", "context" :err.Error()})
+		c.JSON(http.StatusBadRequest, gin.H{"error": tr(c, "problem.invalid_student_id"), "context" :err.Error()})
 		return
 	}
 
-	student , err := sc.service.FindStudentById(id)
+	student , err := sc.service.FindStudentById(c.Request.Context(), id)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error" : "Faild to find the student by their ID", "content" : err.Error()})
+		writeStudentError(c, "problem.find_student_failed", err)
 		return
 	}
 
 	if student == nil {
-		c.JSON(http.StatusNotFound, gin.H{"error" : "Student not found"})
+		c.JSON(http.StatusNotFound, gin.H{"error": tr(c, "problem.student_not_found")})
 		return
 	}
 
-	response := mapper.ToStudentResponse(student.Result)
+	if sc.notModified(c, studentETag(student.Result), student.Result.UpdatedAt) {
+		return
+	}
 
+	response := mapper.ToStudentResponse(student.Result)
 	c.JSON(http.StatusOK, response)
 	
 }
@@ -103,25 +154,97 @@ func (sc *StudentController) GetStudentByIdController(c *gin.Context) {
 func (sc *StudentController) PutStudentController(c *gin.Context) {
 	var updateRequest request.UpdateStudentResquest
 
+	expectedVersion, ok := requireIfMatch(c)
+	if !ok {
+		return
+	}
+
 	if err := c.ShouldBindJSON(&updateRequest); err != nil {
-		c.JSON(http.StatusBadRequest,gin.H{"message": "Invalid request","error1":err.Error()} )
+		c.JSON(http.StatusBadRequest,gin.H{"message": tr(c, "problem.invalid_request"),"error1": bindErrorDetail(c, err)} )
 		return
 	}
 
 	updateStudentCommand , err := updateRequest.ToUpdateStudentCommand()
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error" : "Failed to create a update student command", "content" : err.Error() })
+		c.JSON(http.StatusBadRequest, gin.H{"error": tr(c, "problem.invalid_student_update"), "content": errorDetail(c, err) })
 		return
 	}
+	updateStudentCommand.ExpectedVersion = expectedVersion
 
-	commandResult , err := sc.service.UpdateStudent(updateStudentCommand)
+	commandResult , err := sc.service.UpdateStudent(c.Request.Context(), updateStudentCommand)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error":"Failed to update student (in service)" , "content" : err.Error()})
+		writeStudentError(c, "problem.update_student_failed", err)
+		return
 	}
 
 	response := mapper.ToStudentResponse(commandResult.Result)
+	setStudentETag(c, commandResult.Result)
 	c.JSON(http.StatusOK, response)
 	
 
 }
 
+func (sc *StudentController) DeleteStudentController(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": tr(c, "problem.invalid_student_id"), "context" :err.Error()})
+		return
+	}
+
+	expectedVersion, ok := requireIfMatch(c)
+	if !ok {
+		return
+	}
+
+	if err := sc.service.DeleteStudent(c.Request.Context(), id, expectedVersion); err != nil {
+		writeStudentError(c, "problem.delete_student_failed", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": tr(c, "message.student_deleted")})
+}
+
+func (sc *StudentController) RestoreStudentController(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": tr(c, "problem.invalid_student_id"), "context" :err.Error()})
+		return
+	}
+
+	student, err := sc.service.RestoreStudent(c.Request.Context(), id)
+	if err != nil {
+		writeStudentError(c, "problem.restore_student_failed", err)
+		return
+	}
+
+	setStudentETag(c, student.Result)
+	c.JSON(http.StatusOK, mapper.ToStudentResponse(student.Result))
+}
+
+func (sc *StudentController) GetStudentHistoryController(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": tr(c, "problem.invalid_student_id"), "context" :err.Error()})
+		return
+	}
+
+	page, err := strconv.Atoi(c.DefaultQuery("page", "1"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": tr(c, "problem.invalid_page"), "context": err.Error()})
+		return
+	}
+
+	pageSize, err := strconv.Atoi(c.DefaultQuery("page_size", "0"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": tr(c, "problem.invalid_page_size"), "context": err.Error()})
+		return
+	}
+
+	history, err := sc.service.FindStudentHistory(c.Request.Context(), id, page, pageSize)
+	if err != nil {
+		writeStudentError(c, "problem.load_student_history_failed", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, mapper.ToStudentHistoryResponse(history))
+}
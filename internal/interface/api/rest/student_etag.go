@@ -0,0 +1,69 @@
+package rest
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/tranvu1111/go-students-new/internal/application/common"
+	"github.com/tranvu1111/go-students-new/internal/domain/entities"
+	"github.com/tranvu1111/go-students-new/internal/domain/repositories"
+)
+
+// studentETag is a strong entity tag naming the student's version, e.g. "3".
+func studentETag(student *common.StudentResult) string {
+	return `"` + strconv.Itoa(student.Version) + `"`
+}
+
+func setStudentETag(c *gin.Context, student *common.StudentResult) {
+	c.Header("ETag", studentETag(student))
+}
+
+// requireIfMatch returns the version named by If-Match. Writes must name the
+// version they were based on, so a missing or unusable header is rejected.
+func requireIfMatch(c *gin.Context) (int, bool) {
+	ifMatch := strings.TrimSpace(c.GetHeader("If-Match"))
+	if ifMatch == "" {
+		c.JSON(http.StatusPreconditionRequired, gin.H{"error": tr(c, "problem.if_match_required"), "content": tr(c, "problem.if_match_send_etag")})
+		return 0, false
+	}
+
+	version, err := strconv.Atoi(strings.Trim(ifMatch, `"`))
+	if err != nil || version < 1 || !strings.HasPrefix(ifMatch, `"`) || !strings.HasSuffix(ifMatch, `"`) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": tr(c, "problem.if_match_invalid"), "content": tr(c, "problem.if_match_expected")})
+		return 0, false
+	}
+	return version, true
+}
+
+// writeStudentError maps the errors of a student write to a status code. code
+// names the catalog title of the response, e.g. "problem.create_student_failed".
+func writeStudentError(c *gin.Context, code string, err error) {
+	var conflict *repositories.VersionConflictError
+	switch {
+	case errors.As(err, &conflict):
+		if conflict.CurrentVersion > 0 {
+			c.Header("ETag", `"`+strconv.Itoa(conflict.CurrentVersion)+`"`)
+		}
+		c.JSON(http.StatusPreconditionFailed, gin.H{"error": tr(c, "problem.student_modified"), "content": errorDetail(c, err)})
+	case errors.Is(err, repositories.ErrStudentNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": tr(c, "problem.student_not_found"), "content": errorDetail(c, err)})
+	case errors.Is(err, entities.ErrContactNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": tr(c, "problem.contact_not_found"), "content": errorDetail(c, err)})
+	case errors.Is(err, repositories.ErrDuplicateEmail):
+		c.JSON(http.StatusConflict, gin.H{"error": tr(c, code), "content": errorDetail(c, err)})
+	case errors.Is(err, entities.ErrStatusTransitionNotAllowed):
+		c.JSON(http.StatusConflict, gin.H{"error": tr(c, code), "content": errorDetail(c, err)})
+	case errors.Is(err, entities.ErrInvalidStudent), errors.Is(err, entities.ErrInvalidPatch), errors.Is(err, entities.ErrInvalidStatusChange),
+		errors.Is(err, entities.ErrInvalidProgramDeclaration), errors.Is(err, entities.ErrInvalidContact),
+		errors.Is(err, entities.ErrInvalidVerificationToken):
+		c.JSON(http.StatusUnprocessableEntity, gin.H{"error": tr(c, code), "content": errorDetail(c, err)})
+	case errors.Is(err, repositories.ErrUnavailable):
+		c.Header("Retry-After", "10")
+		c.JSON(http.StatusServiceUnavailable, gin.H{"error": tr(c, code), "content": errorDetail(c, err)})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": tr(c, code), "content": errorDetail(c, err)})
+	}
+}
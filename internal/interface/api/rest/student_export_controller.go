@@ -0,0 +1,74 @@
+package rest
+
+import (
+	"log"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/tranvu1111/go-students-new/internal/application/common"
+	"github.com/tranvu1111/go-students-new/internal/interface/api/rest/dto/mapper"
+	"github.com/tranvu1111/go-students-new/internal/interface/api/rest/dto/request"
+	"github.com/tranvu1111/go-students-new/internal/interface/api/rest/export"
+)
+
+// exportFlushEvery bounds how many rows are buffered before they are pushed
+// to the client.
+const exportFlushEvery = 500
+
+// ExportStudentsController streams the filtered students as
+// ?format=csv|ndjson|xlsx, optionally restricted to ?columns=A,B.
+func (sc *StudentController) ExportStudentsController(c *gin.Context) {
+	format, err := export.LookupFormat(c.DefaultQuery("format", "csv"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": tr(c, "problem.invalid_format"), "context": err.Error()})
+		return
+	}
+
+	columns, err := export.SelectColumns(c.Query("columns"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": tr(c, "problem.invalid_columns"), "context": err.Error()})
+		return
+	}
+
+	var listRequest request.StudentListRequest
+	if err := c.ShouldBindQuery(&listRequest); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"message": tr(c, "problem.invalid_request"), "error1": bindErrorDetail(c, err)})
+		return
+	}
+	listQuery, err := listRequest.ToStudentListQuery()
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"message": tr(c, "problem.invalid_request"), "error1": bindErrorDetail(c, err)})
+		return
+	}
+
+	c.Header("Content-Type", format.ContentType)
+	c.Header("Content-Disposition", `attachment; filename="students.`+format.Extension+`"`)
+	c.Status(http.StatusOK)
+
+	writer := format.NewWriter(c.Writer)
+	if err := writer.WriteHeader(columns); err != nil {
+		log.Printf("student export: %v", err)
+		return
+	}
+
+	rows := 0
+	err = sc.service.ExportStudents(c.Request.Context(), listQuery, func(student *common.StudentResult) error {
+		if err := writer.WriteRow(export.RowValues(mapper.ToStudentResponse(student), columns)); err != nil {
+			return err
+		}
+		if rows++; rows%exportFlushEvery == 0 {
+			c.Writer.Flush()
+		}
+		return nil
+	})
+	if err != nil {
+		// Headers are already sent, so the client sees a truncated file.
+		log.Printf("student export: %v", err)
+		c.Abort()
+		return
+	}
+
+	if err := writer.Close(); err != nil {
+		log.Printf("student export: %v", err)
+	}
+}
@@ -0,0 +1,82 @@
+package rest
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/tranvu1111/go-students-new/internal/application/command"
+	"github.com/tranvu1111/go-students-new/internal/domain/repositories"
+	"github.com/tranvu1111/go-students-new/internal/interface/api/rest/dto/mapper"
+	"github.com/tranvu1111/go-students-new/internal/interface/api/rest/dto/request"
+)
+
+// ImportStudentsController streams CSV or NDJSON rows from the request body.
+// The format comes from ?format= or else the Content-Type. Query parameters:
+// mode=all_or_nothing|best_effort, dry_run=true and batch_size=N.
+func (sc *StudentController) ImportStudentsController(c *gin.Context) {
+	dryRun, err := strconv.ParseBool(c.DefaultQuery("dry_run", "false"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": tr(c, "problem.invalid_dry_run"), "context": err.Error()})
+		return
+	}
+
+	batchSize, err := strconv.Atoi(c.DefaultQuery("batch_size", "0"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": tr(c, "problem.invalid_batch_size"), "context": err.Error()})
+		return
+	}
+
+	var rows command.StudentRowReader
+	switch importFormat(c) {
+	case "csv":
+		csvReader, err := request.NewCSVStudentReader(c.Request.Body)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": tr(c, "problem.invalid_csv"), "context": err.Error()})
+			return
+		}
+		rows = csvReader
+	case "ndjson":
+		rows = request.NewNDJSONStudentReader(c.Request.Body)
+	default:
+		c.JSON(http.StatusUnsupportedMediaType, gin.H{"error": tr(c, "problem.import_format_invalid")})
+		return
+	}
+
+	result, err := sc.service.ImportStudents(c.Request.Context(), &command.ImportStudentsCommand{
+		Rows: rows,
+		Mode: command.ImportMode(c.DefaultQuery("mode", string(command.ImportModeAllOrNothing))),
+		DryRun: dryRun,
+		BatchSize: batchSize,
+	})
+	if errors.Is(err, repositories.ErrUnavailable) {
+		writeStudentError(c, "problem.import_students_failed", err)
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": tr(c, "problem.import_students_failed"), "content": errorDetail(c, err)})
+		return
+	}
+
+	status := http.StatusOK
+	if !result.DryRun && !result.Committed {
+		status = http.StatusUnprocessableEntity
+	}
+	c.JSON(status, mapper.ToImportStudentsResponse(result))
+}
+
+func importFormat(c *gin.Context) string {
+	if format := c.Query("format"); format != "" {
+		return strings.ToLower(format)
+	}
+
+	switch c.ContentType() {
+	case "text/csv":
+		return "csv"
+	case "application/x-ndjson", "application/jsonl":
+		return "ndjson"
+	}
+	return ""
+}
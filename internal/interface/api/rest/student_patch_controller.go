@@ -0,0 +1,103 @@
+package rest
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/tranvu1111/go-students-new/internal/domain/repositories"
+	"github.com/tranvu1111/go-students-new/internal/interface/api/rest/dto/mapper"
+	"github.com/tranvu1111/go-students-new/internal/interface/api/rest/dto/request"
+	"github.com/tranvu1111/go-students-new/internal/interface/api/rest/jsonpatch"
+)
+
+var acceptPatch = strings.Join([]string{jsonpatch.MergePatchContentType, jsonpatch.JSONPatchContentType}, ", ")
+
+// PatchStudentController changes only the fields named in the patch. It takes
+// an RFC 7396 merge patch (also under plain application/json) or an RFC 6902
+// JSON Patch, which is applied to the student's current representation.
+//
+// That representation is read like the version PatchStudent checks, never
+// from a replica or cache, and must have the If-Match version: a test op or
+// an array edit run against an older snapshot would overwrite newer data.
+func (sc *StudentController) PatchStudentController(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": tr(c, "problem.invalid_student_id"), "context": err.Error()})
+		return
+	}
+
+	expectedVersion, ok := requireIfMatch(c)
+	if !ok {
+		return
+	}
+
+	body, err := io.ReadAll(c.Request.Body)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"message": tr(c, "problem.invalid_request"), "error1": bindErrorDetail(c, err)})
+		return
+	}
+
+	ctx := repositories.ContextForWrite(c.Request.Context())
+	var mergePatch []byte
+	switch c.ContentType() {
+	case jsonpatch.MergePatchContentType, "application/json":
+		mergePatch = body
+	case jsonpatch.JSONPatchContentType:
+		current, err := sc.service.FindStudentById(ctx, id)
+		if err != nil {
+			writeStudentError(c, "problem.patch_student_failed", err)
+			return
+		}
+		if current.Result.Version != expectedVersion {
+			writeStudentError(c, "problem.patch_student_failed", &repositories.VersionConflictError{
+				StudentID: id, ExpectedVersion: expectedVersion, CurrentVersion: current.Result.Version,
+			})
+			return
+		}
+
+		document, err := json.Marshal(mapper.ToStudentResponse(current.Result))
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": tr(c, "problem.patch_student_failed"), "content": errorDetail(c, err)})
+			return
+		}
+		patched, err := jsonpatch.Apply(document, body)
+		if err != nil {
+			c.JSON(http.StatusUnprocessableEntity, gin.H{"error": tr(c, "problem.apply_json_patch_failed"), "content": errorDetail(c, err)})
+			return
+		}
+		if mergePatch, err = jsonpatch.CreateMergePatch(document, patched); err != nil {
+			c.JSON(http.StatusUnprocessableEntity, gin.H{"error": tr(c, "problem.apply_json_patch_failed"), "content": errorDetail(c, err)})
+			return
+		}
+	default:
+		c.Header("Accept-Patch", acceptPatch)
+		c.JSON(http.StatusUnsupportedMediaType, gin.H{"error": tr(c, "problem.unsupported_patch_format"), "content": c.ContentType()})
+		return
+	}
+
+	var studentPatch request.StudentMergePatch
+	if err := json.Unmarshal(mergePatch, &studentPatch); err != nil || studentPatch == nil {
+		c.JSON(http.StatusBadRequest, gin.H{"message": tr(c, "problem.invalid_request"), "error1": "a merge patch must be a JSON object"})
+		return
+	}
+
+	patchCommand, err := studentPatch.ToPatchStudentCommand(id)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"message": tr(c, "problem.invalid_request"), "error1": bindErrorDetail(c, err)})
+		return
+	}
+	patchCommand.ExpectedVersion = expectedVersion
+
+	result, err := sc.service.PatchStudent(ctx, patchCommand)
+	if err != nil {
+		writeStudentError(c, "problem.patch_student_failed", err)
+		return
+	}
+
+	setStudentETag(c, result.Result)
+	c.JSON(http.StatusOK, mapper.ToStudentResponse(result.Result))
+}
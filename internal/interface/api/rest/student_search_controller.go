@@ -0,0 +1,33 @@
+package rest
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/tranvu1111/go-students-new/internal/interface/api/rest/dto/mapper"
+	"github.com/tranvu1111/go-students-new/internal/interface/api/rest/dto/request"
+)
+
+// SearchStudentsController ranks students by ?q= over their names, email and
+// major, tolerating small typos. ?limit= caps the number of results.
+func (sc *StudentController) SearchStudentsController(c *gin.Context) {
+	var searchRequest request.StudentSearchRequest
+	if err := c.ShouldBindQuery(&searchRequest); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"message": tr(c, "problem.invalid_request"), "error1": bindErrorDetail(c, err)})
+		return
+	}
+
+	searchQuery, err := searchRequest.ToStudentSearchQuery()
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"message": tr(c, "problem.invalid_request"), "error1": bindErrorDetail(c, err)})
+		return
+	}
+
+	searchResult, err := sc.service.SearchStudents(c.Request.Context(), searchQuery)
+	if err != nil {
+		writeStudentError(c, "problem.search_students_failed", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, mapper.ToStudentSearchResponse(searchResult))
+}
@@ -0,0 +1,72 @@
+package rest
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/tranvu1111/go-students-new/internal/interface/api/rest/dto/mapper"
+	"github.com/tranvu1111/go-students-new/internal/interface/api/rest/dto/request"
+)
+
+// TransitionStudentStatusController moves a student to another status. A
+// transition the lifecycle forbids is a 409, a malformed one a 422.
+func (sc *StudentController) TransitionStudentStatusController(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": tr(c, "problem.invalid_student_id"), "context": err.Error()})
+		return
+	}
+
+	expectedVersion, ok := requireIfMatch(c)
+	if !ok {
+		return
+	}
+
+	var transitionRequest request.TransitionStudentStatusRequest
+	if err := c.ShouldBindJSON(&transitionRequest); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"message": tr(c, "problem.invalid_request"), "error1": bindErrorDetail(c, err)})
+		return
+	}
+
+	transitionCommand := transitionRequest.ToTransitionStudentStatusCommand(id)
+	transitionCommand.ExpectedVersion = expectedVersion
+
+	result, err := sc.service.TransitionStudentStatus(c.Request.Context(), transitionCommand)
+	if err != nil {
+		writeStudentError(c, "problem.change_status_failed", err)
+		return
+	}
+
+	setStudentETag(c, result.Result)
+	c.JSON(http.StatusOK, mapper.ToStudentResponse(result.Result))
+}
+
+func (sc *StudentController) GetStudentStatusHistoryController(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": tr(c, "problem.invalid_student_id"), "context": err.Error()})
+		return
+	}
+
+	page, err := strconv.Atoi(c.DefaultQuery("page", "1"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": tr(c, "problem.invalid_page"), "context": err.Error()})
+		return
+	}
+
+	pageSize, err := strconv.Atoi(c.DefaultQuery("page_size", "0"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": tr(c, "problem.invalid_page_size"), "context": err.Error()})
+		return
+	}
+
+	history, err := sc.service.FindStudentStatusHistory(c.Request.Context(), id, page, pageSize)
+	if err != nil {
+		writeStudentError(c, "problem.load_status_history_failed", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, mapper.ToStudentStatusHistoryResponse(history))
+}
@@ -0,0 +1,124 @@
+package rest
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/tranvu1111/go-students-new/internal/interface/api/rest/dto/mapper"
+	"github.com/tranvu1111/go-students-new/internal/interface/api/rest/dto/request"
+	"github.com/tranvu1111/go-students-new/internal/interface/api/rest/dto/response"
+)
+
+const studentsV2Path = "/api/v2/students"
+
+// The v2 handlers below differ from v1 only in status codes and in returning
+// bare resources; the routes they share with v1 reuse the v1 handlers.
+
+func (sc *StudentController) CreateStudentV2Controller(c *gin.Context) {
+	var createStudentRequest request.CreateStudentRequest
+	if err := c.ShouldBindJSON(&createStudentRequest); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"message": tr(c, "problem.invalid_request"), "error1": bindErrorDetail(c, err)})
+		return
+	}
+
+	createStudentCommand, err := createStudentRequest.ToCreateStudentCommand()
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"message": tr(c, "problem.invalid_request"), "error1": bindErrorDetail(c, err)})
+		return
+	}
+
+	result, err := sc.service.CreateStudent(c.Request.Context(), createStudentCommand)
+	if err != nil {
+		writeStudentError(c, "problem.create_student_failed", err)
+		return
+	}
+
+	c.Header("Location", studentsV2Path+"/"+result.Result.StudentID.String())
+	setStudentETag(c, result.Result)
+	c.JSON(http.StatusCreated, mapper.ToStudentResponse(result.Result))
+}
+
+func (sc *StudentController) GetAllStudentV2Controller(c *gin.Context) {
+	var listRequest request.StudentListRequest
+	if err := c.ShouldBindQuery(&listRequest); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"message": tr(c, "problem.invalid_request"), "error1": bindErrorDetail(c, err)})
+		return
+	}
+
+	listQuery, err := listRequest.ToStudentListQuery()
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"message": tr(c, "problem.invalid_request"), "error1": bindErrorDetail(c, err)})
+		return
+	}
+
+	students, err := sc.service.FindAllStudent(c.Request.Context(), listQuery)
+	if err != nil {
+		writeStudentError(c, "problem.load_students_failed", err)
+		return
+	}
+
+	if sc.notModified(c, studentListETag(students.Result), time.Time{}) {
+		return
+	}
+
+	body := make([]*response.StudentResponse, 0, len(students.Result))
+	for _, student := range students.Result {
+		body = append(body, mapper.ToStudentResponse(student))
+	}
+	c.JSON(http.StatusOK, body)
+}
+
+// ReplaceStudentController replaces every writable field of the student;
+// use PATCH to change only some of them.
+func (sc *StudentController) ReplaceStudentController(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": tr(c, "problem.invalid_student_id"), "context": err.Error()})
+		return
+	}
+
+	expectedVersion, ok := requireIfMatch(c)
+	if !ok {
+		return
+	}
+
+	var replaceRequest request.ReplaceStudentRequest
+	if err := c.ShouldBindJSON(&replaceRequest); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"message": tr(c, "problem.invalid_request"), "error1": bindErrorDetail(c, err)})
+		return
+	}
+
+	patchCommand := replaceRequest.ToPatchStudentCommand(id)
+	patchCommand.ExpectedVersion = expectedVersion
+
+	result, err := sc.service.PatchStudent(c.Request.Context(), patchCommand)
+	if err != nil {
+		writeStudentError(c, "problem.update_student_failed", err)
+		return
+	}
+
+	setStudentETag(c, result.Result)
+	c.JSON(http.StatusOK, mapper.ToStudentResponse(result.Result))
+}
+
+func (sc *StudentController) DeleteStudentV2Controller(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": tr(c, "problem.invalid_student_id"), "context": err.Error()})
+		return
+	}
+
+	expectedVersion, ok := requireIfMatch(c)
+	if !ok {
+		return
+	}
+
+	if err := sc.service.DeleteStudent(c.Request.Context(), id, expectedVersion); err != nil {
+		writeStudentError(c, "problem.delete_student_failed", err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
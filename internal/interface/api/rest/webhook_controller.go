@@ -0,0 +1,159 @@
+package rest
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/tranvu1111/go-students-new/internal/application/interfaces"
+	"github.com/tranvu1111/go-students-new/internal/domain/entities"
+	"github.com/tranvu1111/go-students-new/internal/domain/repositories"
+	"github.com/tranvu1111/go-students-new/internal/interface/api/rest/dto/mapper"
+	"github.com/tranvu1111/go-students-new/internal/interface/api/rest/dto/request"
+)
+
+type WebhookController struct {
+	service interfaces.WebhookService
+}
+
+func NewWebhookController(r *gin.Engine, service interfaces.WebhookService) *WebhookController {
+	controller := &WebhookController{
+		service: service,
+	}
+
+	r.POST("/api/v1/webhooks", controller.CreateSubscriptionController)
+	r.GET("/api/v1/webhooks", controller.GetAllSubscriptionController)
+	r.GET("/api/v1/webhooks/:id", controller.GetSubscriptionByIdController)
+	r.PUT("/api/v1/webhooks/:id", controller.PutSubscriptionController)
+	r.DELETE("/api/v1/webhooks/:id", controller.DeleteSubscriptionController)
+	r.GET("/api/v1/webhooks/:id/deliveries", controller.GetDeliveriesController)
+
+	return controller
+}
+
+func (wc *WebhookController) CreateSubscriptionController(c *gin.Context) {
+	var createRequest request.CreateWebhookSubscriptionRequest
+	if err := c.ShouldBindJSON(&createRequest); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"message": tr(c, "problem.invalid_request"), "error1": bindErrorDetail(c, err)})
+		return
+	}
+
+	result, err := wc.service.CreateSubscription(c.Request.Context(), createRequest.ToCreateWebhookSubscriptionCommand())
+	if err != nil {
+		writeWebhookError(c, "problem.create_webhook_subscription_failed", err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, mapper.ToWebhookSubscriptionResponse(result.Result))
+}
+
+func (wc *WebhookController) GetAllSubscriptionController(c *gin.Context) {
+	subscriptions, err := wc.service.FindAllSubscriptions(c.Request.Context())
+	if err != nil {
+		writeWebhookError(c, "problem.load_webhook_subscriptions_failed", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, mapper.ToWebhookSubscriptionListResponse(subscriptions.Result))
+}
+
+func (wc *WebhookController) GetSubscriptionByIdController(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": tr(c, "problem.invalid_webhook_id"), "context": err.Error()})
+		return
+	}
+
+	subscription, err := wc.service.FindSubscriptionById(c.Request.Context(), id)
+	if err != nil {
+		writeWebhookError(c, "problem.load_webhook_subscription_failed", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, mapper.ToWebhookSubscriptionResponse(subscription.Result))
+}
+
+func (wc *WebhookController) PutSubscriptionController(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": tr(c, "problem.invalid_webhook_id"), "context": err.Error()})
+		return
+	}
+
+	var updateRequest request.UpdateWebhookSubscriptionRequest
+	if err := c.ShouldBindJSON(&updateRequest); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"message": tr(c, "problem.invalid_request"), "error1": bindErrorDetail(c, err)})
+		return
+	}
+
+	result, err := wc.service.UpdateSubscription(c.Request.Context(), updateRequest.ToUpdateWebhookSubscriptionCommand(id))
+	if err != nil {
+		writeWebhookError(c, "problem.update_webhook_subscription_failed", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, mapper.ToWebhookSubscriptionResponse(result.Result))
+}
+
+func (wc *WebhookController) DeleteSubscriptionController(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": tr(c, "problem.invalid_webhook_id"), "context": err.Error()})
+		return
+	}
+
+	if err := wc.service.DeleteSubscription(c.Request.Context(), id); err != nil {
+		writeWebhookError(c, "problem.delete_webhook_subscription_failed", err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+func (wc *WebhookController) GetDeliveriesController(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": tr(c, "problem.invalid_webhook_id"), "context": err.Error()})
+		return
+	}
+
+	page, err := strconv.Atoi(c.DefaultQuery("page", "1"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": tr(c, "problem.invalid_page"), "context": err.Error()})
+		return
+	}
+
+	pageSize, err := strconv.Atoi(c.DefaultQuery("page_size", "0"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": tr(c, "problem.invalid_page_size"), "context": err.Error()})
+		return
+	}
+
+	deliveries, err := wc.service.FindDeliveries(c.Request.Context(), id, page, pageSize)
+	if err != nil {
+		writeWebhookError(c, "problem.load_webhook_deliveries_failed", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, mapper.ToWebhookDeliveryListResponse(deliveries))
+}
+
+// writeWebhookError maps the subscription errors like writeProgramError maps
+// those of the catalog. Every broken rule of a subscription is a validation
+// error.
+func writeWebhookError(c *gin.Context, code string, err error) {
+	var invalid *entities.ValidationError
+	switch {
+	case errors.Is(err, repositories.ErrWebhookSubscriptionNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": tr(c, "problem.webhook_subscription_not_found"), "content": errorDetail(c, err)})
+	case errors.As(err, &invalid):
+		c.JSON(http.StatusUnprocessableEntity, gin.H{"error": tr(c, code), "content": errorDetail(c, err)})
+	case errors.Is(err, repositories.ErrUnavailable):
+		c.Header("Retry-After", "10")
+		c.JSON(http.StatusServiceUnavailable, gin.H{"error": tr(c, code), "content": errorDetail(c, err)})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": tr(c, code), "content": errorDetail(c, err)})
+	}
+}
@@ -0,0 +1,67 @@
+package rest_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tranvu1111/go-students-new/internal/interface/api/rest/jsonpatch"
+)
+
+// Cases taken from the examples in RFC 6902 appendix A.
+func TestJSONPatchApply(t *testing.T) {
+	tests := []struct {
+		name     string
+		doc      string
+		patch    string
+		expected string
+	}{
+		{"add object member", `{"foo":"bar"}`, `[{"op":"add","path":"/baz","value":"qux"}]`, `{"baz":"qux","foo":"bar"}`},
+		{"add array element", `{"foo":["bar","baz"]}`, `[{"op":"add","path":"/foo/1","value":"qux"}]`, `{"foo":["bar","qux","baz"]}`},
+		{"append to array", `{"foo":["bar"]}`, `[{"op":"add","path":"/foo/-","value":"qux"}]`, `{"foo":["bar","qux"]}`},
+		{"remove array element", `{"foo":["bar","qux","baz"]}`, `[{"op":"remove","path":"/foo/1"}]`, `{"foo":["bar","baz"]}`},
+		{"replace value", `{"baz":"qux","foo":"bar"}`, `[{"op":"replace","path":"/baz","value":"boo"}]`, `{"baz":"boo","foo":"bar"}`},
+		{"move value", `{"foo":{"bar":"baz","waldo":"fred"},"qux":{"corge":"grault"}}`, `[{"op":"move","from":"/foo/waldo","path":"/qux/thud"}]`, `{"foo":{"bar":"baz"},"qux":{"corge":"grault","thud":"fred"}}`},
+		{"copy value", `{"foo":{"bar":1}}`, `[{"op":"copy","from":"/foo","path":"/baz"}]`, `{"baz":{"bar":1},"foo":{"bar":1}}`},
+		{"escaped pointer", `{"a/b":1,"m~n":2}`, `[{"op":"test","path":"/a~1b","value":1},{"op":"remove","path":"/m~0n"}]`, `{"a/b":1}`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			patched, err := jsonpatch.Apply([]byte(tt.doc), []byte(tt.patch))
+			require.NoError(t, err)
+			assert.JSONEq(t, tt.expected, string(patched))
+		})
+	}
+}
+
+func TestJSONPatchApply_Errors(t *testing.T) {
+	tests := []struct {
+		name  string
+		patch string
+	}{
+		{"missing target", `[{"op":"remove","path":"/missing"}]`},
+		{"failed test", `[{"op":"test","path":"/foo","value":"other"}]`},
+		{"move into child", `[{"op":"move","from":"/obj","path":"/obj/child"}]`},
+		{"unknown op", `[{"op":"merge","path":"/foo","value":1}]`},
+		{"missing value", `[{"op":"add","path":"/foo"}]`},
+		{"leading zero index", `[{"op":"add","path":"/list/01","value":1}]`},
+		{"not an array", `{"op":"add","path":"/foo","value":1}`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := jsonpatch.Apply([]byte(`{"foo":"bar","obj":{},"list":[1,2]}`), []byte(tt.patch))
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestCreateMergePatch(t *testing.T) {
+	patch, err := jsonpatch.CreateMergePatch(
+		[]byte(`{"a":"b","c":{"d":"e","f":"g"},"h":1}`),
+		[]byte(`{"a":"z","c":{"d":"e"},"h":1,"i":[1]}`),
+	)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"a":"z","c":{"f":null},"i":[1]}`, string(patch))
+}
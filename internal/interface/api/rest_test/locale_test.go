@@ -0,0 +1,180 @@
+package rest_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/tranvu1111/go-students-new/internal/application/command"
+	"github.com/tranvu1111/go-students-new/internal/domain/entities"
+	"github.com/tranvu1111/go-students-new/internal/domain/repositories"
+	"github.com/tranvu1111/go-students-new/internal/interface/api/rest"
+	"github.com/tranvu1111/go-students-new/internal/interface/api/rest/openapi"
+)
+
+func setupLocaleTest(t *testing.T) (*gin.Engine, *MockStudentService) {
+	gin.SetMode(gin.ReleaseMode)
+	r := gin.New()
+	r.Use(rest.LocaleMiddleware())
+
+	mockStudentService := new(MockStudentService)
+	rest.NewStudentController(r, mockStudentService)
+	return r, mockStudentService
+}
+
+func serveInLocale(r *gin.Engine, method string, path string, body string, acceptLanguage string) (*httptest.ResponseRecorder, map[string]string) {
+	req := httptest.NewRequest(method, path, strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("If-Match", `"1"`)
+	if acceptLanguage != "" {
+		req.Header.Set("Accept-Language", acceptLanguage)
+	}
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+
+	var errorBody map[string]string
+	_ = json.Unmarshal(w.Body.Bytes(), &errorBody)
+	return w, errorBody
+}
+
+func invalidStudentError(t *testing.T) error {
+	t.Helper()
+	future := time.Now().AddDate(1, 0, 0)
+	_, err := entities.NewValidatedStudent(entities.NewStudent("tran", "vu", &future, "tranvu@example.com", nil, nil, time.Now()))
+	require.Error(t, err)
+	return err
+}
+
+func TestValidationError_InRequestedLocale(t *testing.T) {
+	tests := []struct {
+		acceptLanguage string
+		language       string
+		title          string
+		content        string
+	}{
+		{"", "en", "Failed to create student", "Invalid date of birth"},
+		{"en-US,en;q=0.9", "en", "Failed to create student", "Invalid date of birth"},
+		{"vi-VN,vi;q=0.9,en;q=0.8", "vi", "Không thể tạo sinh viên", "Ngày sinh không hợp lệ"},
+		{"fr-FR", "en", "Failed to create student", "Invalid date of birth"},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.acceptLanguage, func(t *testing.T) {
+			r, mockStudentService := setupLocaleTest(t)
+			mockStudentService.On("CreateStudent", mock.Anything).Return(nil, invalidStudentError(t))
+
+			body := `{"FirstName":"tran","LastName":"vu","Email":"tranvu@example.com","EnrollmentDate":"2023-09-01"}`
+			w, errorBody := serveInLocale(r, http.MethodPost, "/api/v2/students", body, tc.acceptLanguage)
+
+			assert.Equal(t, http.StatusUnprocessableEntity, w.Code, w.Body.String())
+			assert.Equal(t, tc.language, w.Header().Get("Content-Language"))
+			assert.Contains(t, w.Header().Values("Vary"), "Accept-Language")
+			assert.Equal(t, tc.title, errorBody["error"])
+			assert.Equal(t, tc.content, errorBody["content"])
+		})
+	}
+}
+
+func TestValidationError_FillsParametersInBothLocales(t *testing.T) {
+	graduate := entities.NewStudent("tran", "vu", nil, "tranvu@example.com", nil, nil, time.Now().AddDate(-4, 0, 0))
+	require.NoError(t, graduate.TransitionTo(entities.StudentStatusGraduated, "finished", time.Now()))
+	transitionErr := graduate.TransitionTo(entities.StudentStatusActive, "readmitted", time.Now())
+	require.ErrorIs(t, transitionErr, entities.ErrStatusTransitionNotAllowed)
+
+	tests := []struct {
+		acceptLanguage string
+		title          string
+		content        string
+	}{
+		{"en", "Failed to change the student's status",
+			"status transition not allowed: a student who is graduated cannot become active"},
+		{"vi", "Không thể thay đổi trạng thái của sinh viên",
+			"không được phép chuyển trạng thái: sinh viên đang ở trạng thái graduated không thể chuyển sang active"},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.acceptLanguage, func(t *testing.T) {
+			r, mockStudentService := setupLocaleTest(t)
+			mockStudentService.On("TransitionStudentStatus", mock.Anything).Return((*command.UpdateStudentCommandResult)(nil), transitionErr)
+
+			body := `{"Status":"active","Reason":"readmitted","EffectiveDate":"2024-09-01"}`
+			w, errorBody := serveInLocale(r, http.MethodPost, "/api/v2/students/"+graduate.StudentID.String()+"/transitions", body, tc.acceptLanguage)
+
+			assert.Equal(t, http.StatusConflict, w.Code, w.Body.String())
+			assert.Equal(t, tc.title, errorBody["error"])
+			assert.Equal(t, tc.content, errorBody["content"])
+		})
+	}
+}
+
+func TestProblemResponses_InBothLocales(t *testing.T) {
+	r, mockStudentService := setupLocaleTest(t)
+	mockStudentService.On("CreateStudent", mock.Anything).Return(nil, repositories.ErrDuplicateEmail)
+	body := `{"FirstName":"tran","LastName":"vu","Email":"tranvu@example.com","EnrollmentDate":"2023-09-01"}`
+
+	w, errorBody := serveInLocale(r, http.MethodPost, "/api/v2/students", body, "en")
+	assert.Equal(t, http.StatusConflict, w.Code)
+	assert.Equal(t, repositories.ErrDuplicateEmail.Error(), errorBody["content"])
+
+	w, errorBody = serveInLocale(r, http.MethodPost, "/api/v2/students", body, "vi")
+	assert.Equal(t, http.StatusConflict, w.Code)
+	assert.Equal(t, "email đã được một sinh viên khác sử dụng", errorBody["content"])
+
+	w, errorBody = serveInLocale(r, http.MethodGet, "/api/v2/students/not-a-uuid", "", "vi")
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Equal(t, "Mã sinh viên không đúng định dạng", errorBody["error"])
+
+	w, errorBody = serveInLocale(r, http.MethodGet, "/api/v2/students/not-a-uuid", "", "en")
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Equal(t, "Invalid student Id format", errorBody["error"])
+}
+
+func TestDeleteStudent_InBothLocales(t *testing.T) {
+	r, mockStudentService := setupLocaleTest(t)
+	id := uuid.New()
+	mockStudentService.On("DeleteStudent", id, 1).Return(nil)
+
+	w, body := serveInLocale(r, http.MethodDelete, "/api/v1/students/"+id.String(), "", "en")
+	assert.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	assert.Equal(t, "Delete a student successfully", body["message"])
+
+	w, body = serveInLocale(r, http.MethodDelete, "/api/v1/students/"+id.String(), "", "vi")
+	assert.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	assert.Equal(t, "Đã xóa sinh viên thành công", body["message"])
+}
+
+func TestBindingErrors_InBothLocales(t *testing.T) {
+	r, _ := setupLocaleTest(t)
+	body := `{"LastName":"vu","Email":"tranvu@example.com","EnrollmentDate":"2023-09-01"}`
+
+	w, errorBody := serveInLocale(r, http.MethodPost, "/api/v2/students", body, "en")
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Equal(t, "Invalid request", errorBody["message"])
+	assert.Equal(t, "FirstName is required", errorBody["error1"])
+
+	w, errorBody = serveInLocale(r, http.MethodPost, "/api/v2/students", body, "vi")
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Equal(t, "Yêu cầu không hợp lệ", errorBody["message"])
+	assert.Equal(t, "FirstName là bắt buộc", errorBody["error1"])
+}
+
+func TestOpenAPIValidationMiddleware_InRequestedLocale(t *testing.T) {
+	gin.SetMode(gin.ReleaseMode)
+	r := gin.New()
+	doc := openapi.StudentsSpec()
+	r.Use(openapi.ValidationMiddleware(doc))
+	rest.NewStudentController(r, new(MockStudentService))
+
+	w, errorBody := serveInLocale(r, http.MethodGet, "/api/v2/students/search", "", "vi")
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Equal(t, "Yêu cầu không hợp lệ", errorBody["message"])
+	assert.Contains(t, errorBody["error1"], "q: is required")
+}
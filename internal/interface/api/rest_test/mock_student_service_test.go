@@ -1,23 +1,25 @@
 package rest_test
 
 import (
-	// "time"
+	"context"
 
 	"time"
 
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/mock"
 	"github.com/tranvu1111/go-students-new/internal/application/command"
+	"github.com/tranvu1111/go-students-new/internal/application/common"
 	"github.com/tranvu1111/go-students-new/internal/application/mapper"
 	"github.com/tranvu1111/go-students-new/internal/application/query"
 	"github.com/tranvu1111/go-students-new/internal/domain/entities"
+	"github.com/tranvu1111/go-students-new/internal/domain/repositories"
 )
 
 type MockStudentService struct {
 	mock.Mock
 }
 
-func(m *MockStudentService) CreateStudent(studentCommand *command.CreateStudentCommand) (*command.CreateStudentCommandResult,error) {
+func(m *MockStudentService) CreateStudent(ctx context.Context, studentCommand *command.CreateStudentCommand) (*command.CreateStudentCommandResult,error) {
 	args := m.Called(studentCommand)
 	var result command.CreateStudentCommandResult
 
@@ -45,8 +47,8 @@ func(m *MockStudentService) CreateStudent(studentCommand *command.CreateStudentC
 
 }
 
-func (m *MockStudentService) FindAllStudent()(*query.StudentQueryListResult, error){
-	args := m.Called()
+func (m *MockStudentService) FindAllStudent(ctx context.Context, listQuery *query.StudentListQuery)(*query.StudentQueryListResult, error){
+	args := m.Called(listQuery)
 
 	studentQueryListResult := &query.StudentQueryListResult{}
 
@@ -58,7 +60,7 @@ func (m *MockStudentService) FindAllStudent()(*query.StudentQueryListResult, err
 
 }
 
-func (m *MockStudentService) FindStudentById(id uuid.UUID)(*query.StudentQueryResult, error){
+func (m *MockStudentService) FindStudentById(ctx context.Context, id uuid.UUID)(*query.StudentQueryResult, error){
 	args := m.Called(id)
 
 	studentQueryResult := &query.StudentQueryResult{
@@ -68,7 +70,17 @@ func (m *MockStudentService) FindStudentById(id uuid.UUID)(*query.StudentQueryRe
 	return studentQueryResult, args.Error(1)
 }
 
-func(m *MockStudentService) UpdateStudent(updateCommand *command.UpdateStudentCommand) (*command.UpdateStudentCommandResult, error) {
+func (m *MockStudentService) FindStudentsByIds(ctx context.Context, ids []uuid.UUID)(*query.StudentQueryListResult, error){
+	args := m.Called(ids)
+
+	studentQueryListResult := &query.StudentQueryListResult{}
+	for _, s := range args.Get(0).([]*entities.Student){
+		studentQueryListResult.Result = append(studentQueryListResult.Result, mapper.NewStudentResultFromEntity(s))
+	}
+	return studentQueryListResult, args.Error(1)
+}
+
+func(m *MockStudentService) UpdateStudent(ctx context.Context, updateCommand *command.UpdateStudentCommand) (*command.UpdateStudentCommandResult, error) {
 	args := m.Called(updateCommand)
 
 	var result command.UpdateStudentCommandResult
@@ -98,6 +110,74 @@ func(m *MockStudentService) UpdateStudent(updateCommand *command.UpdateStudentCo
 	return &result , args.Error(1)
 }
 
-func(m *MockStudentService) DeleteStudent(id uuid.UUID)(error) {
-	return nil
-}
\ No newline at end of file
+func(m *MockStudentService) DeleteStudent(ctx context.Context, id uuid.UUID, expectedVersion int)(error) {
+	args := m.Called(id, expectedVersion)
+	return args.Error(0)
+}
+
+func(m *MockStudentService) RestoreStudent(ctx context.Context, id uuid.UUID)(*query.StudentQueryResult, error) {
+	args := m.Called(id)
+
+	return &query.StudentQueryResult{
+		Result: mapper.NewStudentResultFromEntity(args.Get(0).(*entities.Student)),
+	}, args.Error(1)
+}
+
+func(m *MockStudentService) FindStudentHistory(ctx context.Context, id uuid.UUID, page int, pageSize int)(*query.StudentHistoryQueryResult, error) {
+	args := m.Called(id, page, pageSize)
+	return args.Get(0).(*query.StudentHistoryQueryResult), args.Error(1)
+}
+
+func(m *MockStudentService) FindStudentStatusHistory(ctx context.Context, id uuid.UUID, page int, pageSize int)(*query.StudentStatusHistoryQueryResult, error) {
+	args := m.Called(id, page, pageSize)
+	return args.Get(0).(*query.StudentStatusHistoryQueryResult), args.Error(1)
+}
+
+func(m *MockStudentService) TransitionStudentStatus(ctx context.Context, transitionCommand *command.TransitionStudentStatusCommand)(*command.UpdateStudentCommandResult, error) {
+	args := m.Called(transitionCommand)
+	return args.Get(0).(*command.UpdateStudentCommandResult), args.Error(1)
+}
+
+func(m *MockStudentService) ImportStudents(ctx context.Context, importCommand *command.ImportStudentsCommand)(*command.ImportStudentsCommandResult, error) {
+	args := m.Called(importCommand)
+	return args.Get(0).(*command.ImportStudentsCommandResult), args.Error(1)
+}
+
+func(m *MockStudentService) ExportStudents(ctx context.Context, listQuery *query.StudentListQuery, visit func(*common.StudentResult) error)(error) {
+	args := m.Called(listQuery)
+	for _, s := range args.Get(0).([]*entities.Student) {
+		if err := visit(mapper.NewStudentResultFromEntity(s)); err != nil {
+			return err
+		}
+	}
+	return args.Error(1)
+}
+
+func(m *MockStudentService) SearchStudents(ctx context.Context, searchQuery *query.StudentSearchQuery)(*query.StudentSearchQueryResult, error) {
+	args := m.Called(searchQuery)
+	return args.Get(0).(*query.StudentSearchQueryResult), args.Error(1)
+}
+
+// PatchStudent applies the patch to the student given to Return, so tests see
+// the same field semantics as the real service.
+func(m *MockStudentService) PatchStudent(ctx context.Context, patchCommand *command.PatchStudentCommand)(*command.UpdateStudentCommandResult, error) {
+	args := m.Called(patchCommand.StudentId)
+	student := args.Get(0).(*entities.Student)
+	if patchCommand.ExpectedVersion != student.Version {
+		return nil, &repositories.VersionConflictError{StudentID: student.StudentID, ExpectedVersion: patchCommand.ExpectedVersion, CurrentVersion: student.Version}
+	}
+	if err := student.ApplyPatch(patchCommand.Patch); err != nil {
+		return nil, err
+	}
+	return &command.UpdateStudentCommandResult{Result: mapper.NewStudentResultFromEntity(student)}, args.Error(1)
+}
+
+func(m *MockStudentService) SaveStudentContact(ctx context.Context, contactCommand *command.SaveStudentContactCommand)(*command.UpdateStudentCommandResult, error) {
+	args := m.Called(contactCommand)
+	return args.Get(0).(*command.UpdateStudentCommandResult), args.Error(1)
+}
+
+func(m *MockStudentService) RemoveStudentContact(ctx context.Context, contactCommand *command.RemoveStudentContactCommand)(*command.UpdateStudentCommandResult, error) {
+	args := m.Called(contactCommand)
+	return args.Get(0).(*command.UpdateStudentCommandResult), args.Error(1)
+}
@@ -0,0 +1,186 @@
+package rest_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/tranvu1111/go-students-new/internal/application/services"
+	"github.com/tranvu1111/go-students-new/internal/domain/entities"
+	"github.com/tranvu1111/go-students-new/internal/interface/api/rest"
+	"github.com/tranvu1111/go-students-new/internal/interface/api/rest/openapi"
+)
+
+func setupOpenAPITest(t *testing.T) (*gin.Engine, *MockStudentService, *openapi.Document) {
+	gin.SetMode(gin.ReleaseMode)
+	r := gin.New()
+
+	doc := openapi.StudentsSpec()
+	openapi.Register(r, doc)
+	r.Use(openapi.ValidationMiddleware(doc))
+
+	mockStudentService := new(MockStudentService)
+	rest.NewStudentController(r, mockStudentService)
+	rest.NewEmailVerificationController(r, services.NewEmailVerificationService(nil, nil))
+	return r, mockStudentService, doc
+}
+
+// TestOpenAPISpecMatchesRoutes fails when a student route is added, removed or
+// renamed without updating the spec, or the other way round.
+func TestOpenAPISpecMatchesRoutes(t *testing.T) {
+	r, _, doc := setupOpenAPITest(t)
+
+	var routes []string
+	for _, route := range r.Routes() {
+		if strings.HasPrefix(route.Path, "/api/v1/students") || strings.HasPrefix(route.Path, "/api/v2/students") {
+			routes = append(routes, route.Method+" "+openapi.GinPath(route.Path))
+		}
+	}
+	assert.ElementsMatch(t, routes, doc.Routes())
+}
+
+// TestOpenAPIResponsesMatchSchemas fails when a handler renders a body that
+// its documented response schema does not allow.
+func TestOpenAPIResponsesMatchSchemas(t *testing.T) {
+	r, mockStudentService, doc := setupOpenAPITest(t)
+	phone := "+84947531799"
+	student := entities.NewStudent("tran", "vu", nil, "tranvu@example.com", &phone, nil, time.Now())
+	mockStudentService.On("CreateStudent", mock.Anything).Return(nil, nil)
+	mockStudentService.On("FindAllStudent", mock.Anything).Return([]*entities.Student{student}, nil)
+	mockStudentService.On("FindStudentById", student.StudentID).Return(student, nil)
+
+	create := `{"FirstName":"tran","LastName":"vu","Email":"tranvu@example.com","EnrollmentDate":"2023-09-01"}`
+	id := student.StudentID.String()
+
+	requests := []struct {
+		method string
+		route  string
+		path   string
+		body   string
+	}{
+		{http.MethodPost, "/api/v1/students", "/api/v1/students", create},
+		{http.MethodPost, "/api/v2/students", "/api/v2/students", create},
+		{http.MethodGet, "/api/v1/students", "/api/v1/students", ""},
+		{http.MethodGet, "/api/v2/students", "/api/v2/students", ""},
+		{http.MethodGet, "/api/v1/students/{id}", "/api/v1/students/" + id, ""},
+		{http.MethodGet, "/api/v2/students/{id}", "/api/v2/students/" + id, ""},
+	}
+
+	for _, tc := range requests {
+		t.Run(tc.method+" "+tc.path, func(t *testing.T) {
+			req := httptest.NewRequest(tc.method, tc.path, strings.NewReader(tc.body))
+			if tc.body != "" {
+				req.Header.Set("Content-Type", "application/json")
+			}
+			w := httptest.NewRecorder()
+			r.ServeHTTP(w, req)
+
+			operation, ok := doc.Operation(tc.method, tc.route)
+			require.True(t, ok)
+			documented, ok := operation.Responses[strconv.Itoa(w.Code)]
+			require.True(t, ok, "status %d is not documented: %s", w.Code, w.Body.String())
+
+			var body interface{}
+			require.NoError(t, json.Unmarshal(w.Body.Bytes(), &body))
+			assert.Empty(t, doc.Validate(documented.Content["application/json"].Schema, body))
+		})
+	}
+}
+
+func TestOpenAPIValidationMiddleware(t *testing.T) {
+	r, _, _ := setupOpenAPITest(t)
+
+	tests := []struct {
+		name    string
+		method  string
+		path    string
+		body    string
+		message string
+	}{
+		{"missing required field", http.MethodPost, "/api/v2/students", `{"LastName":"vu","Email":"tranvu@example.com","EnrollmentDate":"2023-09-01"}`, "$.FirstName: is required"},
+		{"wrong type", http.MethodPost, "/api/v2/students", `{"FirstName":1,"LastName":"vu","Email":"tranvu@example.com","EnrollmentDate":"2023-09-01"}`, "$.FirstName: expected string, got integer"},
+		{"bad date", http.MethodPost, "/api/v1/students", `{"FirstName":"tran","LastName":"vu","Email":"tranvu@example.com","EnrollmentDate":"01/09/2023"}`, "$.EnrollmentDate: is not a valid date"},
+		{"bad path parameter", http.MethodGet, "/api/v2/students/not-a-uuid", "", "id: is not a valid uuid"},
+		{"missing query parameter", http.MethodGet, "/api/v2/students/search", "", "q: is required"},
+		{"bad enum", http.MethodGet, "/api/v2/students/export?format=pdf", "", "format: must be one of"},
+		{"negative integer", http.MethodGet, "/api/v2/students/search?q=tran&limit=-1", "", "limit: must be at least 0"},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			req := httptest.NewRequest(tc.method, tc.path, strings.NewReader(tc.body))
+			if tc.body != "" {
+				req.Header.Set("Content-Type", "application/json")
+			}
+			w := httptest.NewRecorder()
+			r.ServeHTTP(w, req)
+
+			assert.Equal(t, http.StatusBadRequest, w.Code)
+			var body map[string]string
+			require.NoError(t, json.Unmarshal(w.Body.Bytes(), &body))
+			assert.Equal(t, "Invalid request", body["message"])
+			assert.Contains(t, body["error1"], tc.message)
+		})
+	}
+}
+
+func TestOpenAPIValidationMiddleware_LeavesIfMatchToHandlers(t *testing.T) {
+	r, _, _ := setupOpenAPITest(t)
+
+	body := `{"FirstName":"tran","LastName":"vu","Email":"tranvu@example.com"}`
+	req := httptest.NewRequest(http.MethodPut, "/api/v2/students/"+entities.NewStudent("tran", "vu", nil, "tranvu@example.com", nil, nil, time.Now()).StudentID.String(), strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusPreconditionRequired, w.Code)
+}
+
+func TestOpenAPIDocumentAndDocsAreServed(t *testing.T) {
+	r, _, _ := setupOpenAPITest(t)
+
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/openapi.json", nil))
+	require.Equal(t, http.StatusOK, w.Code)
+
+	var doc openapi.Document
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &doc))
+	assert.Equal(t, "3.1.0", doc.OpenAPI)
+	assert.Contains(t, doc.Components.Schemas, "CreateStudentRequest")
+	assert.Contains(t, doc.Components.Schemas, "UpdateStudentRequest")
+	assert.Contains(t, doc.Components.Schemas["StudentResponse"].Properties, "FirstName")
+	created := doc.Paths["/api/v1/students"]
+	require.NotNil(t, created)
+	assert.True(t, (*created)["post"].Deprecated)
+
+	w = httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/docs/", nil))
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), "swagger-ui")
+
+	w = httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/docs/swagger-initializer.js", nil))
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), `url: "/openapi.json"`)
+}
+
+func TestCreateStudentV2_RequiresFieldsWithoutMiddleware(t *testing.T) {
+	r, _ := setupV2Test(t)
+
+	body := `{"FirstName":"tran","LastName":"vu","Email":"tranvu@example.com"}`
+	req := httptest.NewRequest(http.MethodPost, "/api/v2/students", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Contains(t, w.Body.String(), "EnrollmentDate")
+}
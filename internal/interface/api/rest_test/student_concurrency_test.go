@@ -0,0 +1,80 @@
+package rest_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/tranvu1111/go-students-new/internal/domain/entities"
+	"github.com/tranvu1111/go-students-new/internal/domain/repositories"
+	"github.com/tranvu1111/go-students-new/internal/interface/api/rest"
+)
+
+func TestGetStudentById_ReturnsETag(t *testing.T) {
+	gin.SetMode(gin.ReleaseMode)
+	r := gin.New()
+
+	mockStudentService := new(MockStudentService)
+	rest.NewStudentController(r, mockStudentService)
+
+	student := entities.NewStudent("tran", "vu", nil, "tranvu@example.com", nil, nil, time.Now())
+	student.Version = 4
+	mockStudentService.On("FindStudentById", student.StudentID).Return(student, nil)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/students/"+student.StudentID.String(), nil)
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, `"4"`, w.Header().Get("ETag"))
+}
+
+func TestStudentWrites_RequireMatchingVersion(t *testing.T) {
+	student := entities.NewStudent("tran", "vu", nil, "tranvu@example.com", nil, nil, time.Now())
+	student.Version = 2
+	conflict := &repositories.VersionConflictError{StudentID: student.StudentID, ExpectedVersion: 1, CurrentVersion: 2}
+
+	tests := []struct {
+		name    string
+		method  string
+		ifMatch string
+		status  int
+		etag    string
+	}{
+		{"patch without If-Match", http.MethodPatch, "", http.StatusPreconditionRequired, ""},
+		{"patch with a weak tag", http.MethodPatch, `W/"2"`, http.StatusBadRequest, ""},
+		{"patch with a stale version", http.MethodPatch, `"1"`, http.StatusPreconditionFailed, `"2"`},
+		{"patch with the current version", http.MethodPatch, `"2"`, http.StatusOK, `"2"`},
+		{"delete without If-Match", http.MethodDelete, "", http.StatusPreconditionRequired, ""},
+		{"delete with a stale version", http.MethodDelete, `"1"`, http.StatusPreconditionFailed, `"2"`},
+		{"delete with the current version", http.MethodDelete, `"2"`, http.StatusOK, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gin.SetMode(gin.ReleaseMode)
+			r := gin.New()
+
+			mockStudentService := new(MockStudentService)
+			rest.NewStudentController(r, mockStudentService)
+			mockStudentService.On("PatchStudent", student.StudentID).Return(student, nil)
+			mockStudentService.On("DeleteStudent", student.StudentID, 1).Return(conflict)
+			mockStudentService.On("DeleteStudent", student.StudentID, 2).Return(nil)
+
+			req := httptest.NewRequest(tt.method, "/api/v1/students/"+student.StudentID.String(), strings.NewReader(`{}`))
+			req.Header.Set("Content-Type", "application/merge-patch+json")
+			if tt.ifMatch != "" {
+				req.Header.Set("If-Match", tt.ifMatch)
+			}
+			w := httptest.NewRecorder()
+			r.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.status, w.Code, w.Body.String())
+			assert.Equal(t, tt.etag, w.Header().Get("ETag"))
+		})
+	}
+}
@@ -3,6 +3,7 @@ package rest_test
 import (
 	"bytes"
 	"encoding/json"
+	"fmt"
 	"net/http"
 	"net/http/httptest"
 	"testing"
@@ -14,7 +15,11 @@ import (
 	"github.com/stretchr/testify/mock"
 	"github.com/tranvu1111/go-students-new/internal/application/command"
 	"github.com/tranvu1111/go-students-new/internal/application/common"
+	"github.com/tranvu1111/go-students-new/internal/application/query"
+	"github.com/tranvu1111/go-students-new/internal/domain/entities"
+	"github.com/tranvu1111/go-students-new/internal/domain/repositories"
 	"github.com/tranvu1111/go-students-new/internal/interface/api/rest"
+	"github.com/tranvu1111/go-students-new/internal/interface/api/rest/dto/response"
 	// "github.com/tranvu1111/go-students-new/internal/interface/api/rest/dto/request"
 )
 
@@ -34,13 +39,13 @@ func TestCreateStudent(t *testing.T) {
 		"LastName":"vu",
 		"DateOfBirth":"2003-03-11",
 		"Email":"tranvu123312312@gmail.com",
-		"Phone":"0931239991",
+		"Phone":"+84931239991",
 		"Major":"CNTT",
 		"EnrollmentDate":"2023-03-11",
 	}
 
 	dob := time.Date(2003, 3, 11, 0, 0, 0, 0, time.UTC)
-	phone := "0931239991"
+	phone := "+84931239991"
 	major := "CNTT"
 	enrollment_date := time.Date(2023, 3, 11, 0, 0, 0, 0, time.UTC)
 	createStudentCommandResult := &command.CreateStudentCommandResult{
@@ -81,6 +86,12 @@ func TestCreateStudent(t *testing.T) {
 	delete(responseBody, "StudentID")
 	delete(responseBody, "EnrollmentDate")
 	delete(responseBody, "DateOfBirth")
+	delete(responseBody, "Status")
+	delete(responseBody, "StatusReason")
+	delete(responseBody, "StatusEffectiveDate")
+	delete(responseBody, "Programs")
+	delete(responseBody, "EmailVerifiedAt")
+	delete(responseBody, "Locale")
 	delete(reqBody, "DateOfBirth")
 	delete(reqBody, "EnrollmentDate")
 
@@ -102,13 +113,13 @@ func TestUpdateStudent(t *testing.T) {
 	reqBody := map[string]interface{}{
 		"StudentID":"6f69799c-1eb2-4266-b28c-9762a4d02129",	
 		"DateOfBirth":"2003-03-11",		
-		"Phone":"09312399912",
+		"Phone":"+849312399912",
 		"Major"	: "deptrai",
 	}
 
 	studentID , _ := uuid.Parse("6f69799c-1eb2-4266-b28c-9762a4d02129")
 	dob := time.Date(2003, 3, 11, 0, 0, 0, 0, time.UTC)
-	phone := "09312399912"
+	phone := "+849312399912"
 	major := "deptrai"
 	enrollment_date := time.Date(2023, 3, 11, 0, 0, 0, 0, time.UTC)
 	updateStudentCommandResult := &command.UpdateStudentCommandResult{
@@ -129,6 +140,7 @@ func TestUpdateStudent(t *testing.T) {
 	reqBodyBytes, _ := json.Marshal(reqBody)
 	req := httptest.NewRequest(http.MethodPut, "/api/v1/students", bytes.NewReader(reqBodyBytes))
 	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("If-Match", `"1"`)
 
 	w := httptest.NewRecorder()
 
@@ -151,6 +163,12 @@ func TestUpdateStudent(t *testing.T) {
 	delete(responseBody, "LastName")
 	delete(responseBody, "EnrollmentDate")
 	delete(responseBody, "DateOfBirth")
+	delete(responseBody, "Status")
+	delete(responseBody, "StatusReason")
+	delete(responseBody, "StatusEffectiveDate")
+	delete(responseBody, "Programs")
+	delete(responseBody, "EmailVerifiedAt")
+	delete(responseBody, "Locale")
 	delete(reqBody, "DateOfBirth")
 	
 
@@ -159,4 +177,93 @@ func TestUpdateStudent(t *testing.T) {
 
 	mockStudentService.AssertExpectations(t)
 
-}
\ No newline at end of file
+}
+func TestGetStudentHistory(t *testing.T) {
+	gin.SetMode(gin.ReleaseMode)
+
+	r := gin.New()
+	r.Use(rest.RequestContextMiddleware())
+
+	mockStudentService := new(MockStudentService)
+	rest.NewStudentController(r, mockStudentService)
+
+	studentID := uuid.New()
+	major := "CNTT"
+	history := &query.StudentHistoryQueryResult{
+		Result: []*common.AuditEntryResult{
+			{
+				ID: uuid.New(),
+				StudentID: studentID,
+				Actor: "advisor",
+				RequestID: "req-1",
+				Operation: "update",
+				Changes: []common.FieldChangeResult{{Field: "Major", After: &major}},
+				OccurredAt: time.Now(),
+			},
+		},
+		Page: 2,
+		PageSize: 5,
+		Total: 6,
+	}
+
+	mockStudentService.On("FindStudentHistory", studentID, 2, 5).Return(history, nil)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/students/"+studentID.String()+"/history?page=2&page_size=5", nil)
+	req.Header.Set(rest.RequestIDHeader, "req-1")
+	w := httptest.NewRecorder()
+
+	r.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "req-1", w.Header().Get(rest.RequestIDHeader))
+
+	var responseBody response.StudentHistoryResponse
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &responseBody))
+	assert.Equal(t, int64(6), responseBody.Total)
+	assert.Len(t, responseBody.Entries, 1)
+	assert.Equal(t, "Major", responseBody.Entries[0].Changes[0].Field)
+	assert.Equal(t, "CNTT", *responseBody.Entries[0].Changes[0].After)
+
+	mockStudentService.AssertExpectations(t)
+}
+
+func TestRequestContextMiddleware_ConsistentRead(t *testing.T) {
+	gin.SetMode(gin.ReleaseMode)
+
+	r := gin.New()
+	r.Use(rest.RequestContextMiddleware())
+	r.GET("/consistency", func(c *gin.Context) {
+		c.JSON(http.StatusOK, repositories.NeedsConsistentRead(c.Request.Context()))
+	})
+
+	for header, want := range map[string]string{"": "false", "true": "true", "1": "true", "no": "false"} {
+		req := httptest.NewRequest(http.MethodGet, "/consistency", nil)
+		if header != "" {
+			req.Header.Set(rest.ConsistentReadHeader, header)
+		}
+		w := httptest.NewRecorder()
+
+		r.ServeHTTP(w, req)
+
+		assert.Equal(t, want, w.Body.String(), "header %q", header)
+	}
+}
+
+func TestGetAllStudents_DatabaseUnavailable(t *testing.T) {
+	gin.SetMode(gin.ReleaseMode)
+
+	r := gin.New()
+	mockStudentService := new(MockStudentService)
+	rest.NewStudentController(r, mockStudentService)
+
+	mockStudentService.On("FindAllStudent", mock.Anything).Return([]*entities.Student{}, fmt.Errorf("find students: %w", repositories.ErrUnavailable))
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/students", nil)
+	w := httptest.NewRecorder()
+
+	r.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusServiceUnavailable, w.Code)
+	assert.NotEmpty(t, w.Header().Get("Retry-After"))
+	mockStudentService.AssertExpectations(t)
+}
@@ -0,0 +1,125 @@
+package rest_test
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/tranvu1111/go-students-new/internal/application/query"
+	"github.com/tranvu1111/go-students-new/internal/domain/entities"
+	"github.com/tranvu1111/go-students-new/internal/interface/api/rest"
+	"github.com/tranvu1111/go-students-new/internal/interface/api/rest/export"
+)
+
+func setupExportTest(t *testing.T) (*gin.Engine, []*entities.Student) {
+	gin.SetMode(gin.ReleaseMode)
+	r := gin.New()
+
+	mockStudentService := new(MockStudentService)
+	rest.NewStudentController(r, mockStudentService)
+
+	enrollment := time.Date(2023, 9, 1, 0, 0, 0, 0, time.UTC)
+	major := "CNTT"
+	students := []*entities.Student{
+		entities.NewStudent("tran", "vu", nil, "tran@example.com", nil, &major, enrollment),
+		entities.NewStudent("Jane", "Smith, Jr.", nil, "jane@example.com", nil, &major, enrollment),
+	}
+
+	isCNTT := mock.MatchedBy(func(q *query.StudentListQuery) bool { return q.Major != nil && *q.Major == "CNTT" })
+	mockStudentService.On("ExportStudents", isCNTT).Return(students, nil)
+	return r, students
+}
+
+func TestExportStudents_CSV(t *testing.T) {
+	r, students := setupExportTest(t)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/students/export?format=csv&major=CNTT&columns=email,LastName,Phone", nil)
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+
+	require.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "text/csv; charset=utf-8", w.Header().Get("Content-Type"))
+	assert.Contains(t, w.Header().Get("Content-Disposition"), "students.csv")
+
+	records, err := csv.NewReader(w.Body).ReadAll()
+	require.NoError(t, err)
+	assert.Equal(t, []string{"Email", "LastName", "Phone"}, records[0])
+	assert.Equal(t, []string{students[1].Email, "Smith, Jr.", ""}, records[2])
+}
+
+func TestExportStudents_NDJSON(t *testing.T) {
+	r, students := setupExportTest(t)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/students/export?format=ndjson&major=CNTT", nil)
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+
+	require.Equal(t, http.StatusOK, w.Code)
+	lines := strings.Split(strings.TrimSpace(w.Body.String()), "\n")
+	require.Len(t, lines, 2)
+	assert.True(t, strings.HasPrefix(lines[0], `{"StudentID":`), "columns follow StudentResponse order")
+
+	var row map[string]interface{}
+	require.NoError(t, json.Unmarshal([]byte(lines[0]), &row))
+	assert.Equal(t, students[0].StudentID.String(), row["StudentID"])
+	assert.Nil(t, row["Phone"])
+	assert.Equal(t, "2023-09-01T00:00:00Z", row["EnrollmentDate"])
+}
+
+func TestExportStudents_XLSX(t *testing.T) {
+	r, _ := setupExportTest(t)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/students/export?format=xlsx&major=CNTT&columns=FirstName", nil)
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+
+	require.Equal(t, http.StatusOK, w.Code)
+	archive, err := zip.NewReader(bytes.NewReader(w.Body.Bytes()), int64(w.Body.Len()))
+	require.NoError(t, err)
+
+	var sheet string
+	for _, file := range archive.File {
+		if file.Name == "xl/worksheets/sheet1.xml" {
+			f, err := file.Open()
+			require.NoError(t, err)
+			content, err := io.ReadAll(f)
+			require.NoError(t, err)
+			sheet = string(content)
+		}
+	}
+	assert.Equal(t, 3, strings.Count(sheet, "<row>"))
+	assert.Contains(t, sheet, ">FirstName<")
+	assert.Contains(t, sheet, ">Jane<")
+}
+
+func TestExportStudents_InvalidRequest(t *testing.T) {
+	r, _ := setupExportTest(t)
+
+	for _, url := range []string{
+		"/api/v1/students/export?format=pdf",
+		"/api/v1/students/export?columns=Password",
+		"/api/v1/students/export?enrolled_from=yesterday",
+	} {
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, url, nil))
+		assert.Equal(t, http.StatusBadRequest, w.Code, url)
+	}
+}
+
+func TestStudentColumnsFollowStudentResponse(t *testing.T) {
+	assert.Equal(t, []string{
+		"StudentID", "FirstName", "LastName", "DateOfBirth", "Email", "EmailVerifiedAt", "Phone", "Major", "Locale", "Programs",
+		"CreatedAt", "UpdatedAt", "EnrollmentDate", "Status", "StatusReason", "StatusEffectiveDate",
+	}, export.StudentColumns)
+}
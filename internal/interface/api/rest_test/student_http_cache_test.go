@@ -0,0 +1,121 @@
+package rest_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/tranvu1111/go-students-new/internal/domain/entities"
+	"github.com/tranvu1111/go-students-new/internal/interface/api/rest"
+)
+
+func TestGetStudentById_ConditionalRequests(t *testing.T) {
+	updatedAt := time.Date(2024, 5, 1, 10, 30, 15, 500, time.UTC)
+	student := entities.NewStudent("tran", "vu", nil, "tranvu@example.com", nil, nil, time.Now())
+	student.UpdatedAt = updatedAt
+	student.Version = 3
+
+	tests := []struct {
+		name   string
+		header string
+		value  string
+		status int
+	}{
+		{"no validators", "", "", http.StatusOK},
+		{"matching ETag", "If-None-Match", `"3"`, http.StatusNotModified},
+		{"weak matching ETag in a list", "If-None-Match", `"1", W/"3"`, http.StatusNotModified},
+		{"stale ETag", "If-None-Match", `"2"`, http.StatusOK},
+		{"not modified since", "If-Modified-Since", updatedAt.Format(http.TimeFormat), http.StatusNotModified},
+		{"modified since", "If-Modified-Since", updatedAt.Add(-time.Minute).Format(http.TimeFormat), http.StatusOK},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gin.SetMode(gin.ReleaseMode)
+			r := gin.New()
+
+			mockStudentService := new(MockStudentService)
+			rest.NewStudentController(r, mockStudentService, rest.WithCacheControl("private, max-age=60"))
+			mockStudentService.On("FindStudentById", student.StudentID).Return(student, nil)
+
+			req := httptest.NewRequest(http.MethodGet, "/api/v1/students/"+student.StudentID.String(), nil)
+			if tt.header != "" {
+				req.Header.Set(tt.header, tt.value)
+			}
+			w := httptest.NewRecorder()
+			r.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.status, w.Code)
+			assert.Equal(t, `"3"`, w.Header().Get("ETag"))
+			assert.Equal(t, "Wed, 01 May 2024 10:30:15 GMT", w.Header().Get("Last-Modified"))
+			assert.Equal(t, "private, max-age=60", w.Header().Get("Cache-Control"))
+			if tt.status == http.StatusNotModified {
+				assert.Empty(t, w.Body.String())
+			}
+		})
+	}
+}
+
+func TestGetAllStudents_ConditionalRequests(t *testing.T) {
+	gin.SetMode(gin.ReleaseMode)
+	r := gin.New()
+
+	mockStudentService := new(MockStudentService)
+	rest.NewStudentController(r, mockStudentService)
+
+	students := []*entities.Student{
+		entities.NewStudent("tran", "vu", nil, "tranvu@example.com", nil, nil, time.Now()),
+		entities.NewStudent("linh", "le", nil, "linh@example.com", nil, nil, time.Now()),
+	}
+	mockStudentService.On("FindAllStudent", mock.Anything).Return(students, nil)
+
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/v1/students", nil))
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, rest.DefaultCacheControl, w.Header().Get("Cache-Control"))
+	etag := w.Header().Get("ETag")
+	assert.Regexp(t, `^W/"[0-9a-f]{32}"$`, etag)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/students", nil)
+	req.Header.Set("If-None-Match", etag)
+	w = httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusNotModified, w.Code)
+
+	students[1].Version++
+	req = httptest.NewRequest(http.MethodGet, "/api/v1/students", nil)
+	req.Header.Set("If-None-Match", etag)
+	w = httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code, "a changed student must change the listing ETag")
+}
+
+func TestGetAllStudents_DeletedStudentIsNotServedFromCache(t *testing.T) {
+	gin.SetMode(gin.ReleaseMode)
+	r := gin.New()
+
+	mockStudentService := new(MockStudentService)
+	rest.NewStudentController(r, mockStudentService)
+
+	remaining := entities.NewStudent("tran", "vu", nil, "tranvu@example.com", nil, nil, time.Now())
+	deleted := entities.NewStudent("linh", "le", nil, "linh@example.com", nil, nil, time.Now())
+	mockStudentService.On("FindAllStudent", mock.Anything).Return([]*entities.Student{remaining, deleted}, nil).Once()
+	mockStudentService.On("FindAllStudent", mock.Anything).Return([]*entities.Student{remaining}, nil)
+
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/v1/students", nil))
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Empty(t, w.Header().Get("Last-Modified"), "a list cannot tell when a student left it")
+	etag := w.Header().Get("ETag")
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/students", nil)
+	req.Header.Set("If-Modified-Since", time.Now().Add(time.Hour).UTC().Format(http.TimeFormat))
+	w = httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code, "If-Modified-Since alone must not revalidate a list")
+	assert.NotEqual(t, etag, w.Header().Get("ETag"))
+}
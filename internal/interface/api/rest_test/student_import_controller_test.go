@@ -0,0 +1,128 @@
+package rest_test
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/tranvu1111/go-students-new/internal/application/command"
+	"github.com/tranvu1111/go-students-new/internal/interface/api/rest"
+	"github.com/tranvu1111/go-students-new/internal/interface/api/rest/dto/request"
+)
+
+func TestCSVStudentReader(t *testing.T) {
+	body := "email,FirstName,LastName,EnrollmentDate,Major,DateOfBirth\n" +
+		"tran@example.com,tran,vu,2023-09-01,CNTT,2003-03-11\n" +
+		"jane@example.com,Jane,Smith,01/09/2023,,\n" +
+		"john@example.com,John,Doe,2023-09-01,,\n"
+
+	reader, err := request.NewCSVStudentReader(strings.NewReader(body))
+	require.NoError(t, err)
+
+	row, err := reader.Next()
+	require.NoError(t, err)
+	assert.Equal(t, "tran", row.FirstName)
+	assert.Equal(t, "CNTT", *row.Major)
+	assert.Nil(t, row.Phone)
+	assert.Equal(t, 2003, row.DateOfBirth.Year())
+
+	_, err = reader.Next()
+	assert.ErrorContains(t, err, "EnrollmentDate")
+
+	row, err = reader.Next()
+	require.NoError(t, err)
+	assert.Nil(t, row.Major)
+	assert.Nil(t, row.DateOfBirth)
+
+	_, err = reader.Next()
+	assert.Equal(t, io.EOF, err)
+
+	_, err = request.NewCSVStudentReader(strings.NewReader("FirstName,LastName\n"))
+	assert.Error(t, err)
+}
+
+func TestNDJSONStudentReader(t *testing.T) {
+	body := `{"FirstName":"tran","LastName":"vu","Email":"tran@example.com","EnrollmentDate":"2023-09-01"}` + "\n\n" +
+		`{"FirstName":` + "\n" +
+		`{"FirstName":"jane","LastName":"smith","Email":"jane@example.com","EnrollmentDate":"2023-09-01","Phone":"+84931239991"}` + "\n"
+
+	reader := request.NewNDJSONStudentReader(strings.NewReader(body))
+
+	row, err := reader.Next()
+	require.NoError(t, err)
+	assert.Equal(t, "tran", row.FirstName)
+
+	_, err = reader.Next()
+	assert.ErrorContains(t, err, "invalid JSON")
+
+	row, err = reader.Next()
+	require.NoError(t, err)
+	assert.Equal(t, "+84931239991", *row.Phone)
+
+	_, err = reader.Next()
+	assert.Equal(t, io.EOF, err)
+}
+
+func TestNDJSONStudentReader_LineOverOneMegabyte(t *testing.T) {
+	body := `{"FirstName":"tran","LastName":"vu","Email":"tran@example.com","EnrollmentDate":"2023-09-01"}` + "\n" +
+		`{"FirstName":"` + strings.Repeat("a", 2*1024*1024) + `"}` + "\n" +
+		`{"FirstName":"jane","LastName":"smith","Email":"jane@example.com","EnrollmentDate":"2023-09-01"}` + "\n"
+
+	reader := request.NewNDJSONStudentReader(strings.NewReader(body))
+
+	_, err := reader.Next()
+	require.NoError(t, err)
+
+	_, err = reader.Next()
+	assert.ErrorIs(t, err, command.ErrImportUnreadable)
+	assert.ErrorContains(t, err, "longer than")
+}
+
+func TestImportStudentsEndpoint(t *testing.T) {
+	gin.SetMode(gin.ReleaseMode)
+
+	r := gin.New()
+	mockStudentService := new(MockStudentService)
+	rest.NewStudentController(r, mockStudentService)
+
+	isBestEffortDryRun := mock.MatchedBy(func(c *command.ImportStudentsCommand) bool {
+		_, csv := c.Rows.(*request.CSVStudentReader)
+		return csv && c.Mode == command.ImportModeBestEffort && c.DryRun && c.BatchSize == 10
+	})
+	mockStudentService.On("ImportStudents", isBestEffortDryRun).Return(&command.ImportStudentsCommandResult{
+		Mode: command.ImportModeBestEffort, DryRun: true, Total: 1,
+		Rows: []command.ImportRowResult{{Row: 1}},
+	}, nil).Once()
+
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/students/import?mode=best_effort&dry_run=true&batch_size=10",
+		strings.NewReader("FirstName,LastName,Email,EnrollmentDate\ntran,vu,tran@example.com,2023-09-01\n"))
+	req.Header.Set("Content-Type", "text/csv")
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), `"DryRun":true`)
+
+	mockStudentService.On("ImportStudents", mock.Anything).Return(&command.ImportStudentsCommandResult{
+		Mode: command.ImportModeAllOrNothing, Total: 1, Failed: 1,
+		Rows: []command.ImportRowResult{{Row: 1, Error: "Invalid email"}},
+	}, nil).Once()
+
+	req = httptest.NewRequest(http.MethodPost, "/api/v1/students/import?format=ndjson", strings.NewReader("{}\n"))
+	w = httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusUnprocessableEntity, w.Code)
+
+	req = httptest.NewRequest(http.MethodPost, "/api/v1/students/import", strings.NewReader("{}"))
+	req.Header.Set("Content-Type", "application/xml")
+	w = httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusUnsupportedMediaType, w.Code)
+
+	mockStudentService.AssertExpectations(t)
+}
@@ -0,0 +1,307 @@
+package rest_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/tranvu1111/go-students-new/internal/application/services"
+	"github.com/tranvu1111/go-students-new/internal/domain/entities"
+	"github.com/tranvu1111/go-students-new/internal/infrastructure/memory"
+	"github.com/tranvu1111/go-students-new/internal/interface/api/rest"
+	"github.com/tranvu1111/go-students-new/internal/interface/api/rest/dto/response"
+)
+
+// setupMemoryTest serves the real StudentService, ProgramService and
+// EmailVerificationService on in-memory repositories.
+func setupMemoryTest(t *testing.T) *gin.Engine {
+	gin.SetMode(gin.ReleaseMode)
+	r := gin.New()
+
+	store := memory.NewStore()
+	studentRepo := memory.NewStudentRepo(store)
+	programRepo := memory.NewProgramRepo(store)
+	service := services.NewStudentService(studentRepo, memory.NewIdempotencyRepository(), memory.NewAuditRepo(store), memory.NewStudentStatusRepo(store), programRepo)
+	rest.NewStudentController(r, service)
+	rest.NewProgramController(r, services.NewProgramService(programRepo, studentRepo))
+	rest.NewEmailVerificationController(r, services.NewEmailVerificationService(studentRepo, verificationSecret))
+	return r
+}
+
+var verificationSecret = []byte("secret")
+
+func serve(r *gin.Engine, method string, path string, body string, header map[string]string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(method, path, strings.NewReader(body))
+	if body != "" {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	for name, value := range header {
+		req.Header.Set(name, value)
+	}
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+	return w
+}
+
+func TestStudentLifecycle_InMemory(t *testing.T) {
+	r := setupMemoryTest(t)
+
+	w := serve(r, http.MethodPost, "/api/v2/students", `{"FirstName":"tran","LastName":"vu","Email":"tranvu@example.com","EnrollmentDate":"2023-09-01"}`, nil)
+	require.Equal(t, http.StatusCreated, w.Code, w.Body.String())
+	location := w.Header().Get("Location")
+
+	w = serve(r, http.MethodPost, "/api/v2/students", `{"FirstName":"an","LastName":"le","Email":"tranvu@example.com","EnrollmentDate":"2023-09-01"}`, nil)
+	assert.Equal(t, http.StatusConflict, w.Code, w.Body.String())
+
+	w = serve(r, http.MethodPut, location, `{"FirstName":"tran","LastName":"vu","Email":"tranvu@example.com","EnrollmentDate":"2023-09-01","Major":"CNTT"}`, map[string]string{"If-Match": `"1"`})
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	assert.Equal(t, `"2"`, w.Header().Get("ETag"))
+
+	w = serve(r, http.MethodDelete, location, "", map[string]string{"If-Match": `"1"`})
+	assert.Equal(t, http.StatusPreconditionFailed, w.Code, w.Body.String())
+	assert.Equal(t, `"2"`, w.Header().Get("ETag"))
+
+	w = serve(r, http.MethodDelete, location, "", map[string]string{"If-Match": `"2"`})
+	require.Equal(t, http.StatusNoContent, w.Code, w.Body.String())
+
+	w = serve(r, http.MethodGet, location, "", nil)
+	assert.Equal(t, http.StatusNotFound, w.Code, w.Body.String())
+
+	w = serve(r, http.MethodPost, location+"/restore", "", nil)
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	assert.Equal(t, `"3"`, w.Header().Get("ETag"))
+	var restored response.StudentResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &restored))
+	require.NotNil(t, restored.Major)
+	assert.Equal(t, "CNTT", *restored.Major)
+
+	w = serve(r, http.MethodGet, location+"/history", "", nil)
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	var history response.StudentHistoryResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &history))
+	assert.Equal(t, int64(4), history.Total)
+}
+
+func TestCreateStudent_IdempotencyKey_InMemory(t *testing.T) {
+	r := setupMemoryTest(t)
+	body := `{"IdempotencyKey":"key-1","FirstName":"tran","LastName":"vu","Email":"tranvu@example.com","EnrollmentDate":"2023-09-01"}`
+
+	first := serve(r, http.MethodPost, "/api/v2/students", body, nil)
+	require.Equal(t, http.StatusCreated, first.Code, first.Body.String())
+	again := serve(r, http.MethodPost, "/api/v2/students", body, nil)
+	require.Equal(t, http.StatusCreated, again.Code, again.Body.String())
+	assert.Equal(t, first.Header().Get("Location"), again.Header().Get("Location"))
+
+	w := serve(r, http.MethodGet, "/api/v2/students", "", nil)
+	require.Equal(t, http.StatusOK, w.Code)
+	var students []response.StudentResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &students))
+	assert.Len(t, students, 1)
+}
+
+func TestStudentStatusTransitions_InMemory(t *testing.T) {
+	r := setupMemoryTest(t)
+
+	w := serve(r, http.MethodPost, "/api/v2/students", `{"FirstName":"tran","LastName":"vu","Email":"tranvu@example.com","EnrollmentDate":"2023-09-01"}`, nil)
+	require.Equal(t, http.StatusCreated, w.Code, w.Body.String())
+	location := w.Header().Get("Location")
+	var created response.StudentResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &created))
+	assert.Equal(t, "active", created.Status)
+
+	w = serve(r, http.MethodPost, location+"/transitions", `{"Status":"on_leave","Reason":"medical leave","EffectiveDate":"2024-01-10"}`, nil)
+	assert.Equal(t, http.StatusPreconditionRequired, w.Code, w.Body.String())
+
+	w = serve(r, http.MethodPost, location+"/transitions", `{"Status":"on_leave","Reason":"medical leave","EffectiveDate":"2024-01-10"}`, map[string]string{"If-Match": `"1"`})
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	assert.Equal(t, `"2"`, w.Header().Get("ETag"))
+	var onLeave response.StudentResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &onLeave))
+	assert.Equal(t, "on_leave", onLeave.Status)
+	assert.Equal(t, "medical leave", onLeave.StatusReason)
+
+	// The lifecycle, not the controller, rejects these.
+	w = serve(r, http.MethodPost, location+"/transitions", `{"Status":"graduated","Reason":"done","EffectiveDate":"2024-02-01"}`, map[string]string{"If-Match": `"2"`})
+	assert.Equal(t, http.StatusConflict, w.Code, w.Body.String())
+	w = serve(r, http.MethodPost, location+"/transitions", `{"Status":"active","Reason":"back","EffectiveDate":"2023-12-01"}`, map[string]string{"If-Match": `"2"`})
+	assert.Equal(t, http.StatusUnprocessableEntity, w.Code, w.Body.String())
+
+	w = serve(r, http.MethodPatch, location, `{"Status":"active"}`, map[string]string{"If-Match": `"2"`})
+	assert.Equal(t, http.StatusBadRequest, w.Code, w.Body.String())
+
+	w = serve(r, http.MethodGet, "/api/v2/students?status=on_leave", "", nil)
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	var students []response.StudentResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &students))
+	assert.Len(t, students, 1)
+
+	w = serve(r, http.MethodGet, "/api/v2/students?status=expelled", "", nil)
+	assert.Equal(t, http.StatusBadRequest, w.Code, w.Body.String())
+
+	w = serve(r, http.MethodGet, location+"/transitions", "", nil)
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	var history response.StudentStatusHistoryResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &history))
+	assert.Equal(t, int64(1), history.Total)
+	require.Len(t, history.Transitions, 1)
+	assert.Equal(t, "active", history.Transitions[0].From)
+	assert.Equal(t, "on_leave", history.Transitions[0].To)
+}
+
+func TestStudentPrograms_InMemory(t *testing.T) {
+	r := setupMemoryTest(t)
+
+	w := serve(r, http.MethodPost, "/api/v1/programs", `{"Code":"cs","Name":"Computer Science","Department":"Computing","DegreeLevel":"bachelor"}`, nil)
+	require.Equal(t, http.StatusCreated, w.Code, w.Body.String())
+	var program response.ProgramResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &program))
+	assert.Equal(t, "CS", program.Code)
+	assert.True(t, program.Active)
+
+	w = serve(r, http.MethodPost, "/api/v1/programs", `{"Code":"CS","Name":"Cognitive Science","Department":"Psychology","DegreeLevel":"bachelor"}`, nil)
+	assert.Equal(t, http.StatusConflict, w.Code, w.Body.String())
+	w = serve(r, http.MethodPost, "/api/v1/programs", `{"Code":"MATH","Name":"Mathematics","Department":"Mathematics","DegreeLevel":"phd"}`, nil)
+	assert.Equal(t, http.StatusUnprocessableEntity, w.Code, w.Body.String())
+
+	body := `{"FirstName":"tran","LastName":"vu","Email":"tranvu@example.com","EnrollmentDate":"2023-09-01",` +
+		`"Programs":[{"ProgramID":"` + program.ID + `","Kind":"major","DeclaredAt":"2023-09-01"}]}`
+	w = serve(r, http.MethodPost, "/api/v2/students", body, nil)
+	require.Equal(t, http.StatusCreated, w.Code, w.Body.String())
+	location := w.Header().Get("Location")
+	var student response.StudentResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &student))
+	require.Len(t, student.Programs, 1)
+	assert.Equal(t, program.ID, student.Programs[0].ProgramID)
+	assert.Equal(t, "major", student.Programs[0].Kind)
+
+	w = serve(r, http.MethodPost, "/api/v2/students", `{"FirstName":"an","LastName":"le","Email":"anle@example.com","EnrollmentDate":"2023-09-01",`+
+		`"Programs":[{"ProgramID":"`+uuid.NewString()+`","Kind":"major","DeclaredAt":"2023-09-01"}]}`, nil)
+	assert.Equal(t, http.StatusUnprocessableEntity, w.Code, "programs must be in the catalog: %s", w.Body.String())
+
+	w = serve(r, http.MethodPut, "/api/v1/programs/"+program.ID, `{"Name":"Computer Science","Department":"Computing","DegreeLevel":"bachelor","Active":false}`, nil)
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+	w = serve(r, http.MethodPost, "/api/v2/students", `{"FirstName":"an","LastName":"le","Email":"anle@example.com","EnrollmentDate":"2023-09-01",`+
+		`"Programs":[{"ProgramID":"`+program.ID+`","Kind":"minor","DeclaredAt":"2023-09-01"}]}`, nil)
+	assert.Equal(t, http.StatusUnprocessableEntity, w.Code, "inactive programs cannot be declared anew: %s", w.Body.String())
+
+	w = serve(r, http.MethodPatch, location, `{"Major":"Computing"}`, map[string]string{"If-Match": `"1"`})
+	require.Equal(t, http.StatusOK, w.Code, "a deactivated program stays declared: %s", w.Body.String())
+
+	w = serve(r, http.MethodPatch, location, `{"Programs":null}`, map[string]string{"If-Match": `"2"`})
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &student))
+	assert.Empty(t, student.Programs)
+
+	w = serve(r, http.MethodGet, "/api/v1/programs?active=false", "", nil)
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	var programs response.ProgramResponseList
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &programs))
+	require.Len(t, programs.Programs, 1)
+	assert.False(t, programs.Programs[0].Active)
+
+	w = serve(r, http.MethodGet, "/api/v1/programs?degree_level=phd", "", nil)
+	assert.Equal(t, http.StatusBadRequest, w.Code, w.Body.String())
+	w = serve(r, http.MethodGet, "/api/v1/programs/"+uuid.NewString(), "", nil)
+	assert.Equal(t, http.StatusNotFound, w.Code, w.Body.String())
+}
+
+func TestStudentContacts_InMemory(t *testing.T) {
+	r := setupMemoryTest(t)
+
+	w := serve(r, http.MethodPost, "/api/v2/students", `{"FirstName":"tran","LastName":"vu","Email":"tranvu@example.com","Phone":"+84 90 123 4567","EnrollmentDate":"2023-09-01"}`, nil)
+	require.Equal(t, http.StatusCreated, w.Code, w.Body.String())
+	location := w.Header().Get("Location")
+	var created response.StudentResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &created))
+	require.NotNil(t, created.Phone)
+	assert.Equal(t, "+84901234567", *created.Phone)
+
+	w = serve(r, http.MethodPost, "/api/v2/students", `{"FirstName":"an","LastName":"le","Email":"anle@example.com","Phone":"0901234567","EnrollmentDate":"2023-09-01"}`, nil)
+	assert.NotEqual(t, http.StatusCreated, w.Code, "a national number is not E.164")
+
+	w = serve(r, http.MethodPost, location+"/contacts/addresses", `{"Type":"home","Line1":"1 Le Loi","City":"Hue","Country":"VN","ValidFrom":"2023-09-01"}`, map[string]string{"If-Match": `"1"`})
+	require.Equal(t, http.StatusCreated, w.Code, w.Body.String())
+	assert.Equal(t, `"2"`, w.Header().Get("ETag"))
+
+	w = serve(r, http.MethodPost, location+"/contacts/addresses", `{"Type":"home","Line1":"2 Tran Hung Dao","City":"Hanoi","Country":"VN","ValidFrom":"2024-01-01"}`, map[string]string{"If-Match": `"2"`})
+	assert.Equal(t, http.StatusUnprocessableEntity, w.Code, w.Body.String())
+
+	w = serve(r, http.MethodPost, location+"/contacts/phones", `{"Number":"0084 90 765 4321"}`, map[string]string{"If-Match": `"2"`})
+	require.Equal(t, http.StatusCreated, w.Code, w.Body.String())
+	w = serve(r, http.MethodPost, location+"/contacts/emails", `{"Address":"vu@work.example.com","Primary":true}`, map[string]string{"If-Match": `"3"`})
+	require.Equal(t, http.StatusCreated, w.Code, w.Body.String())
+
+	w = serve(r, http.MethodGet, location+"/contacts", "", nil)
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	assert.Equal(t, `"4"`, w.Header().Get("ETag"))
+	var contacts response.StudentContactsResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &contacts))
+	require.Len(t, contacts.Addresses, 1)
+	require.Len(t, contacts.Phones, 1)
+	require.Len(t, contacts.Emails, 1)
+	assert.Equal(t, "+84907654321", contacts.Phones[0].Number)
+	assert.True(t, contacts.Phones[0].Primary)
+	assert.Equal(t, "unverified", contacts.Emails[0].Verification)
+	assert.Empty(t, contacts.EmergencyContacts)
+
+	phone := location + "/contacts/phones/" + contacts.Phones[0].ID
+	w = serve(r, http.MethodPut, phone, `{"Number":"12345"}`, map[string]string{"If-Match": `"4"`})
+	assert.Equal(t, http.StatusUnprocessableEntity, w.Code, w.Body.String())
+
+	w = serve(r, http.MethodDelete, location+"/contacts/emails/"+contacts.Phones[0].ID, "", map[string]string{"If-Match": `"4"`})
+	assert.Equal(t, http.StatusNotFound, w.Code, w.Body.String())
+
+	w = serve(r, http.MethodDelete, phone, "", map[string]string{"If-Match": `"4"`})
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	assert.Equal(t, `"5"`, w.Header().Get("ETag"))
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &contacts))
+	assert.Empty(t, contacts.Phones)
+
+	w = serve(r, http.MethodPut, location+"/contacts/emergency-contacts/"+uuid.NewString(), `{"Name":"Tran Mai","Relationship":"mother","Phone":"+84901234567"}`, map[string]string{"If-Match": `"5"`})
+	assert.Equal(t, http.StatusNotFound, w.Code, w.Body.String())
+}
+
+func TestVerifyEmail_InMemory(t *testing.T) {
+	r := setupMemoryTest(t)
+
+	w := serve(r, http.MethodPost, "/api/v2/students", `{"FirstName":"tran","LastName":"vu","Email":"tranvu@example.com","EnrollmentDate":"2023-09-01"}`, nil)
+	require.Equal(t, http.StatusCreated, w.Code, w.Body.String())
+	location := w.Header().Get("Location")
+	var created response.StudentResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &created))
+	assert.Nil(t, created.EmailVerifiedAt)
+
+	token := entities.EmailVerificationToken{StudentID: uuid.MustParse(created.StudentID), Email: created.Email, ExpiresAt: time.Now().Add(time.Hour)}
+	expired := token
+	expired.ExpiresAt = time.Now().Add(-time.Minute)
+	w = serve(r, http.MethodPost, "/api/v2/students/verify-email", `{"Token":"`+expired.Sign(verificationSecret)+`"}`, nil)
+	assert.Equal(t, http.StatusUnprocessableEntity, w.Code, w.Body.String())
+	w = serve(r, http.MethodPost, "/api/v2/students/verify-email", `{"Token":"`+token.Sign([]byte("forged"))+`"}`, nil)
+	assert.Equal(t, http.StatusUnprocessableEntity, w.Code, w.Body.String())
+
+	w = serve(r, http.MethodPost, "/api/v1/students/verify-email", `{"Token":"`+token.Sign(verificationSecret)+`"}`, nil)
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	assert.Equal(t, `"2"`, w.Header().Get("ETag"))
+	assert.Equal(t, "true", w.Header().Get("Deprecation"))
+	var verified response.StudentResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &verified))
+	require.NotNil(t, verified.EmailVerifiedAt)
+
+	w = serve(r, http.MethodPatch, location, `{"Email":"vu.tran@example.com"}`, map[string]string{"If-Match": `"2"`})
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	var changed response.StudentResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &changed))
+	assert.Nil(t, changed.EmailVerifiedAt)
+
+	w = serve(r, http.MethodPost, "/api/v2/students/verify-email", `{"Token":"`+token.Sign(verificationSecret)+`"}`, nil)
+	assert.Equal(t, http.StatusUnprocessableEntity, w.Code, "the token was for the old email: "+w.Body.String())
+}
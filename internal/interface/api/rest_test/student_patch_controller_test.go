@@ -0,0 +1,196 @@
+package rest_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tranvu1111/go-students-new/internal/application/mapper"
+	"github.com/tranvu1111/go-students-new/internal/application/query"
+	"github.com/tranvu1111/go-students-new/internal/domain/entities"
+	"github.com/tranvu1111/go-students-new/internal/domain/repositories"
+	"github.com/tranvu1111/go-students-new/internal/interface/api/rest"
+	"github.com/tranvu1111/go-students-new/internal/interface/api/rest/dto/response"
+)
+
+func setupPatchTest(t *testing.T) (*gin.Engine, *MockStudentService, *entities.Student) {
+	gin.SetMode(gin.ReleaseMode)
+	r := gin.New()
+
+	mockStudentService := new(MockStudentService)
+	rest.NewStudentController(r, mockStudentService)
+
+	phone, major := "+84947531799", "CNTT"
+	student := entities.NewStudent("tran", "vu", nil, "tranvu@example.com", &phone, &major, time.Date(2023, 9, 1, 0, 0, 0, 0, time.UTC))
+	return r, mockStudentService, student
+}
+
+func sendPatch(r *gin.Engine, student *entities.Student, contentType string, body string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodPatch, "/api/v1/students/"+student.StudentID.String(), strings.NewReader(body))
+	req.Header.Set("Content-Type", contentType)
+	req.Header.Set("If-Match", `"1"`)
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+	return w
+}
+
+func TestPatchStudent_MergePatch(t *testing.T) {
+	r, mockStudentService, student := setupPatchTest(t)
+	mockStudentService.On("PatchStudent", student.StudentID).Return(student, nil)
+
+	w := sendPatch(r, student, "application/merge-patch+json", `{"Major": "Physics", "Phone": null, "LastName": " Nguyen "}`)
+
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	var responseBody response.StudentResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &responseBody))
+	assert.Equal(t, "tran", responseBody.FirstName)
+	assert.Equal(t, "Nguyen", responseBody.LastName)
+	assert.Equal(t, "Physics", *responseBody.Major)
+	assert.Nil(t, responseBody.Phone)
+	assert.Equal(t, `"1"`, w.Header().Get("ETag"))
+}
+
+func TestPatchStudent_JSONPatch(t *testing.T) {
+	r, mockStudentService, student := setupPatchTest(t)
+	mockStudentService.On("FindStudentById", student.StudentID).Return(student, nil)
+	mockStudentService.On("PatchStudent", student.StudentID).Return(student, nil)
+
+	w := sendPatch(r, student, "application/json-patch+json", `[
+		{"op": "test", "path": "/FirstName", "value": "tran"},
+		{"op": "replace", "path": "/Major", "value": "Physics"},
+		{"op": "remove", "path": "/Phone"},
+		{"op": "add", "path": "/DateOfBirth", "value": "2001-02-03"}
+	]`)
+
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	var responseBody response.StudentResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &responseBody))
+	assert.Equal(t, "Physics", *responseBody.Major)
+	assert.Nil(t, responseBody.Phone)
+	assert.Equal(t, "2001-02-03", responseBody.DateOfBirth.Format("2006-01-02"))
+	assert.Equal(t, "tranvu@example.com", responseBody.Email)
+}
+
+func TestPatchStudent_Rejections(t *testing.T) {
+	tests := []struct {
+		name        string
+		contentType string
+		body        string
+		status      int
+	}{
+		{"failed test op", "application/json-patch+json", `[{"op": "test", "path": "/FirstName", "value": "someone"}]`, http.StatusUnprocessableEntity},
+		{"read-only field", "application/merge-patch+json", `{"EnrollmentDate": "2020-01-01"}`, http.StatusBadRequest},
+		{"unknown field", "application/merge-patch+json", `{"Nickname": "vu"}`, http.StatusBadRequest},
+		{"wrong type", "application/merge-patch+json", `{"DateOfBirth": 5}`, http.StatusBadRequest},
+		{"not an object", "application/merge-patch+json", `null`, http.StatusBadRequest},
+		{"clearing a required field", "application/merge-patch+json", `{"Email": null}`, http.StatusUnprocessableEntity},
+		{"unsupported format", "text/plain", `Major=Physics`, http.StatusUnsupportedMediaType},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r, mockStudentService, student := setupPatchTest(t)
+			mockStudentService.On("FindStudentById", student.StudentID).Return(student, nil)
+			mockStudentService.On("PatchStudent", student.StudentID).Return(student, nil)
+
+			w := sendPatch(r, student, tt.contentType, tt.body)
+
+			assert.Equal(t, tt.status, w.Code, w.Body.String())
+			assert.Equal(t, "tranvu@example.com", student.Email)
+		})
+	}
+}
+
+// replicatedStudentService serves FindStudentById from a lagging replica
+// unless the read must be consistent.
+type replicatedStudentService struct {
+	*MockStudentService
+	stale *entities.Student
+}
+
+func (s *replicatedStudentService) FindStudentById(ctx context.Context, id uuid.UUID) (*query.StudentQueryResult, error) {
+	if !repositories.NeedsConsistentRead(ctx) {
+		return &query.StudentQueryResult{Result: mapper.NewStudentResultFromEntity(s.stale)}, nil
+	}
+	return s.MockStudentService.FindStudentById(ctx, id)
+}
+
+func sendJSONPatch(r *gin.Engine, student *entities.Student, ifMatch string, body string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodPatch, "/api/v1/students/"+student.StudentID.String(), strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json-patch+json")
+	req.Header.Set("If-Match", ifMatch)
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+	return w
+}
+
+func TestPatchStudent_JSONPatchReadsCurrentVersion(t *testing.T) {
+	gin.SetMode(gin.ReleaseMode)
+	r := gin.New()
+
+	student := entities.NewStudent("tran", "vu", nil, "tranvu@example.com", nil, nil, time.Date(2023, 9, 1, 0, 0, 0, 0, time.UTC))
+	stale := *student
+	require.NoError(t, student.ApplyPatch(entities.StudentPatch{FirstName: entities.Replace("an")}))
+	student.Version = 2
+
+	mockStudentService := new(MockStudentService)
+	mockStudentService.On("FindStudentById", student.StudentID).Return(student, nil)
+	mockStudentService.On("PatchStudent", student.StudentID).Return(student, nil)
+	rest.NewStudentController(r, &replicatedStudentService{MockStudentService: mockStudentService, stale: &stale})
+
+	w := sendJSONPatch(r, student, `"2"`, `[{"op": "test", "path": "/FirstName", "value": "tran"}, {"op": "replace", "path": "/Major", "value": "Physics"}]`)
+	assert.Equal(t, http.StatusUnprocessableEntity, w.Code, "the test op must see the current first name, not the replica's")
+
+	w = sendJSONPatch(r, student, `"2"`, `[{"op": "test", "path": "/FirstName", "value": "an"}, {"op": "replace", "path": "/Major", "value": "Physics"}]`)
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	assert.Equal(t, "an", student.FirstName)
+	assert.Equal(t, "Physics", *student.Major)
+}
+
+func TestPatchStudent_JSONPatchRejectsStaleIfMatch(t *testing.T) {
+	r, mockStudentService, student := setupPatchTest(t)
+	mockStudentService.On("FindStudentById", student.StudentID).Return(student, nil)
+
+	w := sendJSONPatch(r, student, `"2"`, `[{"op": "replace", "path": "/Major", "value": "Physics"}]`)
+
+	assert.Equal(t, http.StatusPreconditionFailed, w.Code, w.Body.String())
+	assert.Equal(t, `"1"`, w.Header().Get("ETag"))
+	mockStudentService.AssertNotCalled(t, "PatchStudent", student.StudentID)
+	assert.Equal(t, "CNTT", *student.Major)
+}
+
+func TestPatchStudent_ReadOnlyFields(t *testing.T) {
+	tests := []struct {
+		name        string
+		contentType string
+		body        string
+		detail      string
+	}{
+		{"merge patch of EmailVerifiedAt", "application/merge-patch+json", `{"EmailVerifiedAt": "2024-01-01T00:00:00Z"}`, "EmailVerifiedAt cannot be changed"},
+		{"JSON Patch of EmailVerifiedAt", "application/json-patch+json", `[{"op": "add", "path": "/EmailVerifiedAt", "value": "2024-01-01T00:00:00Z"}]`, "EmailVerifiedAt cannot be changed"},
+		{"merge patch of Status", "application/merge-patch+json", `{"Status": "graduated"}`, "Status cannot be changed"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r, mockStudentService, student := setupPatchTest(t)
+			mockStudentService.On("FindStudentById", student.StudentID).Return(student, nil)
+
+			w := sendPatch(r, student, tt.contentType, tt.body)
+
+			require.Equal(t, http.StatusBadRequest, w.Code, w.Body.String())
+			var errorBody map[string]string
+			require.NoError(t, json.Unmarshal(w.Body.Bytes(), &errorBody))
+			assert.Equal(t, tt.detail, errorBody["error1"])
+			mockStudentService.AssertNotCalled(t, "PatchStudent", student.StudentID)
+		})
+	}
+}
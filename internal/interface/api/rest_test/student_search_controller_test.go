@@ -0,0 +1,70 @@
+package rest_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tranvu1111/go-students-new/internal/application/common"
+	"github.com/tranvu1111/go-students-new/internal/application/mapper"
+	"github.com/tranvu1111/go-students-new/internal/application/query"
+	"github.com/tranvu1111/go-students-new/internal/domain/entities"
+	"github.com/tranvu1111/go-students-new/internal/interface/api/rest"
+	"github.com/tranvu1111/go-students-new/internal/interface/api/rest/dto/response"
+)
+
+func TestSearchStudents(t *testing.T) {
+	gin.SetMode(gin.ReleaseMode)
+	r := gin.New()
+
+	mockStudentService := new(MockStudentService)
+	rest.NewStudentController(r, mockStudentService)
+
+	student := entities.NewStudent("Jonathan", "Tran", nil, "jonathan@example.com", nil, nil, time.Now())
+	searchResult := &query.StudentSearchQueryResult{
+		Query: "jonatan",
+		Result: []*common.StudentSearchHitResult{
+			{
+				Student:    mapper.NewStudentResultFromEntity(student),
+				Score:      0.6,
+				Highlights: map[string]string{"FirstName": "<em>Jonathan</em>"},
+			},
+		},
+	}
+	mockStudentService.On("SearchStudents", &query.StudentSearchQuery{Query: "jonatan", Limit: 5}).Return(searchResult, nil)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/students/search?q=+jonatan+&limit=5", nil)
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+
+	require.Equal(t, http.StatusOK, w.Code)
+
+	var responseBody response.StudentSearchResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &responseBody))
+	assert.Equal(t, "jonatan", responseBody.Query)
+	require.Len(t, responseBody.Results, 1)
+	assert.Equal(t, student.StudentID.String(), responseBody.Results[0].Student.StudentID)
+	assert.Equal(t, "<em>Jonathan</em>", responseBody.Results[0].Highlights["FirstName"])
+
+	mockStudentService.AssertExpectations(t)
+}
+
+func TestSearchStudents_RejectsShortQuery(t *testing.T) {
+	gin.SetMode(gin.ReleaseMode)
+	r := gin.New()
+
+	mockStudentService := new(MockStudentService)
+	rest.NewStudentController(r, mockStudentService)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/students/search?q=a", nil)
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	mockStudentService.AssertNotCalled(t, "SearchStudents")
+}
@@ -0,0 +1,145 @@
+package rest_test
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/tranvu1111/go-students-new/internal/domain/entities"
+	"github.com/tranvu1111/go-students-new/internal/domain/repositories"
+	"github.com/tranvu1111/go-students-new/internal/interface/api/rest"
+	"github.com/tranvu1111/go-students-new/internal/interface/api/rest/dto/response"
+)
+
+func setupV2Test(t *testing.T) (*gin.Engine, *MockStudentService) {
+	gin.SetMode(gin.ReleaseMode)
+	r := gin.New()
+
+	mockStudentService := new(MockStudentService)
+	rest.NewStudentController(r, mockStudentService)
+	return r, mockStudentService
+}
+
+func TestCreateStudentV2(t *testing.T) {
+	r, mockStudentService := setupV2Test(t)
+	mockStudentService.On("CreateStudent", mock.Anything).Return(nil, nil)
+
+	body := `{"FirstName":"tran","LastName":"vu","Email":"tranvu@example.com","EnrollmentDate":"2023-09-01"}`
+	req := httptest.NewRequest(http.MethodPost, "/api/v2/students", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+
+	require.Equal(t, http.StatusCreated, w.Code, w.Body.String())
+
+	var created response.StudentResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &created))
+	assert.Equal(t, "tran", created.FirstName)
+	assert.Equal(t, "/api/v2/students/"+created.StudentID, w.Header().Get("Location"))
+	assert.Equal(t, `"1"`, w.Header().Get("ETag"))
+	assert.Empty(t, w.Header().Get("Deprecation"))
+}
+
+func TestGetStudentsV2(t *testing.T) {
+	r, mockStudentService := setupV2Test(t)
+	student := entities.NewStudent("tran", "vu", nil, "tranvu@example.com", nil, nil, time.Now())
+	mockStudentService.On("FindAllStudent", mock.Anything).Return([]*entities.Student{}, nil).Once()
+	mockStudentService.On("FindStudentById", student.StudentID).Return(student, nil)
+
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/v2/students", nil))
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.JSONEq(t, `[]`, w.Body.String())
+
+	w = httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/v2/students/"+student.StudentID.String(), nil))
+	assert.Equal(t, http.StatusOK, w.Code)
+	var found response.StudentResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &found))
+	assert.Equal(t, student.StudentID.String(), found.StudentID)
+}
+
+func TestReplaceStudentV2_ClearsOmittedFields(t *testing.T) {
+	r, mockStudentService := setupV2Test(t)
+	phone, major := "+84947531799", "CNTT"
+	student := entities.NewStudent("tran", "vu", nil, "tranvu@example.com", &phone, &major, time.Now())
+	mockStudentService.On("PatchStudent", student.StudentID).Return(student, nil)
+
+	body := `{"FirstName":"tran","LastName":"vu","Email":"tranvu@example.com","Major":"Physics"}`
+	req := httptest.NewRequest(http.MethodPut, "/api/v2/students/"+student.StudentID.String(), strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("If-Match", `"1"`)
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	var replaced response.StudentResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &replaced))
+	assert.Equal(t, "Physics", *replaced.Major)
+	assert.Nil(t, replaced.Phone)
+}
+
+func TestDeleteStudentV2(t *testing.T) {
+	r, mockStudentService := setupV2Test(t)
+	student := entities.NewStudent("tran", "vu", nil, "tranvu@example.com", nil, nil, time.Now())
+	mockStudentService.On("DeleteStudent", student.StudentID, 1).Return(nil)
+
+	req := httptest.NewRequest(http.MethodDelete, "/api/v2/students/"+student.StudentID.String(), nil)
+	req.Header.Set("If-Match", `"1"`)
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusNoContent, w.Code)
+	assert.Empty(t, w.Body.String())
+	mockStudentService.AssertExpectations(t)
+}
+
+func TestStudentsV1_AreDeprecated(t *testing.T) {
+	r, mockStudentService := setupV2Test(t)
+	mockStudentService.On("FindAllStudent", mock.Anything).Return([]*entities.Student{}, nil)
+
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/v1/students", nil))
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "true", w.Header().Get("Deprecation"))
+	assert.Equal(t, `</api/v2/students>; rel="successor-version"`, w.Header().Get("Link"))
+}
+
+func TestPutStudentV1_StopsOnServiceError(t *testing.T) {
+	r, mockStudentService := setupV2Test(t)
+	mockStudentService.On("UpdateStudent", mock.Anything).Return(nil, errors.New("database is down"))
+
+	body := `{"StudentId":"6f69799c-1eb2-4266-b28c-9762a4d02129","Major":"CNTT"}`
+	req := httptest.NewRequest(http.MethodPut, "/api/v1/students", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("If-Match", `"1"`)
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusInternalServerError, w.Code)
+	var errorBody map[string]interface{}
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &errorBody), "only the error should be written")
+}
+
+func TestCreateStudentV2_DuplicateEmail(t *testing.T) {
+	r, mockStudentService := setupV2Test(t)
+	mockStudentService.On("CreateStudent", mock.Anything).Return(nil, repositories.ErrDuplicateEmail)
+
+	body := `{"FirstName":"tran","LastName":"vu","Email":"tranvu@example.com","EnrollmentDate":"2023-09-01"}`
+	req := httptest.NewRequest(http.MethodPost, "/api/v2/students", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusConflict, w.Code, w.Body.String())
+	assert.Contains(t, w.Body.String(), repositories.ErrDuplicateEmail.Error())
+}
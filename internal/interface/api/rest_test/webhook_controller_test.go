@@ -0,0 +1,107 @@
+package rest_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/tranvu1111/go-students-new/internal/application/command"
+	"github.com/tranvu1111/go-students-new/internal/application/query"
+	"github.com/tranvu1111/go-students-new/internal/domain/entities"
+	"github.com/tranvu1111/go-students-new/internal/domain/repositories"
+	"github.com/tranvu1111/go-students-new/internal/interface/api/rest"
+)
+
+type MockWebhookService struct {
+	mock.Mock
+}
+
+func (m *MockWebhookService) CreateSubscription(ctx context.Context, createCommand *command.CreateWebhookSubscriptionCommand) (*command.WebhookSubscriptionCommandResult, error) {
+	args := m.Called(createCommand)
+	result, _ := args.Get(0).(*command.WebhookSubscriptionCommandResult)
+	return result, args.Error(1)
+}
+
+func (m *MockWebhookService) FindAllSubscriptions(ctx context.Context) (*query.WebhookSubscriptionQueryListResult, error) {
+	args := m.Called()
+	result, _ := args.Get(0).(*query.WebhookSubscriptionQueryListResult)
+	return result, args.Error(1)
+}
+
+func (m *MockWebhookService) FindSubscriptionById(ctx context.Context, id uuid.UUID) (*query.WebhookSubscriptionQueryResult, error) {
+	args := m.Called(id)
+	result, _ := args.Get(0).(*query.WebhookSubscriptionQueryResult)
+	return result, args.Error(1)
+}
+
+func (m *MockWebhookService) UpdateSubscription(ctx context.Context, updateCommand *command.UpdateWebhookSubscriptionCommand) (*command.WebhookSubscriptionCommandResult, error) {
+	args := m.Called(updateCommand)
+	result, _ := args.Get(0).(*command.WebhookSubscriptionCommandResult)
+	return result, args.Error(1)
+}
+
+func (m *MockWebhookService) DeleteSubscription(ctx context.Context, id uuid.UUID) error {
+	return m.Called(id).Error(0)
+}
+
+func (m *MockWebhookService) FindDeliveries(ctx context.Context, subscriptionID uuid.UUID, page int, pageSize int) (*query.WebhookDeliveryQueryListResult, error) {
+	args := m.Called(subscriptionID, page, pageSize)
+	result, _ := args.Get(0).(*query.WebhookDeliveryQueryListResult)
+	return result, args.Error(1)
+}
+
+func TestWebhookController_MapsServiceErrors(t *testing.T) {
+	_, invalid := entities.NewWebhookSubscription("not a url", []string{string(entities.StudentCreated)}, "0123456789abcdef")
+	id := uuid.New()
+	createBody := `{"URL":"https://example.com/hook","EventTypes":["StudentCreated"],"Secret":"0123456789abcdef"}`
+
+	tests := []struct {
+		name   string
+		method string
+		path   string
+		body   string
+		setup  func(m *MockWebhookService)
+		want   int
+	}{
+		{"not found", http.MethodGet, "/api/v1/webhooks/" + id.String(), "", func(m *MockWebhookService) {
+			m.On("FindSubscriptionById", id).Return(nil, fmt.Errorf("%w: record not found", repositories.ErrWebhookSubscriptionNotFound))
+		}, http.StatusNotFound},
+		{"delete not found", http.MethodDelete, "/api/v1/webhooks/" + id.String(), "", func(m *MockWebhookService) {
+			m.On("DeleteSubscription", id).Return(repositories.ErrWebhookSubscriptionNotFound)
+		}, http.StatusNotFound},
+		{"invalid", http.MethodPost, "/api/v1/webhooks", createBody, func(m *MockWebhookService) {
+			m.On("CreateSubscription", mock.Anything).Return(nil, invalid)
+		}, http.StatusUnprocessableEntity},
+		{"unavailable", http.MethodGet, "/api/v1/webhooks/" + id.String(), "", func(m *MockWebhookService) {
+			m.On("FindSubscriptionById", id).Return(nil, fmt.Errorf("%w: connection refused", repositories.ErrUnavailable))
+		}, http.StatusServiceUnavailable},
+		{"database error", http.MethodDelete, "/api/v1/webhooks/" + id.String(), "", func(m *MockWebhookService) {
+			m.On("DeleteSubscription", id).Return(errors.New("deadlock detected"))
+		}, http.StatusInternalServerError},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			gin.SetMode(gin.ReleaseMode)
+			r := gin.New()
+			mockWebhookService := new(MockWebhookService)
+			tc.setup(mockWebhookService)
+			rest.NewWebhookController(r, mockWebhookService)
+
+			req := httptest.NewRequest(tc.method, tc.path, strings.NewReader(tc.body))
+			req.Header.Set("Content-Type", "application/json")
+			w := httptest.NewRecorder()
+			r.ServeHTTP(w, req)
+
+			assert.Equal(t, tc.want, w.Code, w.Body.String())
+		})
+	}
+}